@@ -16,7 +16,6 @@ import (
 
 type EC2Client interface {
 	DescribeAvailabilityZones(*awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error)
-	DescribeInstances(*awsec2.DescribeInstancesInput) (*awsec2.DescribeInstancesOutput, error)
 	DescribeVpcs(*awsec2.DescribeVpcsInput) (*awsec2.DescribeVpcsOutput, error)
 }
 
@@ -40,6 +39,9 @@ type Client struct {
 	ec2Client     EC2Client
 	route53Client Route53Client
 	logger        logger
+
+	region            string
+	regionalEC2Client func(region string) EC2Client
 }
 
 func NewClient(creds storage.AWS, logger logger) Client {
@@ -52,6 +54,10 @@ func NewClient(creds storage.AWS, logger logger) Client {
 		ec2Client:     awsec2.New(session.New(config)),
 		route53Client: awsroute53.New(session.New(config)),
 		logger:        logger,
+		region:        creds.Region,
+		regionalEC2Client: func(region string) EC2Client {
+			return awsec2.New(session.New(config.Copy().WithRegion(region)))
+		},
 	}
 }
 
@@ -83,7 +89,12 @@ func (c Client) RetrieveDNS(url string) string {
 
 // Return the AWS Availability Zones for a given region.
 func (c Client) RetrieveAZs(region string) ([]string, error) {
-	output, err := c.ec2Client.DescribeAvailabilityZones(&awsec2.DescribeAvailabilityZonesInput{
+	ec2Client := c.ec2Client
+	if region != c.region && c.regionalEC2Client != nil {
+		ec2Client = c.regionalEC2Client(region)
+	}
+
+	output, err := ec2Client.DescribeAvailabilityZones(&awsec2.DescribeAvailabilityZonesInput{
 		Filters: []*awsec2.Filter{{
 			Name:   awslib.String("region-name"),
 			Values: []*string{awslib.String(region)},
@@ -131,62 +142,6 @@ func (c Client) CheckExists(networkName string) (bool, error) {
 	return false, nil
 }
 
-func (c Client) ValidateSafeToDelete(vpcID, envID string) error {
-	output, err := c.ec2Client.DescribeInstances(&awsec2.DescribeInstancesInput{
-		Filters: []*awsec2.Filter{{
-			Name:   awslib.String("vpc-id"),
-			Values: []*string{awslib.String(vpcID)},
-		}},
-	})
-	if err != nil {
-		return err
-	}
-
-	vms := c.flattenVMs(output.Reservations)
-	vms = c.removeOneVM(vms, fmt.Sprintf("%s-nat", envID))
-	vms = c.removeOneVM(vms, "NAT")
-	vms = c.removeOneVM(vms, "bosh/0")
-	vms = c.removeOneVM(vms, "jumpbox/0")
-
-	if len(vms) > 0 {
-		return fmt.Errorf("vpc %s is not safe to delete; vms still exist: [%s]", vpcID, strings.Join(vms, ", "))
-	}
-
-	return nil
-}
-
-func (c Client) flattenVMs(reservations []*awsec2.Reservation) []string {
-	vms := []string{}
-	for _, reservation := range reservations {
-		for _, instance := range reservation.Instances {
-			vms = append(vms, c.vmName(instance))
-		}
-	}
-	return vms
-}
-
-func (c Client) vmName(instance *awsec2.Instance) string {
-	name := "unnamed"
-
-	for _, tag := range instance.Tags {
-		if awslib.StringValue(tag.Key) == "Name" && awslib.StringValue(tag.Value) != "" {
-			name = awslib.StringValue(tag.Value)
-		}
-	}
-
-	return name
-}
-
-func (c Client) removeOneVM(vms []string, vmToRemove string) []string {
-	for index, vm := range vms {
-		if vm == vmToRemove {
-			return append(vms[:index], vms[index+1:]...)
-		}
-	}
-
-	return vms
-}
-
 func (c Client) GetVPC(vpcName string) (*string, error) {
 	vpcs, err := c.ec2Client.DescribeVpcs(&awsec2.DescribeVpcsInput{
 		Filters: []*awsec2.Filter{{
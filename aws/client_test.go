@@ -160,6 +160,26 @@ var _ = Describe("Client", func() {
 			}))
 		})
 
+		It("asks the ec2 api of the region for the availability zones of another region", func() {
+			regionalEC2Client := &fakes.AWSEC2Client{}
+			regionalEC2Client.DescribeAvailabilityZonesCall.Returns.Output = &awsec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []*awsec2.AvailabilityZone{{ZoneName: awslib.String("us-west-2a")}},
+			}
+
+			var receivedRegion string
+			client = aws.NewClientWithInjectedRegionalEC2Client(ec2Client, "us-east-1", func(region string) aws.EC2Client {
+				receivedRegion = region
+				return regionalEC2Client
+			}, &fakes.Logger{})
+
+			azs, err := client.RetrieveAZs("us-west-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(receivedRegion).To(Equal("us-west-2"))
+			Expect(azs).To(Equal([]string{"us-west-2a"}))
+			Expect(ec2Client.DescribeAvailabilityZonesCall.Receives.Input).To(BeNil())
+		})
+
 		Describe("failure cases", func() {
 			Context("when AWS returns a nil availability zone", func() {
 				BeforeEach(func() {
@@ -199,175 +219,4 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
-
-	Describe("ValidateSafeToDelete", func() {
-		var (
-			client    aws.Client
-			ec2Client *fakes.AWSEC2Client
-		)
-
-		BeforeEach(func() {
-			ec2Client = &fakes.AWSEC2Client{}
-			client = aws.NewClientWithInjectedEC2Client(ec2Client, &fakes.Logger{})
-		})
-
-		Context("when the only EC2 instances are bosh and nat", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-					Reservations: []*awsec2.Reservation{
-						reservationContainingInstance("NAT"),
-						reservationContainingInstance("bosh/0"),
-					},
-				}
-			})
-
-			It("returns nil", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(ec2Client.DescribeInstancesCall.Receives.Input).To(Equal(&awsec2.DescribeInstancesInput{
-					Filters: []*awsec2.Filter{{
-						Name:   awslib.String("vpc-id"),
-						Values: []*string{awslib.String("some-vpc-id")},
-					}},
-				}))
-			})
-		})
-
-		Context("when passed an environment ID", func() {
-			Context("when the only EC2 instances are bosh, jumpbox and envID-nat", func() {
-				BeforeEach(func() {
-					ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-						Reservations: []*awsec2.Reservation{
-							reservationContainingInstance("example-env-id-nat"),
-							reservationContainingInstance("bosh/0"),
-							reservationContainingInstance("jumpbox/0"),
-						},
-					}
-				})
-
-				It("returns nil", func() {
-					err := client.ValidateSafeToDelete("some-vpc-id", "example-env-id")
-					Expect(err).NotTo(HaveOccurred())
-
-					Expect(ec2Client.DescribeInstancesCall.Receives.Input).To(Equal(&awsec2.DescribeInstancesInput{
-						Filters: []*awsec2.Filter{{
-							Name:   awslib.String("vpc-id"),
-							Values: []*string{awslib.String("some-vpc-id")},
-						}},
-					}))
-				})
-			})
-		})
-
-		Context("when there are no instances at all", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{Reservations: []*awsec2.Reservation{}}
-			})
-
-			It("returns nil", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).NotTo(HaveOccurred())
-			})
-		})
-
-		Context("when there are bosh-deployed VMs in the VPC", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-					Reservations: []*awsec2.Reservation{
-						reservationContainingInstance("NAT"),
-						reservationContainingInstance("bosh/0"),
-						reservationContainingInstance("first-bosh-deployed-vm"),
-						reservationContainingInstance("second-bosh-deployed-vm"),
-					},
-				}
-			})
-
-			It("returns an error", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [first-bosh-deployed-vm, second-bosh-deployed-vm]"))
-			})
-		})
-
-		Context("even when there are two VMs in the VPC, but they are not NAT and BOSH", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-					Reservations: []*awsec2.Reservation{
-						reservationContainingInstance("not-bosh"),
-						reservationContainingInstance("not-nat"),
-					},
-				}
-			})
-
-			It("returns an error", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [not-bosh, not-nat]"))
-			})
-		})
-
-		Context("even if the vpc contains other instances tagged NAT and bosh/0", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-					Reservations: []*awsec2.Reservation{
-						reservationContainingInstance("NAT"),
-						reservationContainingInstance("NAT"),
-						reservationContainingInstance("bosh/0"),
-						reservationContainingInstance("bosh/0"),
-						reservationContainingInstance("bosh/0"),
-					},
-				}
-			})
-
-			It("returns an error", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [NAT, bosh/0, bosh/0]"))
-			})
-		})
-
-		Context("even if the vpc contains untagged vms", func() {
-			BeforeEach(func() {
-				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
-					Reservations: []*awsec2.Reservation{
-						{
-							Instances: []*awsec2.Instance{{
-								Tags: []*awsec2.Tag{{
-									Key:   awslib.String("Name"),
-									Value: awslib.String(""),
-								}},
-							}, {}, {}},
-						},
-					},
-				}
-			})
-
-			It("returns an error", func() {
-				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [unnamed, unnamed, unnamed]"))
-			})
-		})
-
-		Describe("failure cases", func() {
-			Context("when the describe instances call fails", func() {
-				BeforeEach(func() {
-					ec2Client.DescribeInstancesCall.Returns.Error = errors.New("failed to describe instances")
-				})
-
-				It("returns an error", func() {
-					err := client.ValidateSafeToDelete("some-vpc-id", "")
-					Expect(err).To(MatchError("failed to describe instances"))
-				})
-			})
-		})
-	})
 })
-
-func reservationContainingInstance(tag string) *awsec2.Reservation {
-	return &awsec2.Reservation{
-		Instances: []*awsec2.Instance{{
-			Tags: []*awsec2.Tag{{
-				Key:   awslib.String("Name"),
-				Value: awslib.String(tag),
-			}},
-		}},
-	}
-}
@@ -7,6 +7,15 @@ func NewClientWithInjectedEC2Client(ec2Client EC2Client, logger logger) Client {
 	}
 }
 
+func NewClientWithInjectedRegionalEC2Client(ec2Client EC2Client, region string, regionalEC2Client func(string) EC2Client, logger logger) Client {
+	return Client{
+		ec2Client:         ec2Client,
+		logger:            logger,
+		region:            region,
+		regionalEC2Client: regionalEC2Client,
+	}
+}
+
 func NewClientWithInjectedRoute53Client(route53Client Route53Client, logger logger) Client {
 	return Client{
 		route53Client: route53Client,
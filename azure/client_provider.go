@@ -1,7 +1,6 @@
 package azure
 
 import (
-	"github.com/Azure/azure-sdk-for-go/arm/compute"
 	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	azurestorage "github.com/Azure/azure-sdk-for-go/arm/storage"
 	"github.com/Azure/go-autorest/autorest"
@@ -25,16 +24,11 @@ func NewClient(azureConfig storage.Azure) (Client, error) {
 	ac.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
 	ac.Sender = autorest.CreateSender(autorest.AsIs())
 
-	vmsClient := compute.NewVirtualMachinesClient(azureConfig.SubscriptionID)
-	vmsClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
-	vmsClient.Sender = autorest.CreateSender(autorest.AsIs())
-
 	groupsClient := resources.NewGroupsClient(azureConfig.SubscriptionID)
 	groupsClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
 	groupsClient.Sender = autorest.CreateSender(autorest.AsIs())
 
 	client := Client{
-		azureVMsClient:    vmsClient,
 		azureGroupsClient: groupsClient,
 	}
 
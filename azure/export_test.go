@@ -1,11 +1,5 @@
 package azure
 
-func NewClientWithInjectedVMsClient(azureVMsClient AzureVMsClient) Client {
-	return Client{
-		azureVMsClient: azureVMsClient,
-	}
-}
-
 func NewClientWithInjectedGroupsClient(azureGroupsClient AzureGroupsClient) Client {
 	return Client{
 		azureGroupsClient: azureGroupsClient,
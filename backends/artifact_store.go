@@ -0,0 +1,70 @@
+package backends
+
+import (
+	"path"
+
+	bblstorage "github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type ArtifactStore struct {
+	provider Provider
+	bucket   string
+}
+
+func NewArtifactStore(provider Provider, bucket string) ArtifactStore {
+	return ArtifactStore{
+		provider: provider,
+		bucket:   bucket,
+	}
+}
+
+func (a ArtifactStore) IsConfigured() bool {
+	return a.bucket != ""
+}
+
+func (a ArtifactStore) Upload(state bblstorage.State, name, sourceDir string) error {
+	backend, err := a.provider.Client(state.IAAS)
+	if err != nil {
+		return err
+	}
+
+	return backend.PutArtifact(a.config(state, ""), a.objectName(state, name), sourceDir)
+}
+
+func (a ArtifactStore) Download(state bblstorage.State, name, destDir string) error {
+	backend, err := a.provider.Client(state.IAAS)
+	if err != nil {
+		return err
+	}
+
+	return backend.GetArtifact(a.config(state, destDir), a.objectName(state, name))
+}
+
+func (a ArtifactStore) objectName(state bblstorage.State, name string) string {
+	return path.Join(state.EnvID, "director-backups", name)
+}
+
+func (a ArtifactStore) config(state bblstorage.State, dest string) Config {
+	switch state.IAAS {
+	case "aws":
+		return Config{
+			Dest:               dest,
+			Bucket:             a.bucket,
+			Region:             state.AWS.Region,
+			AWSAccessKeyID:     state.AWS.AccessKeyID,
+			AWSSecretAccessKey: state.AWS.SecretAccessKey,
+		}
+	case "gcp":
+		return Config{
+			Dest:                 dest,
+			Bucket:               a.bucket,
+			Region:               state.GCP.Region,
+			GCPServiceAccountKey: state.GCP.ServiceAccountKey,
+		}
+	default:
+		return Config{
+			Dest:   dest,
+			Bucket: a.bucket,
+		}
+	}
+}
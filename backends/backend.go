@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/araddon/gou"
 	"github.com/cloudfoundry/bbl-state-resource/storage"
@@ -45,24 +46,14 @@ func (p provider) Client(iaas string) (Backend, error) {
 
 type Backend interface {
 	GetState(Config, string) error
+	GetArtifact(Config, string) error
+	PutArtifact(Config, string, string) error
 }
 
 type cloudStorageBackend struct{}
 
 func (c cloudStorageBackend) GetState(config Config, name string) error {
-	awsAuthSettings := make(gou.JsonHelper)
-	awsAuthSettings[awss3.ConfKeyAccessKey] = config.AWSAccessKeyID
-	awsAuthSettings[awss3.ConfKeyAccessSecret] = config.AWSSecretAccessKey
-
-	csConfig := cloudstorage.Config{
-		Type:       awss3.StoreType,
-		AuthMethod: awss3.AuthAccessKey,
-		Bucket:     config.Bucket,
-		Settings:   awsAuthSettings,
-		Region:     config.Region,
-	}
-
-	store, err := cloudstorage.NewStore(&csConfig)
+	store, err := c.store(config)
 	if err != nil {
 		return err
 	}
@@ -85,6 +76,68 @@ func (c cloudStorageBackend) GetState(config Config, name string) error {
 	return nil
 }
 
+func (c cloudStorageBackend) GetArtifact(config Config, name string) error {
+	store, err := c.store(config)
+	if err != nil {
+		return err
+	}
+
+	tarball, err := store.Get(context.Background(), name)
+	if err != nil {
+		return err
+	}
+
+	artifactTar, err := tarball.Open(cloudstorage.ReadOnly)
+	if err != nil {
+		return err
+	}
+
+	err = archiver.TarGz.Read(artifactTar, config.Dest)
+	if err != nil {
+		return fmt.Errorf("unable to untar artifact: %s", err)
+	}
+
+	return nil
+}
+
+func (c cloudStorageBackend) PutArtifact(config Config, name, sourceDir string) error {
+	store, err := c.store(config)
+	if err != nil {
+		return err
+	}
+
+	paths, err := artifactPaths(sourceDir)
+	if err != nil {
+		return err
+	}
+
+	writer, err := store.NewWriter(name, nil)
+	if err != nil {
+		return err
+	}
+
+	err = archiver.TarGz.Write(writer, paths)
+	if err != nil {
+		return fmt.Errorf("unable to tar artifact: %s", err)
+	}
+
+	return writer.Close()
+}
+
+func (c cloudStorageBackend) store(config Config) (cloudstorage.Store, error) {
+	awsAuthSettings := make(gou.JsonHelper)
+	awsAuthSettings[awss3.ConfKeyAccessKey] = config.AWSAccessKeyID
+	awsAuthSettings[awss3.ConfKeyAccessSecret] = config.AWSSecretAccessKey
+
+	return cloudstorage.NewStore(&cloudstorage.Config{
+		Type:       awss3.StoreType,
+		AuthMethod: awss3.AuthAccessKey,
+		Bucket:     config.Bucket,
+		Settings:   awsAuthSettings,
+		Region:     config.Region,
+	})
+}
+
 type gcsStateBackend struct{}
 
 func (g gcsStateBackend) GetState(config Config, name string) error {
@@ -118,3 +171,65 @@ func (g gcsStateBackend) getGCPServiceAccountKey(key string) (string, error) {
 
 	return string(keyBytes), nil
 }
+
+func (g gcsStateBackend) GetArtifact(config Config, name string) error {
+	gcsStorage, err := g.storage(config, name)
+	if err != nil {
+		return err
+	}
+
+	reader, err := gcsStorage.Object.NewReader()
+	if err != nil {
+		return fmt.Errorf("downloading artifact from GCS: %s", err)
+	}
+	defer reader.Close()
+
+	err = gcsStorage.Archiver.Read(reader, config.Dest)
+	if err != nil {
+		return fmt.Errorf("unable to untar artifact: %s", err)
+	}
+
+	return nil
+}
+
+func (g gcsStateBackend) PutArtifact(config Config, name, sourceDir string) error {
+	gcsStorage, err := g.storage(config, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = gcsStorage.Upload(sourceDir)
+	if err != nil {
+		return fmt.Errorf("uploading artifact to GCS: %s", err)
+	}
+
+	return nil
+}
+
+func (g gcsStateBackend) storage(config Config, name string) (storage.Storage, error) {
+	key, err := g.getGCPServiceAccountKey(config.GCPServiceAccountKey)
+	if err != nil {
+		return storage.Storage{}, fmt.Errorf("could not read GCP service account key: %s", err)
+	}
+
+	gcsStorage, err := storage.NewGCSStorage(key, name, config.Bucket)
+	if err != nil {
+		return storage.Storage{}, fmt.Errorf("could not create GCS client: %s", err)
+	}
+
+	return gcsStorage, nil
+}
+
+func artifactPaths(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, file := range files {
+		paths = append(paths, filepath.Join(dir, file.Name()))
+	}
+
+	return paths, nil
+}
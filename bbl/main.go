@@ -86,6 +86,8 @@ func main() {
 	stateValidator := application.NewStateValidator(appConfig.Global.StateDir)
 	certificateValidator := certs.NewValidator()
 	lbArgsHandler := commands.NewLBArgsHandler(certificateValidator)
+	vmTypesReader := cloudconfig.NewVMTypesReader(afs)
+	networkRangesReader := cloudconfig.NewNetworkRangesReader(afs)
 	sshCLI := ssh.NewCLI(os.Stdin, os.Stdout, os.Stderr)
 	pathFinder := helpers.NewPathFinder()
 
@@ -109,7 +111,8 @@ func main() {
 
 	// BOSH
 	hostKey := proxy.NewHostKey()
-	socks5Proxy := proxy.NewSocks5Proxy(hostKey, nil)
+	hostKeys := ssh.NewHostKeys(hostKey)
+	socks5Proxy := proxy.NewSocks5Proxy(hostKeys, nil)
 	boshPath, err := config.GetBOSHPath()
 	if err != nil {
 		log.Fatal(err)
@@ -117,16 +120,19 @@ func main() {
 	boshCommand := bosh.NewCLI(os.Stderr, boshPath)
 	boshExecutor := bosh.NewExecutor(boshCommand, afs)
 	sshKeyGetter := bosh.NewSSHKeyGetter(stateStore, afs)
+	sshCertificateGetter := bosh.NewSSHCertificateGetter(stateStore, afs, ssh.KeyGenerator{})
 	allProxyGetter := bosh.NewAllProxyGetter(sshKeyGetter, afs)
 	credhubGetter := bosh.NewCredhubGetter(stateStore, afs)
-	boshManager := bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, afs)
-	boshClientProvider := bosh.NewClientProvider(allProxyGetter, socks5Proxy, sshKeyGetter, boshPath)
+	boshClientProvider := bosh.NewClientProvider(socks5Proxy, sshKeyGetter, hostKeys, sshCertificateGetter, hostKeys)
+	boshManager := bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, ssh.KeyGenerator{}, boshClientProvider, afs)
+	tunnelProcess := ssh.NewTunnelProcess(stateStore, afs)
+	bbrCLI := bosh.NewBBRCLI(os.Stdout, os.Stderr, "bbr")
+	artifactStore := backends.NewArtifactStore(storageProvider, globals.StateBucket)
 
 	// Clients that require IAAS credentials.
 	var (
 		// function extract InitializeNetworkClients
-		networkClient            helpers.NetworkClient
-		networkDeletionValidator commands.NetworkDeletionValidator
+		networkClient helpers.NetworkClient
 
 		// function extract InitializeLeftovers
 		leftovers commands.FilteredDeleter
@@ -139,7 +145,6 @@ func main() {
 		case "aws":
 			awsClient = aws.NewClient(appConfig.State.AWS, logger)
 
-			networkDeletionValidator = awsClient
 			networkClient = awsClient
 
 			leftovers, err = awsleftovers.NewLeftovers(logger, appConfig.State.AWS.AccessKeyID, appConfig.State.AWS.SecretAccessKey, appConfig.State.AWS.Region)
@@ -153,7 +158,6 @@ func main() {
 				log.Fatalf("\n\n%s\n", err)
 			}
 
-			networkDeletionValidator = gcpClient
 			networkClient = gcpClient
 
 			gcpZonerHack := config.NewGCPZonerHack(gcpClient)
@@ -174,7 +178,6 @@ func main() {
 				log.Fatalf("\n\n%s\n", err)
 			}
 
-			networkDeletionValidator = azureClient
 			networkClient = azureClient
 
 			leftovers, err = azureleftovers.NewLeftovers(logger, appConfig.State.Azure.ClientID, appConfig.State.Azure.ClientSecret, appConfig.State.Azure.SubscriptionID, appConfig.State.Azure.TenantID)
@@ -245,30 +248,35 @@ func main() {
 		cloudConfigOpsGenerator = openstackcloudconfig.NewOpsGenerator(terraformManager)
 	}
 
-	cloudConfigManager := cloudconfig.NewManager(logger, boshCommand, stateStore, cloudConfigOpsGenerator, boshClientProvider, terraformManager, afs)
-	runtimeConfigManager := runtimeconfig.NewManager(logger, stateStore, boshClientProvider)
+	cloudConfigManager := cloudconfig.NewManager(logger, stateStore, cloudConfigOpsGenerator, boshClientProvider, terraformManager, afs)
+	runtimeConfigManager := runtimeconfig.NewManager(logger, boshCommand, stateStore, boshClientProvider, afs)
 
 	// Commands
 	var envIDManager helpers.EnvIDManager
 	if appConfig.State.IAAS != "" {
 		envIDManager = helpers.NewEnvIDManager(envIDGenerator, networkClient)
 	}
-	plan := commands.NewPlan(boshManager, cloudConfigManager, stateStore, patchDetector, envIDManager, terraformManager, lbArgsHandler, stderrLogger, Version)
-	up := commands.NewUp(plan, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager)
+	plan := commands.NewPlan(boshManager, cloudConfigManager, stateStore, patchDetector, envIDManager, terraformManager, lbArgsHandler, vmTypesReader, networkRangesReader, stderrLogger, Version)
+	up := commands.NewUp(plan, logger, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager, hostKeys, boshClientProvider)
 	usage := commands.NewUsage(logger)
 
 	commandSet := application.CommandSet{}
 	commandSet["help"] = usage
 	commandSet["version"] = commands.NewVersion(Version, logger)
 	commandSet["outputs"] = commands.NewOutputs(logger, terraformManager, stateValidator)
+	commandSet["cloud-config"] = commands.NewCloudConfig(logger, stateValidator, cloudConfigManager, boshClientProvider)
 	commandSet["up"] = up
 	commandSet["plan"] = plan
 	sshKeyDeleter := bosh.NewSSHKeyDeleter(stateStore, afs)
 	commandSet["rotate"] = commands.NewRotate(stateValidator, sshKeyDeleter, up)
-	commandSet["destroy"] = commands.NewDestroy(plan, logger, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator)
+	sshCADeleter := bosh.NewSSHCADeleter(stateStore, afs)
+	commandSet["jumpbox"] = commands.NewJumpbox(logger, stateValidator, stateStore, boshManager, terraformManager, hostKeys, sshCADeleter)
+	commandSet["destroy"] = commands.NewDestroy(plan, logger, boshManager, stateStore, stateValidator, terraformManager, boshClientProvider)
 	commandSet["down"] = commandSet["destroy"]
 	commandSet["cleanup-leftovers"] = commands.NewCleanupLeftovers(leftovers)
 	commandSet["leftovers"] = commandSet["cleanup-leftovers"]
+	commandSet["backup-director"] = commands.NewBackupDirector(logger, stateValidator, bbrCLI, sshKeyGetter, allProxyGetter, boshClientProvider, pathFinder, artifactStore, afs)
+	commandSet["restore-director"] = commands.NewRestoreDirector(logger, stateValidator, bbrCLI, sshKeyGetter, allProxyGetter, boshClientProvider, pathFinder, artifactStore, afs)
 	commandSet["lbs"] = commands.NewLBs(lbsCmd, stateValidator)
 	commandSet["jumpbox-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.JumpboxAddressPropertyName)
 	commandSet["director-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorAddressPropertyName)
@@ -280,8 +288,10 @@ func main() {
 	commandSet["director-ssh-key"] = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter)
 	commandSet["env-id"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.EnvIDPropertyName)
 	commandSet["latest-error"] = commands.NewLatestError(logger, stateValidator)
-	commandSet["print-env"] = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, afs)
-	commandSet["ssh"] = commands.NewSSH(sshCLI, sshKeyGetter, pathFinder, afs, ssh.RandomPort{})
+	commandSet["print-env"] = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, tunnelProcess, afs, sshCertificateGetter)
+	commandSet["doctor"] = commands.NewDoctor(logger, stateValidator, pathFinder, boshManager, terraformManager, sshKeyGetter, hostKeys, boshClientProvider, credhubGetter)
+	commandSet["tunnel"] = commands.NewTunnel(logger, stateValidator, sshKeyGetter, tunnelProcess, ssh.NewTunnelServer(socks5Proxy, hostKeys, sshCertificateGetter, hostKeys, nil))
+	commandSet["ssh"] = commands.NewSSH(sshCLI, sshKeyGetter, afs, ssh.NewTunnelOpener(boshClientProvider, log.New(os.Stderr, "", 0)), boshClientProvider, ssh.KeyGenerator{}, sshCertificateGetter)
 
 	app := application.New(commandSet, appConfig, usage)
 
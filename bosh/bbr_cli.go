@@ -0,0 +1,32 @@
+package bosh
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+type BBRCLI struct {
+	stdout io.Writer
+	stderr io.Writer
+	path   string
+}
+
+func NewBBRCLI(stdout, stderr io.Writer, path string) BBRCLI {
+	return BBRCLI{
+		stdout: stdout,
+		stderr: stderr,
+		path:   path,
+	}
+}
+
+func (c BBRCLI) Run(workingDirectory, boshAllProxy string, args []string) error {
+	command := exec.Command(c.path, args...)
+	command.Dir = workingDirectory
+	command.Env = append(os.Environ(), "BOSH_ALL_PROXY="+boshAllProxy)
+
+	command.Stdout = c.stdout
+	command.Stderr = c.stderr
+
+	return command.Run()
+}
@@ -2,17 +2,18 @@ package bosh
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
 
+// CIDRBlock is an IPv4 or IPv6 CIDR block.
 type CIDRBlock struct {
-	CIDRSize int
+	maskBits int
 	firstIP  IP
 }
 
 func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
-	const HIGHEST_BITMASK = 32
 	const CIDR_PARTS = 2
 
 	cidrParts := strings.Split(cidrBlock, "/")
@@ -31,17 +32,21 @@ func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
 		return CIDRBlock{}, err
 	}
 
-	if maskBits < 0 || maskBits > HIGHEST_BITMASK {
+	if maskBits < 0 || maskBits > ip.len()*8 {
 		return CIDRBlock{}, fmt.Errorf("mask bits out of range")
 	}
 
-	cidrSize := 1 << (HIGHEST_BITMASK - uint(maskBits))
 	return CIDRBlock{
-		CIDRSize: cidrSize,
+		maskBits: maskBits,
 		firstIP:  ip,
 	}, nil
 }
 
+// IsIPv6 returns true for IPv6 CIDR blocks.
+func (c CIDRBlock) IsIPv6() bool {
+	return c.firstIP.IsIPv6()
+}
+
 func (c CIDRBlock) GetFirstIP() IP {
 	return c.GetNthIP(0)
 }
@@ -51,5 +56,12 @@ func (c CIDRBlock) GetNthIP(n int) IP {
 }
 
 func (c CIDRBlock) GetLastIP() IP {
-	return c.GetNthIP(c.CIDRSize - 1)
+	hostBits := uint(c.firstIP.len()*8 - c.maskBits)
+	size := new(big.Int).Lsh(big.NewInt(1), hostBits)
+	return c.firstIP.add(size.Sub(size, big.NewInt(1)))
+}
+
+// Contains returns true when ip is in the cidr block.
+func (c CIDRBlock) Contains(ip IP) bool {
+	return c.GetFirstIP().Compare(ip) <= 0 && ip.Compare(c.GetLastIP()) <= 0
 }
@@ -39,6 +39,47 @@ var _ = Describe("CIDRBlock", func() {
 		})
 	})
 
+	Describe("Contains", func() {
+		It("returns true for the ips of the cidr block", func() {
+			for _, address := range []string{"10.0.16.0", "10.0.20.7", "10.0.31.255"} {
+				ip, err := bosh.ParseIP(address)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cidrBlock.Contains(ip)).To(BeTrue(), address)
+			}
+		})
+
+		It("returns false for other ips", func() {
+			for _, address := range []string{"10.0.15.255", "10.0.32.0", "2600:1f18:4ab:ee01::1"} {
+				ip, err := bosh.ParseIP(address)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cidrBlock.Contains(ip)).To(BeFalse(), address)
+			}
+		})
+	})
+
+	Context("when the cidr block is ipv6", func() {
+		BeforeEach(func() {
+			var err error
+			cidrBlock, err = bosh.ParseCIDRBlock("2600:1f18:4ab:ee01::/64")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the ips of the cidr block", func() {
+			Expect(cidrBlock.IsIPv6()).To(BeTrue())
+			Expect(cidrBlock.GetFirstIP().String()).To(Equal("2600:1f18:4ab:ee01::"))
+			Expect(cidrBlock.GetNthIP(6).String()).To(Equal("2600:1f18:4ab:ee01::6"))
+			Expect(cidrBlock.GetLastIP().String()).To(Equal("2600:1f18:4ab:ee01:ffff:ffff:ffff:ffff"))
+		})
+
+		It("allows mask bits up to 128", func() {
+			_, err := bosh.ParseCIDRBlock("2600:1f18:4ab:ee01::/128")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = bosh.ParseCIDRBlock("2600:1f18:4ab:ee01::/129")
+			Expect(err).To(MatchError(ContainSubstring("mask bits out of range")))
+		})
+	})
+
 	Describe("ParseCIDRBlock", func() {
 		Context("failure cases", func() {
 			Context("when input string is not a valid CIDR block", func() {
@@ -68,6 +109,13 @@ var _ = Describe("CIDRBlock", func() {
 					Expect(err).To(MatchError(ContainSubstring("mask bits out of range")))
 				})
 			})
+
+			Context("when ipv4 mask bits are in the ipv6 range", func() {
+				It("returns an error", func() {
+					_, err := bosh.ParseCIDRBlock("10.0.0.0/64")
+					Expect(err).To(MatchError(ContainSubstring("mask bits out of range")))
+				})
+			})
 		})
 	})
 })
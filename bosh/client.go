@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,8 +21,30 @@ type ConfigUpdater interface {
 	Info() (Info, error)
 }
 
-type RuntimeConfigUpdater interface {
-	UpdateRuntimeConfig(filepath, name string) error
+type DeploymentDeleter interface {
+	Deployments() ([]Deployment, error)
+	RunningTasks() ([]Task, error)
+	DeleteDeployment(name string) error
+}
+
+type DirectorClient interface {
+	ConfigUpdater
+	DeploymentDeleter
+
+	UpdateConfig(configType, name string, content []byte) error
+	Configs(configType, name string) ([]Config, error)
+	LatestConfig(configType, name string) (Config, error)
+	DiffConfig(configType, name string, content []byte) ([]DiffLine, error)
+
+	Stemcells() ([]Stemcell, error)
+	UploadStemcell(location, sha1 string) error
+
+	Task(id int) (Task, error)
+	TaskOutput(id int, outputType string) (string, error)
+	WaitForTask(id int) error
+
+	SetupSSH(deployment string, target SSHTarget, user, publicKey string) ([]SSHHost, error)
+	CleanupSSH(deployment string, target SSHTarget, user string) error
 }
 
 type Info struct {
@@ -28,28 +53,100 @@ type Info struct {
 	Version string `json:"version"`
 }
 
+type Deployment struct {
+	Name string `json:"name"`
+}
+
+type Task struct {
+	ID          int    `json:"id"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	Deployment  string `json:"deployment"`
+	Result      string `json:"result"`
+}
+
+type Stemcell struct {
+	Name            string       `json:"name"`
+	OperatingSystem string       `json:"operating_system"`
+	Version         string       `json:"version"`
+	CID             string       `json:"cid"`
+	Deployments     []Deployment `json:"deployments"`
+}
+
+type Config struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SSHTarget selects the instances of a deployment to set up ssh access on.
+// IDs may hold instance indexes or uuids.
+type SSHTarget struct {
+	Job     string   `json:"job"`
+	Indexes []string `json:"indexes"`
+	IDs     []string `json:"ids"`
+}
+
+type SSHHost struct {
+	Status        string `json:"status"`
+	IP            string `json:"ip"`
+	HostPublicKey string `json:"host_public_key"`
+	Job           string `json:"job"`
+	Index         int    `json:"index"`
+	ID            string `json:"id"`
+}
+
+type sshRequestBody struct {
+	Command        string            `json:"command"`
+	DeploymentName string            `json:"deployment_name"`
+	Target         SSHTarget         `json:"target"`
+	Params         map[string]string `json:"params"`
+}
+
+// DiffLine is a single line of a director config diff. State is "added",
+// "removed" or empty for unchanged context lines.
+type DiffLine struct {
+	Text  string
+	State string
+}
+
 var (
 	MAX_RETRIES = 5
 	RETRY_DELAY = 10 * time.Second
 )
 
+const (
+	taskPollInterval = 5 * time.Second
+	taskTimeout      = 2 * time.Hour
+)
+
 type Client struct {
-	DirectorAddress string
-	UAAAddress      string
-	username        string
-	password        string
-	caCert          string
-	httpClient      *http.Client
+	DirectorAddress  string
+	UAAAddress       string
+	username         string
+	password         string
+	caCert           string
+	httpClient       *http.Client
+	maxRetries       int
+	retryDelay       time.Duration
+	taskPollInterval time.Duration
+	taskTimeout      time.Duration
 }
 
 func NewClient(httpClient *http.Client, DirectorAddress, uaaAddress, username, password, caCert string) Client {
 	return Client{
-		DirectorAddress: DirectorAddress,
-		UAAAddress:      uaaAddress,
-		username:        username,
-		password:        password,
-		caCert:          caCert,
-		httpClient:      httpClient,
+		DirectorAddress:  DirectorAddress,
+		UAAAddress:       uaaAddress,
+		username:         username,
+		password:         password,
+		caCert:           caCert,
+		httpClient:       httpClient,
+		maxRetries:       MAX_RETRIES,
+		retryDelay:       RETRY_DELAY,
+		taskPollInterval: taskPollInterval,
+		taskTimeout:      taskTimeout,
 	}
 }
 
@@ -59,10 +156,11 @@ func (c Client) Info() (Info, error) {
 		return Info{}, err
 	}
 
-	response, err := makeRequests(c.httpClient, request)
+	response, err := c.makeRequests(c.httpClient, request)
 	if err != nil {
 		return Info{}, err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		return Info{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
@@ -83,67 +181,415 @@ type ConfigRequestBody struct {
 }
 
 func (c Client) UpdateConfig(configType, name string, content []byte) error {
-	var body bytes.Buffer
+	request, err := newJSONRequest("POST", fmt.Sprintf("%s/configs", c.DirectorAddress), ConfigRequestBody{
+		Name:    name,
+		Type:    configType,
+		Content: string(content),
+	})
+	if err != nil {
+		return err
+	}
+
+	response, err := c.makeRequests(c.authenticatedHTTPClient(), request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
 
-	config := ConfigRequestBody{
+func (c Client) UpdateCloudConfig(yaml []byte) error {
+	return c.UpdateConfig("cloud", "default", yaml)
+}
+
+func (c Client) Configs(configType, name string) ([]Config, error) {
+	query := url.Values{}
+	query.Set("latest", "true")
+	if configType != "" {
+		query.Set("type", configType)
+	}
+	if name != "" {
+		query.Set("name", name)
+	}
+
+	var configs []Config
+	err := c.getJSON(fmt.Sprintf("/configs?%s", query.Encode()), &configs)
+	if err != nil {
+		return []Config{}, err
+	}
+
+	return configs, nil
+}
+
+func (c Client) LatestConfig(configType, name string) (Config, error) {
+	configs, err := c.Configs(configType, name)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if len(configs) == 0 {
+		return Config{}, fmt.Errorf("no %s config named %q", configType, name)
+	}
+
+	return configs[0], nil
+}
+
+func (c Client) DiffConfig(configType, name string, content []byte) ([]DiffLine, error) {
+	request, err := newJSONRequest("POST", fmt.Sprintf("%s/configs/diff", c.DirectorAddress), ConfigRequestBody{
 		Name:    name,
 		Type:    configType,
 		Content: string(content),
+	})
+	if err != nil {
+		return []DiffLine{}, err
 	}
 
-	var err error
-	err = json.NewEncoder(&body).Encode(config)
+	response, err := c.makeRequests(c.authenticatedHTTPClient(), request)
 	if err != nil {
-		return err // untested
+		return []DiffLine{}, err
 	}
+	defer response.Body.Close()
 
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s/configs", c.DirectorAddress), &body)
+	if response.StatusCode != http.StatusOK {
+		return []DiffLine{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var body struct {
+		Diff [][]*string `json:"diff"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return []DiffLine{}, err
+	}
+
+	lines := []DiffLine{}
+	for _, entry := range body.Diff {
+		var line DiffLine
+		if len(entry) > 0 && entry[0] != nil {
+			line.Text = *entry[0]
+		}
+		if len(entry) > 1 && entry[1] != nil {
+			line.State = *entry[1]
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func (c Client) Deployments() ([]Deployment, error) {
+	var deployments []Deployment
+	err := c.getJSON("/deployments", &deployments)
+	if err != nil {
+		return []Deployment{}, err
+	}
+
+	return deployments, nil
+}
+
+func (c Client) DeleteDeployment(name string) error {
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/deployments/%s", c.DirectorAddress, url.PathEscape(name)), strings.NewReader(""))
 	if err != nil {
 		return err
 	}
-	request.Header.Set("Content-Type", "application/json")
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
 
-	conf := &clientcredentials.Config{
-		ClientID:     c.username,
-		ClientSecret: c.password,
-		TokenURL:     fmt.Sprintf("%s/oauth/token", c.UAAAddress),
+	return c.runTask(request)
+}
+
+func (c Client) Stemcells() ([]Stemcell, error) {
+	var stemcells []Stemcell
+	err := c.getJSON("/stemcells", &stemcells)
+	if err != nil {
+		return []Stemcell{}, err
 	}
 
-	httpClient := conf.Client(ctx)
-	response, err := makeRequests(httpClient, request)
+	return stemcells, nil
+}
+
+// UploadStemcell asks the director to fetch the stemcell at location and
+// waits for the upload task to finish.
+func (c Client) UploadStemcell(location, sha1 string) error {
+	body := struct {
+		Location string `json:"location"`
+		SHA1     string `json:"sha1,omitempty"`
+	}{
+		Location: location,
+		SHA1:     sha1,
+	}
+
+	request, err := newJSONRequest("POST", fmt.Sprintf("%s/stemcells", c.DirectorAddress), body)
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode != http.StatusCreated {
+	return c.runTask(request)
+}
+
+// SetupSSH creates a temporary user with the given public key on the target
+// instances and returns their addresses and host keys.
+func (c Client) SetupSSH(deployment string, target SSHTarget, user, publicKey string) ([]SSHHost, error) {
+	taskID, err := c.startSSHTask(deployment, sshRequestBody{
+		Command: "setup",
+		Target:  target,
+		Params: map[string]string{
+			"user":       user,
+			"public_key": publicKey,
+		},
+	})
+	if err != nil {
+		return []SSHHost{}, err
+	}
+
+	err = c.WaitForTask(taskID)
+	if err != nil {
+		return []SSHHost{}, err
+	}
+
+	output, err := c.TaskOutput(taskID, "result")
+	if err != nil {
+		return []SSHHost{}, err
+	}
+
+	var hosts []SSHHost
+	err = json.Unmarshal([]byte(output), &hosts)
+	if err != nil {
+		return []SSHHost{}, fmt.Errorf("parse ssh setup result: %s", err)
+	}
+
+	for _, host := range hosts {
+		if host.Status != "success" {
+			return []SSHHost{}, fmt.Errorf("ssh setup failed on %s/%s", host.Job, host.ID)
+		}
+	}
+
+	return hosts, nil
+}
+
+// CleanupSSH removes the temporary user created by SetupSSH.
+func (c Client) CleanupSSH(deployment string, target SSHTarget, user string) error {
+	taskID, err := c.startSSHTask(deployment, sshRequestBody{
+		Command: "cleanup",
+		Target:  target,
+		Params: map[string]string{
+			"user_regex": fmt.Sprintf("^%s$", user),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.WaitForTask(taskID)
+}
+
+func (c Client) startSSHTask(deployment string, body sshRequestBody) (int, error) {
+	body.DeploymentName = deployment
+	if body.Target.Indexes == nil {
+		body.Target.Indexes = []string{}
+	}
+	if body.Target.IDs == nil {
+		body.Target.IDs = []string{}
+	}
+
+	request, err := newJSONRequest("POST", fmt.Sprintf("%s/deployments/%s/ssh", c.DirectorAddress, url.PathEscape(deployment)), body)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.startTask(request)
+}
+
+func (c Client) RunningTasks() ([]Task, error) {
+	var tasks []Task
+	err := c.getJSON("/tasks?state=queued,processing,cancelling&verbose=2", &tasks)
+	if err != nil {
+		return []Task{}, err
+	}
+
+	return tasks, nil
+}
+
+func (c Client) Task(id int) (Task, error) {
+	var task Task
+	err := c.getJSON(fmt.Sprintf("/tasks/%d", id), &task)
+	if err != nil {
+		return Task{}, err
+	}
+
+	return task, nil
+}
+
+// TaskOutput returns the raw "event", "result" or "debug" output of a task.
+func (c Client) TaskOutput(id int, outputType string) (string, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/tasks/%d/output?type=%s", c.DirectorAddress, id, url.QueryEscape(outputType)), strings.NewReader(""))
+	if err != nil {
+		return "", err
+	}
+
+	response, err := c.makeRequests(c.authenticatedHTTPClient(), request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return "", fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	output, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(output), nil
+}
+
+// WaitForTask polls the task until it reaches a terminal state and returns
+// an error including the task result when it did not finish successfully,
+// or when it is still running after the task timeout of the client.
+func (c Client) WaitForTask(id int) error {
+	deadline := time.Now().Add(c.taskTimeout)
+	for {
+		task, err := c.Task(id)
+		if err != nil {
+			return err
+		}
+
+		switch task.State {
+		case "done":
+			return nil
+		case "error", "cancelled", "timeout":
+			return fmt.Errorf("task %d %s: %s", id, task.State, task.Result)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("task %d is still %s after %s", id, task.State, c.taskTimeout)
+		}
+
+		time.Sleep(c.taskPollInterval)
+	}
+}
+
+func (c Client) runTask(request *http.Request) error {
+	taskID, err := c.startTask(request)
+	if err != nil {
+		return err
+	}
+
+	return c.WaitForTask(taskID)
+}
+
+// startTask makes a request that the director answers with a redirect to a
+// task, and returns the task id.
+func (c Client) startTask(request *http.Request) (int, error) {
+	httpClient := c.authenticatedHTTPClient()
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	response, err := c.makeRequests(httpClient, request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusFound {
+		return 0, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return taskIDFromLocation(response.Header.Get("Location"))
+}
+
+func (c Client) getJSON(path string, v interface{}) error {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s%s", c.DirectorAddress, path), strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	response, err := c.makeRequests(c.authenticatedHTTPClient(), request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
-	return nil
+	return json.NewDecoder(response.Body).Decode(v)
 }
 
-func (c Client) UpdateCloudConfig(yaml []byte) error {
-	return c.UpdateConfig("cloud", "default", yaml)
+func (c Client) authenticatedHTTPClient() *http.Client {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+
+	conf := &clientcredentials.Config{
+		ClientID:     c.username,
+		ClientSecret: c.password,
+		TokenURL:     fmt.Sprintf("%s/oauth/token", c.UAAAddress),
+	}
+
+	return conf.Client(ctx)
 }
 
-func makeRequests(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+func (c Client) makeRequests(httpClient *http.Client, request *http.Request) (*http.Response, error) {
 	var (
 		response *http.Response
 		err      error
 	)
 
-	for i := 0; i < MAX_RETRIES; i++ {
+	attempts := c.maxRetries
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for i := 0; i < attempts; i++ {
+		if i > 0 && request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return &http.Response{}, err // not tested
+			}
+		}
+
 		response, err = httpClient.Do(request)
 		if err == nil {
 			break
 		}
-		time.Sleep(RETRY_DELAY)
+		if i < attempts-1 {
+			time.Sleep(c.retryDelay)
+		}
 	}
 	if err != nil {
-		return &http.Response{}, fmt.Errorf("made %d attempts, last error: %s", MAX_RETRIES, err)
+		return &http.Response{}, fmt.Errorf("made %d attempts, last error: %s", attempts, err)
 	}
 
 	return response, nil
 }
+
+func newJSONRequest(method, address string, body interface{}) (*http.Request, error) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	request, err := http.NewRequest(method, address, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	return request, nil
+}
+
+func taskIDFromLocation(location string) (int, error) {
+	parts := strings.Split(strings.TrimSuffix(location, "/"), "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0, fmt.Errorf("unexpected task location %q", location)
+	}
+
+	return id, nil
+}
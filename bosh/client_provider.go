@@ -3,65 +3,173 @@ package bosh
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	socks5proxy "github.com/cloudfoundry/socks5-proxy"
 	"golang.org/x/net/proxy"
 )
 
 var proxySOCKS5 func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dialer, error) = proxy.SOCKS5
 
+var (
+	netDial            = net.DialTimeout
+	proxyReadyInterval = 100 * time.Millisecond
+)
+
+const proxyReadyAttempts = 50
+
 type ClientProvider struct {
-	allProxyGetter allProxyGetter
-	socks5Proxy    socks5Proxy
-	sshKeyGetter   sshKeyGetter
-	boshCLIPath    string
+	socks5Proxy       socks5Proxy
+	sshKeyGetter      sshKeyGetter
+	hostKeys          hostKeyPinner
+	certificateGetter sshCertificateGetter
+	certificateDialer certificateDialer
+	proxyMutex        *sync.Mutex
+	proxyStarted      *bool
 }
 
 type socks5Proxy interface {
 	Start(username string, key string, address string) error
+	StartWithDialer(dialer socks5proxy.DialFunc) error
 	Addr() (string, error)
 }
 
-type allProxyGetter interface {
-	GeneratePrivateKey() (string, error)
-	BoshAllProxy(string, string) string
+type hostKeyPinner interface {
+	Pin(serverURL, hostKey string)
+}
+
+type sshCertificateGetter interface {
+	Get() (privateKey string, certificate string, err error)
+}
+
+type certificateDialer interface {
+	Dialer(username, privateKey, certificate, serverURL string) (socks5proxy.DialFunc, error)
 }
 
-func NewClientProvider(allProxyGetter allProxyGetter, socks5Proxy socks5Proxy, sshKeyGetter sshKeyGetter, boshCLIPath string) ClientProvider {
+func NewClientProvider(socks5Proxy socks5Proxy, sshKeyGetter sshKeyGetter, hostKeys hostKeyPinner,
+	certificateGetter sshCertificateGetter, certificateDialer certificateDialer) ClientProvider {
 	return ClientProvider{
-		allProxyGetter: allProxyGetter,
-		socks5Proxy:    socks5Proxy,
-		sshKeyGetter:   sshKeyGetter,
-		boshCLIPath:    boshCLIPath,
+		socks5Proxy:       socks5Proxy,
+		sshKeyGetter:      sshKeyGetter,
+		hostKeys:          hostKeys,
+		certificateGetter: certificateGetter,
+		certificateDialer: certificateDialer,
+		proxyMutex:        &sync.Mutex{},
+		proxyStarted:      new(bool),
 	}
 }
 
-func (c ClientProvider) Dialer(jumpbox storage.Jumpbox) (proxy.Dialer, error) {
-	privateKey, err := c.sshKeyGetter.Get("jumpbox")
+// Dialer returns a dialer that tunnels through the jumpbox. Environments
+// created with --no-jumpbox are dialed directly. The jumpbox must present
+// the host key recorded in its state.
+func (c ClientProvider) Dialer(state storage.State) (proxy.Dialer, error) {
+	if state.NoJumpbox {
+		return proxy.Direct, nil
+	}
+
+	addr, err := c.ProxyAddr(state)
 	if err != nil {
-		return nil, fmt.Errorf("get jumpbox ssh key: %s", err)
+		return nil, err
 	}
 
-	err = c.socks5Proxy.Start("", privateKey, jumpbox.URL)
+	socks5Dialer, err := proxySOCKS5("tcp", addr, nil, proxy.Direct)
 	if err != nil {
-		return nil, fmt.Errorf("start proxy: %s", err)
+		return nil, fmt.Errorf("create socks5 client: %s", err)
 	}
+
+	return socks5Dialer, nil
+}
+
+// ProxyAddr starts the socks5 proxy through the jumpbox, if it is not
+// running yet, and returns the address it listens on. The jumpbox must
+// present the host key recorded in its state.
+func (c ClientProvider) ProxyAddr(state storage.State) (string, error) {
+	jumpbox := state.Jumpbox
+	if jumpbox.URL == "" {
+		return "", errors.New("jumpbox url is missing from the state")
+	}
+
+	c.hostKeys.Pin(jumpbox.URL, jumpbox.HostKey)
+
+	err := c.startProxy(jumpbox.URL)
+	if err != nil {
+		return "", err
+	}
+
 	addr, err := c.socks5Proxy.Addr()
 	if err != nil {
-		return nil, fmt.Errorf("get proxy address: %s", err)
+		return "", fmt.Errorf("get proxy address: %s", err)
 	}
 
-	socks5Dialer, err := proxySOCKS5("tcp", addr, nil, proxy.Direct)
+	err = waitForProxy(addr)
 	if err != nil {
-		return nil, fmt.Errorf("create socks5 client: %s", err)
+		return "", fmt.Errorf("wait for proxy: %s", err)
 	}
 
-	return socks5Dialer, nil
+	return addr, nil
+}
+
+// startProxy logs in to jumpboxes that trust an ssh CA with a short-lived
+// certificate, and to others with the jumpbox key.
+func (c ClientProvider) startProxy(jumpboxURL string) error {
+	c.proxyMutex.Lock()
+	defer c.proxyMutex.Unlock()
+
+	if *c.proxyStarted {
+		return nil
+	}
+
+	privateKey, certificate, err := c.certificateGetter.Get()
+	if err != nil {
+		return fmt.Errorf("get jumpbox ssh certificate: %s", err)
+	}
+
+	if certificate == "" {
+		privateKey, err = c.sshKeyGetter.Get("jumpbox")
+		if err != nil {
+			return fmt.Errorf("get jumpbox ssh key: %s", err)
+		}
+
+		err = c.socks5Proxy.Start("", privateKey, jumpboxURL)
+		if err != nil {
+			return fmt.Errorf("start proxy: %s", err)
+		}
+	} else {
+		dialer, err := c.certificateDialer.Dialer("jumpbox", privateKey, certificate, jumpboxURL)
+		if err != nil {
+			return fmt.Errorf("start proxy: %s", err)
+		}
+
+		err = c.socks5Proxy.StartWithDialer(dialer)
+		if err != nil {
+			return fmt.Errorf("start proxy: %s", err)
+		}
+	}
+
+	*c.proxyStarted = true
+	return nil
+}
+
+// waitForProxy blocks until the socks5 proxy, which listens on a separate
+// goroutine, accepts connections.
+func waitForProxy(addr string) error {
+	var err error
+	for i := 0; i < proxyReadyAttempts; i++ {
+		var conn net.Conn
+		conn, err = netDial("tcp", addr, proxyReadyInterval)
+		if err == nil {
+			return conn.Close()
+		}
+		time.Sleep(proxyReadyInterval)
+	}
+	return err
 }
 
 func (ClientProvider) HTTPClient(dialer proxy.Dialer, directorCACert []byte) *http.Client {
@@ -79,6 +187,10 @@ func (ClientProvider) HTTPClient(dialer proxy.Dialer, directorCACert []byte) *ht
 	}
 }
 
+func (c ClientProvider) EndpointChecker(dialer proxy.Dialer, caCerts []byte) EndpointChecker {
+	return NewHealthChecker(c.HTTPClient(dialer, caCerts))
+}
+
 func parseHostFromAddress(address string) (string, error) {
 	urlParts, err := url.Parse(address)
 	if err != nil {
@@ -89,29 +201,20 @@ func parseHostFromAddress(address string) (string, error) {
 	return boshHost, err
 }
 
-func (c ClientProvider) Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, directorCACert string) (ConfigUpdater, error) {
-	dialer, err := c.Dialer(jumpbox)
+func (c ClientProvider) Client(state storage.State) (DirectorClient, error) {
+	dialer, err := c.Dialer(state)
 	if err != nil {
-		return Client{}, err // not tested
+		return Client{}, err
 	}
 
+	directorAddress := state.BOSH.DirectorAddress
 	boshHost, err := parseHostFromAddress(directorAddress)
 	if err != nil {
 		return Client{}, err
 	}
 	uaaAddress := fmt.Sprintf("https://%s:8443", boshHost)
 
-	httpClient := c.HTTPClient(dialer, []byte(directorCACert))
-	boshClient := NewClient(httpClient, directorAddress, uaaAddress, directorUsername, directorPassword, directorCACert)
+	httpClient := c.HTTPClient(dialer, []byte(state.BOSH.DirectorSSLCA))
+	boshClient := NewClient(httpClient, directorAddress, uaaAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
 	return boshClient, nil
 }
-
-func (c ClientProvider) BoshCLI(jumpbox storage.Jumpbox, stderr io.Writer, directorAddress, directorUsername, directorPassword, directorCACert string) (RuntimeConfigUpdater, error) {
-	privateKey, err := c.allProxyGetter.GeneratePrivateKey()
-	if err != nil {
-		return BOSHCLI{}, err
-	}
-
-	boshAllProxy := c.allProxyGetter.BoshAllProxy(jumpbox.URL, privateKey)
-	return NewBOSHCLI(stderr, c.boshCLIPath, directorAddress, directorUsername, directorPassword, directorCACert, boshAllProxy), nil
-}
@@ -3,7 +3,9 @@ package bosh_test
 import (
 	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
@@ -16,19 +18,36 @@ import (
 var _ = Describe("Client Provider", func() {
 	var (
 		clientProvider bosh.ClientProvider
-		jumpbox        storage.Jumpbox
-		allProxyGetter *fakes.AllProxyGetter
+		state          storage.State
 		socks5Proxy    *fakes.Socks5Proxy
 		sshKeyGetter   *fakes.SSHKeyGetter
+		hostKeys       *fakes.HostKeyPinner
+		certGetter     *fakes.SSHCertificateGetter
+		certDialer     *fakes.CertificateDialer
+		dialedAddrs    []string
 	)
 
 	BeforeEach(func() {
-		allProxyGetter = &fakes.AllProxyGetter{}
 		socks5Proxy = &fakes.Socks5Proxy{}
 		sshKeyGetter = &fakes.SSHKeyGetter{}
 		sshKeyGetter.GetCall.Returns.PrivateKey = "some-private-key"
+		hostKeys = &fakes.HostKeyPinner{}
+		certGetter = &fakes.SSHCertificateGetter{}
+		certDialer = &fakes.CertificateDialer{}
+		state = storage.State{Jumpbox: storage.Jumpbox{URL: "https://some-jumpbox"}}
 
-		clientProvider = bosh.NewClientProvider(allProxyGetter, socks5Proxy, sshKeyGetter, "some-path-to-bosh")
+		dialedAddrs = []string{}
+		bosh.SetNetDial(func(network, addr string, timeout time.Duration) (net.Conn, error) {
+			dialedAddrs = append(dialedAddrs, addr)
+			conn, _ := net.Pipe()
+			return conn, nil
+		})
+
+		clientProvider = bosh.NewClientProvider(socks5Proxy, sshKeyGetter, hostKeys, certGetter, certDialer)
+	})
+
+	AfterEach(func() {
+		bosh.ResetNetDial()
 	})
 
 	Describe("Dialer", func() {
@@ -58,7 +77,7 @@ var _ = Describe("Client Provider", func() {
 
 		Context("when using a jumpbox", func() {
 			It("starts the socks 5 proxy to the jumpbox and returns a socks 5 client", func() {
-				proxyDialer, err := clientProvider.Dialer(storage.Jumpbox{URL: "https://some-jumpbox"})
+				proxyDialer, err := clientProvider.Dialer(state)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(proxyDialer).To(Equal(fakeDialer))
 
@@ -75,12 +94,98 @@ var _ = Describe("Client Provider", func() {
 				Expect(socks5Forward).To(Equal(proxy.Direct))
 			})
 
+			It("pins the host key of the jumpbox before starting the proxy", func() {
+				_, err := clientProvider.Dialer(storage.State{
+					Jumpbox: storage.Jumpbox{URL: "https://some-jumpbox", HostKey: "ssh-rsa some-host-key"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(hostKeys.PinCall.CallCount).To(Equal(1))
+				Expect(hostKeys.PinCall.Receives.ServerURL).To(Equal("https://some-jumpbox"))
+				Expect(hostKeys.PinCall.Receives.HostKey).To(Equal("ssh-rsa some-host-key"))
+			})
+
+			It("waits for the socks 5 proxy to accept connections", func() {
+				bosh.SetProxyReadyInterval(time.Millisecond)
+				defer bosh.ResetProxyReadyInterval()
+
+				bosh.SetNetDial(func(network, addr string, timeout time.Duration) (net.Conn, error) {
+					dialedAddrs = append(dialedAddrs, addr)
+					if len(dialedAddrs) < 3 {
+						return nil, errors.New("connection refused")
+					}
+					conn, _ := net.Pipe()
+					return conn, nil
+				})
+
+				_, err := clientProvider.Dialer(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(dialedAddrs).To(Equal([]string{
+					"some-socks-proxy-addr",
+					"some-socks-proxy-addr",
+					"some-socks-proxy-addr",
+				}))
+			})
+
+			It("starts the proxy only once", func() {
+				_, err := clientProvider.Dialer(state)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = clientProvider.Dialer(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+				Expect(sshKeyGetter.GetCall.CallCount).To(Equal(1))
+			})
+
+			Context("when the jumpbox trusts an ssh CA", func() {
+				var dialFunc *fakes.Dialer
+
+				BeforeEach(func() {
+					certGetter.GetCall.Returns.PrivateKey = "some-short-lived-key"
+					certGetter.GetCall.Returns.Certificate = "some-certificate"
+					dialFunc = &fakes.Dialer{}
+					certDialer.DialerCall.Returns.Dialer = dialFunc.Dial
+				})
+
+				It("starts the proxy with a short-lived certificate instead of the jumpbox key", func() {
+					_, err := clientProvider.Dialer(state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(certDialer.DialerCall.Receives.Username).To(Equal("jumpbox"))
+					Expect(certDialer.DialerCall.Receives.PrivateKey).To(Equal("some-short-lived-key"))
+					Expect(certDialer.DialerCall.Receives.Certificate).To(Equal("some-certificate"))
+					Expect(certDialer.DialerCall.Receives.ServerURL).To(Equal("https://some-jumpbox"))
+
+					Expect(socks5Proxy.StartWithDialerCall.CallCount).To(Equal(1))
+					socks5Proxy.StartWithDialerCall.Receives.Dialer("tcp", "some-addr")
+					Expect(dialFunc.DialCall.Receives.Addr).To(Equal("some-addr"))
+
+					Expect(socks5Proxy.StartCall.CallCount).To(Equal(0))
+					Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+				})
+
+				It("returns an error when the certificate cannot be issued", func() {
+					certGetter.GetCall.Returns.Error = errors.New("lychee")
+
+					_, err := clientProvider.Dialer(state)
+					Expect(err).To(MatchError("get jumpbox ssh certificate: lychee"))
+				})
+
+				It("returns an error when the jumpbox cannot be reached", func() {
+					certDialer.DialerCall.Returns.Error = errors.New("rambutan")
+
+					_, err := clientProvider.Dialer(state)
+					Expect(err).To(MatchError("start proxy: rambutan"))
+				})
+			})
+
 			Context("when retrieving the private key fails", func() {
 				BeforeEach(func() {
 					sshKeyGetter.GetCall.Returns.Error = errors.New("tamarind")
 				})
 				It("returns an error", func() {
-					_, err := clientProvider.Dialer(jumpbox)
+					_, err := clientProvider.Dialer(state)
 					Expect(err).To(MatchError("get jumpbox ssh key: tamarind"))
 					Expect(sshKeyGetter.GetCall.Receives.Deployment).To(Equal("jumpbox"))
 				})
@@ -91,7 +196,7 @@ var _ = Describe("Client Provider", func() {
 					socks5Proxy.StartCall.Returns.Error = errors.New("coconut")
 				})
 				It("returns an error", func() {
-					_, err := clientProvider.Dialer(jumpbox)
+					_, err := clientProvider.Dialer(state)
 					Expect(err).To(MatchError(ContainSubstring("start proxy: coconut")))
 				})
 			})
@@ -101,11 +206,29 @@ var _ = Describe("Client Provider", func() {
 					socks5Proxy.AddrCall.Returns.Error = errors.New("mango")
 				})
 				It("returns an error", func() {
-					_, err := clientProvider.Dialer(jumpbox)
+					_, err := clientProvider.Dialer(state)
 					Expect(err).To(MatchError(ContainSubstring("get proxy address: mango")))
 				})
 			})
 
+			Context("when the socks 5 proxy never accepts connections", func() {
+				BeforeEach(func() {
+					bosh.SetProxyReadyInterval(time.Millisecond)
+					bosh.SetNetDial(func(network, addr string, timeout time.Duration) (net.Conn, error) {
+						return nil, errors.New("connection refused")
+					})
+				})
+
+				AfterEach(func() {
+					bosh.ResetProxyReadyInterval()
+				})
+
+				It("returns an error", func() {
+					_, err := clientProvider.Dialer(state)
+					Expect(err).To(MatchError("wait for proxy: connection refused"))
+				})
+			})
+
 			Context("when the socks5 client cannot be created", func() {
 				BeforeEach(func() {
 					bosh.SetProxySOCKS5(func(network, addr string, auth *proxy.Auth, forward proxy.Dialer) (proxy.Dialer, error) {
@@ -113,11 +236,53 @@ var _ = Describe("Client Provider", func() {
 					})
 				})
 				It("returns an error", func() {
-					_, err := clientProvider.Dialer(jumpbox)
+					_, err := clientProvider.Dialer(state)
 					Expect(err).To(MatchError(ContainSubstring("create socks5 client: banana")))
 				})
 			})
 		})
+
+		Context("when the environment was created without a jumpbox", func() {
+			It("dials the director directly", func() {
+				proxyDialer, err := clientProvider.Dialer(storage.State{NoJumpbox: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proxyDialer).To(Equal(proxy.Direct))
+
+				Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the jumpbox url is missing from the state", func() {
+			It("returns an error instead of dialing the director directly", func() {
+				_, err := clientProvider.Dialer(storage.State{})
+				Expect(err).To(MatchError("jumpbox url is missing from the state"))
+
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ProxyAddr", func() {
+		BeforeEach(func() {
+			socks5Proxy.AddrCall.Returns.Addr = "127.0.0.1:1234"
+		})
+
+		It("starts the socks 5 proxy to the jumpbox and returns its address", func() {
+			addr, err := clientProvider.ProxyAddr(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addr).To(Equal("127.0.0.1:1234"))
+
+			Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+			Expect(dialedAddrs).To(Equal([]string{"127.0.0.1:1234"}))
+		})
+
+		Context("when the jumpbox url is missing from the state", func() {
+			It("returns an error", func() {
+				_, err := clientProvider.ProxyAddr(storage.State{})
+				Expect(err).To(MatchError("jumpbox url is missing from the state"))
+			})
+		})
 	})
 
 	Describe("HttpClient", func() {
@@ -132,7 +297,7 @@ var _ = Describe("Client Provider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			sshKeyGetter := &fakes.SSHKeyGetter{}
 
-			clientProvider = bosh.NewClientProvider(allProxyGetter, socks5Proxy, sshKeyGetter, "some-path-to-bosh")
+			clientProvider = bosh.NewClientProvider(socks5Proxy, sshKeyGetter, hostKeys, certGetter, certDialer)
 			dialer = &fakes.Dialer{}
 		})
 
@@ -157,8 +322,17 @@ var _ = Describe("Client Provider", func() {
 	})
 
 	Describe("Client", func() {
+		BeforeEach(func() {
+			state.BOSH = storage.BOSH{
+				DirectorAddress:  "https://director:9999",
+				DirectorUsername: "user",
+				DirectorPassword: "pass",
+				DirectorSSLCA:    "some-fake-ca",
+			}
+		})
+
 		It("returns a well-formed bosh client", func() {
-			client, err := clientProvider.Client(storage.Jumpbox{URL: "https://some-jumpbox"}, "https://director:9999", "user", "pass", "some-fake-ca")
+			client, err := clientProvider.Client(state)
 			Expect(err).NotTo(HaveOccurred())
 
 			structClient := client.(bosh.Client)
@@ -168,35 +342,17 @@ var _ = Describe("Client Provider", func() {
 
 		Context("given a director address without a port", func() {
 			It("Errors", func() {
-				_, err := clientProvider.Client(storage.Jumpbox{URL: "https://some-jumpbox"}, "https://director", "user", "pass", "some-fake-ca")
+				state.BOSH.DirectorAddress = "https://director"
+
+				_, err := clientProvider.Client(state)
 				Expect(err).To(HaveOccurred())
 			})
 		})
-	})
-
-	Describe("BoshCLI", func() {
-		It("returns an authenticated bosh cli", func() {
-			allProxyGetter.BoshAllProxyCall.Returns.URL = "some-all-proxy-url"
-			cli, err := clientProvider.BoshCLI(storage.Jumpbox{URL: "https://some-jumpbox"}, nil, "some-address", "some-username", "some-password", "some-fake-ca")
-			Expect(err).NotTo(HaveOccurred())
 
-			boshCLI := cli.(bosh.BOSHCLI)
-			Expect(boshCLI.GlobalArgs).To(Equal([]string{
-				"--environment", "some-address",
-				"--client", "some-username",
-				"--client-secret", "some-password",
-				"--ca-cert", "some-fake-ca",
-				"--non-interactive",
-			}))
-			Expect(boshCLI.BOSHAllProxy).To(Equal("some-all-proxy-url"))
-		})
-
-		Context("when it can not get the correct key", func() {
-			It("Errors", func() {
-				allProxyGetter.GeneratePrivateKeyCall.Returns.Error = errors.New("fruit")
-				_, err := clientProvider.BoshCLI(storage.Jumpbox{URL: "https://some-jumpbox"}, nil, "some-address", "some-username", "some-password", "some-fake-ca")
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(MatchError("fruit"))
+		Context("when the dialer cannot be created", func() {
+			It("returns an error", func() {
+				_, err := clientProvider.Client(storage.State{BOSH: state.BOSH})
+				Expect(err).To(MatchError("jumpbox url is missing from the state"))
 			})
 		})
 	})
@@ -30,6 +30,14 @@ var _ = Describe("Client", func() {
 		failStatus        int
 		configRequestBody bosh.ConfigRequestBody
 		contentType       string
+		tasksQuery        string
+		deletedPath       string
+		taskStates        []string
+		configsQuery      string
+		uploadRequest     map[string]string
+		dropConnections   int
+		sshRequests       []map[string]interface{}
+		sshResult         string
 	)
 
 	BeforeEach(func() {
@@ -54,6 +62,11 @@ var _ = Describe("Client", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		configRequestBody = bosh.ConfigRequestBody{}
+		taskStates = []string{"processing", "done"}
+		uploadRequest = map[string]string{}
+		dropConnections = 0
+		sshRequests = []map[string]interface{}{}
+		sshResult = `[{"status": "success", "ip": "10.0.16.5", "host_public_key": "ssh-rsa some-host-key", "job": "router", "index": 0, "id": "some-uuid"}]`
 
 		fakeBOSH = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			switch req.URL.Path {
@@ -83,6 +96,20 @@ var _ = Describe("Client", func() {
 					return
 				}
 
+				if req.Method == "GET" {
+					configsQuery = req.URL.RawQuery
+					w.Write([]byte(`[{"id": "3", "name": "some-name", "type": "runtime", "content": "some: yaml", "created_at": "2018-01-02 00:00:00 UTC"}]`))
+					return
+				}
+
+				if dropConnections > 0 {
+					dropConnections--
+					conn, _, err := w.(http.Hijacker).Hijack()
+					Expect(err).NotTo(HaveOccurred())
+					conn.Close()
+					return
+				}
+
 				token = req.Header.Get("Authorization")
 				contentType = req.Header.Get("Content-Type")
 
@@ -91,6 +118,60 @@ var _ = Describe("Client", func() {
 				var err error
 				err = json.NewDecoder(req.Body).Decode(&configRequestBody)
 				Expect(err).NotTo(HaveOccurred())
+			case "/configs/diff":
+				err := json.NewDecoder(req.Body).Decode(&configRequestBody)
+				Expect(err).NotTo(HaveOccurred())
+
+				w.Write([]byte(`{"diff": [["some:", null], ["  old: value", "removed"], ["  new: value", "added"]]}`))
+			case "/stemcells":
+				if req.Method == "POST" {
+					err := json.NewDecoder(req.Body).Decode(&uploadRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					w.Header().Set("Location", fakeBOSH.URL+"/tasks/42")
+					w.WriteHeader(http.StatusFound)
+					return
+				}
+
+				w.Write([]byte(`[{"name": "bosh-warden-boshlite-ubuntu-trusty-go_agent", "operating_system": "ubuntu-trusty", "version": "3541.10", "cid": "some-cid", "deployments": [{"name": "cf"}]}]`))
+			case "/tasks/42/output":
+				Expect(req.URL.Query().Get("type")).To(Equal("event"))
+				w.Write([]byte("{\"stage\": \"Deleting instances\"}\n"))
+			case "/deployments":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				token = req.Header.Get("Authorization")
+				w.Write([]byte(`[{"name": "some-deployment"}, {"name": "other-deployment"}]`))
+			case "/deployments/some-deployment":
+				Expect(req.Method).To(Equal("DELETE"))
+				deletedPath = req.URL.Path
+
+				w.Header().Set("Location", fakeBOSH.URL+"/tasks/42")
+				w.WriteHeader(http.StatusFound)
+			case "/deployments/some-deployment/ssh":
+				Expect(req.Method).To(Equal("POST"))
+				var body map[string]interface{}
+				err := json.NewDecoder(req.Body).Decode(&body)
+				Expect(err).NotTo(HaveOccurred())
+				sshRequests = append(sshRequests, body)
+
+				w.Header().Set("Location", fakeBOSH.URL+"/tasks/43")
+				w.WriteHeader(http.StatusFound)
+			case "/tasks/43":
+				w.Write([]byte(`{"id": 43, "state": "done"}`))
+			case "/tasks/43/output":
+				Expect(req.URL.Query().Get("type")).To(Equal("result"))
+				w.Write([]byte(sshResult))
+			case "/tasks":
+				tasksQuery = req.URL.RawQuery
+				w.Write([]byte(`[{"id": 7, "state": "processing", "description": "create deployment", "deployment": "some-deployment"}]`))
+			case "/tasks/42":
+				state := taskStates[0]
+				taskStates = taskStates[1:]
+				w.Write([]byte(fmt.Sprintf(`{"id": 42, "state": %q, "result": "some-result"}`, state)))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				Expect(err).NotTo(HaveOccurred())
@@ -186,7 +267,7 @@ var _ = Describe("Client", func() {
 		BeforeEach(func() {
 			fakeBOSH.StartTLS()
 
-			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca))
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
 		})
 
 		It("uses uaa to get a token", func() {
@@ -208,7 +289,7 @@ var _ = Describe("Client", func() {
 	Describe("UpdateCloudConfig", func() {
 		It("uses UAA to get a token in order to upload the cloud-config", func() {
 			fakeBOSH.StartTLS()
-			client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca))
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
 
 			err := client.UpdateCloudConfig([]byte("cloud: config"))
 			Expect(err).NotTo(HaveOccurred())
@@ -226,7 +307,7 @@ var _ = Describe("Client", func() {
 			It("returns an error", func() {
 				fakeBOSH.StartTLS()
 
-				client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "", "", string(ca))
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "", "", string(ca)).WithTaskPollInterval(time.Millisecond)
 
 				err := client.UpdateCloudConfig([]byte("cloud: config"))
 				Expect(err).To(MatchError(ContainSubstring("unexpected http response 500")))
@@ -237,7 +318,7 @@ var _ = Describe("Client", func() {
 			It("returns an error", func() {
 				fakeBOSH.StartTLS()
 
-				client := bosh.NewClient(httpClient, "https://bad-url:9999", fakeBOSH.URL, "", "", string(ca))
+				client := bosh.NewClient(httpClient, "https://bad-url:9999", fakeBOSH.URL, "", "", string(ca)).WithTaskPollInterval(time.Millisecond)
 
 				err := client.UpdateCloudConfig([]byte("cloud: config"))
 				Expect(err).To(MatchError(ContainSubstring("made 1 attempts, last error: Post")))
@@ -245,4 +326,307 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("Deployments", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+		})
+
+		It("lists the deployments on the director", func() {
+			deployments, err := client.Deployments()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(deployments).To(Equal([]bosh.Deployment{
+				{Name: "some-deployment"},
+				{Name: "other-deployment"},
+			}))
+		})
+
+		Context("when the response is not StatusOK", func() {
+			BeforeEach(func() {
+				failStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				_, err := client.Deployments()
+				Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+			})
+		})
+	})
+
+	Describe("RunningTasks", func() {
+		It("lists the unfinished tasks on the director", func() {
+			fakeBOSH.StartTLS()
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+
+			tasks, err := client.RunningTasks()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tasksQuery).To(Equal("state=queued,processing,cancelling&verbose=2"))
+			Expect(tasks).To(Equal([]bosh.Task{{
+				ID:          7,
+				State:       "processing",
+				Description: "create deployment",
+				Deployment:  "some-deployment",
+			}}))
+		})
+	})
+
+	Describe("DeleteDeployment", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+		})
+
+		It("deletes the deployment and waits for the task to finish", func() {
+			err := client.DeleteDeployment("some-deployment")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deletedPath).To(Equal("/deployments/some-deployment"))
+			Expect(taskStates).To(BeEmpty())
+		})
+
+		Context("when the task fails", func() {
+			BeforeEach(func() {
+				taskStates = []string{"error"}
+			})
+
+			It("returns an error", func() {
+				err := client.DeleteDeployment("some-deployment")
+				Expect(err).To(MatchError("task 42 error: some-result"))
+			})
+		})
+	})
+
+	Describe("Configs", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+		})
+
+		It("lists the latest configs of a type and name", func() {
+			configs, err := client.Configs("runtime", "some-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(configsQuery).To(Equal("latest=true&name=some-name&type=runtime"))
+			Expect(configs).To(Equal([]bosh.Config{{
+				ID:        "3",
+				Name:      "some-name",
+				Type:      "runtime",
+				Content:   "some: yaml",
+				CreatedAt: "2018-01-02 00:00:00 UTC",
+			}}))
+		})
+
+		It("gets the latest config", func() {
+			config, err := client.LatestConfig("runtime", "some-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config.Content).To(Equal("some: yaml"))
+		})
+	})
+
+	Describe("DiffConfig", func() {
+		It("returns the diff lines against the current config", func() {
+			fakeBOSH.StartTLS()
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+
+			diff, err := client.DiffConfig("cloud", "default", []byte("some: yaml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(configRequestBody).To(Equal(bosh.ConfigRequestBody{
+				Name:    "default",
+				Type:    "cloud",
+				Content: "some: yaml",
+			}))
+			Expect(diff).To(Equal([]bosh.DiffLine{
+				{Text: "some:"},
+				{Text: "  old: value", State: "removed"},
+				{Text: "  new: value", State: "added"},
+			}))
+		})
+	})
+
+	Describe("Stemcells", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+		})
+
+		It("lists the uploaded stemcells", func() {
+			stemcells, err := client.Stemcells()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stemcells).To(Equal([]bosh.Stemcell{{
+				Name:            "bosh-warden-boshlite-ubuntu-trusty-go_agent",
+				OperatingSystem: "ubuntu-trusty",
+				Version:         "3541.10",
+				CID:             "some-cid",
+				Deployments:     []bosh.Deployment{{Name: "cf"}},
+			}}))
+		})
+
+		It("uploads a stemcell from a url and waits for the task", func() {
+			err := client.UploadStemcell("https://example.com/stemcell.tgz", "some-sha1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(uploadRequest).To(Equal(map[string]string{
+				"location": "https://example.com/stemcell.tgz",
+				"sha1":     "some-sha1",
+			}))
+			Expect(taskStates).To(BeEmpty())
+		})
+	})
+
+	Describe("Tasks", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+		})
+
+		It("gets a task", func() {
+			task, err := client.Task(42)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(task).To(Equal(bosh.Task{ID: 42, State: "processing", Result: "some-result"}))
+		})
+
+		It("gets the output of a task", func() {
+			output, err := client.TaskOutput(42, "event")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).To(Equal("{\"stage\": \"Deleting instances\"}\n"))
+		})
+
+		It("waits for a task to finish", func() {
+			err := client.WaitForTask(42)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(taskStates).To(BeEmpty())
+		})
+
+		Context("when the task is cancelled", func() {
+			It("returns an error", func() {
+				taskStates = []string{"queued", "cancelled"}
+
+				err := client.WaitForTask(42)
+				Expect(err).To(MatchError("task 42 cancelled: some-result"))
+			})
+		})
+
+		Context("when the task does not finish in time", func() {
+			It("returns an error", func() {
+				taskStates = []string{"queued", "processing", "processing"}
+
+				err := client.WithTaskTimeout(0).WaitForTask(42)
+				Expect(err).To(MatchError("task 42 is still queued after 0s"))
+			})
+		})
+	})
+
+	Describe("SSH", func() {
+		var (
+			client bosh.Client
+			target bosh.SSHTarget
+		)
+
+		BeforeEach(func() {
+			fakeBOSH.StartTLS()
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithTaskPollInterval(time.Millisecond)
+			target = bosh.SSHTarget{Job: "router", IDs: []string{"0"}}
+		})
+
+		It("sets up ssh on the target instances and returns their hosts", func() {
+			hosts, err := client.SetupSSH("some-deployment", target, "some-user", "ssh-rsa some-public-key")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshRequests).To(Equal([]map[string]interface{}{{
+				"command":         "setup",
+				"deployment_name": "some-deployment",
+				"target": map[string]interface{}{
+					"job":     "router",
+					"indexes": []interface{}{},
+					"ids":     []interface{}{"0"},
+				},
+				"params": map[string]interface{}{
+					"user":       "some-user",
+					"public_key": "ssh-rsa some-public-key",
+				},
+			}}))
+			Expect(hosts).To(Equal([]bosh.SSHHost{{
+				Status:        "success",
+				IP:            "10.0.16.5",
+				HostPublicKey: "ssh-rsa some-host-key",
+				Job:           "router",
+				Index:         0,
+				ID:            "some-uuid",
+			}}))
+		})
+
+		It("cleans up the ssh user", func() {
+			err := client.CleanupSSH("some-deployment", target, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshRequests[0]["command"]).To(Equal("cleanup"))
+			Expect(sshRequests[0]["params"]).To(Equal(map[string]interface{}{
+				"user_regex": "^some-user$",
+			}))
+		})
+
+		Context("when the setup fails on an instance", func() {
+			It("returns an error", func() {
+				sshResult = `[{"status": "failure", "job": "router", "id": "some-uuid"}]`
+
+				_, err := client.SetupSSH("some-deployment", target, "some-user", "ssh-rsa some-public-key")
+				Expect(err).To(MatchError("ssh setup failed on router/some-uuid"))
+			})
+		})
+	})
+
+	Describe("retries", func() {
+		It("makes the configured number of attempts", func() {
+			fakeBOSH.StartTLS()
+			client := bosh.NewClient(httpClient, "fake://some-url", "fake://some-url", "some-username", "some-password", string(ca)).WithRetries(3, time.Millisecond)
+
+			_, err := client.Info()
+			Expect(err).To(MatchError(ContainSubstring("made 3 attempts, last error:")))
+		})
+
+		It("does not wait after the last attempt", func() {
+			fakeBOSH.StartTLS()
+			client := bosh.NewClient(httpClient, "fake://some-url", "fake://some-url", "some-username", "some-password", string(ca)).WithRetries(1, time.Hour)
+
+			done := make(chan error)
+			go func() {
+				_, err := client.Info()
+				done <- err
+			}()
+
+			Eventually(done).Should(Receive(MatchError(ContainSubstring("made 1 attempts, last error:"))))
+		})
+
+		It("resends the request body on each attempt", func() {
+			fakeBOSH.StartTLS()
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, fakeBOSH.URL, "some-username", "some-password", string(ca)).WithRetries(2, time.Millisecond)
+			dropConnections = 1
+
+			err := client.UpdateConfig("runtime", "some-name", []byte("some: yaml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dropConnections).To(Equal(0))
+			Expect(configRequestBody.Content).To(Equal("some: yaml"))
+		})
+	})
 })
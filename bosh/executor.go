@@ -37,6 +37,26 @@ type cli interface {
 	Run(stdout io.Writer, workingDirectory string, args []string) error
 }
 
+// vmTypeProperties maps each IaaS to the cloud property cpi.yml uses for
+// the vm size. vSphere sizes vms by cpu and ram, so it has no entry.
+var vmTypeProperties = map[string]string{
+	"aws":       "instance_type",
+	"azure":     "instance_type",
+	"gcp":       "machine_type",
+	"openstack": "instance_type",
+}
+
+// jumpboxDiskSizeProperties maps each IaaS to the cloud property the jumpbox
+// cpi.yml sizes its only disk with. Google and OpenStack take gigabytes, the
+// others megabytes.
+var jumpboxDiskSizeProperties = map[string]string{
+	"aws":       "ephemeral_disk/size",
+	"azure":     "ephemeral_disk?/size",
+	"gcp":       "root_disk_size_gb",
+	"openstack": "root_disk?/size",
+	"vsphere":   "disk",
+}
+
 type setupFile struct {
 	source   string
 	dest     string
@@ -71,7 +91,8 @@ func (e Executor) getSetupFiles(sourcePath, destPath string) []setupFile {
 	return files
 }
 
-func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error {
+func (e Executor) PlanJumpbox(input DirInput, deploymentDir string, state storage.State) error {
+	iaas := state.IAAS
 	setupFiles := e.getSetupFiles(jumpboxDeploymentRepo, deploymentDir)
 
 	for _, f := range setupFiles {
@@ -105,6 +126,60 @@ func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error
 		}
 	}
 
+	if property, ok := vmTypeProperties[iaas]; ok && state.JumpboxConfig.VMType != "" {
+		path := filepath.Join(deploymentDir, "vm-type-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, []byte(fmt.Sprintf(VMTypeOps, property)), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write vm type ops file: %s", err) //not tested
+		}
+	}
+
+	if property, ok := jumpboxDiskSizeProperties[iaas]; ok && state.JumpboxConfig.DiskSize != 0 {
+		path := filepath.Join(deploymentDir, "disk-size-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, []byte(fmt.Sprintf(JumpboxDiskSizeOps, property)), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write disk size ops file: %s", err) //not tested
+		}
+	}
+
+	if state.JumpboxConfig.AuthorizedKey != "" || state.JumpboxConfig.SSHCA {
+		path := filepath.Join(deploymentDir, "authorized-key-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, jumpboxAuthorizedKeyOps(state.JumpboxConfig), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write authorized key ops file: %s", err) //not tested
+		}
+	}
+
+	if len(state.JumpboxConfig.Users) > 0 {
+		path := filepath.Join(deploymentDir, "users-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, jumpboxUsersOps(state.JumpboxConfig.Users), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write users ops file: %s", err) //not tested
+		}
+	}
+
+	if len(state.Network.DNSServers) > 0 {
+		path := filepath.Join(deploymentDir, "dns-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, []byte(JumpboxDNSOps), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write dns ops file: %s", err) //not tested
+		}
+	}
+
+	if len(state.Network.NTPServers) > 0 {
+		path := filepath.Join(deploymentDir, "ntp-ops.yml")
+		sharedArgs = append(sharedArgs, "-o", path)
+		err := e.fs.WriteFile(path, []byte(JumpboxNTPOps), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Jumpbox write ntp ops file: %s", err) //not tested
+		}
+	}
+
 	jumpboxState := filepath.Join(input.VarsDir, "jumpbox-state.json")
 
 	boshArgs := append([]string{filepath.Join(deploymentDir, "jumpbox.yml"), "--state", jumpboxState}, sharedArgs...)
@@ -159,9 +234,10 @@ func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error
 	return nil
 }
 
-func (e Executor) getDirectorSetupFiles(stateDir, deploymentDir, iaas string) []setupFile {
+func (e Executor) getDirectorSetupFiles(stateDir, deploymentDir string, state storage.State) []setupFile {
 	files := e.getSetupFiles(boshDeploymentRepo, deploymentDir)
 
+	iaas := state.IAAS
 	statePath := filepath.Join(stateDir, "bbl-ops-files", iaas)
 	assetPath := filepath.Join(boshDeploymentRepo, iaas)
 
@@ -171,38 +247,131 @@ func (e Executor) getDirectorSetupFiles(stateDir, deploymentDir, iaas string) []
 			dest:     filepath.Join(statePath, "bosh-director-ephemeral-ip-ops.yml"),
 			contents: []byte(GCPBoshDirectorEphemeralIPOps),
 		})
+		if state.Director.ExternalDatabase() {
+			files = append(files, setupFile{
+				source:   filepath.Join(assetPath, "external-database-ops.yml"),
+				dest:     filepath.Join(statePath, "external-database-ops.yml"),
+				contents: []byte(GCPExternalDatabaseOps),
+			})
+		}
+		if state.Director.DiskEncryptionKey != "" {
+			files = append(files, setupFile{
+				source:   filepath.Join(assetPath, "disk-encryption-ops.yml"),
+				dest:     filepath.Join(statePath, "disk-encryption-ops.yml"),
+				contents: []byte(GCPDiskEncryptionOps),
+			})
+		}
 	} else if iaas == "aws" {
 		files = append(files, setupFile{
 			source:   filepath.Join(assetPath, "bosh-director-ephemeral-ip-ops.yml"),
 			dest:     filepath.Join(statePath, "bosh-director-ephemeral-ip-ops.yml"),
 			contents: []byte(AWSBoshDirectorEphemeralIPOps),
 		})
+		if state.Director.ExternalBlobstore() {
+			files = append(files, setupFile{
+				source:   filepath.Join(assetPath, "external-blobstore-ops.yml"),
+				dest:     filepath.Join(statePath, "external-blobstore-ops.yml"),
+				contents: []byte(AWSExternalBlobstoreOps),
+			})
+		}
+		if state.Director.ExternalDatabase() {
+			files = append(files, setupFile{
+				source:   filepath.Join(assetPath, "external-database-ops.yml"),
+				dest:     filepath.Join(statePath, "external-database-ops.yml"),
+				contents: []byte(AWSExternalDatabaseOps),
+			})
+		}
+	} else if iaas == "azure" && state.Director.DiskEncryptionKey != "" {
+		files = append(files, setupFile{
+			source:   filepath.Join(assetPath, "disk-encryption-ops.yml"),
+			dest:     filepath.Join(statePath, "disk-encryption-ops.yml"),
+			contents: []byte(AzureDiskEncryptionOps),
+		})
+	}
+
+	if property, ok := vmTypeProperties[iaas]; ok && state.Director.VMType != "" {
+		files = append(files, setupFile{
+			source:   filepath.Join(assetPath, "vm-type-ops.yml"),
+			dest:     filepath.Join(statePath, "vm-type-ops.yml"),
+			contents: []byte(fmt.Sprintf(VMTypeOps, property)),
+		})
+	}
+	if state.Director.DiskSize != 0 {
+		files = append(files, setupFile{
+			source:   filepath.Join(assetPath, "disk-size-ops.yml"),
+			dest:     filepath.Join(statePath, "disk-size-ops.yml"),
+			contents: []byte(DirectorDiskSizeOps),
+		})
 	}
 
 	return files
 }
 
-func (e Executor) getDirectorOpsFiles(stateDir, deploymentDir, iaas string) []string {
+func (e Executor) getDirectorOpsFiles(stateDir, deploymentDir string, state storage.State) []string {
+	iaas := state.IAAS
 	files := []string{
 		filepath.Join(deploymentDir, iaas, "cpi.yml"),
 		filepath.Join(deploymentDir, "jumpbox-user.yml"),
 		filepath.Join(deploymentDir, "uaa.yml"),
 		filepath.Join(deploymentDir, "credhub.yml"),
+		filepath.Join(deploymentDir, "bbr.yml"),
 	}
 	if iaas == "gcp" {
 		files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "bosh-director-ephemeral-ip-ops.yml"))
+		if state.Director.ExternalBlobstore() {
+			files = append(files, filepath.Join(deploymentDir, iaas, "gcs-blobstore.yml"))
+		}
+		if state.Director.ExternalDatabase() {
+			files = append(files, filepath.Join(deploymentDir, "misc", "external-db.yml"))
+			files = append(files, filepath.Join(deploymentDir, "experimental", "db-enable-tls.yml"))
+			files = append(files, filepath.Join(deploymentDir, "experimental", "db-enable-mutual-tls.yml"))
+			files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "external-database-ops.yml"))
+		}
+		if state.Director.DiskEncryptionKey != "" {
+			files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "disk-encryption-ops.yml"))
+		}
 	} else if iaas == "aws" {
 		files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "bosh-director-ephemeral-ip-ops.yml"))
 		files = append(files, filepath.Join(deploymentDir, iaas, "iam-instance-profile.yml"))
 		files = append(files, filepath.Join(deploymentDir, iaas, "encrypted-disk.yml"))
+		if state.Director.ExternalBlobstore() {
+			files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "external-blobstore-ops.yml"))
+		}
+		if state.Director.ExternalDatabase() {
+			files = append(files, filepath.Join(deploymentDir, "misc", "external-db.yml"))
+			files = append(files, filepath.Join(deploymentDir, "experimental", "db-enable-tls.yml"))
+			files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "external-database-ops.yml"))
+		}
+	} else if iaas == "azure" {
+		// The cpi only places vms in availability zones, and encrypts disks
+		// with a disk encryption set, when they are managed disks.
+		if len(state.Azure.AvailabilityZones()) > 0 || state.Director.DiskEncryptionKey != "" {
+			files = append(files, filepath.Join(deploymentDir, iaas, "use-managed-disks.yml"))
+		}
+		if state.Director.DiskEncryptionKey != "" {
+			files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "disk-encryption-ops.yml"))
+		}
 	} else if iaas == "vsphere" {
 		files = append(files, filepath.Join(deploymentDir, "vsphere", "resource-pool.yml"))
 	}
+	if _, ok := vmTypeProperties[iaas]; ok && state.Director.VMType != "" {
+		files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "vm-type-ops.yml"))
+	}
+	if state.Director.DiskSize != 0 {
+		files = append(files, filepath.Join(stateDir, "bbl-ops-files", iaas, "disk-size-ops.yml"))
+	}
+	// These follow cpi.yml, which sets the ntp servers of some IaaSes.
+	if len(state.Network.DNSServers) > 0 {
+		files = append(files, filepath.Join(deploymentDir, "misc", "dns.yml"))
+	}
+	if len(state.Network.NTPServers) > 0 {
+		files = append(files, filepath.Join(deploymentDir, "misc", "ntp.yml"))
+	}
 	return files
 }
 
-func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error {
-	setupFiles := e.getDirectorSetupFiles(input.StateDir, deploymentDir, iaas)
+func (e Executor) PlanDirector(input DirInput, deploymentDir string, state storage.State) error {
+	setupFiles := e.getDirectorSetupFiles(input.StateDir, deploymentDir, state)
 
 	for _, f := range setupFiles {
 		if f.source != "" {
@@ -218,7 +387,7 @@ func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error
 		"--vars-file", filepath.Join(input.VarsDir, "director-vars-file.yml"),
 	}
 
-	for _, f := range e.getDirectorOpsFiles(input.StateDir, deploymentDir, iaas) {
+	for _, f := range e.getDirectorOpsFiles(input.StateDir, deploymentDir, state) {
 		sharedArgs = append(sharedArgs, "-o", f)
 	}
 
@@ -226,7 +395,7 @@ func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error
 
 	boshArgs := append([]string{filepath.Join(deploymentDir, "bosh.yml"), "--state", boshState}, sharedArgs...)
 
-	switch iaas {
+	switch state.IAAS {
 	case "aws":
 		boshArgs = append(boshArgs,
 			"-v", `access_key_id="${BBL_AWS_ACCESS_KEY_ID}"`,
@@ -427,3 +596,49 @@ func (e Executor) Version() (string, error) {
 
 	return version, nil
 }
+
+type opsEntry struct {
+	Type  string      `yaml:"type"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value"`
+}
+
+type jumpboxUser struct {
+	Name      string `yaml:"name"`
+	PublicKey string `yaml:"public_key"`
+}
+
+// jumpboxAuthorizedKeyOps authorizes the external key and the ssh CA for
+// the jumpbox user next to bbl's own key.
+// jumpboxAuthorizedKeyOps stops authorizing the long-lived jumpbox key once
+// the jumpbox trusts the ssh CA, so that only short-lived certificates can
+// log in as the jumpbox user.
+func jumpboxAuthorizedKeyOps(config storage.JumpboxConfig) []byte {
+	keys := []string{}
+	if config.SSHCA {
+		keys = append(keys, "cert-authority ((jumpbox_ssh_ca.public_key))")
+	} else {
+		keys = append(keys, "((jumpbox_ssh.public_key))")
+	}
+	if config.AuthorizedKey != "" {
+		keys = append(keys, "((jumpbox_authorized_key))")
+	}
+
+	return mustMarshal([]opsEntry{{
+		Type:  "replace",
+		Path:  "/instance_groups/name=jumpbox/jobs/name=user_add/properties/users/name=jumpbox/public_key",
+		Value: strings.Join(keys, "\n"),
+	}})
+}
+
+func jumpboxUsersOps(users []storage.JumpboxUser) []byte {
+	ops := []opsEntry{}
+	for _, user := range users {
+		ops = append(ops, opsEntry{
+			Type:  "replace",
+			Path:  "/instance_groups/name=jumpbox/jobs/name=user_add/properties/users/-",
+			Value: jumpboxUser{Name: user.Name, PublicKey: user.PublicKey},
+		})
+	}
+	return mustMarshal(ops)
+}
@@ -67,7 +67,7 @@ var _ = Describe("Executor", func() {
 
 	Describe("PlanJumpbox", func() {
 		It("writes bosh-deployment assets to the deployment dir", func() {
-			err := executor.PlanJumpbox(dirInput, deploymentDir, "aws")
+			err := executor.PlanJumpbox(dirInput, deploymentDir, storage.State{IAAS: "aws"})
 			Expect(err).NotTo(HaveOccurred())
 
 			By("writing bosh-deployment assets to the deployment dir", func() {
@@ -122,7 +122,7 @@ var _ = Describe("Executor", func() {
 
 		Context("on azure", func() {
 			It("generates create-env args for jumpbox", func() {
-				err := executor.PlanJumpbox(dirInput, deploymentDir, "azure")
+				err := executor.PlanJumpbox(dirInput, deploymentDir, storage.State{IAAS: "azure"})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedArgs := []string{
@@ -155,9 +155,166 @@ var _ = Describe("Executor", func() {
 			})
 		})
 
+		Context("when the jumpbox vm type and disk size are set", func() {
+			It("adds the vm type and disk size ops files", func() {
+				state := storage.State{
+					IAAS:          "gcp",
+					JumpboxConfig: storage.JumpboxConfig{VMType: "n1-standard-2", DiskSize: 50},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(fmt.Sprintf("%s/create-jumpbox.sh", stateDir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -o  %s \\\n  --var-file",
+					filepath.Join(relativeDeploymentDir, "gcp", "cpi.yml"),
+					filepath.Join(relativeDeploymentDir, "vm-type-ops.yml"),
+					filepath.Join(relativeDeploymentDir, "disk-size-ops.yml"),
+				)))
+
+				vmTypeOps, err := fs.ReadFile(filepath.Join(deploymentDir, "vm-type-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(vmTypeOps)).To(Equal(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/machine_type
+  value: ((vm_type))
+`))
+
+				diskSizeOps, err := fs.ReadFile(filepath.Join(deploymentDir, "disk-size-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(diskSizeOps)).To(Equal(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/root_disk_size_gb
+  value: ((disk_size))
+`))
+			})
+
+			It("resizes the disk of the jumpbox vm on aws", func() {
+				state := storage.State{
+					IAAS:          "aws",
+					JumpboxConfig: storage.JumpboxConfig{DiskSize: 50},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				diskSizeOps, err := fs.ReadFile(filepath.Join(deploymentDir, "disk-size-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(diskSizeOps)).To(Equal(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/ephemeral_disk/size
+  value: ((disk_size))
+`))
+			})
+		})
+
+		Context("when dns and ntp servers are set", func() {
+			It("adds the dns and ntp ops files after the cpi", func() {
+				state := storage.State{
+					IAAS:    "gcp",
+					Network: storage.Network{DNSServers: []string{"10.0.0.2"}, NTPServers: []string{"10.0.0.3"}},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(fmt.Sprintf("%s/create-jumpbox.sh", stateDir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -o  %s \\\n  --var-file",
+					filepath.Join(relativeDeploymentDir, "gcp", "cpi.yml"),
+					filepath.Join(relativeDeploymentDir, "dns-ops.yml"),
+					filepath.Join(relativeDeploymentDir, "ntp-ops.yml"),
+				)))
+
+				dnsOps, err := fs.ReadFile(filepath.Join(deploymentDir, "dns-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(dnsOps)).To(Equal(bosh.JumpboxDNSOps))
+
+				ntpOps, err := fs.ReadFile(filepath.Join(deploymentDir, "ntp-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(ntpOps)).To(Equal(bosh.JumpboxNTPOps))
+			})
+		})
+
+		Context("when the jumpbox has an authorized key", func() {
+			It("adds the authorized key ops file", func() {
+				state := storage.State{
+					IAAS:          "gcp",
+					JumpboxConfig: storage.JumpboxConfig{AuthorizedKey: "ssh-ed25519 AAAA"},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(fmt.Sprintf("%s/create-jumpbox.sh", stateDir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  --var-file",
+					filepath.Join(relativeDeploymentDir, "gcp", "cpi.yml"),
+					filepath.Join(relativeDeploymentDir, "authorized-key-ops.yml"),
+				)))
+
+				authorizedKeyOps, err := fs.ReadFile(filepath.Join(deploymentDir, "authorized-key-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(authorizedKeyOps)).To(MatchYAML(`
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/name=user_add/properties/users/name=jumpbox/public_key
+  value: |-
+    ((jumpbox_ssh.public_key))
+    ((jumpbox_authorized_key))
+`))
+			})
+
+			It("authorizes the ssh CA instead of the jumpbox key when certificates are enabled", func() {
+				state := storage.State{
+					IAAS:          "gcp",
+					JumpboxConfig: storage.JumpboxConfig{SSHCA: true},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				authorizedKeyOps, err := fs.ReadFile(filepath.Join(deploymentDir, "authorized-key-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(authorizedKeyOps)).To(MatchYAML(`
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/name=user_add/properties/users/name=jumpbox/public_key
+  value: cert-authority ((jumpbox_ssh_ca.public_key))
+`))
+			})
+
+			It("adds an ops file for the jumpbox users", func() {
+				state := storage.State{
+					IAAS: "gcp",
+					JumpboxConfig: storage.JumpboxConfig{Users: []storage.JumpboxUser{
+						{Name: "alice", PublicKey: "ssh-ed25519 AAAA alice@example.com"},
+						{Name: "bob", PublicKey: "ssh-rsa BBBB"},
+					}},
+				}
+				err := executor.PlanJumpbox(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(fmt.Sprintf("%s/create-jumpbox.sh", stateDir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  --var-file",
+					filepath.Join(relativeDeploymentDir, "users-ops.yml"),
+				)))
+
+				usersOps, err := fs.ReadFile(filepath.Join(deploymentDir, "users-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(usersOps)).To(MatchYAML(`
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/name=user_add/properties/users/-
+  value:
+    name: alice
+    public_key: ssh-ed25519 AAAA alice@example.com
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/name=user_add/properties/users/-
+  value:
+    name: bob
+    public_key: ssh-rsa BBBB
+`))
+			})
+		})
+
 		Context("on gcp", func() {
 			It("generates create-env args for jumpbox", func() {
-				err := executor.PlanJumpbox(dirInput, deploymentDir, "gcp")
+				err := executor.PlanJumpbox(dirInput, deploymentDir, storage.State{IAAS: "gcp"})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedArgs := []string{
@@ -191,7 +348,7 @@ var _ = Describe("Executor", func() {
 
 		Context("when the iaas is vsphere", func() {
 			It("generates create-env args for jumpbox", func() {
-				err := executor.PlanJumpbox(dirInput, deploymentDir, "vsphere")
+				err := executor.PlanJumpbox(dirInput, deploymentDir, storage.State{IAAS: "vsphere"})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedArgs := []string{
@@ -233,7 +390,7 @@ var _ = Describe("Executor", func() {
 
 		Context("openstack", func() {
 			It("generates create-env args for jumpbox", func() {
-				err := executor.PlanJumpbox(dirInput, deploymentDir, "openstack")
+				err := executor.PlanJumpbox(dirInput, deploymentDir, storage.State{IAAS: "openstack"})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedArgs := []string{
@@ -275,7 +432,7 @@ var _ = Describe("Executor", func() {
 
 	Describe("PlanDirector", func() {
 		It("writes bosh-deployment assets to the deployment dir", func() {
-			err := executor.PlanDirector(dirInput, deploymentDir, "warden")
+			err := executor.PlanDirector(dirInput, deploymentDir, storage.State{IAAS: "warden"})
 			Expect(err).NotTo(HaveOccurred())
 
 			simplePath := filepath.Join(deploymentDir, "LICENSE")
@@ -304,6 +461,7 @@ var _ = Describe("Executor", func() {
 					"-o", filepath.Join(relativeDeploymentDir, "jumpbox-user.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "uaa.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "credhub.yml"),
+					"-o", filepath.Join(relativeDeploymentDir, "bbr.yml"),
 					"-o", filepath.Join(relativeStateDir, "bbl-ops-files", "aws", "bosh-director-ephemeral-ip-ops.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "aws", "iam-instance-profile.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "aws", "encrypted-disk.yml"),
@@ -315,7 +473,7 @@ var _ = Describe("Executor", func() {
 			})
 
 			It("writes aws-specific ops files", func() {
-				err := executor.PlanDirector(dirInput, deploymentDir, "aws")
+				err := executor.PlanDirector(dirInput, deploymentDir, storage.State{IAAS: "aws"})
 				Expect(err).NotTo(HaveOccurred())
 
 				ipOpsFile := filepath.Join(stateDir, "bbl-ops-files", "aws", "bosh-director-ephemeral-ip-ops.yml")
@@ -328,6 +486,33 @@ var _ = Describe("Executor", func() {
   value: true
 `))
 			})
+			Context("when the director uses an external blobstore and database", func() {
+				It("adds the external blobstore and database ops files", func() {
+					state := storage.State{
+						IAAS:     "aws",
+						Director: storage.Director{Blobstore: "external", Database: "external"},
+					}
+					err := executor.PlanDirector(dirInput, deploymentDir, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -o  %s \\\n  -o  %s \\\n  -v",
+						filepath.Join(relativeStateDir, "bbl-ops-files", "aws", "external-blobstore-ops.yml"),
+						filepath.Join(relativeDeploymentDir, "misc", "external-db.yml"),
+						filepath.Join(relativeDeploymentDir, "experimental", "db-enable-tls.yml"),
+						filepath.Join(relativeStateDir, "bbl-ops-files", "aws", "external-database-ops.yml"),
+					)))
+
+					blobstoreOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "aws", "external-blobstore-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(blobstoreOps)).To(Equal(bosh.AWSExternalBlobstoreOps))
+
+					databaseOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "aws", "external-database-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(databaseOps)).To(Equal(bosh.AWSExternalDatabaseOps))
+				})
+			})
 		})
 
 		Context("gcp", func() {
@@ -341,6 +526,7 @@ var _ = Describe("Executor", func() {
 					"-o", filepath.Join(relativeDeploymentDir, "jumpbox-user.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "uaa.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "credhub.yml"),
+					"-o", filepath.Join(relativeDeploymentDir, "bbr.yml"),
 					"-o", filepath.Join(relativeStateDir, "bbl-ops-files", "gcp", "bosh-director-ephemeral-ip-ops.yml"),
 					"--var-file", `gcp_credentials_json="${BBL_GCP_SERVICE_ACCOUNT_KEY_PATH}"`,
 					"-v", `project_id="${BBL_GCP_PROJECT_ID}"`,
@@ -351,7 +537,7 @@ var _ = Describe("Executor", func() {
 			})
 
 			It("writes gcp-specific ops files", func() {
-				err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+				err := executor.PlanDirector(dirInput, deploymentDir, storage.State{IAAS: "gcp"})
 				Expect(err).NotTo(HaveOccurred())
 
 				ipOpsFile := filepath.Join(stateDir, "bbl-ops-files", "gcp", "bosh-director-ephemeral-ip-ops.yml")
@@ -364,6 +550,100 @@ var _ = Describe("Executor", func() {
   value: true
 `))
 			})
+			Context("when the director uses an external blobstore and database", func() {
+				It("adds the external blobstore and database ops files", func() {
+					state := storage.State{
+						IAAS:     "gcp",
+						Director: storage.Director{Blobstore: "external", Database: "external"},
+					}
+					err := executor.PlanDirector(dirInput, deploymentDir, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -o  %s \\\n  -o  %s \\\n  -o  %s \\\n  --var-file",
+						filepath.Join(relativeDeploymentDir, "gcp", "gcs-blobstore.yml"),
+						filepath.Join(relativeDeploymentDir, "misc", "external-db.yml"),
+						filepath.Join(relativeDeploymentDir, "experimental", "db-enable-tls.yml"),
+						filepath.Join(relativeDeploymentDir, "experimental", "db-enable-mutual-tls.yml"),
+						filepath.Join(relativeStateDir, "bbl-ops-files", "gcp", "external-database-ops.yml"),
+					)))
+
+					databaseOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "gcp", "external-database-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(databaseOps)).To(Equal(bosh.GCPExternalDatabaseOps))
+				})
+			})
+
+			Context("when a disk encryption key is set", func() {
+				It("adds the disk encryption ops file", func() {
+					state := storage.State{
+						IAAS:     "gcp",
+						Director: storage.Director{DiskEncryptionKey: "some-key"},
+					}
+					err := executor.PlanDirector(dirInput, deploymentDir, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  --var-file",
+						filepath.Join(relativeStateDir, "bbl-ops-files", "gcp", "bosh-director-ephemeral-ip-ops.yml"),
+						filepath.Join(relativeStateDir, "bbl-ops-files", "gcp", "disk-encryption-ops.yml"),
+					)))
+
+					diskEncryptionOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "gcp", "disk-encryption-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(diskEncryptionOps)).To(Equal(bosh.GCPDiskEncryptionOps))
+				})
+			})
+
+			Context("when the director vm type and disk size are set", func() {
+				It("adds the vm type and disk size ops files", func() {
+					state := storage.State{
+						IAAS:     "aws",
+						Director: storage.Director{VMType: "m4.2xlarge", DiskSize: 100},
+					}
+					err := executor.PlanDirector(dirInput, deploymentDir, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -v",
+						filepath.Join(relativeStateDir, "bbl-ops-files", "aws", "vm-type-ops.yml"),
+						filepath.Join(relativeStateDir, "bbl-ops-files", "aws", "disk-size-ops.yml"),
+					)))
+
+					vmTypeOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "aws", "vm-type-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(vmTypeOps)).To(Equal(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: ((vm_type))
+`))
+
+					diskSizeOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "aws", "disk-size-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(diskSizeOps)).To(Equal(bosh.DirectorDiskSizeOps))
+				})
+			})
+		})
+
+		Context("when dns and ntp servers are set", func() {
+			It("adds the dns and ntp ops files of bosh-deployment last", func() {
+				state := storage.State{
+					IAAS:    "gcp",
+					Network: storage.Network{DNSServers: []string{"10.0.0.2"}, NTPServers: []string{"10.0.0.3"}},
+				}
+				err := executor.PlanDirector(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  --var-file",
+					filepath.Join(relativeDeploymentDir, "misc", "dns.yml"),
+					filepath.Join(relativeDeploymentDir, "misc", "ntp.yml"),
+				)))
+			})
 		})
 
 		Context("azure", func() {
@@ -377,6 +657,7 @@ var _ = Describe("Executor", func() {
 					"-o", filepath.Join(relativeDeploymentDir, "jumpbox-user.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "uaa.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "credhub.yml"),
+					"-o", filepath.Join(relativeDeploymentDir, "bbr.yml"),
 					"-v", `subscription_id="${BBL_AZURE_SUBSCRIPTION_ID}"`,
 					"-v", `client_id="${BBL_AZURE_CLIENT_ID}"`,
 					"-v", `client_secret="${BBL_AZURE_CLIENT_SECRET}"`,
@@ -385,6 +666,40 @@ var _ = Describe("Executor", func() {
 
 				behavesLikePlan(expectedArgs, cli, fs, executor, dirInput, deploymentDir, "azure", stateDir)
 			})
+
+			It("uses managed disks in a region with availability zones", func() {
+				err := executor.PlanDirector(dirInput, deploymentDir, storage.State{IAAS: "azure", Azure: storage.Azure{Region: "westus2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -v",
+					filepath.Join(relativeDeploymentDir, "bbr.yml"),
+					filepath.Join(relativeDeploymentDir, "azure", "use-managed-disks.yml"),
+				)))
+			})
+
+			It("uses managed disks with a disk encryption set when a disk encryption key is set", func() {
+				state := storage.State{
+					IAAS:     "azure",
+					Azure:    storage.Azure{Region: "westus"},
+					Director: storage.Director{DiskEncryptionKey: "some-key"},
+				}
+				err := executor.PlanDirector(dirInput, deploymentDir, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				shellScript, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(shellScript)).To(ContainSubstring(fmt.Sprintf("-o  %s \\\n  -o  %s \\\n  -o  %s \\\n  -v",
+					filepath.Join(relativeDeploymentDir, "bbr.yml"),
+					filepath.Join(relativeDeploymentDir, "azure", "use-managed-disks.yml"),
+					filepath.Join(relativeStateDir, "bbl-ops-files", "azure", "disk-encryption-ops.yml"),
+				)))
+
+				diskEncryptionOps, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "azure", "disk-encryption-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(diskEncryptionOps)).To(Equal(bosh.AzureDiskEncryptionOps))
+			})
 		})
 
 		Context("vsphere", func() {
@@ -398,6 +713,7 @@ var _ = Describe("Executor", func() {
 					"-o", filepath.Join(relativeDeploymentDir, "jumpbox-user.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "uaa.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "credhub.yml"),
+					"-o", filepath.Join(relativeDeploymentDir, "bbr.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "vsphere", "resource-pool.yml"),
 					"-v", `vcenter_user="${BBL_VSPHERE_VCENTER_USER}"`,
 					"-v", `vcenter_password="${BBL_VSPHERE_VCENTER_PASSWORD}"`,
@@ -418,6 +734,7 @@ var _ = Describe("Executor", func() {
 					"-o", filepath.Join(relativeDeploymentDir, "jumpbox-user.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "uaa.yml"),
 					"-o", filepath.Join(relativeDeploymentDir, "credhub.yml"),
+					"-o", filepath.Join(relativeDeploymentDir, "bbr.yml"),
 					"-v", `openstack_username="${BBL_OPENSTACK_USERNAME}"`,
 					"-v", `openstack_password="${BBL_OPENSTACK_PASSWORD}"`,
 				}
@@ -937,7 +1254,7 @@ func behavesLikePlan(expectedArgs []string, cli *fakes.BOSHCLI, fs behavesLikePl
 		return nil
 	}
 
-	err := executor.PlanDirector(input, deploymentDir, iaas)
+	err := executor.PlanDirector(input, deploymentDir, storage.State{IAAS: iaas})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cli.RunCallCount()).To(Equal(0))
 
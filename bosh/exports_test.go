@@ -1,7 +1,9 @@
 package bosh
 
 import (
+	"net"
 	"os"
+	"time"
 
 	"golang.org/x/net/proxy"
 )
@@ -29,3 +31,35 @@ func SetProxySOCKS5(f func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dia
 func ResetProxySOCKS5() {
 	proxySOCKS5 = proxy.SOCKS5
 }
+
+func SetNetDial(f func(string, string, time.Duration) (net.Conn, error)) {
+	netDial = f
+}
+
+func ResetNetDial() {
+	netDial = net.DialTimeout
+}
+
+func SetProxyReadyInterval(d time.Duration) {
+	proxyReadyInterval = d
+}
+
+func ResetProxyReadyInterval() {
+	proxyReadyInterval = 100 * time.Millisecond
+}
+
+func (c Client) WithRetries(maxRetries int, retryDelay time.Duration) Client {
+	c.maxRetries = maxRetries
+	c.retryDelay = retryDelay
+	return c
+}
+
+func (c Client) WithTaskPollInterval(interval time.Duration) Client {
+	c.taskPollInterval = interval
+	return c
+}
+
+func (c Client) WithTaskTimeout(timeout time.Duration) Client {
+	c.taskTimeout = timeout
+	return c
+}
@@ -0,0 +1,52 @@
+package bosh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+type EndpointChecker interface {
+	Get(address string) error
+	UAAToken(uaaAddress, clientID, clientSecret string) error
+}
+
+type HealthChecker struct {
+	httpClient *http.Client
+}
+
+func NewHealthChecker(httpClient *http.Client) HealthChecker {
+	return HealthChecker{
+		httpClient: httpClient,
+	}
+}
+
+func (h HealthChecker) Get(address string) error {
+	response, err := h.httpClient.Get(address)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
+
+func (h HealthChecker) UAAToken(uaaAddress, clientID, clientSecret string) error {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, h.httpClient)
+
+	conf := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     fmt.Sprintf("%s/oauth/token", uaaAddress),
+	}
+
+	_, err := conf.Token(ctx)
+	return err
+}
@@ -0,0 +1,90 @@
+package bosh_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HealthChecker", func() {
+	var (
+		server        *httptest.Server
+		healthChecker bosh.HealthChecker
+		failStatus    int
+		clientID      string
+		clientSecret  string
+	)
+
+	BeforeEach(func() {
+		failStatus = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if failStatus != 0 {
+				w.WriteHeader(failStatus)
+				return
+			}
+
+			switch req.URL.Path {
+			case "/info":
+				w.Write([]byte(`{}`))
+			case "/oauth/token":
+				clientID, clientSecret, _ = req.BasicAuth()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"access_token": "some-uaa-token", "token_type": "bearer", "expires_in": 3600}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		healthChecker = bosh.NewHealthChecker(server.Client())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Get", func() {
+		It("succeeds when the endpoint responds with 200", func() {
+			err := healthChecker.Get(server.URL + "/info")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the endpoint responds with an error status", func() {
+			It("returns an error", func() {
+				failStatus = http.StatusServiceUnavailable
+
+				err := healthChecker.Get(server.URL + "/info")
+				Expect(err).To(MatchError("unexpected http response 503 Service Unavailable"))
+			})
+		})
+
+		Context("when the request fails", func() {
+			It("returns an error", func() {
+				err := healthChecker.Get("fake://some-url")
+				Expect(err).To(MatchError(ContainSubstring("unsupported protocol scheme")))
+			})
+		})
+	})
+
+	Describe("UAAToken", func() {
+		It("fetches a client credentials token", func() {
+			err := healthChecker.UAAToken(server.URL, "some-client", "some-secret")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(clientID).To(Equal("some-client"))
+			Expect(clientSecret).To(Equal("some-secret"))
+		})
+
+		Context("when uaa rejects the credentials", func() {
+			It("returns an error", func() {
+				failStatus = http.StatusUnauthorized
+
+				err := healthChecker.UAAToken(server.URL, "some-client", "some-secret")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
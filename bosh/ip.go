@@ -1,16 +1,25 @@
 package bosh
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
+	"net"
 	"strconv"
 	"strings"
 )
 
+// IP is an IPv4 or IPv6 address. IPv4 addresses are 4 bytes long and IPv6
+// addresses 16, so arithmetic wraps around within the family.
 type IP struct {
-	ip int64
+	ip []byte
 }
 
 func ParseIP(ip string) (IP, error) {
+	if strings.Contains(ip, ":") {
+		return parseIPv6(ip)
+	}
+
 	const IP_PARTS = 4
 	const MAX_IP_PART = int64(256)
 
@@ -20,8 +29,8 @@ func ParseIP(ip string) (IP, error) {
 		return IP{}, fmt.Errorf(`'%s' is not a valid ip address`, ip)
 	}
 
-	ipValue := int64(0)
-	for _, ipPart := range ipParts {
+	ipBytes := make([]byte, net.IPv4len)
+	for i, ipPart := range ipParts {
 		ipPartInt, err := strconv.ParseInt(ipPart, 10, 0)
 		if err != nil {
 			return IP{}, err
@@ -31,30 +40,79 @@ func ParseIP(ip string) (IP, error) {
 			return IP{}, fmt.Errorf("invalid ip, %s has values out of range", ip)
 		}
 
-		ipValue = ipValue*MAX_IP_PART + ipPartInt
+		ipBytes[i] = byte(ipPartInt)
 	}
 
 	return IP{
-		ip: ipValue,
+		ip: ipBytes,
 	}, nil
 }
 
-func (i IP) Add(offset int) IP {
-	return IP{
-		ip: i.ip + int64(offset),
+func parseIPv6(ip string) (IP, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return IP{}, fmt.Errorf(`'%s' is not a valid ip address`, ip)
 	}
+
+	return IP{
+		ip: parsed.To16(),
+	}, nil
+}
+
+// IsIPv6 returns true for IPv6 addresses.
+func (i IP) IsIPv6() bool {
+	return len(i.ip) == net.IPv6len
+}
+
+func (i IP) Add(offset int) IP {
+	return i.add(big.NewInt(int64(offset)))
 }
 
 func (i IP) Subtract(offset int) IP {
+	return i.add(big.NewInt(-int64(offset)))
+}
+
+func (i IP) add(offset *big.Int) IP {
+	size := i.len()
+
+	value := new(big.Int).SetBytes(i.ip)
+	value.Add(value, offset)
+	value.Mod(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+
+	ipBytes := make([]byte, size)
+	valueBytes := value.Bytes()
+	copy(ipBytes[size-len(valueBytes):], valueBytes)
+
 	return IP{
-		ip: i.ip - int64(offset),
+		ip: ipBytes,
 	}
 }
 
+// Compare returns -1, 0 or 1 when i is before, equal to or after other. IPv4
+// addresses are before IPv6 addresses.
+func (i IP) Compare(other IP) int {
+	if len(i.ip) != len(other.ip) {
+		if len(i.ip) < len(other.ip) {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(i.ip, other.ip)
+}
+
+func (i IP) len() int {
+	if i.IsIPv6() {
+		return net.IPv6len
+	}
+	return net.IPv4len
+}
+
 func (i IP) String() string {
-	first := i.ip & 0xff000000 >> 24
-	second := i.ip & 0xff0000 >> 16
-	third := i.ip & 0xff00 >> 8
-	fourth := i.ip & 0xff
-	return fmt.Sprintf("%v.%v.%v.%v", first, second, third, fourth)
+	if i.IsIPv6() {
+		return net.IP(i.ip).String()
+	}
+
+	ipBytes := make([]byte, net.IPv4len)
+	copy(ipBytes, i.ip)
+	return fmt.Sprintf("%v.%v.%v.%v", ipBytes[0], ipBytes[1], ipBytes[2], ipBytes[3])
 }
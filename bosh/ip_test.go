@@ -15,6 +15,13 @@ var _ = Describe("IP", func() {
 			Expect(ip.String()).To(Equal("10.0.16.255"))
 		})
 
+		It("parses ipv6 addresses", func() {
+			ip, err := bosh.ParseIP("2600:1F18:04ab:ee01:0:0:0:1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.IsIPv6()).To(BeTrue())
+			Expect(ip.String()).To(Equal("2600:1f18:4ab:ee01::1"))
+		})
+
 		Context("failure cases", func() {
 			It("returns an error if it cannot parse ip", func() {
 				_, err := bosh.ParseIP("not valid")
@@ -35,6 +42,11 @@ var _ = Describe("IP", func() {
 				_, err := bosh.ParseIP("1.1.1.1.1.1.1")
 				Expect(err).To(MatchError(ContainSubstring("not a valid ip address")))
 			})
+
+			It("returns an error if an ipv6 address cannot be parsed", func() {
+				_, err := bosh.ParseIP("2600::1f18::1")
+				Expect(err).To(MatchError(ContainSubstring("not a valid ip address")))
+			})
 		})
 	})
 
@@ -45,6 +57,16 @@ var _ = Describe("IP", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip.String()).To(Equal("10.0.16.2"))
 		})
+
+		It("carries over into the next part", func() {
+			ip, err := bosh.ParseIP("10.0.16.255")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.Add(1).String()).To(Equal("10.0.17.0"))
+
+			ip, err = bosh.ParseIP("2600:1f18:4ab:ee01::ffff")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.Add(1).String()).To(Equal("2600:1f18:4ab:ee01::1:0"))
+		})
 	})
 
 	Describe("Subtract", func() {
@@ -54,6 +76,28 @@ var _ = Describe("IP", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip.String()).To(Equal("10.0.16.1"))
 		})
+
+		It("subtracts from ipv6 addresses", func() {
+			ip, err := bosh.ParseIP("2600:1f18:4ab:ee01:ffff:ffff:ffff:ffff")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.Subtract(65).String()).To(Equal("2600:1f18:4ab:ee01:ffff:ffff:ffff:ffbe"))
+		})
+	})
+
+	Describe("Compare", func() {
+		It("orders ips", func() {
+			first, err := bosh.ParseIP("10.0.16.9")
+			Expect(err).NotTo(HaveOccurred())
+			second, err := bosh.ParseIP("10.0.16.10")
+			Expect(err).NotTo(HaveOccurred())
+			ipv6, err := bosh.ParseIP("::1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Compare(second)).To(Equal(-1))
+			Expect(second.Compare(first)).To(Equal(1))
+			Expect(first.Compare(first)).To(Equal(0))
+			Expect(second.Compare(ipv6)).To(Equal(-1))
+		})
 	})
 
 	Describe("String", func() {
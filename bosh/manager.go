@@ -1,6 +1,8 @@
 package bosh
 
 import (
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,16 +21,19 @@ var (
 )
 
 type managerFs interface {
+	fileio.FileReader
 	fileio.FileWriter
 	fileio.TempDirer
 }
 
 type Manager struct {
-	executor     executor
-	logger       logger
-	stateStore   stateStore
-	sshKeyGetter sshKeyGetter
-	fs           managerFs
+	executor        executor
+	logger          logger
+	stateStore      stateStore
+	sshKeyGetter    sshKeyGetter
+	sshKeyGenerator sshKeyGenerator
+	jumpboxProxy    jumpboxProxy
+	fs              managerFs
 }
 
 type directorVars struct {
@@ -40,8 +45,8 @@ type directorVars struct {
 }
 
 type executor interface {
-	PlanDirector(DirInput, string, string) error
-	PlanJumpbox(DirInput, string, string) error
+	PlanDirector(DirInput, string, storage.State) error
+	PlanJumpbox(DirInput, string, storage.State) error
 	CreateEnv(DirInput, storage.State) (string, error)
 	DeleteEnv(DirInput, storage.State) error
 	WriteDeploymentVars(DirInput, string) error
@@ -65,13 +70,24 @@ type sshKeyGetter interface {
 	Get(string) (string, error)
 }
 
-func NewManager(executor executor, logger logger, stateStore stateStore, sshKeyGetter sshKeyGetter, fs deleterFs) *Manager {
+type sshKeyGenerator interface {
+	GenerateKey(keyType string) (string, string, error)
+}
+
+type jumpboxProxy interface {
+	ProxyAddr(state storage.State) (string, error)
+}
+
+func NewManager(executor executor, logger logger, stateStore stateStore, sshKeyGetter sshKeyGetter,
+	sshKeyGenerator sshKeyGenerator, jumpboxProxy jumpboxProxy, fs deleterFs) *Manager {
 	return &Manager{
-		executor:     executor,
-		logger:       logger,
-		stateStore:   stateStore,
-		sshKeyGetter: sshKeyGetter,
-		fs:           fs,
+		executor:        executor,
+		logger:          logger,
+		stateStore:      stateStore,
+		sshKeyGetter:    sshKeyGetter,
+		sshKeyGenerator: sshKeyGenerator,
+		jumpboxProxy:    jumpboxProxy,
+		fs:              fs,
 	}
 }
 
@@ -106,7 +122,7 @@ func (m *Manager) InitializeJumpbox(state storage.State) error {
 		VarsDir:  varsDir,
 	}
 
-	err = m.executor.PlanJumpbox(iaasInputs, deploymentDir, state.IAAS)
+	err = m.executor.PlanJumpbox(iaasInputs, deploymentDir, state)
 	if err != nil {
 		return fmt.Errorf("Jumpbox interpolate: %s", err)
 	}
@@ -135,6 +151,11 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs terraform.
 		return storage.State{}, fmt.Errorf("Write deployment vars: %s", err)
 	}
 
+	err = m.writeJumpboxSSHKeys(varsDir, state.JumpboxConfig)
+	if err != nil {
+		return storage.State{}, fmt.Errorf("Write jumpbox ssh key: %s", err)
+	}
+
 	_, err = m.executor.CreateEnv(dirInput, state)
 	if err != nil {
 		return storage.State{}, NewManagerCreateError(state, err)
@@ -145,26 +166,178 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs terraform.
 		URL: terraformOutputs.GetString("jumpbox_url"),
 	}
 
+	if state.JumpboxConfig.SSHCA {
+		return state, nil
+	}
+
+	err = m.setJumpboxKeyProxy(state)
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	return state, nil
+}
+
+// setJumpboxKeyProxy has the bosh cli open its own ssh tunnel to the jumpbox
+// with the jumpbox key.
+func (m *Manager) setJumpboxKeyProxy(state storage.State) error {
 	dir, err := m.fs.TempDir("", "bosh-jumpbox")
 	if err != nil {
-		return storage.State{}, fmt.Errorf("Create temp dir for jumpbox private key: %s", err)
+		return fmt.Errorf("Create temp dir for jumpbox private key: %s", err)
 	}
 
 	privateKeyPath := filepath.Join(dir, "bosh_jumpbox_private.key")
 
 	privateKeyContents, err := m.sshKeyGetter.Get("jumpbox")
 	if err != nil {
-		return storage.State{}, fmt.Errorf("Get jumpbox private key: %s", err)
+		return fmt.Errorf("Get jumpbox private key: %s", err)
 	}
 
 	err = m.fs.WriteFile(privateKeyPath, []byte(privateKeyContents), 0600)
 	if err != nil {
-		return storage.State{}, fmt.Errorf("Write jumpbox private key: %s", err)
+		return fmt.Errorf("Write jumpbox private key: %s", err)
 	}
 
 	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("ssh+socks5://jumpbox@%s?private-key=%s", state.Jumpbox.URL, privateKeyPath))
 
-	return state, nil
+	return nil
+}
+
+// setJumpboxCertificateProxy points the bosh cli at the proxy bbl runs
+// itself, since jumpboxes that trust an ssh CA only accept certificates,
+// which the bosh cli cannot log in with.
+func (m *Manager) setJumpboxCertificateProxy(state storage.State) error {
+	addr, err := m.jumpboxProxy.ProxyAddr(state)
+	if err != nil {
+		return fmt.Errorf("Start jumpbox proxy: %s", err)
+	}
+
+	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("socks5://%s", addr))
+
+	return nil
+}
+
+// writeJumpboxSSHKeys puts a jumpbox key of the configured type, and the
+// ssh CA when certificates are enabled, in the vars store before create-env,
+// since bosh only generates rsa keys.
+func (m *Manager) writeJumpboxSSHKeys(varsDir string, config storage.JumpboxConfig) error {
+	varsStorePath := filepath.Join(varsDir, "jumpbox-vars-store.yml")
+
+	vars := map[string]interface{}{}
+	contents, err := m.fs.ReadFile(varsStorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Read jumpbox vars store: %s", err)
+	}
+
+	err = yaml.Unmarshal(contents, &vars)
+	if err != nil {
+		return fmt.Errorf("Parse jumpbox vars store: %s", err)
+	}
+
+	keyChanged, err := m.setJumpboxSSHKey(vars, config.SSHKeyType)
+	if err != nil {
+		return err
+	}
+
+	caChanged, err := m.setJumpboxSSHCA(vars, config.SSHCA)
+	if err != nil {
+		return err
+	}
+
+	if !keyChanged && !caChanged {
+		return nil
+	}
+
+	err = m.fs.WriteFile(varsStorePath, mustMarshal(vars), storage.StateMode)
+	if err != nil {
+		return fmt.Errorf("Write jumpbox vars store: %s", err)
+	}
+
+	return nil
+}
+
+// setJumpboxSSHKey replaces a key of another type than keyType, or removes
+// it for rsa so that bosh generates a new one.
+func (m *Manager) setJumpboxSSHKey(vars map[string]interface{}, keyType string) (bool, error) {
+	if keyType == "" {
+		keyType = "rsa"
+	}
+
+	currentKeyType := jumpboxSSHKeyType(vars)
+	if currentKeyType == keyType || (currentKeyType == "" && keyType == "rsa") {
+		return false, nil
+	}
+
+	if keyType == "rsa" {
+		delete(vars, "jumpbox_ssh")
+		return true, nil
+	}
+
+	privateKey, publicKey, err := m.sshKeyGenerator.GenerateKey(keyType)
+	if err != nil {
+		return false, fmt.Errorf("Generate %s key: %s", keyType, err)
+	}
+
+	publicKey = strings.TrimSpace(publicKey)
+	vars["jumpbox_ssh"] = map[string]string{
+		"private_key":            privateKey,
+		"public_key":             publicKey,
+		"public_key_fingerprint": publicKeyFingerprint(publicKey),
+	}
+	return true, nil
+}
+
+// setJumpboxSSHCA generates the CA that signs jumpbox user certificates,
+// unless there already is one. bbl jumpbox ssh-ca rotate removes it.
+func (m *Manager) setJumpboxSSHCA(vars map[string]interface{}, enabled bool) (bool, error) {
+	if _, ok := vars["jumpbox_ssh_ca"]; ok || !enabled {
+		return false, nil
+	}
+
+	privateKey, publicKey, err := m.sshKeyGenerator.GenerateKey("ed25519")
+	if err != nil {
+		return false, fmt.Errorf("Generate ssh CA: %s", err)
+	}
+
+	vars["jumpbox_ssh_ca"] = map[string]string{
+		"private_key": privateKey,
+		"public_key":  strings.TrimSpace(publicKey),
+	}
+	return true, nil
+}
+
+func jumpboxSSHKeyType(vars map[string]interface{}) string {
+	jumpboxSSH, _ := vars["jumpbox_ssh"].(map[interface{}]interface{})
+	publicKey, _ := jumpboxSSH["public_key"].(string)
+
+	switch {
+	case strings.HasPrefix(publicKey, "ssh-rsa "):
+		return "rsa"
+	case strings.HasPrefix(publicKey, "ssh-ed25519 "):
+		return "ed25519"
+	}
+	return ""
+}
+
+// publicKeyFingerprint returns the md5 fingerprint bosh stores next to the
+// keys it generates.
+func publicKeyFingerprint(publicKey string) string {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return ""
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return ""
+	}
+
+	sum := md5.Sum(keyBytes)
+	hexSum := make([]string, len(sum))
+	for i, b := range sum {
+		hexSum[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(hexSum, ":")
 }
 
 func (m *Manager) InitializeDirector(state storage.State) error {
@@ -185,7 +358,7 @@ func (m *Manager) InitializeDirector(state storage.State) error {
 		VarsDir:  varsDir,
 	}
 
-	err = m.executor.PlanDirector(iaasInputs, directorDeploymentDir, state.IAAS)
+	err = m.executor.PlanDirector(iaasInputs, directorDeploymentDir, state)
 	if err != nil {
 		return err
 	}
@@ -214,6 +387,15 @@ func (m *Manager) CreateDirector(state storage.State, terraformOutputs terraform
 		return storage.State{}, fmt.Errorf("Write deployment vars: %s", err)
 	}
 
+	if state.NoJumpbox {
+		osUnsetenv("BOSH_ALL_PROXY")
+	} else if state.JumpboxConfig.SSHCA {
+		err = m.setJumpboxCertificateProxy(state)
+		if err != nil {
+			return storage.State{}, err
+		}
+	}
+
 	variables, err := m.executor.CreateEnv(dirInput, state)
 	if err != nil {
 		state.BOSH = storage.BOSH{
@@ -275,25 +457,20 @@ func (m *Manager) DeleteDirector(state storage.State, terraformOutputs terraform
 		return fmt.Errorf("Write deployment vars: %s", err)
 	}
 
-	dir, err := m.fs.TempDir("", "bosh-jumpbox")
-	if err != nil {
-		return fmt.Errorf("Create temp dir for jumpbox private key: %s", err)
-	}
-
-	privateKeyPath := filepath.Join(dir, "bosh_jumpbox_private.key")
-
-	privateKeyContents, err := m.sshKeyGetter.Get("jumpbox")
-	if err != nil {
-		return fmt.Errorf("Get jumpbox private key: %s", err)
-	}
-
-	err = m.fs.WriteFile(privateKeyPath, []byte(privateKeyContents), 0600)
-	if err != nil {
-		return fmt.Errorf("Write jumpbox private key: %s", err)
+	if state.NoJumpbox {
+		osUnsetenv("BOSH_ALL_PROXY")
+	} else if state.JumpboxConfig.SSHCA {
+		err = m.setJumpboxCertificateProxy(state)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = m.setJumpboxKeyProxy(state)
+		if err != nil {
+			return err
+		}
 	}
 
-	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("ssh+socks5://jumpbox@%s?private-key=%s", state.Jumpbox.URL, privateKeyPath))
-
 	err = m.executor.DeleteEnv(dirInput, state)
 	if err != nil {
 		return NewManagerDeleteError(state, err)
@@ -351,6 +528,17 @@ func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs
 		}
 	}
 
+	if state.JumpboxConfig.VMType != "" {
+		allOutputs["vm_type"] = state.JumpboxConfig.VMType
+	}
+	if state.JumpboxConfig.DiskSize != 0 {
+		allOutputs["disk_size"] = jumpboxDiskSize(state.IAAS, state.JumpboxConfig.DiskSize)
+	}
+	if state.JumpboxConfig.AuthorizedKey != "" {
+		allOutputs["jumpbox_authorized_key"] = state.JumpboxConfig.AuthorizedKey
+	}
+	addNetworkVars(allOutputs, state.Network)
+
 	vars := sharedDeploymentVarsYAML{
 		TerraformOutputs: allOutputs,
 	}
@@ -383,6 +571,14 @@ func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutput
 		}
 	}
 
+	if state.Director.VMType != "" {
+		allOutputs["vm_type"] = state.Director.VMType
+	}
+	if state.Director.DiskSize != 0 {
+		allOutputs["disk_size"] = gigabytesToMegabytes(state.Director.DiskSize)
+	}
+	addNetworkVars(allOutputs, state.Network)
+
 	vars := sharedDeploymentVarsYAML{
 		TerraformOutputs: allOutputs,
 	}
@@ -390,6 +586,30 @@ func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutput
 	return string(mustMarshal(vars))
 }
 
+// addNetworkVars adds the vars of the dns and ntp ops files, which are only
+// used when servers are configured.
+func addNetworkVars(vars map[string]interface{}, network storage.Network) {
+	if len(network.DNSServers) > 0 {
+		vars["internal_dns"] = network.DNSServers
+	}
+	if len(network.NTPServers) > 0 {
+		vars["internal_ntp"] = network.NTPServers
+	}
+}
+
+// gigabytesToMegabytes converts a disk size from the bbl flags into the
+// megabytes bosh expects in a manifest.
+func gigabytesToMegabytes(size int) int {
+	return size * 1024
+}
+
+func jumpboxDiskSize(iaas string, size int) int {
+	if iaas == "gcp" || iaas == "openstack" {
+		return size
+	}
+	return gigabytesToMegabytes(size)
+}
+
 func getDirectorVars(v string) directorVars {
 	var vars struct {
 		AdminPassword string `yaml:"admin_password"`
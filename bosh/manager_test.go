@@ -21,6 +21,8 @@ var _ = Describe("Manager", func() {
 		logger       *fakes.Logger
 		stateStore   *fakes.StateStore
 		sshKeyGetter *fakes.SSHKeyGetter
+		keyGenerator *fakes.SSHKeyGenerator
+		jumpboxProxy *fakes.JumpboxProxy
 		fs           *fakes.FileIO
 
 		boshManager      *bosh.Manager
@@ -37,6 +39,8 @@ var _ = Describe("Manager", func() {
 		logger = &fakes.Logger{}
 		sshKeyGetter = &fakes.SSHKeyGetter{}
 		sshKeyGetter.GetCall.Returns.PrivateKey = "some-jumpbox-private-key"
+		keyGenerator = &fakes.SSHKeyGenerator{}
+		jumpboxProxy = &fakes.JumpboxProxy{}
 		fs = &fakes.FileIO{}
 
 		stateStore = &fakes.StateStore{}
@@ -45,7 +49,7 @@ var _ = Describe("Manager", func() {
 		stateStore.GetDirectorDeploymentDirCall.Returns.Directory = "some-director-deployment-dir"
 		stateStore.GetJumpboxDeploymentDirCall.Returns.Directory = "some-jumpbox-deployment-dir"
 
-		boshManager = bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, fs)
+		boshManager = bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, keyGenerator, jumpboxProxy, fs)
 
 		boshVars = `admin_password: some-admin-password
 director_ssl:
@@ -97,6 +101,7 @@ director_ssl:
 				Expect(boshExecutor.PlanDirectorCall.Receives.DirInput.VarsDir).To(Equal("some-bbl-vars-dir"))
 				Expect(boshExecutor.PlanDirectorCall.Receives.DirInput.StateDir).To(Equal("some-state-dir"))
 				Expect(boshExecutor.PlanDirectorCall.Receives.DeploymentDir).To(Equal("some-director-deployment-dir"))
+				Expect(boshExecutor.PlanDirectorCall.Receives.State).To(Equal(state))
 				Expect(boshExecutor.PlanJumpboxCall.CallCount).To(Equal(0))
 
 				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
@@ -164,6 +169,46 @@ director_ssl:
 				}))
 			})
 
+			Context("when there is no jumpbox", func() {
+				It("unsets BOSH_ALL_PROXY so the director is reached directly", func() {
+					osUnsetenvKey = ""
+					state.NoJumpbox = true
+
+					_, err := boshManager.CreateDirector(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(osUnsetenvKey).To(Equal("BOSH_ALL_PROXY"))
+					Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+				})
+			})
+
+			Context("when the jumpbox trusts an ssh CA", func() {
+				BeforeEach(func() {
+					state.JumpboxConfig.SSHCA = true
+					jumpboxProxy.ProxyAddrCall.Returns.Addr = "127.0.0.1:1234"
+				})
+
+				It("creates the director through the proxy bbl runs with a certificate", func() {
+					_, err := boshManager.CreateDirector(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(jumpboxProxy.ProxyAddrCall.Receives.State).To(Equal(state))
+					Expect(osSetenvKey).To(Equal("BOSH_ALL_PROXY"))
+					Expect(osSetenvValue).To(Equal("socks5://127.0.0.1:1234"))
+					Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+				})
+
+				Context("when the proxy cannot be started", func() {
+					It("returns an error", func() {
+						jumpboxProxy.ProxyAddrCall.Returns.Error = errors.New("durian")
+
+						_, err := boshManager.CreateDirector(state, terraformOutputs)
+						Expect(err).To(MatchError("Start jumpbox proxy: durian"))
+						Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when an error occurs", func() {
 				Context("when get vars dir fails", func() {
 					It("returns an error", func() {
@@ -231,6 +276,7 @@ director_ssl:
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(boshExecutor.PlanJumpboxCall.Receives.DeploymentDir).To(Equal("some-jumpbox-deployment-dir"))
+				Expect(boshExecutor.PlanJumpboxCall.Receives.State).To(Equal(state))
 				Expect(boshExecutor.PlanJumpboxCall.Receives.DirInput.VarsDir).To(Equal("some-bbl-vars-dir"))
 				Expect(boshExecutor.PlanJumpboxCall.Receives.DirInput.StateDir).To(Equal("some-state-dir"))
 			})
@@ -271,6 +317,20 @@ director_ssl:
 				}))
 			})
 
+			Context("when the jumpbox trusts an ssh CA", func() {
+				It("leaves BOSH_ALL_PROXY unset, since the jumpbox key is no longer authorized", func() {
+					osSetenvKey = ""
+					state.JumpboxConfig.SSHCA = true
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(osUnsetenvKey).To(Equal("BOSH_ALL_PROXY"))
+					Expect(osSetenvKey).To(BeEmpty())
+					Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+				})
+			})
+
 			It("returns a bbl state with bosh and jumpbox deployment values", func() {
 				state, err := boshManager.CreateJumpbox(state, terraformOutputs)
 				Expect(err).NotTo(HaveOccurred())
@@ -294,6 +354,135 @@ director_ssl:
 				}))
 			})
 
+			Context("when the jumpbox ssh key type is ed25519", func() {
+				BeforeEach(func() {
+					state.JumpboxConfig.SSHKeyType = "ed25519"
+					fs.ReadFileCall.Returns.Contents = []byte("mbus_bootstrap_password: some-password\n")
+					keyGenerator.GenerateKeyCall.Returns.PrivateKey = "some-ed25519-private-key"
+					keyGenerator.GenerateKeyCall.Returns.PublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBB2XBnQ+hOVF6QQQHRtXIR4wbOQIKdtPNswf+hYE+/H\n"
+				})
+
+				It("writes a generated key to the vars store before create-env", func() {
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fs.ReadFileCall.Receives.Filename).To(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+					Expect(keyGenerator.GenerateKeyCall.Receives.KeyType).To(Equal("ed25519"))
+
+					Expect(fs.WriteFileCall.Receives[0].Filename).To(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+					Expect(fs.WriteFileCall.Receives[0].Contents).To(MatchYAML(`
+mbus_bootstrap_password: some-password
+jumpbox_ssh:
+  private_key: some-ed25519-private-key
+  public_key: ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBB2XBnQ+hOVF6QQQHRtXIR4wbOQIKdtPNswf+hYE+/H
+  public_key_fingerprint: 44:5d:85:14:ca:e0:ea:7b:2b:f1:22:4b:72:a9:d5:fe
+`))
+				})
+
+				It("keeps an existing ed25519 key", func() {
+					fs.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  public_key: ssh-ed25519 AAAA\n")
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(keyGenerator.GenerateKeyCall.CallCount).To(Equal(0))
+					Expect(fs.WriteFileCall.Receives[0].Filename).NotTo(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+				})
+
+				It("replaces an existing rsa key", func() {
+					fs.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  public_key: ssh-rsa AAAA\n")
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(keyGenerator.GenerateKeyCall.CallCount).To(Equal(1))
+				})
+
+				Context("when the key cannot be generated", func() {
+					It("returns an error", func() {
+						keyGenerator.GenerateKeyCall.Returns.Error = errors.New("tamarind")
+
+						_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+						Expect(err).To(MatchError("Write jumpbox ssh key: Generate ed25519 key: tamarind"))
+						Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+					})
+				})
+
+				Context("when the vars store cannot be parsed", func() {
+					It("returns an error", func() {
+						fs.ReadFileCall.Returns.Contents = []byte("%%%")
+
+						_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+						Expect(err).To(MatchError(ContainSubstring("Write jumpbox ssh key: Parse jumpbox vars store")))
+					})
+				})
+			})
+
+			Context("when the jumpbox ssh key type is rsa", func() {
+				It("leaves an rsa key to bosh", func() {
+					fs.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  public_key: ssh-rsa AAAA\n")
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(keyGenerator.GenerateKeyCall.CallCount).To(Equal(0))
+					Expect(fs.WriteFileCall.Receives[0].Filename).NotTo(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+				})
+
+				It("removes a key of another type so that bosh generates an rsa key", func() {
+					fs.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  public_key: ssh-ed25519 AAAA\nmbus_bootstrap_password: some-password\n")
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fs.WriteFileCall.Receives[0].Filename).To(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+					Expect(fs.WriteFileCall.Receives[0].Contents).To(MatchYAML("mbus_bootstrap_password: some-password"))
+				})
+			})
+
+			Context("when ssh certificates are enabled", func() {
+				BeforeEach(func() {
+					state.JumpboxConfig.SSHCA = true
+					fs.ReadFileCall.Returns.Contents = []byte("mbus_bootstrap_password: some-password\n")
+					keyGenerator.GenerateKeyCall.Returns.PrivateKey = "some-ca-private-key"
+					keyGenerator.GenerateKeyCall.Returns.PublicKey = "ssh-ed25519 some-ca-public-key\n"
+				})
+
+				It("writes a generated ssh CA to the vars store before create-env", func() {
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(keyGenerator.GenerateKeyCall.Receives.KeyType).To(Equal("ed25519"))
+					Expect(fs.WriteFileCall.Receives[0].Filename).To(Equal("some-bbl-vars-dir/jumpbox-vars-store.yml"))
+					Expect(fs.WriteFileCall.Receives[0].Contents).To(MatchYAML(`
+mbus_bootstrap_password: some-password
+jumpbox_ssh_ca:
+  private_key: some-ca-private-key
+  public_key: ssh-ed25519 some-ca-public-key
+`))
+				})
+
+				It("keeps an existing ssh CA", func() {
+					fs.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh_ca:\n  public_key: ssh-ed25519 AAAA\n")
+
+					_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(keyGenerator.GenerateKeyCall.CallCount).To(Equal(0))
+					Expect(fs.WriteFileCall.Receives).To(BeEmpty())
+				})
+
+				Context("when the CA cannot be generated", func() {
+					It("returns an error", func() {
+						keyGenerator.GenerateKeyCall.Returns.Error = errors.New("jackfruit")
+
+						_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+						Expect(err).To(MatchError("Write jumpbox ssh key: Generate ssh CA: jackfruit"))
+						Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when an error occurs", func() {
 				Context("when geting the jumpbox key fails", func() {
 					BeforeEach(func() {
@@ -447,6 +636,49 @@ director_ssl:
 			}))
 		})
 
+		Context("when there is no jumpbox", func() {
+			It("calls delete env without a proxy", func() {
+				osSetenvKey = ""
+				osUnsetenvKey = ""
+
+				err := boshManager.DeleteDirector(storage.State{
+					NoJumpbox: true,
+					BOSH: storage.BOSH{
+						Manifest:  "some-manifest",
+						Variables: boshVars,
+					},
+				}, terraform.Outputs{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+				Expect(osSetenvKey).To(BeEmpty())
+				Expect(osUnsetenvKey).To(Equal("BOSH_ALL_PROXY"))
+				Expect(boshExecutor.DeleteEnvCall.CallCount).To(Equal(1))
+			})
+		})
+
+		Context("when the jumpbox trusts an ssh CA", func() {
+			It("calls delete env through the proxy bbl runs with a certificate", func() {
+				jumpboxProxy.ProxyAddrCall.Returns.Addr = "127.0.0.1:1234"
+
+				err := boshManager.DeleteDirector(storage.State{
+					Jumpbox:       storage.Jumpbox{URL: "some-jumpbox-url:22"},
+					JumpboxConfig: storage.JumpboxConfig{SSHCA: true},
+					BOSH: storage.BOSH{
+						Manifest:  "some-manifest",
+						Variables: boshVars,
+					},
+				}, terraform.Outputs{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+				Expect(jumpboxProxy.ProxyAddrCall.CallCount).To(Equal(1))
+				Expect(osSetenvKey).To(Equal("BOSH_ALL_PROXY"))
+				Expect(osSetenvValue).To(Equal("socks5://127.0.0.1:1234"))
+				Expect(boshExecutor.DeleteEnvCall.CallCount).To(Equal(1))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			var state storage.State
 
@@ -520,6 +752,48 @@ director_ssl:
 			Expect(vars).To(MatchYAML(`---
 some-key: some-value
 key: some-jumpbox-value
+`))
+		})
+
+		It("adds the jumpbox vm type and disk size in megabytes", func() {
+			vars := boshManager.GetJumpboxDeploymentVars(storage.State{
+				JumpboxConfig: storage.JumpboxConfig{VMType: "some-vm-type", DiskSize: 50},
+			}, terraform.Outputs{Map: map[string]interface{}{
+				"some-key": "some-value",
+			}})
+			Expect(vars).To(MatchYAML(`---
+some-key: some-value
+vm_type: some-vm-type
+disk_size: 51200
+`))
+		})
+
+		It("adds the jumpbox disk size in gigabytes on gcp", func() {
+			vars := boshManager.GetJumpboxDeploymentVars(storage.State{
+				IAAS:          "gcp",
+				JumpboxConfig: storage.JumpboxConfig{DiskSize: 50},
+			}, terraform.Outputs{Map: map[string]interface{}{}})
+			Expect(vars).To(MatchYAML(`---
+disk_size: 50
+`))
+		})
+
+		It("adds the authorized key", func() {
+			vars := boshManager.GetJumpboxDeploymentVars(storage.State{
+				JumpboxConfig: storage.JumpboxConfig{AuthorizedKey: "ssh-ed25519 AAAA some-user@some-host"},
+			}, terraform.Outputs{Map: map[string]interface{}{}})
+			Expect(vars).To(MatchYAML(`---
+jumpbox_authorized_key: ssh-ed25519 AAAA some-user@some-host
+`))
+		})
+
+		It("adds the dns and ntp servers", func() {
+			vars := boshManager.GetJumpboxDeploymentVars(storage.State{
+				Network: storage.Network{DNSServers: []string{"10.0.0.2"}, NTPServers: []string{"ntp.example.com"}},
+			}, terraform.Outputs{Map: map[string]interface{}{}})
+			Expect(vars).To(MatchYAML(`---
+internal_dns: [10.0.0.2]
+internal_ntp: [ntp.example.com]
 `))
 		})
 	})
@@ -535,6 +809,29 @@ key: some-jumpbox-value
 			Expect(vars).To(MatchYAML(`---
 some-key: some-value
 key: some-director-value
+`))
+		})
+
+		It("adds the director vm type and disk size in megabytes", func() {
+			vars := boshManager.GetDirectorDeploymentVars(storage.State{
+				Director: storage.Director{VMType: "some-vm-type", DiskSize: 100},
+			}, terraform.Outputs{Map: map[string]interface{}{
+				"some-key": "some-value",
+			}})
+			Expect(vars).To(MatchYAML(`---
+some-key: some-value
+vm_type: some-vm-type
+disk_size: 102400
+`))
+		})
+
+		It("adds the dns and ntp servers", func() {
+			vars := boshManager.GetDirectorDeploymentVars(storage.State{
+				Network: storage.Network{DNSServers: []string{"10.0.0.2", "10.0.0.3"}, NTPServers: []string{"10.0.0.4"}},
+			}, terraform.Outputs{Map: map[string]interface{}{}})
+			Expect(vars).To(MatchYAML(`---
+internal_dns: [10.0.0.2, 10.0.0.3]
+internal_ntp: [10.0.0.4]
 `))
 		})
 	})
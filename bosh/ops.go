@@ -41,3 +41,91 @@ const OpenStackJumpboxKeystoneV3Ops = `---
   path: /cloud_provider/properties/openstack/human_readable_vm_names?
   value: true
 `
+
+const AWSExternalBlobstoreOps = `
+- type: replace
+  path: /instance_groups/name=bosh/properties/blobstore?
+  value:
+    provider: s3
+    bucket_name: ((blobstore_bucket_name))
+    s3_region: ((region))
+    access_key_id: ((blobstore_access_key_id))
+    secret_access_key: ((blobstore_secret_access_key))
+
+- type: remove
+  path: /instance_groups/name=bosh/jobs/name=blobstore
+
+- type: replace
+  path: /instance_groups/name=bosh/properties/agent/blobstore?
+  value:
+    access_key_id: ((blobstore_access_key_id))
+    secret_access_key: ((blobstore_secret_access_key))
+`
+
+const AWSExternalDatabaseOps = `
+- type: replace
+  path: /instance_groups/name=bosh/properties/director/db/connection_options?
+  value:
+    sslmode: verify-full
+`
+
+const GCPExternalDatabaseOps = `
+- type: replace
+  path: /instance_groups/name=bosh/properties/director/db/connection_options?
+  value:
+    sslmode: verify-ca
+`
+
+const GCPDiskEncryptionOps = `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/kms_key_name?
+  value: ((disk_encryption_key))
+
+- type: replace
+  path: /disk_pools/name=disks/cloud_properties/kms_key_name?
+  value: ((disk_encryption_key))
+`
+
+const AzureDiskEncryptionOps = `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/root_disk?/disk_encryption_set_name?
+  value: ((disk_encryption_set_name))
+
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/ephemeral_disk?/disk_encryption_set_name?
+  value: ((disk_encryption_set_name))
+
+- type: replace
+  path: /disk_pools/name=disks/cloud_properties?/disk_encryption_set_name?
+  value: ((disk_encryption_set_name))
+`
+
+const VMTypeOps = `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/%s
+  value: ((vm_type))
+`
+
+const DirectorDiskSizeOps = `
+- type: replace
+  path: /disk_pools/name=disks/disk_size
+  value: ((disk_size))
+`
+
+const JumpboxDiskSizeOps = `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/%s
+  value: ((disk_size))
+`
+
+const JumpboxDNSOps = `
+- type: replace
+  path: /networks/name=private/subnets/0/dns
+  value: ((internal_dns))
+`
+
+const JumpboxNTPOps = `
+- type: replace
+  path: /cloud_provider/properties/ntp
+  value: ((internal_ntp))
+`
@@ -0,0 +1,75 @@
+package bosh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// JumpboxCertificateValidity is how long the certificates bbl issues for
+// the jumpbox user are accepted.
+const JumpboxCertificateValidity = time.Hour
+
+type certificateGenerator interface {
+	GenerateCertificate(caPrivateKey, principal string, validity time.Duration) (string, string, error)
+}
+
+// SSHCertificateGetter issues short-lived certificates for the jumpbox user,
+// signed by the ssh CA in the jumpbox vars store.
+type SSHCertificateGetter struct {
+	stateStore           stateStore
+	fReader              fileio.FileReader
+	certificateGenerator certificateGenerator
+}
+
+func NewSSHCertificateGetter(stateStore stateStore, fReader fileio.FileReader, certificateGenerator certificateGenerator) SSHCertificateGetter {
+	return SSHCertificateGetter{
+		stateStore:           stateStore,
+		fReader:              fReader,
+		certificateGenerator: certificateGenerator,
+	}
+}
+
+// Get returns a new private key and a certificate for it. Jumpboxes without
+// an ssh CA get neither, and are logged in to with the jumpbox key instead.
+func (s SSHCertificateGetter) Get() (string, string, error) {
+	var p struct {
+		JumpboxSSHCA struct {
+			PrivateKey string `yaml:"private_key"`
+		} `yaml:"jumpbox_ssh_ca"`
+	}
+
+	varsDir, err := s.stateStore.GetVarsDir()
+	if err != nil {
+		return "", "", fmt.Errorf("Get vars directory: %s", err)
+	}
+
+	varsStore, err := s.fReader.ReadFile(filepath.Join(varsDir, "jumpbox-vars-store.yml"))
+	if os.IsNotExist(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("Read jumpbox vars file: %s", err)
+	}
+
+	err = yaml.Unmarshal(varsStore, &p)
+	if err != nil {
+		return "", "", err
+	}
+
+	if p.JumpboxSSHCA.PrivateKey == "" {
+		return "", "", nil
+	}
+
+	privateKey, certificate, err := s.certificateGenerator.GenerateCertificate(p.JumpboxSSHCA.PrivateKey, "jumpbox", JumpboxCertificateValidity)
+	if err != nil {
+		return "", "", fmt.Errorf("Generate certificate: %s", err)
+	}
+
+	return privateKey, certificate, nil
+}
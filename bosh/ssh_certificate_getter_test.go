@@ -0,0 +1,88 @@
+package bosh_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCertificateGetter", func() {
+	var (
+		stateStore        *fakes.StateStore
+		fileIO            *fakes.FileIO
+		keyGenerator      *fakes.SSHKeyGenerator
+		certificateGetter bosh.SSHCertificateGetter
+	)
+
+	BeforeEach(func() {
+		stateStore = &fakes.StateStore{}
+		stateStore.GetVarsDirCall.Returns.Directory = "some-vars-dir"
+
+		fileIO = &fakes.FileIO{}
+		fileIO.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh_ca:\n  private_key: some-ca-private-key\n")
+
+		keyGenerator = &fakes.SSHKeyGenerator{}
+		keyGenerator.GenerateCertificateCall.Returns.PrivateKey = "some-private-key"
+		keyGenerator.GenerateCertificateCall.Returns.Certificate = "some-certificate"
+
+		certificateGetter = bosh.NewSSHCertificateGetter(stateStore, fileIO, keyGenerator)
+	})
+
+	It("issues a short-lived certificate for the jumpbox user", func() {
+		privateKey, certificate, err := certificateGetter.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(privateKey).To(Equal("some-private-key"))
+		Expect(certificate).To(Equal("some-certificate"))
+
+		Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-vars-dir/jumpbox-vars-store.yml"))
+		Expect(keyGenerator.GenerateCertificateCall.Receives.CAPrivateKey).To(Equal("some-ca-private-key"))
+		Expect(keyGenerator.GenerateCertificateCall.Receives.Principal).To(Equal("jumpbox"))
+		Expect(keyGenerator.GenerateCertificateCall.Receives.Validity).To(Equal(bosh.JumpboxCertificateValidity))
+	})
+
+	It("returns no certificate when the jumpbox has no ssh CA", func() {
+		fileIO.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  private_key: some-private-key\n")
+
+		privateKey, certificate, err := certificateGetter.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(privateKey).To(BeEmpty())
+		Expect(certificate).To(BeEmpty())
+		Expect(keyGenerator.GenerateCertificateCall.CallCount).To(Equal(0))
+	})
+
+	It("returns no certificate when there is no vars store", func() {
+		fileIO.ReadFileCall.Returns.Error = os.ErrNotExist
+
+		_, certificate, err := certificateGetter.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certificate).To(BeEmpty())
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the vars dir cannot be found", func() {
+			stateStore.GetVarsDirCall.Returns.Error = errors.New("tangelo")
+
+			_, _, err := certificateGetter.Get()
+			Expect(err).To(MatchError("Get vars directory: tangelo"))
+		})
+
+		It("returns an error when the vars store cannot be read", func() {
+			fileIO.ReadFileCall.Returns.Error = errors.New("orange")
+
+			_, _, err := certificateGetter.Get()
+			Expect(err).To(MatchError("Read jumpbox vars file: orange"))
+		})
+
+		It("returns an error when the certificate cannot be generated", func() {
+			keyGenerator.GenerateCertificateCall.Returns.Error = errors.New("kumquat")
+
+			_, _, err := certificateGetter.Get()
+			Expect(err).To(MatchError("Generate certificate: kumquat"))
+		})
+	})
+})
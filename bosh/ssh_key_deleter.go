@@ -19,12 +19,25 @@ type deleterFs interface {
 type SSHKeyDeleter struct {
 	stateStore stateStore
 	fs         deleterFs
+	variable   string
 }
 
 func NewSSHKeyDeleter(stateStore stateStore, fs deleterFs) SSHKeyDeleter {
 	return SSHKeyDeleter{
 		stateStore: stateStore,
 		fs:         fs,
+		variable:   "jumpbox_ssh",
+	}
+}
+
+// NewSSHCADeleter returns a deleter for the jumpbox ssh CA, so the next
+// create-env generates a new one and certificates signed by the old one stop
+// working.
+func NewSSHCADeleter(stateStore stateStore, fs deleterFs) SSHKeyDeleter {
+	return SSHKeyDeleter{
+		stateStore: stateStore,
+		fs:         fs,
+		variable:   "jumpbox_ssh_ca",
 	}
 }
 
@@ -37,7 +50,7 @@ func (s SSHKeyDeleter) Delete() error {
 	varsStore := filepath.Join(varsDir, "jumpbox-vars-store.yml")
 	variables, err := s.fs.ReadFile(varsStore)
 	if err == nil {
-		varString, err := deleteJumpboxSSHKey(string(variables))
+		varString, err := deleteVariable(string(variables), s.variable)
 		if err != nil {
 			return fmt.Errorf("Jumpbox variables: %s", err)
 		}
@@ -53,13 +66,13 @@ func (s SSHKeyDeleter) Delete() error {
 	return nil
 }
 
-func deleteJumpboxSSHKey(varsString string) (string, error) {
+func deleteVariable(varsString, name string) (string, error) {
 	vars := make(map[string]interface{})
 	err := yaml.Unmarshal([]byte(varsString), &vars)
 	if err != nil {
 		return "", err
 	}
-	delete(vars, "jumpbox_ssh")
+	delete(vars, name)
 	newVars, err := yaml.Marshal(vars)
 	if err != nil {
 		return "", err // not tested
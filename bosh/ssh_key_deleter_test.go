@@ -49,6 +49,21 @@ var _ = Describe("SSHKeyDeleter", func() {
 			})
 		})
 
+		Context("when deleting the ssh CA", func() {
+			BeforeEach(func() {
+				fileIO.ReadFileCall.Returns.Contents = []byte("jumpbox_ssh:\n  private_key: some-private-key\njumpbox_ssh_ca:\n  private_key: some-ca-key\n")
+				sshKeyDeleter = bosh.NewSSHCADeleter(stateStore, fileIO)
+			})
+
+			It("deletes only the CA from the file on disk", func() {
+				err := sshKeyDeleter.Delete()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.WriteFileCall.Receives[0].Filename).To(Equal(filepath.Join("some-vars-dir", "jumpbox-vars-store.yml")))
+				Expect(string(fileIO.WriteFileCall.Receives[0].Contents)).To(Equal("jumpbox_ssh:\n  private_key: some-private-key\n"))
+			})
+		})
+
 		Context("when the jumpbox-vars-store.yml doesn't contain ssh key", func() {
 			BeforeEach(func() {
 				fileIO.ReadFileCall.Returns.Contents = []byte(afterDeletionVars)
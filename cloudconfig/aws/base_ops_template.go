@@ -374,5 +374,13 @@ const (
     ephemeral_disk:
       size: 1048576
       type: gp2
+
+- type: replace
+  path: /vm_extensions/-
+  value:
+    name: spot
+    cloud_properties:
+      spot_bid_price: 1.0
+      spot_ondemand_fallback: true
 `
 )
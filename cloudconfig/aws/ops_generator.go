@@ -9,6 +9,7 @@ import (
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
@@ -34,6 +35,7 @@ type op struct {
 
 type az struct {
 	Name            string
+	CPI             string            `yaml:"cpi,omitempty"`
 	CloudProperties azCloudProperties `yaml:"cloud_properties"`
 }
 
@@ -53,6 +55,7 @@ type networkSubnet struct {
 	Range           string
 	Reserved        []string
 	Static          []string
+	DNS             []string                     `yaml:"dns,omitempty"`
 	CloudProperties networkSubnetCloudProperties `yaml:"cloud_properties"`
 }
 
@@ -72,8 +75,32 @@ type lbCloudProperties struct {
 	SecurityGroups []string `yaml:"security_groups"`
 }
 
+type cpiConfig struct {
+	CPIs []cpi `yaml:"cpis"`
+}
+
+type cpi struct {
+	Name       string
+	Type       string
+	Properties cpiProperties
+}
+
+type cpiProperties struct {
+	AccessKeyID           string   `yaml:"access_key_id"`
+	SecretAccessKey       string   `yaml:"secret_access_key"`
+	DefaultKeyName        string   `yaml:"default_key_name"`
+	DefaultSecurityGroups []string `yaml:"default_security_groups"`
+	Region                string
+}
+
 var marshal func(interface{}) ([]byte, error) = yaml.Marshal
 
+// CPIName returns the name of the cpi for region in the cpi config that bbl
+// generates for environments with extra regions.
+func CPIName(region string) string {
+	return "aws-" + region
+}
+
 func NewOpsGenerator(terraformManager terraformManager, availabilityZones availabilityZones) OpsGenerator {
 	return OpsGenerator{
 		terraformManager:  terraformManager,
@@ -111,6 +138,25 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		)
 	}
 
+	if state.Network.DualStack {
+		requiredOutputs = append(requiredOutputs, "internal_az_subnet_ipv6_cidr_mapping")
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		requiredOutputs = append(requiredOutputs, "kms_key_arn")
+	}
+
+	for _, region := range state.AWS.ExtraRegions {
+		key := storage.ExtraRegionKey(region)
+		requiredOutputs = append(
+			requiredOutputs,
+			key+"_default_key_name",
+			key+"_internal_security_group",
+			key+"_az_subnet_id_mapping",
+			key+"_az_subnet_cidr_mapping",
+		)
+	}
+
 	for _, output := range requiredOutputs {
 		if _, ok := terraformOutputs.Map[output]; !ok {
 			return "", fmt.Errorf("missing %s terraform output", output)
@@ -135,6 +181,34 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		}
 	}
 
+	if state.Network.DualStack {
+		ipv6AZs, err := generateAZs(0, internalAZSubnetIDMap, terraformOutputs.GetStringMap("internal_az_subnet_ipv6_cidr_mapping"))
+		if err != nil {
+			return "", err
+		}
+		for _, az := range ipv6AZs {
+			for key, value := range ipv6Vars(az) {
+				varsYAML[key] = value
+			}
+		}
+	}
+
+	for _, region := range state.AWS.ExtraRegions {
+		key := storage.ExtraRegionKey(region)
+		extraAZs, err := generateAZs(0,
+			terraformOutputs.GetStringMap(key+"_az_subnet_id_mapping"),
+			terraformOutputs.GetStringMap(key+"_az_subnet_cidr_mapping"),
+		)
+		if err != nil {
+			return "", err
+		}
+		for _, az := range extraAZs {
+			for name, value := range az {
+				varsYAML[key+"_"+name] = value
+			}
+		}
+	}
+
 	isoSegAZSubnetIDMap := terraformOutputs.GetStringMap("iso_az_subnet_id_mapping")
 	isoSegAZSubnetCIDRMap := terraformOutputs.GetStringMap("iso_az_subnet_cidr_mapping")
 	if len(isoSegAZSubnetIDMap) > 0 && len(isoSegAZSubnetCIDRMap) > 0 {
@@ -148,6 +222,14 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		}
 	}
 
+	networkRangesVars, err := cloudconfig.NetworkRangesVars(state, o.Generate, varsYAML)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range networkRangesVars {
+		varsYAML[key] = value
+	}
+
 	varsBytes, err := marshal(varsYAML)
 	if err != nil {
 		panic(err) // not tested; cannot occur
@@ -186,6 +268,55 @@ func generateAZs(startingIndex int, idMap, cidrMap map[string]string) ([]map[str
 	return azs, nil
 }
 
+// GenerateCPIConfig returns a cpi config with a cpi for the region of the
+// director and one for each extra region. Environments without extra regions
+// have no cpi config.
+func (o OpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	if len(state.AWS.ExtraRegions) == 0 {
+		return "", nil
+	}
+
+	terraformOutputs, err := o.terraformManager.GetOutputs()
+	if err != nil {
+		return "", fmt.Errorf("Get terraform outputs: %s", err)
+	}
+
+	config := cpiConfig{CPIs: []cpi{
+		awsCPI(state, state.AWS.Region,
+			terraformOutputs.GetString("default_key_name"),
+			terraformOutputs.GetStringSlice("director__default_security_groups"),
+		),
+	}}
+	for _, region := range state.AWS.ExtraRegions {
+		key := storage.ExtraRegionKey(region)
+		config.CPIs = append(config.CPIs, awsCPI(state, region,
+			terraformOutputs.GetString(key+"_default_key_name"),
+			[]string{terraformOutputs.GetString(key + "_internal_security_group")},
+		))
+	}
+
+	configYAML, err := marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return string(configYAML), nil
+}
+
+func awsCPI(state storage.State, region, keyName string, securityGroups []string) cpi {
+	return cpi{
+		Name: CPIName(region),
+		Type: "aws",
+		Properties: cpiProperties{
+			AccessKeyID:           state.AWS.AccessKeyID,
+			SecretAccessKey:       state.AWS.SecretAccessKey,
+			DefaultKeyName:        keyName,
+			DefaultSecurityGroups: securityGroups,
+			Region:                region,
+		},
+	}
+}
+
 func (o OpsGenerator) Generate(state storage.State) (string, error) {
 	ops, err := o.generateOps(state)
 	if err != nil {
@@ -197,10 +328,47 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		return "", err
 	}
 
-	return strings.Join([]string{
+	vmTypesOps, err := cloudconfig.VMTypesOps("aws", state.CloudConfig.VMTypes, vmTypeCloudProperties)
+	if err != nil {
+		return "", err
+	}
+
+	opsYAML := strings.Join([]string{
 		BaseOps,
 		string(cloudConfigOpsYAML),
-	}, "\n"), nil
+		vmTypesOps,
+	}, "\n")
+
+	// encrypted-disk.yml makes kms_key_arn the default key of the director,
+	// the vm types, disk types and ephemeral disks name it in the cloud
+	// config too. The base disk types are encrypted already.
+	if state.Director.DiskEncryptionKey != "" {
+		encryptedOps, err := cloudconfig.DiskEncryptionOps(opsYAML, cloudconfig.DiskEncryptionPaths{
+			VMTypes:        []string{"ephemeral_disk/encrypted?"},
+			EphemeralDisks: []string{"ephemeral_disk/encrypted?"},
+		}, true)
+		if err != nil {
+			return "", err
+		}
+
+		keyOps, err := cloudconfig.DiskEncryptionOps(opsYAML, cloudconfig.DiskEncryptionPaths{
+			VMTypes:        []string{"ephemeral_disk/kms_key_arn?"},
+			DiskTypes:      []string{"kms_key_arn?"},
+			EphemeralDisks: []string{"ephemeral_disk/kms_key_arn?"},
+		}, "((kms_key_arn))")
+		if err != nil {
+			return "", err
+		}
+
+		opsYAML = opsYAML + encryptedOps + keyOps
+	}
+
+	networkRangesOps, err := cloudconfig.NetworkRangesOps(state.CloudConfig.NetworkRanges, opsYAML)
+	if err != nil {
+		return "", err
+	}
+
+	return opsYAML + networkRangesOps, nil
 }
 
 func createOp(opType, opPath string, value interface{}) op {
@@ -220,9 +388,17 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		return []op{}, fmt.Errorf("Retrieve availability zones: %s", err)
 	}
 
+	// With extra regions every az names the cpi of its region, as the
+	// director then uses the cpis of the cpi config.
+	var mainCPI string
+	if len(state.AWS.ExtraRegions) > 0 {
+		mainCPI = CPIName(state.AWS.Region)
+	}
+
 	for i := range azs {
 		azOp := createOp("replace", "/azs/-", az{
 			Name: fmt.Sprintf("z%d", i+1),
+			CPI:  mainCPI,
 			CloudProperties: azCloudProperties{
 				AvailabilityZone: fmt.Sprintf("((az%d_name))", i+1),
 			},
@@ -233,6 +409,33 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		subnets = append(subnets, subnet)
 	}
 
+	for _, region := range state.AWS.ExtraRegions {
+		extraAZs, err := o.availabilityZones.RetrieveAZs(region)
+		if err != nil {
+			return []op{}, fmt.Errorf("Retrieve availability zones of %s: %s", region, err)
+		}
+
+		key := storage.ExtraRegionKey(region)
+		for i := range extraAZs {
+			name := fmt.Sprintf("z%d", len(subnets)+1)
+			vars := fmt.Sprintf("%s_az%d", key, i+1)
+
+			ops = append(ops, createOp("replace", "/azs/-", az{
+				Name: name,
+				CPI:  CPIName(region),
+				CloudProperties: azCloudProperties{
+					AvailabilityZone: fmt.Sprintf("((%s_name))", vars),
+				},
+			}))
+
+			subnets = append(subnets, networkSubnetForAZ(name, vars, fmt.Sprintf("((%s_internal_security_group))", key)))
+		}
+	}
+
+	for i := range subnets {
+		subnets[i].DNS = state.Network.DNSServers
+	}
+
 	ops = append(ops, createOp("replace", "/networks/-", network{
 		Name:    "private",
 		Subnets: subnets,
@@ -245,6 +448,35 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		Type:    "manual",
 	}))
 
+	// Only the vpc of the director is dual stack, so the ipv6 network has
+	// no subnets in extra regions.
+	if state.Network.DualStack {
+		ipv6Subnets := []networkSubnet{}
+		for i := range azs {
+			subnet := networkSubnetForAZ(
+				fmt.Sprintf("z%d", i+1),
+				fmt.Sprintf("az%d_ipv6", i+1),
+				"((internal_security_group))",
+			)
+			subnet.DNS = state.Network.DNSServers
+			ipv6Subnets = append(ipv6Subnets, subnet)
+		}
+
+		ops = append(ops, createOp("replace", "/networks/-", network{
+			Name:    "default-ipv6",
+			Subnets: ipv6Subnets,
+			Type:    "manual",
+		}))
+	}
+
+	if state.CloudConfig.SpotBidPrice != 0 {
+		ops = append(ops, createOp("replace", "/vm_extensions/name=spot/cloud_properties/spot_bid_price", state.CloudConfig.SpotBidPrice))
+	}
+
+	if state.CloudConfig.CompilationVMsPreemptible {
+		ops = append(ops, createOp("replace", "/compilation/vm_extensions/-", "spot"))
+	}
+
 	switch state.LB.Type {
 	case "cf":
 		lbSecurityGroups := []map[string]string{
@@ -307,22 +539,42 @@ func azify(az int, azName, cidr, subnet string) (map[string]string, error) {
 	}, nil
 }
 
+// ipv6Vars renames the vars of an az whose range is an ipv6 cidr block,
+// e.g. az1_range to az1_ipv6_range. The az name is the same for both.
+func ipv6Vars(az map[string]string) map[string]string {
+	vars := map[string]string{}
+	for key, value := range az {
+		if strings.HasSuffix(key, "_name") {
+			continue
+		}
+		parts := strings.SplitN(key, "_", 2)
+		vars[parts[0]+"_ipv6_"+parts[1]] = value
+	}
+	return vars
+}
+
 func generateNetworkSubnet(az int) networkSubnet {
 	az++
+	return networkSubnetForAZ(fmt.Sprintf("z%d", az), fmt.Sprintf("az%d", az), "((internal_security_group))")
+}
+
+// networkSubnetForAZ returns the subnet of the az named name, whose range
+// and subnet come from the vars that start with vars, e.g. az1.
+func networkSubnetForAZ(name, vars, securityGroup string) networkSubnet {
 	return networkSubnet{
-		AZ:      fmt.Sprintf("z%d", az),
-		Gateway: fmt.Sprintf("((az%d_gateway))", az),
-		Range:   fmt.Sprintf("((az%d_range))", az),
+		AZ:      name,
+		Gateway: fmt.Sprintf("((%s_gateway))", vars),
+		Range:   fmt.Sprintf("((%s_range))", vars),
 		Reserved: []string{
-			fmt.Sprintf("((az%d_reserved_1))", az),
-			fmt.Sprintf("((az%d_reserved_2))", az),
+			fmt.Sprintf("((%s_reserved_1))", vars),
+			fmt.Sprintf("((%s_reserved_2))", vars),
 		},
 		Static: []string{
-			fmt.Sprintf("((az%d_static))", az),
+			fmt.Sprintf("((%s_static))", vars),
 		},
 		CloudProperties: networkSubnetCloudProperties{
-			Subnet:         fmt.Sprintf("((az%d_subnet))", az),
-			SecurityGroups: []string{"((internal_security_group))"},
+			Subnet:         fmt.Sprintf("((%s_subnet))", vars),
+			SecurityGroups: []string{securityGroup},
 		},
 	}
 }
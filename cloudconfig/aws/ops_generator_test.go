@@ -11,6 +11,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
+	yaml "gopkg.in/yaml.v2"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -158,6 +159,157 @@ iso_az_subnet_id_mapping:
 `))
 		})
 
+		Context("when there are extra regions", func() {
+			BeforeEach(func() {
+				incomingState.AWS.ExtraRegions = []string{"us-west-2"}
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_default_key_name"] = "some-key-name"
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_internal_security_group"] = "some-us-west-2-security-group"
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_az_subnet_id_mapping"] = map[string]interface{}{
+					"us-west-2a": "some-us-west-2-subnet-id-1",
+				}
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_az_subnet_cidr_mapping"] = map[string]interface{}{
+					"us-west-2a": "10.1.16.0/20",
+				}
+			})
+
+			It("adds the azs of each extra region with the region as prefix", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var vars map[string]interface{}
+				Expect(yaml.Unmarshal([]byte(varsYAML), &vars)).To(Succeed())
+
+				Expect(vars).To(HaveKeyWithValue("extra_us_west_2_az1_name", "us-west-2a"))
+				Expect(vars).To(HaveKeyWithValue("extra_us_west_2_az1_gateway", "10.1.16.1"))
+				Expect(vars).To(HaveKeyWithValue("extra_us_west_2_az1_range", "10.1.16.0/20"))
+				Expect(vars).To(HaveKeyWithValue("extra_us_west_2_az1_subnet", "some-us-west-2-subnet-id-1"))
+				Expect(vars).To(HaveKeyWithValue("extra_us_west_2_internal_security_group", "some-us-west-2-security-group"))
+				Expect(vars).To(HaveKeyWithValue("az1_name", "us-east-1a"))
+			})
+
+			It("returns an error when an output of an extra region is missing", func() {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs.Map, "extra_us_west_2_az_subnet_id_mapping")
+
+				_, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).To(MatchError("missing extra_us_west_2_az_subnet_id_mapping terraform output"))
+			})
+		})
+
+		Context("when there are network ranges", func() {
+			BeforeEach(func() {
+				availabilityZones.RetrieveAZsCall.Returns.AZs = []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+				incomingState.CloudConfig.NetworkRanges = []storage.NetworkRanges{
+					{Network: "default", StaticSize: 500},
+					{Network: "private", Reserved: []string{"10.0.32.10-10.0.32.19"}},
+				}
+			})
+
+			It("adds the reserved and static ips of each subnet of the networks", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var vars map[string]interface{}
+				Expect(yaml.Unmarshal([]byte(varsYAML), &vars)).To(Succeed())
+
+				Expect(vars).To(HaveKeyWithValue("default_subnet1_static", []interface{}{"10.0.30.11-10.0.31.254"}))
+				Expect(vars).To(HaveKeyWithValue("default_subnet3_static", []interface{}{"10.0.62.11-10.0.63.254"}))
+				Expect(vars).To(HaveKeyWithValue("private_subnet1_reserved", []interface{}{"10.0.16.2-10.0.16.3", "10.0.31.255"}))
+				Expect(vars).To(HaveKeyWithValue("private_subnet2_reserved", []interface{}{"10.0.32.2-10.0.32.3", "10.0.47.255", "10.0.32.10-10.0.32.19"}))
+				Expect(vars).NotTo(HaveKey("default_subnet1_reserved"))
+				Expect(vars).To(HaveKeyWithValue("az1_static", "10.0.31.190-10.0.31.254"))
+			})
+
+			It("points the subnets of the networks at them", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /networks/name=default/subnets/2/static?
+  value: ((default_subnet3_static))
+- type: replace
+  path: /networks/name=private/subnets/0/reserved?
+  value: ((private_subnet1_reserved))
+`))
+			})
+
+			It("returns an error when the ranges do not fit the subnets", func() {
+				incomingState.CloudConfig.NetworkRanges = []storage.NetworkRanges{{Network: "default", Static: []string{"10.0.16.3"}}}
+
+				_, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).To(MatchError(`Network "default": static ips 10.0.16.3 overlap reserved ips 10.0.16.2-10.0.16.3.`))
+			})
+		})
+
+		Context("when dual stack is on", func() {
+			BeforeEach(func() {
+				incomingState.Network.DualStack = true
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["internal_az_subnet_ipv6_cidr_mapping"] = map[string]interface{}{
+					"us-east-1a": "2600:1f18:4ab:ee01::/64",
+					"us-east-1b": "2600:1f18:4ab:ee02::/64",
+					"us-east-1c": "2600:1f18:4ab:ee03::/64",
+				}
+			})
+
+			It("adds the ipv6 range, gateway, reserved and static ips of each az", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var vars map[string]interface{}
+				Expect(yaml.Unmarshal([]byte(varsYAML), &vars)).To(Succeed())
+
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_range", "2600:1f18:4ab:ee01::/64"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_gateway", "2600:1f18:4ab:ee01::1"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_reserved_1", "2600:1f18:4ab:ee01::2-2600:1f18:4ab:ee01::3"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_reserved_2", "2600:1f18:4ab:ee01:ffff:ffff:ffff:ffff"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_static", "2600:1f18:4ab:ee01:ffff:ffff:ffff:ffbe-2600:1f18:4ab:ee01:ffff:ffff:ffff:fffe"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_subnet", "some-internal-subnet-ids-1"))
+				Expect(vars).To(HaveKeyWithValue("az3_ipv6_range", "2600:1f18:4ab:ee03::/64"))
+				Expect(vars).To(HaveKeyWithValue("az1_range", "10.0.16.0/20"))
+			})
+
+			It("returns an error when the ipv6 cidrs are missing", func() {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs.Map, "internal_az_subnet_ipv6_cidr_mapping")
+
+				_, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).To(MatchError("missing internal_az_subnet_ipv6_cidr_mapping terraform output"))
+			})
+		})
+
+		Context("when a spot bid price is set", func() {
+			BeforeEach(func() {
+				incomingState.CloudConfig.SpotBidPrice = 0.25
+			})
+
+			It("bids that price for spot instances", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /vm_extensions/name=spot/cloud_properties/spot_bid_price
+  value: 0.25
+`))
+			})
+		})
+
+		Context("when a disk encryption key is set", func() {
+			BeforeEach(func() {
+				incomingState.Director.DiskEncryptionKey = "some-key-arn"
+			})
+
+			It("passes the kms key arn", func() {
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["kms_key_arn"] = "some-key-arn"
+
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(varsYAML).To(ContainSubstring("kms_key_arn: some-key-arn\n"))
+			})
+
+			It("returns an error when the kms key arn is missing", func() {
+				_, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).To(MatchError("missing kms_key_arn terraform output"))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when the az subnet id map has a key not in the cidr map", func() {
 				BeforeEach(func() {
@@ -267,6 +419,270 @@ iso_az_subnet_id_mapping:
 			})
 		})
 
+		Context("when there is a vm type catalog", func() {
+			BeforeEach(func() {
+				baseOpsYAMLContents, err := ioutil.ReadFile(filepath.Join("fixtures", "aws-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				expectedOpsYAML = strings.Join([]string{string(baseOpsYAMLContents), `
+- type: replace
+  path: /vm_types/name=router-small?
+  value:
+    name: router-small
+    cloud_properties:
+      instance_type: r4.large
+      ephemeral_disk:
+        size: 20480
+        type: gp2
+- type: replace
+  path: /vm_types/name=small?
+  value:
+    name: small
+    cloud_properties:
+      instance_type: t2.medium
+`}, "\n")
+			})
+
+			It("resolves the vm types to instance types and appends them", func() {
+				incomingState.CloudConfig.VMTypes = []storage.VMType{
+					{Name: "router-small", CPU: 2, RAM: 12288, EphemeralDiskSize: 20480},
+					{Name: "small", CloudProperties: map[string]map[string]interface{}{
+						"aws": {"instance_type": "t2.medium"},
+					}},
+				}
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(MatchYAML(expectedOpsYAML))
+			})
+
+			It("returns an error when a vm type is too big", func() {
+				incomingState.CloudConfig.VMTypes = []storage.VMType{{Name: "huge", CPU: 128, RAM: 1024}}
+
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError(`Vm type "huge": no instance type has 128 CPUs and 1024 MB of RAM`))
+			})
+		})
+
+		Context("when dual stack is on", func() {
+			BeforeEach(func() {
+				incomingState.Network.DualStack = true
+				availabilityZones.RetrieveAZsCall.Returns.AZs = []string{"us-east-1a", "us-east-1b"}
+			})
+
+			It("adds an ipv6 network with a subnet in each az", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ops []struct {
+					Type  string
+					Path  string
+					Value interface{}
+				}
+				Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+				var ipv6Network interface{}
+				for _, op := range ops {
+					if network, ok := op.Value.(map[interface{}]interface{}); ok && op.Path == "/networks/-" && network["name"] == "default-ipv6" {
+						ipv6Network = network
+					}
+				}
+
+				networkYAML, err := yaml.Marshal(ipv6Network)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(networkYAML).To(MatchYAML(`
+name: default-ipv6
+type: manual
+subnets:
+- az: z1
+  gateway: ((az1_ipv6_gateway))
+  range: ((az1_ipv6_range))
+  reserved: [((az1_ipv6_reserved_1)), ((az1_ipv6_reserved_2))]
+  static: [((az1_ipv6_static))]
+  cloud_properties:
+    subnet: ((az1_ipv6_subnet))
+    security_groups: [((internal_security_group))]
+- az: z2
+  gateway: ((az2_ipv6_gateway))
+  range: ((az2_ipv6_range))
+  reserved: [((az2_ipv6_reserved_1)), ((az2_ipv6_reserved_2))]
+  static: [((az2_ipv6_static))]
+  cloud_properties:
+    subnet: ((az2_ipv6_subnet))
+    security_groups: [((internal_security_group))]
+`))
+			})
+		})
+
+		Context("when compilation vms are preemptible", func() {
+			BeforeEach(func() {
+				incomingState.CloudConfig.CompilationVMsPreemptible = true
+			})
+
+			It("adds the spot vm extension to the compilation vms", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /compilation/vm_extensions/-
+  value: spot
+`))
+			})
+		})
+
+		Context("when a disk encryption key is set", func() {
+			BeforeEach(func() {
+				incomingState.Director.DiskEncryptionKey = "some-key-arn"
+			})
+
+			BeforeEach(func() {
+				incomingState.CloudConfig.VMTypes = []storage.VMType{{Name: "worker", CPU: 3, RAM: 8192, EphemeralDiskSize: 20000}}
+			})
+
+			It("encrypts the disks of every vm type, disk type and ephemeral disk vm extension with the kms key", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, path := range []string{
+					"/vm_types/name=default/cloud_properties?/ephemeral_disk/kms_key_arn?",
+					"/vm_types/name=worker/cloud_properties?/ephemeral_disk/kms_key_arn?",
+					"/disk_types/name=1TB/cloud_properties?/kms_key_arn?",
+					"/vm_extensions/name=100GB_ephemeral_disk/cloud_properties?/ephemeral_disk/kms_key_arn?",
+				} {
+					Expect(opsYAML).To(ContainSubstring("path: %s\n  value: ((kms_key_arn))\n", path))
+				}
+				for _, path := range []string{
+					"/vm_types/name=worker/cloud_properties?/ephemeral_disk/encrypted?",
+					"/vm_extensions/name=100GB_ephemeral_disk/cloud_properties?/ephemeral_disk/encrypted?",
+				} {
+					Expect(opsYAML).To(ContainSubstring("path: %s\n  value: true\n", path))
+				}
+			})
+
+			It("leaves the disks without a kms key when no key is set", func() {
+				incomingState.Director.DiskEncryptionKey = ""
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).NotTo(ContainSubstring("kms_key_arn"))
+			})
+		})
+
+		Context("when dns servers are set", func() {
+			BeforeEach(func() {
+				incomingState.Network.DNSServers = []string{"10.0.0.2", "10.0.0.3"}
+				incomingState.Network.DualStack = true
+			})
+
+			It("sets the dns servers of every subnet of every network", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ops []struct {
+					Type  string
+					Path  string
+					Value interface{}
+				}
+				Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+				networks := 0
+				for _, op := range ops {
+					if op.Path != "/networks/-" {
+						continue
+					}
+					networks++
+
+					networkYAML, err := yaml.Marshal(op.Value)
+					Expect(err).NotTo(HaveOccurred())
+
+					var network struct {
+						Name    string
+						Subnets []struct {
+							DNS []string
+						}
+					}
+					Expect(yaml.Unmarshal(networkYAML, &network)).To(Succeed())
+					for _, subnet := range network.Subnets {
+						Expect(subnet.DNS).To(Equal([]string{"10.0.0.2", "10.0.0.3"}), network.Name)
+					}
+				}
+				Expect(networks).To(Equal(3))
+			})
+		})
+
+		Context("when there are extra regions", func() {
+			BeforeEach(func() {
+				incomingState.AWS.ExtraRegions = []string{"us-west-2"}
+				availabilityZones.RetrieveAZsCall.Fake = func(region string) ([]string, error) {
+					if region == "us-west-2" {
+						return []string{"us-west-2a", "us-west-2b"}, nil
+					}
+					return []string{"us-east-1a", "us-east-1b", "us-east-1c"}, nil
+				}
+			})
+
+			It("adds the azs and subnets of each extra region with the cpi of their region", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ops []struct {
+					Type  string
+					Path  string
+					Value interface{}
+				}
+				Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+				azs := []interface{}{}
+				var privateNetwork map[interface{}]interface{}
+				for _, op := range ops {
+					if op.Path == "/azs/-" {
+						azs = append(azs, op.Value)
+					}
+					if network, ok := op.Value.(map[interface{}]interface{}); ok && op.Path == "/networks/-" && network["name"] == "private" {
+						privateNetwork = network
+					}
+				}
+
+				azsYAML, err := yaml.Marshal(azs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(azsYAML).To(MatchYAML(`
+- {name: z1, cpi: aws-us-east-1, cloud_properties: {availability_zone: ((az1_name))}}
+- {name: z2, cpi: aws-us-east-1, cloud_properties: {availability_zone: ((az2_name))}}
+- {name: z3, cpi: aws-us-east-1, cloud_properties: {availability_zone: ((az3_name))}}
+- {name: z4, cpi: aws-us-west-2, cloud_properties: {availability_zone: ((extra_us_west_2_az1_name))}}
+- {name: z5, cpi: aws-us-west-2, cloud_properties: {availability_zone: ((extra_us_west_2_az2_name))}}
+`))
+
+				subnets := privateNetwork["subnets"].([]interface{})
+				Expect(subnets).To(HaveLen(5))
+				subnetYAML, err := yaml.Marshal(subnets[4])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(subnetYAML).To(MatchYAML(`
+az: z5
+gateway: ((extra_us_west_2_az2_gateway))
+range: ((extra_us_west_2_az2_range))
+reserved: [((extra_us_west_2_az2_reserved_1)), ((extra_us_west_2_az2_reserved_2))]
+static: [((extra_us_west_2_az2_static))]
+cloud_properties:
+  subnet: ((extra_us_west_2_az2_subnet))
+  security_groups: [((extra_us_west_2_internal_security_group))]
+`))
+			})
+
+			It("returns an error when the azs of an extra region cannot be retrieved", func() {
+				availabilityZones.RetrieveAZsCall.Fake = func(region string) ([]string, error) {
+					if region == "us-west-2" {
+						return nil, errors.New("kumquat")
+					}
+					return []string{"us-east-1a"}, nil
+				}
+
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError("Retrieve availability zones of us-west-2: kumquat"))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when ops fails to marshal", func() {
 				It("returns an error", func() {
@@ -280,4 +696,54 @@ iso_az_subnet_id_mapping:
 			})
 		})
 	})
+
+	Describe("GenerateCPIConfig", func() {
+		It("returns nothing without extra regions", func() {
+			cpiConfig, err := opsGenerator.GenerateCPIConfig(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cpiConfig).To(BeEmpty())
+			Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(0))
+		})
+
+		It("returns a cpi for the director's region and each extra region", func() {
+			incomingState.AWS.AccessKeyID = "some-access-key-id"
+			incomingState.AWS.SecretAccessKey = "some-secret-access-key"
+			incomingState.AWS.ExtraRegions = []string{"us-west-2"}
+			terraformManager.GetOutputsCall.Returns.Outputs.Map["default_key_name"] = "some-key-name"
+			terraformManager.GetOutputsCall.Returns.Outputs.Map["director__default_security_groups"] = []interface{}{"some-bosh-security-group"}
+			terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_default_key_name"] = "some-key-name"
+			terraformManager.GetOutputsCall.Returns.Outputs.Map["extra_us_west_2_internal_security_group"] = "some-us-west-2-security-group"
+
+			cpiConfig, err := opsGenerator.GenerateCPIConfig(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cpiConfig).To(MatchYAML(`
+cpis:
+- name: aws-us-east-1
+  type: aws
+  properties:
+    access_key_id: some-access-key-id
+    secret_access_key: some-secret-access-key
+    default_key_name: some-key-name
+    default_security_groups: [some-bosh-security-group]
+    region: us-east-1
+- name: aws-us-west-2
+  type: aws
+  properties:
+    access_key_id: some-access-key-id
+    secret_access_key: some-secret-access-key
+    default_key_name: some-key-name
+    default_security_groups: [some-us-west-2-security-group]
+    region: us-west-2
+`))
+		})
+
+		It("returns an error when terraform fails to get outputs", func() {
+			incomingState.AWS.ExtraRegions = []string{"us-west-2"}
+			terraformManager.GetOutputsCall.Returns.Error = errors.New("starfruit")
+
+			_, err := opsGenerator.GenerateCPIConfig(incomingState)
+			Expect(err).To(MatchError("Get terraform outputs: starfruit"))
+		})
+	})
 })
@@ -0,0 +1,38 @@
+package aws
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// instanceTypes are the general purpose and memory optimized instance types
+// that catalog vm types resolve to, from smallest to largest.
+var instanceTypes = []cloudconfig.InstanceType{
+	{Name: "t2.small", CPU: 1, RAM: 2048},
+	{Name: "t2.medium", CPU: 2, RAM: 4096},
+	{Name: "m4.large", CPU: 2, RAM: 8192},
+	{Name: "r4.large", CPU: 2, RAM: 15616},
+	{Name: "m4.xlarge", CPU: 4, RAM: 16384},
+	{Name: "r4.xlarge", CPU: 4, RAM: 31232},
+	{Name: "m4.2xlarge", CPU: 8, RAM: 32768},
+	{Name: "r4.2xlarge", CPU: 8, RAM: 62464},
+	{Name: "m4.4xlarge", CPU: 16, RAM: 65536},
+	{Name: "r4.4xlarge", CPU: 16, RAM: 124928},
+	{Name: "m4.10xlarge", CPU: 40, RAM: 163840},
+	{Name: "m4.16xlarge", CPU: 64, RAM: 262144},
+}
+
+func vmTypeCloudProperties(vmType storage.VMType) (map[string]interface{}, error) {
+	instanceType, err := cloudconfig.NearestInstanceType(instanceTypes, vmType.CPU, vmType.RAM)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"instance_type": instanceType,
+		"ephemeral_disk": map[string]interface{}{
+			"size": cloudconfig.EphemeralDiskSize(vmType),
+			"type": "gp2",
+		},
+	}, nil
+}
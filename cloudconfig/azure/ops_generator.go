@@ -6,6 +6,7 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
@@ -33,6 +34,11 @@ type cloudProperties struct {
 	ApplicationGateway string `yaml:"application_gateway,omitempty"`
 	SecurityGroup      string `yaml:"security_group,omitempty"`
 	LoadBalancer       string `yaml:"load_balancer,omitempty"`
+	AvailabilitySet    string `yaml:"availability_set,omitempty"`
+}
+
+type azCloudProperties struct {
+	AvailabilityZone string `yaml:"availability_zone"`
 }
 
 type network struct {
@@ -75,6 +81,14 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		varsYAML[k] = v
 	}
 
+	networkRangesVars, err := cloudconfig.NetworkRangesVars(state, o.Generate, varsYAML)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range networkRangesVars {
+		varsYAML[key] = value
+	}
+
 	varsBytes, err := marshal(varsYAML)
 	if err != nil {
 		return "", err
@@ -97,6 +111,10 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		},
 	}
 
+	if len(state.Network.DNSServers) > 0 {
+		subnet.DNS = state.Network.DNSServers
+	}
+
 	cloudConfigOps := []op{
 		{
 			Type: "replace",
@@ -118,6 +136,26 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		},
 	}
 
+	// In regions with availability zones each az is pinned to a zone. In
+	// other regions the load balancer vm extensions name the availability
+	// set of their instance group, as the vms behind a basic load balancer
+	// must share one.
+	zones := state.Azure.AvailabilityZones()
+	for i, zone := range zones {
+		cloudConfigOps = append(cloudConfigOps, op{
+			Type:  "replace",
+			Path:  fmt.Sprintf("/azs/name=z%d/cloud_properties?", i+1),
+			Value: azCloudProperties{AvailabilityZone: zone},
+		})
+	}
+
+	availabilitySet := func(group string) string {
+		if len(zones) > 0 {
+			return ""
+		}
+		return fmt.Sprintf("%s-%s", state.EnvID, group)
+	}
+
 	switch state.LB.Type {
 	case "cf":
 		lbOps := []op{
@@ -129,18 +167,25 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 					CloudProperties: cloudProperties{
 						ApplicationGateway: "((cf_app_gateway_name))",
 						SecurityGroup:      "((cf_security_group))",
+						AvailabilitySet:    availabilitySet("cf-router"),
 					},
 				},
 			},
 			{
-				Type:  "replace",
-				Path:  "/vm_extensions/-",
-				Value: lb{Name: "cf-tcp-router-network-properties"},
+				Type: "replace",
+				Path: "/vm_extensions/-",
+				Value: lb{
+					Name:            "cf-tcp-router-network-properties",
+					CloudProperties: cloudProperties{AvailabilitySet: availabilitySet("cf-tcp-router")},
+				},
 			},
 			{
-				Type:  "replace",
-				Path:  "/vm_extensions/-",
-				Value: lb{Name: "diego-ssh-proxy-network-properties"},
+				Type: "replace",
+				Path: "/vm_extensions/-",
+				Value: lb{
+					Name:            "diego-ssh-proxy-network-properties",
+					CloudProperties: cloudProperties{AvailabilitySet: availabilitySet("diego-ssh-proxy")},
+				},
 			}}
 		cloudConfigOps = append(cloudConfigOps, lbOps...)
 	case "concourse":
@@ -150,7 +195,8 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 			Value: lb{
 				Name: "lb",
 				CloudProperties: cloudProperties{
-					LoadBalancer: "((concourse_lb_name))",
+					LoadBalancer:    "((concourse_lb_name))",
+					AvailabilitySet: availabilitySet("concourse-web"),
 				},
 			},
 		}
@@ -162,8 +208,36 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		return "", err
 	}
 
-	return strings.Join([]string{
+	vmTypesOps, err := cloudconfig.VMTypesOps("azure", state.CloudConfig.VMTypes, vmTypeCloudProperties)
+	if err != nil {
+		return "", err
+	}
+
+	opsYAML := strings.Join([]string{
 		BaseOps,
 		string(cloudConfigOpsYAML),
-	}, "\n"), nil
+		vmTypesOps,
+	}, "\n")
+
+	if state.Director.DiskEncryptionKey != "" {
+		diskEncryptionOps, err := cloudconfig.DiskEncryptionOps(opsYAML, cloudconfig.DiskEncryptionPaths{
+			VMTypes: []string{
+				"root_disk?/disk_encryption_set_name?",
+				"ephemeral_disk?/disk_encryption_set_name?",
+			},
+			DiskTypes:      []string{"disk_encryption_set_name?"},
+			EphemeralDisks: []string{"ephemeral_disk?/disk_encryption_set_name?"},
+		}, "((disk_encryption_set_name))")
+		if err != nil {
+			return "", err
+		}
+		opsYAML = opsYAML + diskEncryptionOps
+	}
+
+	networkRangesOps, err := cloudconfig.NetworkRangesOps(state.CloudConfig.NetworkRanges, opsYAML)
+	if err != nil {
+		return "", err
+	}
+
+	return opsYAML + networkRangesOps, nil
 }
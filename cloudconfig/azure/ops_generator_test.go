@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"path/filepath"
 
+	yaml "gopkg.in/yaml.v2"
+
 	"github.com/cloudfoundry/bosh-bootloader/cloudconfig/azure"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -132,6 +134,169 @@ some-key: some-value
 			Expect(opsYAML).To(MatchYAML(expectedOpsFile))
 		})
 
+		It("replaces the default dns servers when dns servers are set", func() {
+			incomingState.Network.DNSServers = []string{"10.0.0.2", "10.0.0.3"}
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			var ops []struct {
+				Type  string
+				Path  string
+				Value interface{}
+			}
+			Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+			networks := 0
+			for _, op := range ops {
+				if op.Path != "/networks/-" {
+					continue
+				}
+				networks++
+
+				networkYAML, err := yaml.Marshal(op.Value)
+				Expect(err).NotTo(HaveOccurred())
+
+				var network struct {
+					Name    string
+					Subnets []struct {
+						DNS []string
+					}
+				}
+				Expect(yaml.Unmarshal(networkYAML, &network)).To(Succeed())
+				for _, subnet := range network.Subnets {
+					Expect(subnet.DNS).To(Equal([]string{"10.0.0.2", "10.0.0.3"}), network.Name)
+				}
+			}
+			Expect(networks).To(Equal(2))
+		})
+
+		It("pins each az to an availability zone when the region has them", func() {
+			incomingState.Azure.Region = "westus2"
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /azs/name=z1/cloud_properties?
+  value:
+    availability_zone: "1"
+- type: replace
+  path: /azs/name=z2/cloud_properties?
+  value:
+    availability_zone: "2"
+- type: replace
+  path: /azs/name=z3/cloud_properties?
+  value:
+    availability_zone: "3"
+`))
+		})
+
+		It("leaves the azs without cloud properties when the region has no availability zones", func() {
+			incomingState.Azure.Region = "westus"
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).NotTo(ContainSubstring("availability_zone"))
+		})
+
+		Context("with a cf load balancer", func() {
+			BeforeEach(func() {
+				incomingState.EnvID = "some-env-id"
+				incomingState.LB.Type = "cf"
+			})
+
+			It("puts each load balanced instance group in an availability set when the region has no availability zones", func() {
+				incomingState.Azure.Region = "westus"
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /vm_extensions/-
+  value:
+    name: cf-router-network-properties
+    cloud_properties:
+      application_gateway: ((cf_app_gateway_name))
+      security_group: ((cf_security_group))
+      availability_set: some-env-id-cf-router
+- type: replace
+  path: /vm_extensions/-
+  value:
+    name: cf-tcp-router-network-properties
+    cloud_properties:
+      availability_set: some-env-id-cf-tcp-router
+- type: replace
+  path: /vm_extensions/-
+  value:
+    name: diego-ssh-proxy-network-properties
+    cloud_properties:
+      availability_set: some-env-id-diego-ssh-proxy
+`))
+			})
+
+			It("leaves the availability sets out when the region has availability zones", func() {
+				incomingState.Azure.Region = "westus2"
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).NotTo(ContainSubstring("availability_set"))
+			})
+		})
+
+		Context("with a concourse load balancer", func() {
+			BeforeEach(func() {
+				incomingState.EnvID = "some-env-id"
+				incomingState.LB.Type = "concourse"
+				incomingState.Azure.Region = "westus"
+			})
+
+			It("puts the web instance group in an availability set when the region has no availability zones", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /vm_extensions/-
+  value:
+    name: lb
+    cloud_properties:
+      load_balancer: ((concourse_lb_name))
+      availability_set: some-env-id-concourse-web
+`))
+			})
+		})
+
+		Context("when a disk encryption key is set", func() {
+			BeforeEach(func() {
+				incomingState.Director.DiskEncryptionKey = "https://some-vault.vault.azure.net/keys/some-key/0123456789abcdef"
+			})
+
+			It("encrypts the disks of every vm type, disk type and ephemeral disk vm extension with the disk encryption set", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, path := range []string{
+					"/vm_types/name=default/cloud_properties?/root_disk?/disk_encryption_set_name?",
+					"/vm_types/name=minimal/cloud_properties?/ephemeral_disk?/disk_encryption_set_name?",
+					"/disk_types/name=1TB/cloud_properties?/disk_encryption_set_name?",
+					"/vm_extensions/name=100GB_ephemeral_disk/cloud_properties?/ephemeral_disk?/disk_encryption_set_name?",
+				} {
+					Expect(opsYAML).To(ContainSubstring("path: %s\n  value: ((disk_encryption_set_name))\n", path))
+				}
+			})
+
+			It("leaves the disks unencrypted when no key is set", func() {
+				incomingState.Director.DiskEncryptionKey = ""
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).NotTo(ContainSubstring("disk_encryption_set_name"))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when ops fail to marshal", func() {
 				BeforeEach(func() {
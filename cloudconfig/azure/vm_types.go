@@ -0,0 +1,36 @@
+package azure
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// instanceTypes are the general purpose and memory optimized instance types
+// that catalog vm types resolve to, from smallest to largest.
+var instanceTypes = []cloudconfig.InstanceType{
+	{Name: "Standard_F1", CPU: 1, RAM: 2048},
+	{Name: "Standard_D1_v2", CPU: 1, RAM: 3584},
+	{Name: "Standard_F2", CPU: 2, RAM: 4096},
+	{Name: "Standard_D2_v2", CPU: 2, RAM: 7168},
+	{Name: "Standard_D11_v2", CPU: 2, RAM: 14336},
+	{Name: "Standard_D3_v2", CPU: 4, RAM: 14336},
+	{Name: "Standard_D12_v2", CPU: 4, RAM: 28672},
+	{Name: "Standard_D4_v2", CPU: 8, RAM: 28672},
+	{Name: "Standard_D13_v2", CPU: 8, RAM: 57344},
+	{Name: "Standard_D5_v2", CPU: 16, RAM: 57344},
+	{Name: "Standard_D14_v2", CPU: 16, RAM: 114688},
+}
+
+func vmTypeCloudProperties(vmType storage.VMType) (map[string]interface{}, error) {
+	instanceType, err := cloudconfig.NearestInstanceType(instanceTypes, vmType.CPU, vmType.RAM)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"instance_type": instanceType,
+		"ephemeral_disk": map[string]interface{}{
+			"size": cloudconfig.EphemeralDiskSize(vmType),
+		},
+	}, nil
+}
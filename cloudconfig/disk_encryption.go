@@ -0,0 +1,80 @@
+package cloudconfig
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DiskEncryptionPaths are the cloud properties that name a disk encryption
+// key, relative to the cloud_properties of each kind of cloud config entry.
+type DiskEncryptionPaths struct {
+	VMTypes        []string
+	DiskTypes      []string
+	EphemeralDisks []string
+}
+
+type diskEncryptionOp struct {
+	Type  string
+	Path  string
+	Value interface{}
+}
+
+type cloudConfigNames struct {
+	VMTypes []struct {
+		Name string
+	} `yaml:"vm_types"`
+	DiskTypes []struct {
+		Name string
+	} `yaml:"disk_types"`
+	VMExtensions []struct {
+		Name string
+	} `yaml:"vm_extensions"`
+}
+
+// DiskEncryptionOps sets value, usually a key, on every vm type, disk type
+// and ephemeral disk vm extension of the cloud config that ops produce. The
+// ops have to follow ops, which may replace whole vm types.
+func DiskEncryptionOps(ops string, paths DiskEncryptionPaths, value interface{}) (string, error) {
+	contents, err := interpolate(ops, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var names cloudConfigNames
+	err = yaml.Unmarshal(contents, &names)
+	if err != nil {
+		return "", fmt.Errorf("Parse cloud config names: %s", err)
+	}
+
+	encryptionOps := []diskEncryptionOp{}
+	addOps := func(section, name string, properties []string) {
+		for _, property := range properties {
+			encryptionOps = append(encryptionOps, diskEncryptionOp{
+				Type:  "replace",
+				Path:  fmt.Sprintf("/%s/name=%s/cloud_properties?/%s", section, name, property),
+				Value: value,
+			})
+		}
+	}
+
+	for _, vmType := range names.VMTypes {
+		addOps("vm_types", vmType.Name, paths.VMTypes)
+	}
+	for _, diskType := range names.DiskTypes {
+		addOps("disk_types", diskType.Name, paths.DiskTypes)
+	}
+	for _, vmExtension := range names.VMExtensions {
+		if strings.HasSuffix(vmExtension.Name, "_ephemeral_disk") {
+			addOps("vm_extensions", vmExtension.Name, paths.EphemeralDisks)
+		}
+	}
+
+	opsYAML, err := yaml.Marshal(encryptionOps)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(opsYAML), nil
+}
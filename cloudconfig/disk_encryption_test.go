@@ -0,0 +1,85 @@
+package cloudconfig_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("DiskEncryptionOps", func() {
+	var paths cloudconfig.DiskEncryptionPaths
+
+	BeforeEach(func() {
+		paths = cloudconfig.DiskEncryptionPaths{
+			VMTypes:        []string{"root_disk?/key?", "ephemeral_disk?/key?"},
+			DiskTypes:      []string{"key?"},
+			EphemeralDisks: []string{"ephemeral_disk?/key?"},
+		}
+	})
+
+	opPaths := func(opsYAML string) []string {
+		var ops []struct {
+			Type  string
+			Path  string
+			Value string
+		}
+		Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+		paths := []string{}
+		for _, op := range ops {
+			Expect(op.Type).To(Equal("replace"))
+			Expect(op.Value).To(Equal("((some-key))"))
+			paths = append(paths, op.Path)
+		}
+		return paths
+	}
+
+	It("sets the key on every vm type, disk type and ephemeral disk vm extension", func() {
+		opsYAML, err := cloudconfig.DiskEncryptionOps(`
+- type: replace
+  path: /vm_types/-
+  value:
+    name: router-small
+- type: replace
+  path: /vm_extensions/-
+  value:
+    name: lb
+`, paths, "((some-key))")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(opPaths(opsYAML)).To(SatisfyAll(
+			ContainElement("/vm_types/name=default/cloud_properties?/root_disk?/key?"),
+			ContainElement("/vm_types/name=extra-large/cloud_properties?/ephemeral_disk?/key?"),
+			ContainElement("/vm_types/name=router-small/cloud_properties?/root_disk?/key?"),
+			ContainElement("/vm_types/name=router-small/cloud_properties?/ephemeral_disk?/key?"),
+			ContainElement("/disk_types/name=default/cloud_properties?/key?"),
+			ContainElement("/disk_types/name=1TB/cloud_properties?/key?"),
+			ContainElement("/vm_extensions/name=1GB_ephemeral_disk/cloud_properties?/ephemeral_disk?/key?"),
+			ContainElement("/vm_extensions/name=1TB_ephemeral_disk/cloud_properties?/ephemeral_disk?/key?"),
+			Not(ContainElement(ContainSubstring("name=lb"))),
+		))
+		Expect(opPaths(opsYAML)).To(HaveLen(9*2 + 8 + 7))
+	})
+
+	It("sets values that are not strings", func() {
+		opsYAML, err := cloudconfig.DiskEncryptionOps("", cloudconfig.DiskEncryptionPaths{
+			DiskTypes: []string{"encrypted?"},
+		}, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /disk_types/name=default/cloud_properties?/encrypted?
+  value: true
+`))
+	})
+
+	It("returns an error when the ops cannot be applied", func() {
+		_, err := cloudconfig.DiskEncryptionOps(`
+- type: remove
+  path: /missing
+`, paths, "((some-key))")
+		Expect(err).To(MatchError(ContainSubstring("Apply cloud config ops: ")))
+	})
+})
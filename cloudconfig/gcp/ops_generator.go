@@ -7,6 +7,7 @@ import (
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
@@ -46,6 +47,7 @@ type networkSubnet struct {
 	Range           string
 	Reserved        []string
 	Static          []string
+	DNS             []string              `yaml:"dns,omitempty"`
 	CloudProperties subnetCloudProperties `yaml:"cloud_properties"`
 }
 
@@ -81,17 +83,9 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		return "", fmt.Errorf("Get terraform outputs: %s", err)
 	}
 
-	subnetCidrVal, ok := terraformOutputs.Map["internal_cidr"]
-	if !ok {
-		return "", fmt.Errorf("internal_cidr was not in terraform outputs")
-	}
-	subnetCidr, ok := subnetCidrVal.(string)
-	if !ok {
-		return "", fmt.Errorf("internal_cidr requires a string value")
-	}
-	parsedCidr, err := bosh.ParseCIDRBlock(subnetCidr)
+	parsedCidr, err := cidrOutput(terraformOutputs, "internal_cidr")
 	if err != nil {
-		return "", fmt.Errorf("internal_cidr is not a valid cidr")
+		return "", err
 	}
 
 	varsYAML := map[string]interface{}{}
@@ -108,6 +102,29 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	varsYAML["subnetwork_reserved_ips"] = fmt.Sprintf("%s-%s", firstReserved, lastReserved)
 	varsYAML["subnetwork_static_ips"] = fmt.Sprintf("%s-%s", firstStatic, lastStatic)
 
+	if state.Network.DualStack {
+		parsedIPv6Cidr, err := cidrOutput(terraformOutputs, "internal_ipv6_cidr")
+		if err != nil {
+			return "", err
+		}
+
+		varsYAML["internal_ipv6_gw"] = parsedIPv6Cidr.GetNthIP(1).String()
+		varsYAML["subnetwork_ipv6_reserved_ips"] = fmt.Sprintf("%s-%s",
+			parsedIPv6Cidr.GetNthIP(1).String(),
+			parsedIPv6Cidr.GetNthIP(255).String())
+		varsYAML["subnetwork_ipv6_static_ips"] = fmt.Sprintf("%s-%s",
+			parsedIPv6Cidr.GetLastIP().Subtract(255).String(),
+			parsedIPv6Cidr.GetLastIP().Subtract(1).String())
+	}
+
+	networkRangesVars, err := cloudconfig.NetworkRangesVars(state, o.Generate, varsYAML)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range networkRangesVars {
+		varsYAML[key] = value
+	}
+
 	varsBytes, err := marshal(varsYAML)
 	if err != nil {
 		return "", err
@@ -116,6 +133,23 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	return string(varsBytes), nil
 }
 
+func cidrOutput(terraformOutputs terraform.Outputs, name string) (bosh.CIDRBlock, error) {
+	cidrVal, ok := terraformOutputs.Map[name]
+	if !ok {
+		return bosh.CIDRBlock{}, fmt.Errorf("%s was not in terraform outputs", name)
+	}
+	cidr, ok := cidrVal.(string)
+	if !ok {
+		return bosh.CIDRBlock{}, fmt.Errorf("%s requires a string value", name)
+	}
+	parsedCidr, err := bosh.ParseCIDRBlock(cidr)
+	if err != nil {
+		return bosh.CIDRBlock{}, fmt.Errorf("%s is not a valid cidr", name)
+	}
+
+	return parsedCidr, nil
+}
+
 func (o OpsGenerator) Generate(state storage.State) (string, error) {
 	ops, err := o.generateGCPOps(state)
 	if err != nil {
@@ -127,10 +161,35 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		return "", err
 	}
 
-	return strings.Join([]string{
+	vmTypesOps, err := cloudconfig.VMTypesOps("gcp", state.CloudConfig.VMTypes, vmTypeCloudProperties)
+	if err != nil {
+		return "", err
+	}
+
+	opsYAML := strings.Join([]string{
 		BaseOps,
 		string(cloudConfigOpsYAML),
-	}, "\n"), nil
+		vmTypesOps,
+	}, "\n")
+
+	if state.Director.DiskEncryptionKey != "" {
+		diskEncryptionOps, err := cloudconfig.DiskEncryptionOps(opsYAML, cloudconfig.DiskEncryptionPaths{
+			VMTypes:        []string{"kms_key_name?"},
+			DiskTypes:      []string{"kms_key_name?"},
+			EphemeralDisks: []string{"kms_key_name?"},
+		}, "((disk_encryption_key))")
+		if err != nil {
+			return "", err
+		}
+		opsYAML = opsYAML + diskEncryptionOps
+	}
+
+	networkRangesOps, err := cloudconfig.NetworkRangesOps(state.CloudConfig.NetworkRanges, opsYAML)
+	if err != nil {
+		return "", err
+	}
+
+	return opsYAML + networkRangesOps, nil
 }
 
 func createOp(opType, opPath string, value interface{}) op {
@@ -167,6 +226,7 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		Static: []string{
 			"((subnetwork_static_ips))",
 		},
+		DNS: state.Network.DNSServers,
 		CloudProperties: subnetCloudProperties{
 			EphemeralExternalIP: true,
 			NetworkName:         "((network))",
@@ -187,6 +247,24 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		Type:    "manual",
 	}))
 
+	if state.Network.DualStack {
+		ipv6Subnet := subnets[0]
+		ipv6Subnet.Gateway = "((internal_ipv6_gw))"
+		ipv6Subnet.Range = "((internal_ipv6_cidr))"
+		ipv6Subnet.Reserved = []string{"((subnetwork_ipv6_reserved_ips))"}
+		ipv6Subnet.Static = []string{"((subnetwork_ipv6_static_ips))"}
+
+		ops = append(ops, createOp("replace", "/networks/-", network{
+			Name:    "default-ipv6",
+			Subnets: []networkSubnet{ipv6Subnet},
+			Type:    "manual",
+		}))
+	}
+
+	if state.CloudConfig.CompilationVMsPreemptible {
+		ops = append(ops, createOp("replace", "/compilation/vm_extensions/-", "preemptible"))
+	}
+
 	if state.LB.Type == "concourse" {
 		ops = append(ops, createOp("replace", "/vm_extensions/-", lb{
 			Name: "lb",
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	yaml "gopkg.in/yaml.v2"
+
 	"github.com/cloudfoundry/bosh-bootloader/cloudconfig/gcp"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -79,6 +81,32 @@ tcp_router_target_pool: some-tcp-router-target-pool
 concourse_target_pool: some-concourse-target-pool
 `))
 		})
+		Context("when dual stack is on", func() {
+			BeforeEach(func() {
+				incomingState.Network.DualStack = true
+				terraformOutputs["internal_ipv6_cidr"] = "2600:1900:4000:9b1a::/64"
+				terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: terraformOutputs}
+				opsGenerator = gcp.NewOpsGenerator(terraformManager)
+			})
+
+			It("adds the ipv6 gateway, reserved and static ips", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(varsYAML).To(ContainSubstring("internal_ipv6_cidr: 2600:1900:4000:9b1a::/64\n"))
+				Expect(varsYAML).To(ContainSubstring("internal_ipv6_gw: 2600:1900:4000:9b1a::1\n"))
+				Expect(varsYAML).To(ContainSubstring("subnetwork_ipv6_reserved_ips: 2600:1900:4000:9b1a::1-2600:1900:4000:9b1a::ff\n"))
+				Expect(varsYAML).To(ContainSubstring("subnetwork_ipv6_static_ips: 2600:1900:4000:9b1a:ffff:ffff:ffff:ff00-2600:1900:4000:9b1a:ffff:ffff:ffff:fffe\n"))
+			})
+
+			It("returns a descriptive error when the ipv6 cidr is missing", func() {
+				delete(terraformOutputs, "internal_ipv6_cidr")
+
+				_, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).To(MatchError("internal_ipv6_cidr was not in terraform outputs"))
+			})
+		})
+
 		Context("when terraform output provider fails to retrieve", func() {
 			BeforeEach(func() {
 				terraformManager.GetOutputsCall.Returns.Error = errors.New("tomato")
@@ -159,6 +187,134 @@ concourse_target_pool: some-concourse-target-pool
 			Entry("concourse load balancer exists", "concourse"),
 		)
 
+		It("adds an ipv6 network when dual stack is on", func() {
+			incomingState.Network.DualStack = true
+			expectedOps := strings.Join([]string{string(expectedOpsFile), `
+- type: replace
+  path: /networks/-
+  value:
+    name: default-ipv6
+    type: manual
+    subnets:
+    - azs: [z1, z2, z3]
+      gateway: ((internal_ipv6_gw))
+      range: ((internal_ipv6_cidr))
+      reserved: [((subnetwork_ipv6_reserved_ips))]
+      static: [((subnetwork_ipv6_static_ips))]
+      cloud_properties:
+        ephemeral_external_ip: true
+        network_name: ((network))
+        subnetwork_name: ((subnetwork))
+        tags: [((internal_tag_name))]
+`}, "\n")
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).To(MatchYAML(expectedOps))
+		})
+
+		It("adds the preemptible vm extension to the compilation vms when they are preemptible", func() {
+			incomingState.CloudConfig.CompilationVMsPreemptible = true
+			expectedOps := strings.Join([]string{string(expectedOpsFile), `
+- type: replace
+  path: /compilation/vm_extensions/-
+  value: preemptible
+`}, "\n")
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).To(MatchYAML(expectedOps))
+		})
+
+		It("sets the dns servers of every network", func() {
+			incomingState.Network.DNSServers = []string{"10.0.0.2", "10.0.0.3"}
+			incomingState.Network.DualStack = true
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			var ops []struct {
+				Type  string
+				Path  string
+				Value interface{}
+			}
+			Expect(yaml.Unmarshal([]byte(opsYAML), &ops)).To(Succeed())
+
+			networks := 0
+			for _, op := range ops {
+				if op.Path != "/networks/-" {
+					continue
+				}
+				networks++
+
+				networkYAML, err := yaml.Marshal(op.Value)
+				Expect(err).NotTo(HaveOccurred())
+
+				var network struct {
+					Name    string
+					Subnets []struct {
+						DNS []string
+					}
+				}
+				Expect(yaml.Unmarshal(networkYAML, &network)).To(Succeed())
+				for _, subnet := range network.Subnets {
+					Expect(subnet.DNS).To(Equal([]string{"10.0.0.2", "10.0.0.3"}), network.Name)
+				}
+			}
+			Expect(networks).To(Equal(3))
+		})
+
+		It("resolves catalog vm types to machine types", func() {
+			incomingState.CloudConfig.VMTypes = []storage.VMType{{Name: "worker", CPU: 3, RAM: 8192, EphemeralDiskSize: 20000}}
+			expectedOps := strings.Join([]string{string(expectedOpsFile), `
+- type: replace
+  path: /vm_types/name=worker?
+  value:
+    name: worker
+    cloud_properties:
+      machine_type: n1-standard-4
+      root_disk_size_gb: 20
+      root_disk_type: pd-ssd
+`}, "\n")
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).To(MatchYAML(expectedOps))
+		})
+
+		Context("when a disk encryption key is set", func() {
+			BeforeEach(func() {
+				incomingState.Director.DiskEncryptionKey = "projects/some-project/locations/us-east1/keyRings/some-ring/cryptoKeys/some-key"
+				incomingState.CloudConfig.VMTypes = []storage.VMType{{Name: "worker", CPU: 3, RAM: 8192, EphemeralDiskSize: 20000}}
+			})
+
+			It("encrypts the disks of every vm type, disk type and ephemeral disk vm extension with the key", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, path := range []string{
+					"/vm_types/name=default/cloud_properties?/kms_key_name?",
+					"/vm_types/name=worker/cloud_properties?/kms_key_name?",
+					"/disk_types/name=1TB/cloud_properties?/kms_key_name?",
+					"/vm_extensions/name=100GB_ephemeral_disk/cloud_properties?/kms_key_name?",
+				} {
+					Expect(opsYAML).To(ContainSubstring("path: %s\n  value: ((disk_encryption_key))\n", path))
+				}
+			})
+
+			It("leaves the disks unencrypted when no key is set", func() {
+				incomingState.Director.DiskEncryptionKey = ""
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).NotTo(ContainSubstring("kms_key_name"))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when ops fail to marshal", func() {
 				BeforeEach(func() {
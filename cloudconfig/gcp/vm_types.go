@@ -0,0 +1,36 @@
+package gcp
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// machineTypes are the standard and highmem machine types that catalog vm
+// types resolve to, from smallest to largest.
+var machineTypes = []cloudconfig.InstanceType{
+	{Name: "g1-small", CPU: 1, RAM: 1740},
+	{Name: "n1-standard-1", CPU: 1, RAM: 3840},
+	{Name: "n1-standard-2", CPU: 2, RAM: 7680},
+	{Name: "n1-highmem-2", CPU: 2, RAM: 13312},
+	{Name: "n1-standard-4", CPU: 4, RAM: 15360},
+	{Name: "n1-highmem-4", CPU: 4, RAM: 26624},
+	{Name: "n1-standard-8", CPU: 8, RAM: 30720},
+	{Name: "n1-highmem-8", CPU: 8, RAM: 53248},
+	{Name: "n1-standard-16", CPU: 16, RAM: 61440},
+	{Name: "n1-highmem-16", CPU: 16, RAM: 106496},
+	{Name: "n1-standard-32", CPU: 32, RAM: 122880},
+	{Name: "n1-standard-64", CPU: 64, RAM: 245760},
+}
+
+func vmTypeCloudProperties(vmType storage.VMType) (map[string]interface{}, error) {
+	machineType, err := cloudconfig.NearestInstanceType(machineTypes, vmType.CPU, vmType.RAM)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"machine_type":      machineType,
+		"root_disk_size_gb": (cloudconfig.EphemeralDiskSize(vmType) + 1023) / 1024,
+		"root_disk_type":    "pd-ssd",
+	}, nil
+}
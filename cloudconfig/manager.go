@@ -1,18 +1,23 @@
 package cloudconfig
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/patch"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type fs interface {
+	fileio.FileReader
 	fileio.FileWriter
 	fileio.DirReader
 	fileio.Stater
@@ -20,7 +25,6 @@ type fs interface {
 
 type Manager struct {
 	logger             logger
-	command            command
 	stateStore         stateStore
 	opsGenerator       OpsGenerator
 	boshClientProvider boshClientProvider
@@ -32,17 +36,20 @@ type logger interface {
 	Step(string, ...interface{})
 }
 
-type command interface {
-	Run(stdout io.Writer, cloudConfigDirectory string, args []string) error
-}
-
 type OpsGenerator interface {
 	Generate(state storage.State) (string, error)
 	GenerateVars(state storage.State) (string, error)
 }
 
+// CPIConfigGenerator is implemented by the ops generators of IaaSes whose
+// director can have more than one cpi. The cpi config it generates may be
+// empty when the director has a single one.
+type CPIConfigGenerator interface {
+	GenerateCPIConfig(state storage.State) (string, error)
+}
+
 type boshClientProvider interface {
-	Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, caCert string) (bosh.ConfigUpdater, error)
+	Client(state storage.State) (bosh.DirectorClient, error)
 }
 
 type terraformManager interface {
@@ -54,11 +61,10 @@ type stateStore interface {
 	GetVarsDir() (string, error)
 }
 
-func NewManager(logger logger, cmd command, stateStore stateStore, opsGenerator OpsGenerator, boshClientProvider boshClientProvider,
+func NewManager(logger logger, stateStore stateStore, opsGenerator OpsGenerator, boshClientProvider boshClientProvider,
 	terraformManager terraformManager, fs fs) Manager {
 	return Manager{
 		logger:             logger,
-		command:            cmd,
 		stateStore:         stateStore,
 		opsGenerator:       opsGenerator,
 		boshClientProvider: boshClientProvider,
@@ -139,6 +145,10 @@ func (m Manager) IsPresentCloudConfigVars() bool {
 	return true
 }
 
+// Interpolate applies bbl's ops and the user ops files to the base cloud
+// config and fills in its variables. User ops files with a numeric prefix,
+// e.g. 10-azs.yml, are applied first in the order of that number, and the
+// others after them in alphabetical order.
 func (m Manager) Interpolate() (string, error) {
 	cloudConfigDir, err := m.stateStore.GetCloudConfigDir()
 	if err != nil {
@@ -150,35 +160,116 @@ func (m Manager) Interpolate() (string, error) {
 		return "", err
 	}
 
-	args := []string{
-		"interpolate", filepath.Join(cloudConfigDir, "cloud-config.yml"),
-		"--vars-file", filepath.Join(varsDir, "cloud-config-vars.yml"),
-		"-o", filepath.Join(cloudConfigDir, "ops.yml"),
+	contents, err := m.fs.ReadFile(filepath.Join(cloudConfigDir, "cloud-config.yml"))
+	if err != nil {
+		return "", fmt.Errorf("Read cloud config: %s", err)
+	}
+
+	var cloudConfig interface{}
+	err = yaml.Unmarshal(contents, &cloudConfig)
+	if err != nil {
+		return "", fmt.Errorf("Parse cloud config: %s", err)
+	}
+
+	opsFiles, err := m.userOpsFiles(cloudConfigDir)
+	if err != nil {
+		return "", err
 	}
 
+	for _, opsFile := range append([]string{filepath.Join(cloudConfigDir, "ops.yml")}, opsFiles...) {
+		contents, err := m.fs.ReadFile(opsFile)
+		if err != nil {
+			return "", fmt.Errorf("Read ops file: %s", err)
+		}
+
+		ops, err := patch.ParseOps(contents)
+		if err != nil {
+			return "", fmt.Errorf("Parse ops file %s: %s", filepath.Base(opsFile), err)
+		}
+
+		cloudConfig, err = patch.Apply(cloudConfig, ops)
+		if err != nil {
+			return "", fmt.Errorf("Apply ops file %s: %s", filepath.Base(opsFile), err)
+		}
+	}
+
+	contents, err = m.fs.ReadFile(filepath.Join(varsDir, "cloud-config-vars.yml"))
+	if err != nil {
+		return "", fmt.Errorf("Read cloud config vars: %s", err)
+	}
+
+	vars := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(contents, &vars)
+	if err != nil {
+		return "", fmt.Errorf("Parse cloud config vars: %s", err)
+	}
+
+	cloudConfig, err = patch.Interpolate(cloudConfig, vars)
+	if err != nil {
+		return "", fmt.Errorf("Interpolate cloud config vars: %s", err)
+	}
+
+	cloudConfigYAML, err := yaml.Marshal(cloudConfig)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(cloudConfigYAML), nil
+}
+
+// userOpsFiles returns the ops files that users added to the cloud config
+// directory, which are applied after bbl's own, in the order Interpolate
+// applies them.
+func (m Manager) userOpsFiles(cloudConfigDir string) ([]string, error) {
 	files, err := m.fs.ReadDir(cloudConfigDir)
 	if err != nil {
-		return "", fmt.Errorf("Read cloud config dir: %s", err)
+		return nil, fmt.Errorf("Read cloud config dir: %s", err)
 	}
 
+	names := []string{}
 	for _, file := range files {
 		name := file.Name()
-		if name != "cloud-config.yml" && name != "ops.yml" {
-			args = append(args, "-o", filepath.Join(cloudConfigDir, name))
+		if !file.IsDir() && name != "cloud-config.yml" && name != "ops.yml" {
+			names = append(names, name)
 		}
 	}
 
-	buf := bytes.NewBuffer([]byte{})
-	err = m.command.Run(buf, cloudConfigDir, args)
-	if err != nil {
-		return "", err
+	sort.Slice(names, func(i, j int) bool {
+		return opsFileLess(names[i], names[j])
+	})
+
+	opsFiles := []string{}
+	for _, name := range names {
+		opsFiles = append(opsFiles, filepath.Join(cloudConfigDir, name))
 	}
 
-	return buf.String(), nil
+	return opsFiles, nil
+}
+
+var numberedOpsFile = regexp.MustCompile(`^(\d+)[-_]`)
+
+func opsFileLess(a, b string) bool {
+	aMatch := numberedOpsFile.FindStringSubmatch(a)
+	bMatch := numberedOpsFile.FindStringSubmatch(b)
+
+	switch {
+	case aMatch != nil && bMatch != nil:
+		aNumber, _ := strconv.Atoi(aMatch[1])
+		bNumber, _ := strconv.Atoi(bMatch[1])
+		if aNumber != bNumber {
+			return aNumber < bNumber
+		}
+	case aMatch != nil:
+		return true
+	case bMatch != nil:
+		return false
+	}
+
+	return a < b
 }
 
 func (m Manager) Update(state storage.State) error {
-	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	boshClient, err := m.boshClientProvider.Client(state)
 	if err != nil {
 		return err // not tested
 	}
@@ -195,6 +286,21 @@ func (m Manager) Update(state storage.State) error {
 		return err
 	}
 
+	// The cpis that the cloud config's azs refer to must exist first.
+	if cpiConfigGenerator, ok := m.opsGenerator.(CPIConfigGenerator); ok {
+		cpiConfig, err := cpiConfigGenerator.GenerateCPIConfig(state)
+		if err != nil {
+			return fmt.Errorf("Generate cpi config: %s", err)
+		}
+		if cpiConfig != "" {
+			m.logger.Step("applying cpi config")
+			err = boshClient.UpdateConfig("cpi", "bbl", []byte(cpiConfig))
+			if err != nil {
+				return fmt.Errorf("Update cpi config: %s", err)
+			}
+		}
+	}
+
 	m.logger.Step("applying cloud config")
 	err = boshClient.UpdateCloudConfig([]byte(cloudConfig))
 	if err != nil {
@@ -3,7 +3,6 @@ package cloudconfig_test
 import (
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -20,7 +19,6 @@ import (
 var _ = Describe("Manager", func() {
 	var (
 		logger             *fakes.Logger
-		cli                *fakes.BOSHCLI
 		stateStore         *fakes.StateStore
 		opsGenerator       *fakes.CloudConfigOpsGenerator
 		boshClientProvider *fakes.BOSHClientProvider
@@ -34,11 +32,11 @@ var _ = Describe("Manager", func() {
 		incomingState  storage.State
 
 		baseCloudConfig []byte
+		files           map[string]string
 	)
 
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
-		cli = &fakes.BOSHCLI{}
 		stateStore = &fakes.StateStore{}
 		opsGenerator = &fakes.CloudConfigOpsGenerator{}
 		boshClient = &fakes.BOSHClient{}
@@ -54,9 +52,27 @@ var _ = Describe("Manager", func() {
 		varsDir = "some-vars-dir"
 		stateStore.GetVarsDirCall.Returns.Directory = varsDir
 
-		cli.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-			stdout.Write([]byte("some-cloud-config"))
-			return nil
+		files = map[string]string{
+			filepath.Join(cloudConfigDir, "cloud-config.yml"): `
+azs:
+- name: z1
+  cloud_properties:
+    zone: ((z1))
+vm_types: []
+`,
+			filepath.Join(cloudConfigDir, "ops.yml"): `
+- type: replace
+  path: /vm_types/-
+  value: {name: default}
+`,
+			filepath.Join(varsDir, "cloud-config-vars.yml"): "z1: us-east1-b\n",
+		}
+		fileIO.ReadFileCall.Fake = func(filename string) ([]byte, error) {
+			contents, ok := files[filename]
+			if !ok {
+				return nil, errors.New("no such file")
+			}
+			return []byte(contents), nil
 		}
 
 		incomingState = storage.State{
@@ -75,7 +91,7 @@ var _ = Describe("Manager", func() {
 		baseCloudConfig, err = ioutil.ReadFile("fixtures/base-cloud-config.yml")
 		Expect(err).NotTo(HaveOccurred())
 
-		manager = cloudconfig.NewManager(logger, cli, stateStore, opsGenerator, boshClientProvider, terraformManager, fileIO)
+		manager = cloudconfig.NewManager(logger, stateStore, opsGenerator, boshClientProvider, terraformManager, fileIO)
 	})
 
 	Describe("Initialize", func() {
@@ -285,35 +301,52 @@ var _ = Describe("Manager", func() {
 	})
 
 	Describe("Interpolate", func() {
-		BeforeEach(func() {
+		It("applies the ops files to the base cloud config and fills in the vars", func() {
+			cloudConfigYAML, err := manager.Interpolate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cloudConfigYAML).To(MatchYAML(`
+azs:
+- name: z1
+  cloud_properties:
+    zone: us-east1-b
+vm_types:
+- name: default
+`))
+		})
+
+		It("applies user ops files with a numeric prefix in that order, then the others alphabetically", func() {
 			fileIO.ReadDirCall.Returns.FileInfos = []os.FileInfo{
-				fakes.FileInfo{
-					FileName: "shenanigans-ops.yml",
-				},
-				fakes.FileInfo{
-					FileName: "ops.yml",
-				},
-				fakes.FileInfo{
-					FileName: "cloud-config.yml",
-				},
+				fakes.FileInfo{FileName: "zebra.yml"},
+				fakes.FileInfo{FileName: "10-second.yml"},
+				fakes.FileInfo{FileName: "ops.yml"},
+				fakes.FileInfo{FileName: "apple.yml"},
+				fakes.FileInfo{FileName: "2_first.yml"},
+				fakes.FileInfo{FileName: "cloud-config.yml"},
+			}
+			for i, name := range []string{"zebra.yml", "10-second.yml", "apple.yml", "2_first.yml"} {
+				files[filepath.Join(cloudConfigDir, name)] = fmt.Sprintf(`
+- type: replace
+  path: /vm_types/-
+  value: {name: %s, cloud_properties: {index: %d}}
+`, name, i)
 			}
-		})
 
-		It("returns a cloud config yaml provided a valid bbl state", func() {
 			cloudConfigYAML, err := manager.Interpolate()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(cli.RunCallCount()).To(Equal(1))
-			_, workingDirectory, args := cli.RunArgsForCall(0)
-			Expect(workingDirectory).To(Equal(cloudConfigDir))
-			Expect(args).To(Equal([]string{
-				"interpolate", fmt.Sprintf("%s%ccloud-config.yml", cloudConfigDir, os.PathSeparator),
-				"--vars-file", fmt.Sprintf("%s%ccloud-config-vars.yml", varsDir, os.PathSeparator),
-				"-o", fmt.Sprintf("%s/ops.yml", cloudConfigDir),
-				"-o", fmt.Sprintf("%s/shenanigans-ops.yml", cloudConfigDir),
-			}))
-
-			Expect(cloudConfigYAML).To(Equal("some-cloud-config"))
+			Expect(cloudConfigYAML).To(MatchYAML(`
+azs:
+- name: z1
+  cloud_properties:
+    zone: us-east1-b
+vm_types:
+- name: default
+- {name: 2_first.yml, cloud_properties: {index: 3}}
+- {name: 10-second.yml, cloud_properties: {index: 1}}
+- {name: apple.yml, cloud_properties: {index: 2}}
+- {name: zebra.yml, cloud_properties: {index: 0}}
+`))
 		})
 
 		Context("failure cases", func() {
@@ -350,14 +383,56 @@ var _ = Describe("Manager", func() {
 				})
 			})
 
-			Context("when command fails to run", func() {
+			Context("when the base cloud config cannot be read", func() {
+				BeforeEach(func() {
+					delete(files, filepath.Join(cloudConfigDir, "cloud-config.yml"))
+				})
+
+				It("returns an error", func() {
+					_, err := manager.Interpolate()
+					Expect(err).To(MatchError("Read cloud config: no such file"))
+				})
+			})
+
+			Context("when an ops file is invalid", func() {
+				BeforeEach(func() {
+					files[filepath.Join(cloudConfigDir, "ops.yml")] = "- type: upsert\n  path: /azs\n"
+				})
+
+				It("returns an error naming the ops file", func() {
+					_, err := manager.Interpolate()
+					Expect(err).To(MatchError(`Parse ops file ops.yml: op 1: unknown type "upsert", use replace, remove or test`))
+				})
+			})
+
+			Context("when an ops file does not apply", func() {
+				BeforeEach(func() {
+					fileIO.ReadDirCall.Returns.FileInfos = []os.FileInfo{fakes.FileInfo{FileName: "my-ops.yml"}}
+					files[filepath.Join(cloudConfigDir, "my-ops.yml")] = `
+- type: replace
+  path: /vm_types/name=default/cloud_properties?/machine_type
+  value: n1-standard-1
+- type: replace
+  path: /networks/name=private/type
+  value: manual
+`
+				})
+
+				It("returns an error naming the ops file, op and path", func() {
+					_, err := manager.Interpolate()
+					Expect(err).To(MatchError("Apply ops file my-ops.yml: op 2 (replace /networks/name=private/type): " +
+						`expected to find map key "networks" at /networks`))
+				})
+			})
+
+			Context("when the vars cannot be read", func() {
 				BeforeEach(func() {
-					cli.RunReturns(errors.New("Interpolate cloud config: failed to run"))
+					delete(files, filepath.Join(varsDir, "cloud-config-vars.yml"))
 				})
 
 				It("returns an error", func() {
 					_, err := manager.Interpolate()
-					Expect(err).To(MatchError("Interpolate cloud config: failed to run"))
+					Expect(err).To(MatchError("Read cloud config vars: no such file"))
 				})
 			})
 		})
@@ -377,22 +452,88 @@ var _ = Describe("Manager", func() {
 			err := manager.Update(incomingState)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
-			Expect(boshClientProvider.ClientCall.Receives.DirectorUsername).To(Equal("some-director-username"))
-			Expect(boshClientProvider.ClientCall.Receives.DirectorPassword).To(Equal("some-director-password"))
+			Expect(boshClientProvider.ClientCall.Receives.State).To(Equal(incomingState))
+
+			Expect(boshClient.UpdateCloudConfigCall.Receives.Yaml).To(MatchYAML(`
+azs:
+- name: z1
+  cloud_properties:
+    zone: us-east1-b
+vm_types:
+- name: default
+`))
+		})
+
+		It("does not apply a cpi config when the ops generator does not generate one", func() {
+			manager = cloudconfig.NewManager(logger, stateStore, struct{ cloudconfig.OpsGenerator }{opsGenerator}, boshClientProvider, terraformManager, fileIO)
+
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsGenerator.GenerateCPIConfigCall.CallCount).To(Equal(0))
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(1))
+		})
+
+		It("does not apply a cpi config when the director has a single cpi", func() {
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsGenerator.GenerateCPIConfigCall.Receives.State).To(Equal(incomingState))
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+		})
+
+		It("applies the cpi config before the cloud config", func() {
+			opsGenerator.GenerateCPIConfigCall.Returns.CPIConfig = "cpis: []\n"
+
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
 
-			Expect(boshClient.UpdateCloudConfigCall.Receives.Yaml).To(Equal([]byte("some-cloud-config")))
+			Expect(boshClient.UpdateConfigCall.Receives).To(Equal([]fakes.BOSHConfigReceive{{
+				Type:    "cpi",
+				Name:    "bbl",
+				Content: []byte("cpis: []\n"),
+			}}))
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"generating cloud config",
+				"applying cpi config",
+				"applying cloud config",
+			}))
 		})
 
 		Context("failure cases", func() {
-			Context("when manager generate's command fails to run", func() {
+			Context("when the cloud config cannot be interpolated", func() {
+				BeforeEach(func() {
+					fileIO.ReadDirCall.Returns.Error = errors.New("aubergine")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError("Read cloud config dir: aubergine"))
+				})
+			})
+
+			Context("when the cpi config cannot be generated", func() {
+				BeforeEach(func() {
+					opsGenerator.GenerateCPIConfigCall.Returns.Error = errors.New("papaya")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError("Generate cpi config: papaya"))
+				})
+			})
+
+			Context("when bosh client fails to update the cpi config", func() {
 				BeforeEach(func() {
-					cli.RunReturns(errors.New("failed to run"))
+					opsGenerator.GenerateCPIConfigCall.Returns.CPIConfig = "cpis: []\n"
+					boshClient.UpdateConfigCall.Returns.Error = errors.New("guava")
 				})
 
 				It("returns an error", func() {
 					err := manager.Update(storage.State{})
-					Expect(err).To(MatchError("failed to run"))
+					Expect(err).To(MatchError("Update cpi config: guava"))
+					Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(0))
 				})
 			})
 
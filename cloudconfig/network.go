@@ -0,0 +1,30 @@
+package cloudconfig
+
+import (
+	yaml "gopkg.in/yaml.v2"
+)
+
+type dnsOp struct {
+	Type  string
+	Path  string
+	Value []string
+}
+
+// DNSOps replaces the dns servers of the default network, for the IaaSes
+// whose base ops have a single network with a single subnet.
+func DNSOps(servers []string) (string, error) {
+	if len(servers) == 0 {
+		return "", nil
+	}
+
+	opsYAML, err := yaml.Marshal([]dnsOp{{
+		Type:  "replace",
+		Path:  "/networks/name=default/subnets/0/dns",
+		Value: servers,
+	}})
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(opsYAML), nil
+}
@@ -0,0 +1,409 @@
+package cloudconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/patch"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type networkRangesFile struct {
+	Networks []struct {
+		Name         string   `yaml:"name"`
+		ReservedSize int      `yaml:"reserved_size"`
+		Reserved     []string `yaml:"reserved"`
+		StaticSize   int      `yaml:"static_size"`
+		Static       []string `yaml:"static"`
+	} `yaml:"networks"`
+}
+
+type networkRangesOp struct {
+	Type  string
+	Path  string
+	Value string
+}
+
+type cloudConfigNetworks struct {
+	Networks []struct {
+		Name    string
+		Subnets []cloudConfigSubnet
+	}
+}
+
+type cloudConfigSubnet struct {
+	Range    string
+	Reserved []string
+	Static   []string
+}
+
+// ipRange is an ip, or the ips from first to last, of a reserved or static
+// range.
+type ipRange struct {
+	first bosh.IP
+	last  bosh.IP
+}
+
+type NetworkRangesReader struct {
+	fs fileio.FileReader
+}
+
+func NewNetworkRangesReader(fs fileio.FileReader) NetworkRangesReader {
+	return NetworkRangesReader{
+		fs: fs,
+	}
+}
+
+// Read parses and validates a network ranges file. The ranges are checked
+// against the subnets of each network when the cloud config vars are
+// generated, as the subnets are only known after terraform applies.
+func (r NetworkRangesReader) Read(path string) ([]storage.NetworkRanges, error) {
+	contents, err := r.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Read network ranges file: %s", err)
+	}
+
+	var file networkRangesFile
+	err = yaml.UnmarshalStrict(contents, &file)
+	if err != nil {
+		return nil, fmt.Errorf("Parse network ranges file: %s", err)
+	}
+
+	if len(file.Networks) == 0 {
+		return nil, fmt.Errorf("Network ranges file %s has no networks.", path)
+	}
+
+	networkRanges := []storage.NetworkRanges{}
+	names := map[string]bool{}
+	for _, n := range file.Networks {
+		if n.Name == "" {
+			return nil, errors.New("Every network in the network ranges file needs a name.")
+		}
+		if names[n.Name] {
+			return nil, fmt.Errorf("Network %q is defined more than once.", n.Name)
+		}
+		names[n.Name] = true
+
+		if n.ReservedSize < 0 || n.StaticSize < 0 {
+			return nil, fmt.Errorf("Network %q needs a positive reserved_size and static_size.", n.Name)
+		}
+		if n.ReservedSize > 0 && len(n.Reserved) > 0 {
+			return nil, fmt.Errorf("Network %q needs either reserved_size or reserved, not both.", n.Name)
+		}
+		if n.StaticSize > 0 && len(n.Static) > 0 {
+			return nil, fmt.Errorf("Network %q needs either static_size or static, not both.", n.Name)
+		}
+		if n.ReservedSize == 0 && len(n.Reserved) == 0 && n.StaticSize == 0 && len(n.Static) == 0 {
+			return nil, fmt.Errorf("Network %q needs reserved or static ips.", n.Name)
+		}
+
+		for _, r := range append(append([]string{}, n.Reserved...), n.Static...) {
+			if _, err := parseIPRange(r, false); err != nil {
+				return nil, fmt.Errorf("Network %q: %s.", n.Name, err)
+			}
+		}
+
+		networkRanges = append(networkRanges, storage.NetworkRanges{
+			Network:      n.Name,
+			ReservedSize: n.ReservedSize,
+			Reserved:     n.Reserved,
+			StaticSize:   n.StaticSize,
+			Static:       n.Static,
+		})
+	}
+
+	return networkRanges, nil
+}
+
+// NetworkRangesOps returns ops that point the reserved and static ips of
+// every subnet of the networks with ranges at the vars of NetworkRangesVars.
+// ops are the other ops of the cloud config, which define the networks.
+func NetworkRangesOps(networkRanges []storage.NetworkRanges, ops string) (string, error) {
+	if len(networkRanges) == 0 {
+		return "", nil
+	}
+
+	networks, err := interpolateNetworks(ops, nil)
+	if err != nil {
+		return "", err
+	}
+
+	rangesOps := []networkRangesOp{}
+	for _, n := range networkRanges {
+		subnets, ok := networks[n.Network]
+		if !ok {
+			return "", fmt.Errorf("Network %q of the network ranges is not in the cloud config.", n.Network)
+		}
+
+		for i := range subnets {
+			if n.ReservedSize > 0 || len(n.Reserved) > 0 {
+				rangesOps = append(rangesOps, networkRangesOp{
+					Type:  "replace",
+					Path:  fmt.Sprintf("/networks/name=%s/subnets/%d/reserved?", n.Network, i),
+					Value: fmt.Sprintf("((%s))", rangesVar(n.Network, i, "reserved")),
+				})
+			}
+			if n.StaticSize > 0 || len(n.Static) > 0 {
+				rangesOps = append(rangesOps, networkRangesOp{
+					Type:  "replace",
+					Path:  fmt.Sprintf("/networks/name=%s/subnets/%d/static?", n.Network, i),
+					Value: fmt.Sprintf("((%s))", rangesVar(n.Network, i, "static")),
+				})
+			}
+		}
+	}
+
+	opsYAML, err := yaml.Marshal(rangesOps)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(opsYAML), nil
+}
+
+// NetworkRangesVars returns the reserved and static ips of every subnet of
+// the networks with ranges. Reserved ips are added to the ones bbl reserves,
+// static ips replace bbl's. generate returns the cloud config ops, which are
+// interpolated with vars to find the subnets.
+func NetworkRangesVars(state storage.State, generate func(storage.State) (string, error), vars map[string]interface{}) (map[string]interface{}, error) {
+	networkRanges := state.CloudConfig.NetworkRanges
+	if len(networkRanges) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	state.CloudConfig.NetworkRanges = nil
+	ops, err := generate(state)
+	if err != nil {
+		return nil, err
+	}
+
+	interpolationVars := map[interface{}]interface{}{}
+	for key, value := range vars {
+		interpolationVars[key] = value
+	}
+
+	networks, err := interpolateNetworks(ops, interpolationVars)
+	if err != nil {
+		return nil, err
+	}
+
+	rangesVars := map[string]interface{}{}
+	for _, n := range networkRanges {
+		subnets, ok := networks[n.Network]
+		if !ok {
+			return nil, fmt.Errorf("Network %q of the network ranges is not in the cloud config.", n.Network)
+		}
+
+		err := addNetworkRangesVars(rangesVars, n, subnets)
+		if err != nil {
+			return nil, fmt.Errorf("Network %q: %s.", n.Network, err)
+		}
+	}
+
+	return rangesVars, nil
+}
+
+func addNetworkRangesVars(rangesVars map[string]interface{}, n storage.NetworkRanges, subnets []cloudConfigSubnet) error {
+	blocks := []bosh.CIDRBlock{}
+	for _, subnet := range subnets {
+		block, err := bosh.ParseCIDRBlock(subnet.Range)
+		if err != nil {
+			return fmt.Errorf("subnet range %q: %s", subnet.Range, err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	reserved, err := placeIPRanges(n.Reserved, blocks)
+	if err != nil {
+		return err
+	}
+	static, err := placeIPRanges(n.Static, blocks)
+	if err != nil {
+		return err
+	}
+
+	for i, block := range blocks {
+		usable := ipRange{first: block.GetNthIP(2), last: block.GetLastIP().Subtract(1)}
+
+		if n.ReservedSize > 0 {
+			r := ipRange{first: block.GetNthIP(2), last: block.GetNthIP(n.ReservedSize + 1)}
+			if !usable.contains(r) {
+				return fmt.Errorf("reserved_size %d does not fit in subnet %s", n.ReservedSize, subnets[i].Range)
+			}
+			reserved[i] = append(reserved[i], r)
+		}
+		if n.StaticSize > 0 {
+			r := ipRange{first: block.GetLastIP().Subtract(n.StaticSize), last: block.GetLastIP().Subtract(1)}
+			if !usable.contains(r) {
+				return fmt.Errorf("static_size %d does not fit in subnet %s", n.StaticSize, subnets[i].Range)
+			}
+			static[i] = append(static[i], r)
+		}
+
+		allReserved := append([]ipRange{}, reserved[i]...)
+		for _, defaultReserved := range subnets[i].Reserved {
+			r, err := parseIPRange(defaultReserved, true)
+			if err != nil {
+				return fmt.Errorf("reserved ips of subnet %s: %s", subnets[i].Range, err)
+			}
+			allReserved = append(allReserved, r)
+		}
+		for _, s := range static[i] {
+			for _, r := range allReserved {
+				if s.overlaps(r) {
+					return fmt.Errorf("static ips %s overlap reserved ips %s", s, r)
+				}
+			}
+		}
+
+		if n.ReservedSize > 0 || len(n.Reserved) > 0 {
+			rangesVars[rangesVar(n.Network, i, "reserved")] = append(append([]string{}, subnets[i].Reserved...), ipRangeStrings(reserved[i])...)
+		}
+		if n.StaticSize > 0 || len(n.Static) > 0 {
+			rangesVars[rangesVar(n.Network, i, "static")] = ipRangeStrings(static[i])
+		}
+	}
+
+	return nil
+}
+
+// placeIPRanges returns the ranges in each of the cidr blocks. Every range
+// must be in one of them.
+func placeIPRanges(ranges []string, blocks []bosh.CIDRBlock) ([][]ipRange, error) {
+	placed := make([][]ipRange, len(blocks))
+	for _, value := range ranges {
+		r, err := parseIPRange(value, false)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for i, block := range blocks {
+			if block.Contains(r.first) && block.Contains(r.last) {
+				placed[i] = append(placed[i], r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not in a subnet of the network", value)
+		}
+	}
+	return placed, nil
+}
+
+// interpolateNetworks applies ops to the base cloud config, interpolates it
+// with vars and returns the subnets of each network.
+func interpolateNetworks(ops string, vars map[interface{}]interface{}) (map[string][]cloudConfigSubnet, error) {
+	contents, err := interpolate(ops, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed cloudConfigNetworks
+	err = yaml.Unmarshal(contents, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("Parse cloud config networks: %s", err)
+	}
+
+	networks := map[string][]cloudConfigSubnet{}
+	for _, network := range parsed.Networks {
+		networks[network.Name] = network.Subnets
+	}
+	return networks, nil
+}
+
+// interpolate applies ops to the base cloud config and interpolates it with
+// vars.
+func interpolate(ops string, vars map[interface{}]interface{}) ([]byte, error) {
+	var cloudConfig interface{}
+	err := yaml.Unmarshal([]byte(BaseCloudConfig), &cloudConfig)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	parsedOps, err := patch.ParseOps([]byte(ops))
+	if err != nil {
+		return nil, fmt.Errorf("Parse cloud config ops: %s", err)
+	}
+
+	cloudConfig, err = patch.Apply(cloudConfig, parsedOps)
+	if err != nil {
+		return nil, fmt.Errorf("Apply cloud config ops: %s", err)
+	}
+
+	cloudConfig, err = patch.Interpolate(cloudConfig, vars)
+	if err != nil {
+		return nil, fmt.Errorf("Interpolate cloud config vars: %s", err)
+	}
+
+	contents, err := yaml.Marshal(cloudConfig)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	return contents, nil
+}
+
+func rangesVar(network string, subnet int, kind string) string {
+	return fmt.Sprintf("%s_subnet%d_%s", network, subnet+1, kind)
+}
+
+// parseIPRange parses an ip or a first-last range of ips. bbl's own reserved
+// ips may also be a cidr block.
+func parseIPRange(value string, allowCIDR bool) (ipRange, error) {
+	if allowCIDR && strings.Contains(value, "/") {
+		block, err := bosh.ParseCIDRBlock(value)
+		if err != nil {
+			return ipRange{}, err
+		}
+		return ipRange{first: block.GetFirstIP(), last: block.GetLastIP()}, nil
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) > 2 {
+		return ipRange{}, fmt.Errorf("%q is not an ip or a range of ips", value)
+	}
+
+	first, err := bosh.ParseIP(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return ipRange{}, fmt.Errorf("%q is not an ip or a range of ips", value)
+	}
+	last := first
+	if len(parts) == 2 {
+		last, err = bosh.ParseIP(strings.TrimSpace(parts[1]))
+		if err != nil || last.IsIPv6() != first.IsIPv6() || last.Compare(first) < 0 {
+			return ipRange{}, fmt.Errorf("%q is not an ip or a range of ips", value)
+		}
+	}
+
+	return ipRange{first: first, last: last}, nil
+}
+
+func (r ipRange) contains(other ipRange) bool {
+	return r.first.Compare(other.first) <= 0 && other.first.Compare(other.last) <= 0 && other.last.Compare(r.last) <= 0
+}
+
+func (r ipRange) overlaps(other ipRange) bool {
+	return r.first.Compare(other.last) <= 0 && other.first.Compare(r.last) <= 0
+}
+
+func (r ipRange) String() string {
+	if r.first.Compare(r.last) == 0 {
+		return r.first.String()
+	}
+	return fmt.Sprintf("%s-%s", r.first, r.last)
+}
+
+func ipRangeStrings(ranges []ipRange) []string {
+	values := []string{}
+	for _, r := range ranges {
+		values = append(values, r.String())
+	}
+	return values
+}
@@ -0,0 +1,251 @@
+package cloudconfig_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkRanges", func() {
+	const ops = `
+- type: replace
+  path: /networks/-
+  value:
+    name: default
+    type: manual
+    subnets:
+    - range: ((az1_range))
+      gateway: ((az1_gateway))
+      reserved: [((az1_reserved))]
+      static: [((az1_static))]
+    - range: ((az2_range))
+      gateway: ((az2_gateway))
+      reserved: [((az2_reserved))]
+      static: [((az2_static))]
+`
+
+	Describe("NetworkRangesReader", func() {
+		var (
+			fileIO *fakes.FileIO
+			reader cloudconfig.NetworkRangesReader
+		)
+
+		BeforeEach(func() {
+			fileIO = &fakes.FileIO{}
+			reader = cloudconfig.NewNetworkRangesReader(fileIO)
+		})
+
+		It("reads the ranges of each network", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte(`---
+networks:
+- name: default
+  reserved_size: 10
+  static_size: 200
+- name: private
+  reserved: [10.0.16.20-10.0.16.29, 10.0.16.40]
+  static: [10.0.33.10 - 10.0.33.20]
+`)
+
+			networkRanges, err := reader.Read("some-ranges.yml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-ranges.yml"))
+			Expect(networkRanges).To(Equal([]storage.NetworkRanges{
+				{Network: "default", ReservedSize: 10, StaticSize: 200},
+				{Network: "private", Reserved: []string{"10.0.16.20-10.0.16.29", "10.0.16.40"}, Static: []string{"10.0.33.10 - 10.0.33.20"}},
+			}))
+		})
+
+		It("returns an error when the file cannot be read", func() {
+			fileIO.ReadFileCall.Returns.Error = errors.New("kiwi")
+
+			_, err := reader.Read("some-ranges.yml")
+			Expect(err).To(MatchError("Read network ranges file: kiwi"))
+		})
+
+		DescribeTable("validates the file",
+			func(file, message string) {
+				fileIO.ReadFileCall.Returns.Contents = []byte(file)
+
+				_, err := reader.Read("some-ranges.yml")
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("invalid yaml", "networks: %%%", "Parse network ranges file: "),
+			Entry("unknown keys", "networks:\n- name: a\n  static_count: 10\n", "Parse network ranges file: "),
+			Entry("no networks", "networks: []\n", "Network ranges file some-ranges.yml has no networks."),
+			Entry("a missing name", "networks:\n- static_size: 10\n", "Every network in the network ranges file needs a name."),
+			Entry("a duplicate name", "networks:\n- {name: a, static_size: 10}\n- {name: a, reserved_size: 10}\n", `Network "a" is defined more than once.`),
+			Entry("a negative size", "networks:\n- {name: a, static_size: -1}\n", `Network "a" needs a positive reserved_size and static_size.`),
+			Entry("both a reserved size and ranges", "networks:\n- {name: a, reserved_size: 1, reserved: [10.0.0.5]}\n", `Network "a" needs either reserved_size or reserved, not both.`),
+			Entry("both a static size and ranges", "networks:\n- {name: a, static_size: 1, static: [10.0.0.5]}\n", `Network "a" needs either static_size or static, not both.`),
+			Entry("no ranges", "networks:\n- {name: a}\n", `Network "a" needs reserved or static ips.`),
+			Entry("an invalid ip", "networks:\n- {name: a, static: [10.0.0.300]}\n", `Network "a": "10.0.0.300" is not an ip or a range of ips.`),
+			Entry("a cidr block", "networks:\n- {name: a, reserved: [10.0.0.0/28]}\n", `Network "a": "10.0.0.0/28" is not an ip or a range of ips.`),
+			Entry("a backwards range", "networks:\n- {name: a, static: [10.0.0.9-10.0.0.5]}\n", `Network "a": "10.0.0.9-10.0.0.5" is not an ip or a range of ips.`),
+		)
+	})
+
+	Describe("NetworkRangesOps", func() {
+		It("points the reserved and static ips of each subnet at vars", func() {
+			rangesOps, err := cloudconfig.NetworkRangesOps([]storage.NetworkRanges{
+				{Network: "default", ReservedSize: 10, StaticSize: 200},
+			}, ops)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rangesOps).To(MatchYAML(`
+- type: replace
+  path: /networks/name=default/subnets/0/reserved?
+  value: ((default_subnet1_reserved))
+- type: replace
+  path: /networks/name=default/subnets/0/static?
+  value: ((default_subnet1_static))
+- type: replace
+  path: /networks/name=default/subnets/1/reserved?
+  value: ((default_subnet2_reserved))
+- type: replace
+  path: /networks/name=default/subnets/1/static?
+  value: ((default_subnet2_static))
+`))
+		})
+
+		It("only replaces the ips that are configured", func() {
+			rangesOps, err := cloudconfig.NetworkRangesOps([]storage.NetworkRanges{
+				{Network: "default", Static: []string{"10.0.33.10-10.0.33.20"}},
+			}, ops)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rangesOps).To(MatchYAML(`
+- type: replace
+  path: /networks/name=default/subnets/0/static?
+  value: ((default_subnet1_static))
+- type: replace
+  path: /networks/name=default/subnets/1/static?
+  value: ((default_subnet2_static))
+`))
+		})
+
+		It("returns nothing without network ranges", func() {
+			rangesOps, err := cloudconfig.NetworkRangesOps(nil, ops)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rangesOps).To(BeEmpty())
+		})
+
+		It("returns an error when the network is not in the cloud config", func() {
+			_, err := cloudconfig.NetworkRangesOps([]storage.NetworkRanges{{Network: "private", StaticSize: 10}}, ops)
+			Expect(err).To(MatchError(`Network "private" of the network ranges is not in the cloud config.`))
+		})
+	})
+
+	Describe("NetworkRangesVars", func() {
+		var (
+			state    storage.State
+			vars     map[string]interface{}
+			generate func(storage.State) (string, error)
+		)
+
+		BeforeEach(func() {
+			state = storage.State{}
+			vars = map[string]interface{}{
+				"az1_range":    "10.0.16.0/20",
+				"az1_gateway":  "10.0.16.1",
+				"az1_reserved": "10.0.16.2-10.0.16.3",
+				"az1_static":   "10.0.31.190-10.0.31.254",
+				"az2_range":    "10.0.32.0/20",
+				"az2_gateway":  "10.0.32.1",
+				"az2_reserved": "10.0.32.2-10.0.32.3",
+				"az2_static":   "10.0.47.190-10.0.47.254",
+			}
+			generate = func(state storage.State) (string, error) {
+				Expect(state.CloudConfig.NetworkRanges).To(BeNil())
+				return ops, nil
+			}
+		})
+
+		It("reserves ips after the gateway and makes ips before the last one static", func() {
+			state.CloudConfig.NetworkRanges = []storage.NetworkRanges{{Network: "default", ReservedSize: 10, StaticSize: 200}}
+
+			rangesVars, err := cloudconfig.NetworkRangesVars(state, generate, vars)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rangesVars).To(Equal(map[string]interface{}{
+				"default_subnet1_reserved": []string{"10.0.16.2-10.0.16.3", "10.0.16.2-10.0.16.11"},
+				"default_subnet1_static":   []string{"10.0.31.55-10.0.31.254"},
+				"default_subnet2_reserved": []string{"10.0.32.2-10.0.32.3", "10.0.32.2-10.0.32.11"},
+				"default_subnet2_static":   []string{"10.0.47.55-10.0.47.254"},
+			}))
+		})
+
+		It("adds explicit ranges to the subnet that contains them", func() {
+			state.CloudConfig.NetworkRanges = []storage.NetworkRanges{{
+				Network:  "default",
+				Reserved: []string{"10.0.16.20-10.0.16.29", "10.0.32.40"},
+				Static:   []string{"10.0.33.10 - 10.0.33.20"},
+			}}
+
+			rangesVars, err := cloudconfig.NetworkRangesVars(state, generate, vars)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rangesVars).To(Equal(map[string]interface{}{
+				"default_subnet1_reserved": []string{"10.0.16.2-10.0.16.3", "10.0.16.20-10.0.16.29"},
+				"default_subnet1_static":   []string{},
+				"default_subnet2_reserved": []string{"10.0.32.2-10.0.32.3", "10.0.32.40"},
+				"default_subnet2_static":   []string{"10.0.33.10-10.0.33.20"},
+			}))
+		})
+
+		It("returns no vars without network ranges", func() {
+			rangesVars, err := cloudconfig.NetworkRangesVars(state, func(storage.State) (string, error) {
+				Fail("generate should not be called")
+				return "", nil
+			}, vars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rangesVars).To(BeEmpty())
+		})
+
+		It("returns an error when generate fails", func() {
+			state.CloudConfig.NetworkRanges = []storage.NetworkRanges{{Network: "default", StaticSize: 10}}
+
+			_, err := cloudconfig.NetworkRangesVars(state, func(storage.State) (string, error) {
+				return "", errors.New("kiwi")
+			}, vars)
+			Expect(err).To(MatchError("kiwi"))
+		})
+
+		DescribeTable("validates the ranges against the subnets",
+			func(networkRanges storage.NetworkRanges, message string) {
+				state.CloudConfig.NetworkRanges = []storage.NetworkRanges{networkRanges}
+
+				_, err := cloudconfig.NetworkRangesVars(state, generate, vars)
+				Expect(err).To(MatchError(message))
+			},
+			Entry("an unknown network", storage.NetworkRanges{Network: "private", StaticSize: 10},
+				`Network "private" of the network ranges is not in the cloud config.`),
+			Entry("a range outside the subnets", storage.NetworkRanges{Network: "default", Static: []string{"10.0.48.10-10.0.48.20"}},
+				`Network "default": 10.0.48.10-10.0.48.20 is not in a subnet of the network.`),
+			Entry("a range across subnets", storage.NetworkRanges{Network: "default", Reserved: []string{"10.0.31.250-10.0.32.5"}},
+				`Network "default": 10.0.31.250-10.0.32.5 is not in a subnet of the network.`),
+			Entry("a static range over reserved ips", storage.NetworkRanges{Network: "default", Static: []string{"10.0.16.3-10.0.16.10"}},
+				`Network "default": static ips 10.0.16.3-10.0.16.10 overlap reserved ips 10.0.16.2-10.0.16.3.`),
+			Entry("sizes that overlap", storage.NetworkRanges{Network: "default", ReservedSize: 3000, StaticSize: 2000},
+				`Network "default": static ips 10.0.24.47-10.0.31.254 overlap reserved ips 10.0.16.2-10.0.27.185.`),
+			Entry("a static size bigger than the subnet", storage.NetworkRanges{Network: "default", StaticSize: 5000},
+				`Network "default": static_size 5000 does not fit in subnet 10.0.16.0/20.`),
+			Entry("a reserved size bigger than the subnet", storage.NetworkRanges{Network: "default", ReservedSize: 5000},
+				`Network "default": reserved_size 5000 does not fit in subnet 10.0.16.0/20.`),
+		)
+
+		It("returns an error when a subnet range is not a cidr block", func() {
+			state.CloudConfig.NetworkRanges = []storage.NetworkRanges{{Network: "default", StaticSize: 10}}
+			delete(vars, "az1_range")
+
+			_, err := cloudconfig.NetworkRangesVars(state, generate, vars)
+			Expect(err).To(MatchError(ContainSubstring(`Network "default": subnet range "((az1_range))": `)))
+		})
+	})
+})
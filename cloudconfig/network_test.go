@@ -0,0 +1,27 @@
+package cloudconfig_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DNSOps", func() {
+	It("replaces the dns servers of the default network", func() {
+		ops, err := cloudconfig.DNSOps([]string{"10.0.0.2", "10.0.0.3"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ops).To(MatchYAML(`
+- type: replace
+  path: /networks/name=default/subnets/0/dns
+  value: [10.0.0.2, 10.0.0.3]
+`))
+	})
+
+	It("returns nothing without dns servers", func() {
+		ops, err := cloudconfig.DNSOps(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(BeEmpty())
+	})
+})
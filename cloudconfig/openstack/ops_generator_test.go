@@ -46,4 +46,49 @@ some-key: some-value
 			})
 		})
 	})
+
+	Describe("Generate", func() {
+		var opsGenerator openstack.OpsGenerator
+
+		BeforeEach(func() {
+			opsGenerator = openstack.NewOpsGenerator(&fakes.TerraformManager{})
+		})
+
+		It("uses the openstack cloud properties of catalog vm types", func() {
+			ops, err := opsGenerator.Generate(storage.State{CloudConfig: storage.CloudConfig{VMTypes: []storage.VMType{{
+				Name:            "router-small",
+				CloudProperties: map[string]map[string]interface{}{"openstack": {"instance_type": "m1.small"}},
+			}}}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(openstack.BaseOps + `
+- type: replace
+  path: /vm_types/name=router-small?
+  value:
+    name: router-small
+    cloud_properties:
+      instance_type: m1.small
+`))
+		})
+
+		It("returns an error for catalog vm types given as cpu and ram", func() {
+			_, err := opsGenerator.Generate(storage.State{CloudConfig: storage.CloudConfig{VMTypes: []storage.VMType{{
+				Name: "router-small", CPU: 2, RAM: 4096,
+			}}}})
+			Expect(err).To(MatchError(`Vm type "router-small": openstack flavors cannot be resolved from cpu and ram, set cloud_properties.openstack.instance_type`))
+		})
+
+		It("replaces the dns servers of the default network", func() {
+			ops, err := openstack.NewOpsGenerator(&fakes.TerraformManager{}).Generate(storage.State{
+				Network: storage.Network{DNSServers: []string{"10.0.0.2"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(openstack.BaseOps + `
+- type: replace
+  path: /networks/name=default/subnets/0/dns
+  value: [10.0.0.2]
+`))
+		})
+	})
 })
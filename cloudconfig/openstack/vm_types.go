@@ -0,0 +1,13 @@
+package openstack
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// Flavors differ between OpenStack installations, so catalog vm types need
+// openstack cloud properties naming one.
+func vmTypeCloudProperties(vmType storage.VMType) (map[string]interface{}, error) {
+	return nil, errors.New("openstack flavors cannot be resolved from cpu and ram, set cloud_properties.openstack.instance_type")
+}
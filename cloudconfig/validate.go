@@ -0,0 +1,142 @@
+package cloudconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type named struct {
+	Name string `yaml:"name"`
+}
+
+type cloudConfigReferences struct {
+	AZs      []named `yaml:"azs"`
+	Networks []struct {
+		Name    string `yaml:"name"`
+		Subnets []struct {
+			AZ  string   `yaml:"az"`
+			AZs []string `yaml:"azs"`
+		} `yaml:"subnets"`
+	} `yaml:"networks"`
+	VMTypes      []named `yaml:"vm_types"`
+	VMExtensions []named `yaml:"vm_extensions"`
+	Compilation  struct {
+		AZ           string   `yaml:"az"`
+		Network      string   `yaml:"network"`
+		VMType       string   `yaml:"vm_type"`
+		VMExtensions []string `yaml:"vm_extensions"`
+	} `yaml:"compilation"`
+}
+
+type opsFileOp struct {
+	Type string `yaml:"type"`
+	Path string `yaml:"path"`
+}
+
+// The kinds of cloud config entries that can be referred to, by the section
+// that defines them.
+var referenceKinds = map[string]string{
+	"azs":           "az",
+	"networks":      "network",
+	"vm_types":      "vm_type",
+	"vm_extensions": "vm_extension",
+}
+
+// Validate returns a problem for each az, network, vm type and vm extension
+// that the interpolated cloud config or the user ops files refer to, but
+// which the cloud config does not define.
+func (m Manager) Validate(cloudConfig string) ([]string, error) {
+	var config cloudConfigReferences
+	err := yaml.Unmarshal([]byte(cloudConfig), &config)
+	if err != nil {
+		return nil, fmt.Errorf("Parse cloud config: %s", err)
+	}
+
+	defined := map[string]map[string]bool{}
+	for _, kind := range referenceKinds {
+		defined[kind] = map[string]bool{}
+	}
+	for _, az := range config.AZs {
+		defined["az"][az.Name] = true
+	}
+	for _, network := range config.Networks {
+		defined["network"][network.Name] = true
+	}
+	for _, vmType := range config.VMTypes {
+		defined["vm_type"][vmType.Name] = true
+	}
+	for _, vmExtension := range config.VMExtensions {
+		defined["vm_extension"][vmExtension.Name] = true
+	}
+
+	problems := []string{}
+	check := func(source, kind, name string) {
+		if name != "" && !defined[kind][name] {
+			problems = append(problems, fmt.Sprintf("%s: %s %q does not exist", source, kind, name))
+		}
+	}
+
+	check("compilation", "az", config.Compilation.AZ)
+	check("compilation", "network", config.Compilation.Network)
+	check("compilation", "vm_type", config.Compilation.VMType)
+	for _, vmExtension := range config.Compilation.VMExtensions {
+		check("compilation", "vm_extension", vmExtension)
+	}
+
+	for _, network := range config.Networks {
+		for _, subnet := range network.Subnets {
+			source := fmt.Sprintf("network %q", network.Name)
+			check(source, "az", subnet.AZ)
+			for _, az := range subnet.AZs {
+				check(source, "az", az)
+			}
+		}
+	}
+
+	cloudConfigDir, err := m.stateStore.GetCloudConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	opsFiles, err := m.userOpsFiles(cloudConfigDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opsFile := range opsFiles {
+		contents, err := m.fs.ReadFile(opsFile)
+		if err != nil {
+			return nil, fmt.Errorf("Read ops file: %s", err)
+		}
+
+		var ops []opsFileOp
+		err = yaml.Unmarshal(contents, &ops)
+		if err != nil {
+			return nil, fmt.Errorf("Parse ops file %s: %s", filepath.Base(opsFile), err)
+		}
+
+		for _, op := range ops {
+			if op.Type == "remove" {
+				continue
+			}
+
+			segments := strings.Split(op.Path, "/")
+			if len(segments) < 3 || !strings.HasPrefix(segments[2], "name=") {
+				continue
+			}
+
+			kind, ok := referenceKinds[segments[1]]
+			if !ok {
+				continue
+			}
+
+			name := strings.TrimSuffix(strings.TrimPrefix(segments[2], "name="), "?")
+			check(filepath.Base(opsFile), kind, name)
+		}
+	}
+
+	return problems, nil
+}
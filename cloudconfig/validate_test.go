@@ -0,0 +1,128 @@
+package cloudconfig_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	var (
+		stateStore *fakes.StateStore
+		fileIO     *fakes.FileIO
+		manager    cloudconfig.Manager
+		opsFiles   map[string]string
+	)
+
+	const cloudConfig = `
+azs:
+- name: z1
+- name: z2
+compilation:
+  az: z1
+  network: private
+  vm_type: default
+  vm_extensions: [100GB_ephemeral_disk]
+networks:
+- name: private
+  subnets:
+  - azs: [z1, z2]
+  - az: z2
+vm_types:
+- name: default
+vm_extensions:
+- name: 100GB_ephemeral_disk
+`
+
+	BeforeEach(func() {
+		stateStore = &fakes.StateStore{}
+		stateStore.GetCloudConfigDirCall.Returns.Directory = "some-cloud-config-dir"
+		fileIO = &fakes.FileIO{}
+		fileIO.ReadDirCall.Returns.FileInfos = []os.FileInfo{
+			fakes.FileInfo{FileName: "cloud-config.yml"},
+			fakes.FileInfo{FileName: "ops.yml"},
+			fakes.FileInfo{FileName: "my-ops.yml"},
+		}
+		opsFiles = map[string]string{
+			filepath.Join("some-cloud-config-dir", "my-ops.yml"): `
+- type: replace
+  path: /vm_types/name=default/cloud_properties?/instance_type
+  value: m4.large
+- type: replace
+  path: /vm_extensions/name=lb?
+  value: {name: lb}
+- type: remove
+  path: /networks/name=default?
+`,
+		}
+		fileIO.ReadFileCall.Fake = func(filename string) ([]byte, error) {
+			contents, ok := opsFiles[filename]
+			if !ok {
+				return nil, errors.New("no such file")
+			}
+			return []byte(contents), nil
+		}
+
+		manager = cloudconfig.NewManager(&fakes.Logger{}, stateStore, &fakes.CloudConfigOpsGenerator{},
+			&fakes.BOSHClientProvider{}, &fakes.TerraformManager{}, fileIO)
+	})
+
+	It("returns no problems when every reference exists", func() {
+		problems, err := manager.Validate(cloudConfig + "- name: lb\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("returns a problem for each reference that does not exist", func() {
+		problems, err := manager.Validate(`
+azs:
+- name: z1
+compilation:
+  az: z1
+  network: compilation
+  vm_type: default
+  vm_extensions: [1TB_ephemeral_disk]
+networks:
+- name: private
+  subnets:
+  - azs: [z1, z3]
+vm_types:
+- name: default
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(problems).To(Equal([]string{
+			`compilation: network "compilation" does not exist`,
+			`compilation: vm_extension "1TB_ephemeral_disk" does not exist`,
+			`network "private": az "z3" does not exist`,
+			`my-ops.yml: vm_extension "lb" does not exist`,
+		}))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the cloud config is invalid yaml", func() {
+			_, err := manager.Validate("%%%")
+			Expect(err).To(MatchError(ContainSubstring("Parse cloud config: ")))
+		})
+
+		It("returns an error when the cloud config dir cannot be read", func() {
+			fileIO.ReadDirCall.Returns.Error = errors.New("aubergine")
+
+			_, err := manager.Validate(cloudConfig)
+			Expect(err).To(MatchError("Read cloud config dir: aubergine"))
+		})
+
+		It("returns an error when an ops file is invalid yaml", func() {
+			opsFiles[filepath.Join("some-cloud-config-dir", "my-ops.yml")] = "%%%"
+
+			_, err := manager.Validate(cloudConfig)
+			Expect(err).To(MatchError(ContainSubstring("Parse ops file my-ops.yml: ")))
+		})
+	})
+})
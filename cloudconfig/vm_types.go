@@ -0,0 +1,204 @@
+package cloudconfig
+
+import (
+	"errors"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// DefaultEphemeralDiskSize is the ephemeral disk, in MB, of catalog vm types
+// that do not set one. It matches the base vm types.
+const DefaultEphemeralDiskSize = 10240
+
+var catalogIAASes = []string{"aws", "azure", "gcp", "openstack", "vsphere"}
+
+// InstanceType is an instance type that catalog vm types given as cpu and
+// ram can resolve to. RAM is in MB.
+type InstanceType struct {
+	Name string
+	CPU  int
+	RAM  int
+}
+
+type vmTypeCatalog struct {
+	VMTypes []struct {
+		Name              string                            `yaml:"name"`
+		CPU               int                               `yaml:"cpu"`
+		RAM               int                               `yaml:"ram"`
+		EphemeralDiskSize int                               `yaml:"ephemeral_disk_size"`
+		CloudProperties   map[string]map[string]interface{} `yaml:"cloud_properties"`
+	} `yaml:"vm_types"`
+}
+
+type vmTypeOp struct {
+	Type  string
+	Path  string
+	Value vmTypeValue
+}
+
+type vmTypeValue struct {
+	Name            string
+	CloudProperties map[string]interface{} `yaml:"cloud_properties"`
+}
+
+type VMTypesReader struct {
+	fs fileio.FileReader
+}
+
+func NewVMTypesReader(fs fileio.FileReader) VMTypesReader {
+	return VMTypesReader{
+		fs: fs,
+	}
+}
+
+// Read parses and validates a vm type catalog.
+func (r VMTypesReader) Read(path string) ([]storage.VMType, error) {
+	contents, err := r.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Read vm types file: %s", err)
+	}
+
+	var catalog vmTypeCatalog
+	err = yaml.UnmarshalStrict(contents, &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("Parse vm types file: %s", err)
+	}
+
+	if len(catalog.VMTypes) == 0 {
+		return nil, fmt.Errorf("Vm types file %s has no vm_types.", path)
+	}
+
+	vmTypes := []storage.VMType{}
+	names := map[string]bool{}
+	for _, v := range catalog.VMTypes {
+		if v.Name == "" {
+			return nil, errors.New("Every vm type in the vm types file needs a name.")
+		}
+		if names[v.Name] {
+			return nil, fmt.Errorf("Vm type %q is defined more than once.", v.Name)
+		}
+		names[v.Name] = true
+
+		if v.CPU < 0 || v.RAM < 0 || v.EphemeralDiskSize < 0 || (v.CPU == 0) != (v.RAM == 0) {
+			return nil, fmt.Errorf("Vm type %q needs both cpu and ram, and they must be positive.", v.Name)
+		}
+		if v.CPU == 0 && len(v.CloudProperties) == 0 {
+			return nil, fmt.Errorf("Vm type %q needs cloud_properties or cpu and ram.", v.Name)
+		}
+
+		var cloudProperties map[string]map[string]interface{}
+		for iaas, properties := range v.CloudProperties {
+			if !contains(catalogIAASes, iaas) {
+				return nil, fmt.Errorf("Vm type %q has cloud_properties for unknown IaaS %q.", v.Name, iaas)
+			}
+			if cloudProperties == nil {
+				cloudProperties = map[string]map[string]interface{}{}
+			}
+			cloudProperties[iaas] = stringKeys(properties).(map[string]interface{})
+		}
+
+		vmTypes = append(vmTypes, storage.VMType{
+			Name:              v.Name,
+			CPU:               v.CPU,
+			RAM:               v.RAM,
+			EphemeralDiskSize: v.EphemeralDiskSize,
+			CloudProperties:   cloudProperties,
+		})
+	}
+
+	return vmTypes, nil
+}
+
+// VMTypesOps returns ops that add the catalog vm types to the cloud config,
+// replacing base vm types of the same name. Vm types without cloud properties
+// for iaas get the ones resolve returns.
+func VMTypesOps(iaas string, vmTypes []storage.VMType, resolve func(storage.VMType) (map[string]interface{}, error)) (string, error) {
+	if len(vmTypes) == 0 {
+		return "", nil
+	}
+
+	ops := []vmTypeOp{}
+	for _, vmType := range vmTypes {
+		cloudProperties, ok := vmType.CloudProperties[iaas]
+		if !ok {
+			var err error
+			cloudProperties, err = resolve(vmType)
+			if err != nil {
+				return "", fmt.Errorf("Vm type %q: %s", vmType.Name, err)
+			}
+		}
+
+		ops = append(ops, vmTypeOp{
+			Type: "replace",
+			Path: fmt.Sprintf("/vm_types/name=%s?", vmType.Name),
+			Value: vmTypeValue{
+				Name:            vmType.Name,
+				CloudProperties: cloudProperties,
+			},
+		})
+	}
+
+	opsYAML, err := yaml.Marshal(ops)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(opsYAML), nil
+}
+
+// NearestInstanceType returns the first of instanceTypes, ordered from
+// smallest to largest, with at least cpu CPUs and ram MB of RAM.
+func NearestInstanceType(instanceTypes []InstanceType, cpu, ram int) (string, error) {
+	for _, instanceType := range instanceTypes {
+		if instanceType.CPU >= cpu && instanceType.RAM >= ram {
+			return instanceType.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no instance type has %d CPUs and %d MB of RAM", cpu, ram)
+}
+
+// EphemeralDiskSize returns the ephemeral disk size of vmType in MB.
+func EphemeralDiskSize(vmType storage.VMType) int {
+	if vmType.EphemeralDiskSize == 0 {
+		return DefaultEphemeralDiskSize
+	}
+	return vmType.EphemeralDiskSize
+}
+
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, value := range v {
+			m[fmt.Sprintf("%v", key)] = stringKeys(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for key, value := range v {
+			m[key] = stringKeys(value)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			s[i] = stringKeys(value)
+		}
+		return s
+	}
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package cloudconfig_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMTypes", func() {
+	Describe("VMTypesReader", func() {
+		var (
+			fileIO *fakes.FileIO
+			reader cloudconfig.VMTypesReader
+		)
+
+		BeforeEach(func() {
+			fileIO = &fakes.FileIO{}
+			reader = cloudconfig.NewVMTypesReader(fileIO)
+		})
+
+		It("reads the vm types from the catalog", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte(`---
+vm_types:
+- name: router-small
+  cpu: 2
+  ram: 4096
+- name: database
+  cpu: 4
+  ram: 16384
+  ephemeral_disk_size: 51200
+  cloud_properties:
+    aws:
+      instance_type: r4.xlarge
+      ephemeral_disk:
+        size: 51200
+`)
+
+			vmTypes, err := reader.Read("some-catalog.yml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-catalog.yml"))
+			Expect(vmTypes).To(Equal([]storage.VMType{
+				{Name: "router-small", CPU: 2, RAM: 4096},
+				{
+					Name:              "database",
+					CPU:               4,
+					RAM:               16384,
+					EphemeralDiskSize: 51200,
+					CloudProperties: map[string]map[string]interface{}{
+						"aws": {
+							"instance_type":  "r4.xlarge",
+							"ephemeral_disk": map[string]interface{}{"size": 51200},
+						},
+					},
+				},
+			}))
+		})
+
+		It("returns an error when the file cannot be read", func() {
+			fileIO.ReadFileCall.Returns.Error = errors.New("kiwi")
+
+			_, err := reader.Read("some-catalog.yml")
+			Expect(err).To(MatchError("Read vm types file: kiwi"))
+		})
+
+		DescribeTable("validates the catalog",
+			func(catalog, message string) {
+				fileIO.ReadFileCall.Returns.Contents = []byte(catalog)
+
+				_, err := reader.Read("some-catalog.yml")
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("invalid yaml", "vm_types: %%%", "Parse vm types file: "),
+			Entry("unknown keys", "vm_types:\n- name: a\n  memory: 1024\n", "Parse vm types file: "),
+			Entry("no vm types", "vm_types: []\n", "Vm types file some-catalog.yml has no vm_types."),
+			Entry("a missing name", "vm_types:\n- cpu: 1\n  ram: 1024\n", "Every vm type in the vm types file needs a name."),
+			Entry("a duplicate name", "vm_types:\n- {name: a, cpu: 1, ram: 1024}\n- {name: a, cpu: 2, ram: 1024}\n", `Vm type "a" is defined more than once.`),
+			Entry("cpu without ram", "vm_types:\n- {name: a, cpu: 1}\n", `Vm type "a" needs both cpu and ram, and they must be positive.`),
+			Entry("negative ram", "vm_types:\n- {name: a, cpu: 1, ram: -1}\n", `Vm type "a" needs both cpu and ram, and they must be positive.`),
+			Entry("no sizing", "vm_types:\n- {name: a}\n", `Vm type "a" needs cloud_properties or cpu and ram.`),
+			Entry("an unknown IaaS", "vm_types:\n- {name: a, cloud_properties: {ec2: {}}}\n", `Vm type "a" has cloud_properties for unknown IaaS "ec2".`),
+		)
+	})
+
+	Describe("VMTypesOps", func() {
+		var resolve func(storage.VMType) (map[string]interface{}, error)
+
+		BeforeEach(func() {
+			resolve = func(vmType storage.VMType) (map[string]interface{}, error) {
+				return map[string]interface{}{"cpu": vmType.CPU}, nil
+			}
+		})
+
+		It("adds or replaces each vm type with its cloud properties for the IaaS", func() {
+			ops, err := cloudconfig.VMTypesOps("aws", []storage.VMType{
+				{Name: "router-small", CPU: 2, RAM: 4096},
+				{Name: "small", CloudProperties: map[string]map[string]interface{}{
+					"aws": {"instance_type": "m4.large"},
+					"gcp": {"machine_type": "n1-standard-2"},
+				}},
+			}, resolve)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(`
+- type: replace
+  path: /vm_types/name=router-small?
+  value:
+    name: router-small
+    cloud_properties:
+      cpu: 2
+- type: replace
+  path: /vm_types/name=small?
+  value:
+    name: small
+    cloud_properties:
+      instance_type: m4.large
+`))
+		})
+
+		It("returns nothing without vm types", func() {
+			ops, err := cloudconfig.VMTypesOps("aws", nil, resolve)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ops).To(BeEmpty())
+		})
+
+		It("returns an error naming the vm type that cannot be resolved", func() {
+			resolve = func(storage.VMType) (map[string]interface{}, error) {
+				return nil, errors.New("too big")
+			}
+
+			_, err := cloudconfig.VMTypesOps("aws", []storage.VMType{{Name: "huge", CPU: 512, RAM: 1024}}, resolve)
+			Expect(err).To(MatchError(`Vm type "huge": too big`))
+		})
+	})
+
+	Describe("NearestInstanceType", func() {
+		instanceTypes := []cloudconfig.InstanceType{
+			{Name: "small", CPU: 1, RAM: 2048},
+			{Name: "highmem", CPU: 1, RAM: 8192},
+			{Name: "large", CPU: 4, RAM: 8192},
+		}
+
+		It("returns the smallest instance type that is big enough", func() {
+			Expect(cloudconfig.NearestInstanceType(instanceTypes, 1, 1024)).To(Equal("small"))
+			Expect(cloudconfig.NearestInstanceType(instanceTypes, 1, 4096)).To(Equal("highmem"))
+			Expect(cloudconfig.NearestInstanceType(instanceTypes, 2, 1024)).To(Equal("large"))
+		})
+
+		It("returns an error when no instance type is big enough", func() {
+			_, err := cloudconfig.NearestInstanceType(instanceTypes, 8, 1024)
+			Expect(err).To(MatchError("no instance type has 8 CPUs and 1024 MB of RAM"))
+		})
+	})
+})
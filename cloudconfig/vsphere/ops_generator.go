@@ -5,6 +5,7 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
@@ -24,7 +25,24 @@ func NewOpsGenerator(terraformManager terraformManager) OpsGenerator {
 }
 
 func (o OpsGenerator) Generate(state storage.State) (string, error) {
-	return BaseOps, nil
+	vmTypesOps, err := cloudconfig.VMTypesOps("vsphere", state.CloudConfig.VMTypes, vmTypeCloudProperties)
+	if err != nil {
+		return "", err
+	}
+
+	dnsOps, err := cloudconfig.DNSOps(state.Network.DNSServers)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	ops := BaseOps + vmTypesOps + dnsOps
+
+	networkRangesOps, err := cloudconfig.NetworkRangesOps(state.CloudConfig.NetworkRanges, ops)
+	if err != nil {
+		return "", err
+	}
+
+	return ops + networkRangesOps, nil
 }
 
 func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
@@ -32,7 +50,20 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("Get terraform outputs: %s", err)
 	}
-	varsBytes, err := yaml.Marshal(terraformOutputs.Map)
+	networkRangesVars, err := cloudconfig.NetworkRangesVars(state, o.Generate, terraformOutputs.Map)
+	if err != nil {
+		return "", err
+	}
+
+	vars := map[string]interface{}{}
+	for key, value := range terraformOutputs.Map {
+		vars[key] = value
+	}
+	for key, value := range networkRangesVars {
+		vars[key] = value
+	}
+
+	varsBytes, err := yaml.Marshal(vars)
 	if err != nil {
 		panic(err) // not tested; cannot occur
 	}
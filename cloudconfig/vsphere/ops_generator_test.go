@@ -35,6 +35,41 @@ some-key: some-value
 `))
 		})
 
+		It("adds the reserved and static ips of the network ranges", func() {
+			terraformManager.GetOutputsCall.Returns.Outputs.Map = map[string]interface{}{
+				"internal_cidr":        "10.0.0.0/24",
+				"internal_gw":          "10.0.0.1",
+				"network_name":         "some-network",
+				"jumpbox__internal_ip": "10.0.0.5",
+			}
+
+			vars, err := opsGenerator.GenerateVars(storage.State{CloudConfig: storage.CloudConfig{
+				NetworkRanges: []storage.NetworkRanges{{Network: "default", ReservedSize: 20, StaticSize: 50}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(vars).To(MatchYAML(`---
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+network_name: some-network
+jumpbox__internal_ip: 10.0.0.5
+default_subnet1_reserved: [10.0.0.5, 10.0.0.2-10.0.0.21]
+default_subnet1_static: [10.0.0.205-10.0.0.254]
+`))
+		})
+
+		It("returns an error when the static ips of the network ranges include the jumpbox", func() {
+			terraformManager.GetOutputsCall.Returns.Outputs.Map = map[string]interface{}{
+				"internal_cidr":        "10.0.0.0/24",
+				"jumpbox__internal_ip": "10.0.0.5",
+			}
+
+			_, err := opsGenerator.GenerateVars(storage.State{CloudConfig: storage.CloudConfig{
+				NetworkRanges: []storage.NetworkRanges{{Network: "default", Static: []string{"10.0.0.2-10.0.0.10"}}},
+			}})
+			Expect(err).To(MatchError(`Network "default": static ips 10.0.0.2-10.0.0.10 overlap reserved ips 10.0.0.5.`))
+		})
+
 		Context("when terraform manager get outputs fails", func() {
 			BeforeEach(func() {
 				terraformManager.GetOutputsCall.Returns.Error = errors.New("kiwi")
@@ -46,4 +81,59 @@ some-key: some-value
 			})
 		})
 	})
+
+	Describe("Generate", func() {
+		It("sizes catalog vm types directly", func() {
+			opsGenerator := vsphere.NewOpsGenerator(&fakes.TerraformManager{})
+
+			ops, err := opsGenerator.Generate(storage.State{CloudConfig: storage.CloudConfig{VMTypes: []storage.VMType{{
+				Name: "router-small", CPU: 2, RAM: 4096,
+			}}}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(vsphere.BaseOps + `
+- type: replace
+  path: /vm_types/name=router-small?
+  value:
+    name: router-small
+    cloud_properties:
+      cpu: 2
+      ram: 4096
+      disk: 10240
+`))
+		})
+
+		It("points the default network at the vars of the network ranges", func() {
+			ops, err := vsphere.NewOpsGenerator(&fakes.TerraformManager{}).Generate(storage.State{CloudConfig: storage.CloudConfig{
+				NetworkRanges: []storage.NetworkRanges{{Network: "default", StaticSize: 50}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(vsphere.BaseOps + `
+- type: replace
+  path: /networks/name=default/subnets/0/static?
+  value: ((default_subnet1_static))
+`))
+		})
+
+		It("returns an error for network ranges of a network that is not in the cloud config", func() {
+			_, err := vsphere.NewOpsGenerator(&fakes.TerraformManager{}).Generate(storage.State{CloudConfig: storage.CloudConfig{
+				NetworkRanges: []storage.NetworkRanges{{Network: "private", StaticSize: 50}},
+			}})
+			Expect(err).To(MatchError(`Network "private" of the network ranges is not in the cloud config.`))
+		})
+
+		It("replaces the dns servers of the default network", func() {
+			ops, err := vsphere.NewOpsGenerator(&fakes.TerraformManager{}).Generate(storage.State{
+				Network: storage.Network{DNSServers: []string{"10.0.0.2"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ops).To(MatchYAML(vsphere.BaseOps + `
+- type: replace
+  path: /networks/name=default/subnets/0/dns
+  value: [10.0.0.2]
+`))
+		})
+	})
 })
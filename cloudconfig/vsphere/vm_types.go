@@ -0,0 +1,15 @@
+package vsphere
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// vSphere VMs are sized directly, so catalog vm types need no instance type.
+func vmTypeCloudProperties(vmType storage.VMType) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"cpu":  vmType.CPU,
+		"ram":  vmType.RAM,
+		"disk": cloudconfig.EphemeralDiskSize(vmType),
+	}, nil
+}
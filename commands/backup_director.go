@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type BackupDirector struct {
+	logger         logger
+	stateValidator stateValidator
+	bbr            directorBBR
+	artifactStore  artifactStore
+	fs             backupFs
+}
+
+type pathFinder interface {
+	CommandExists(string) bool
+}
+
+type bbrCLI interface {
+	Run(workingDirectory, boshAllProxy string, args []string) error
+}
+
+type jumpboxProxy interface {
+	ProxyAddr(state storage.State) (string, error)
+}
+
+type artifactStore interface {
+	IsConfigured() bool
+	Upload(state storage.State, name, sourceDir string) error
+	Download(state storage.State, name, destDir string) error
+}
+
+type backupFs interface {
+	fileio.TempDirer
+	fileio.FileWriter
+	fileio.AllRemover
+	fileio.DirReader
+	fileio.AllMkdirer
+	fileio.Stater
+}
+
+func NewBackupDirector(logger logger, stateValidator stateValidator, bbrCLI bbrCLI, sshKeyGetter sshKeyGetter,
+	allProxyGetter allProxyGetter, jumpboxProxy jumpboxProxy, pathFinder pathFinder, artifactStore artifactStore,
+	fs backupFs) BackupDirector {
+	return BackupDirector{
+		logger:         logger,
+		stateValidator: stateValidator,
+		bbr: directorBBR{
+			cli:            bbrCLI,
+			sshKeyGetter:   sshKeyGetter,
+			allProxyGetter: allProxyGetter,
+			jumpboxProxy:   jumpboxProxy,
+			pathFinder:     pathFinder,
+			fs:             fs,
+		},
+		artifactStore: artifactStore,
+		fs:            fs,
+	}
+}
+
+func (b BackupDirector) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := b.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return b.bbr.checkFastFails(state)
+}
+
+func (b BackupDirector) Execute(args []string, state storage.State) error {
+	var artifactPath string
+	backupFlags := flags.New("backup-director")
+	backupFlags.String(&artifactPath, "artifact-path", ".")
+	err := backupFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	artifactPath, err = filepath.Abs(artifactPath)
+	if err != nil {
+		return fmt.Errorf("Resolve artifact path: %s", err) // not tested
+	}
+
+	err = b.fs.MkdirAll(artifactPath, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Create artifact directory: %s", err)
+	}
+
+	b.logger.Step("backing up bosh director")
+	err = b.bbr.run(state, artifactPath, "backup")
+	if err != nil {
+		return fmt.Errorf("Backup director: %s", err)
+	}
+
+	artifact, err := b.latestArtifact(artifactPath, b.bbr.host(state))
+	if err != nil {
+		return err
+	}
+	b.logger.Step("backed up bosh director to %s", artifact)
+
+	if b.artifactStore.IsConfigured() {
+		name := filepath.Base(artifact)
+		b.logger.Step("uploading director backup %s to the remote state bucket", name)
+
+		err = b.artifactStore.Upload(state, name, artifact)
+		if err != nil {
+			return fmt.Errorf("Upload director backup: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// bbr names each artifact "<host>_<timestamp>" inside its working directory.
+func (b BackupDirector) latestArtifact(artifactPath, host string) (string, error) {
+	files, err := b.fs.ReadDir(artifactPath)
+	if err != nil {
+		return "", fmt.Errorf("Read artifact directory: %s", err)
+	}
+
+	artifacts := []string{}
+	for _, file := range files {
+		if file.IsDir() && strings.HasPrefix(file.Name(), fmt.Sprintf("%s_", host)) {
+			artifacts = append(artifacts, file.Name())
+		}
+	}
+
+	if len(artifacts) == 0 {
+		return "", fmt.Errorf("No director backup artifact was found in %s", artifactPath)
+	}
+
+	sort.Strings(artifacts)
+	return filepath.Join(artifactPath, artifacts[len(artifacts)-1]), nil
+}
+
+type directorBBR struct {
+	cli            bbrCLI
+	sshKeyGetter   sshKeyGetter
+	allProxyGetter allProxyGetter
+	jumpboxProxy   jumpboxProxy
+	pathFinder     pathFinder
+	fs             backupFs
+}
+
+func (d directorBBR) checkFastFails(state storage.State) error {
+	if state.NoDirector || state.BOSH.DirectorAddress == "" {
+		return errors.New("Invalid bbl state: a bosh director is required to back up or restore.")
+	}
+
+	if !d.pathFinder.CommandExists("bbr") {
+		return errors.New("bbr must be installed and available on your PATH.")
+	}
+
+	return nil
+}
+
+func (d directorBBR) host(state storage.State) string {
+	return strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
+}
+
+func (d directorBBR) run(state storage.State, workingDirectory string, subcommand ...string) error {
+	tempDir, err := d.fs.TempDir("", "")
+	if err != nil {
+		return fmt.Errorf("Create temp directory: %s", err)
+	}
+	defer d.fs.RemoveAll(tempDir)
+
+	directorPrivateKey, err := d.sshKeyGetter.Get("director")
+	if err != nil {
+		return fmt.Errorf("Get director private key: %s", err)
+	}
+
+	directorKeyPath := filepath.Join(tempDir, "director-private-key")
+	err = d.fs.WriteFile(directorKeyPath, []byte(directorPrivateKey), 0600)
+	if err != nil {
+		return fmt.Errorf("Write private key file: %s", err)
+	}
+
+	var boshAllProxy string
+	switch {
+	case state.NoJumpbox:
+	case state.JumpboxConfig.SSHCA:
+		// Jumpboxes that trust an ssh CA only accept certificates, which bbr
+		// cannot log in with, so it goes through the proxy bbl runs instead.
+		addr, err := d.jumpboxProxy.ProxyAddr(state)
+		if err != nil {
+			return fmt.Errorf("Start jumpbox proxy: %s", err)
+		}
+		boshAllProxy = fmt.Sprintf("socks5://%s", addr)
+	default:
+		jumpboxKeyPath, err := d.allProxyGetter.GeneratePrivateKey()
+		if err != nil {
+			return fmt.Errorf("Get jumpbox private key: %s", err)
+		}
+		// The key is written to a temp directory of its own.
+		defer d.fs.RemoveAll(filepath.Dir(jumpboxKeyPath))
+		boshAllProxy = d.allProxyGetter.BoshAllProxy(state.Jumpbox.URL, jumpboxKeyPath)
+	}
+
+	args := append([]string{
+		"director",
+		"--host", d.host(state),
+		"--username", "jumpbox",
+		"--private-key-path", directorKeyPath,
+	}, subcommand...)
+
+	return d.cli.Run(workingDirectory, boshAllProxy, args)
+}
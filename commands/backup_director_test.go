@@ -0,0 +1,252 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackupDirector", func() {
+	var (
+		backup         commands.BackupDirector
+		logger         *fakes.Logger
+		stateValidator *fakes.StateValidator
+		bbrCLI         *fakes.BBRCLI
+		sshKeyGetter   *fakes.FancySSHKeyGetter
+		allProxyGetter *fakes.AllProxyGetter
+		jumpboxProxy   *fakes.JumpboxProxy
+		pathFinder     *fakes.PathFinder
+		artifactStore  *fakes.ArtifactStore
+		fileIO         *fakes.FileIO
+		state          storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		bbrCLI = &fakes.BBRCLI{}
+		sshKeyGetter = &fakes.FancySSHKeyGetter{}
+		allProxyGetter = &fakes.AllProxyGetter{}
+		jumpboxProxy = &fakes.JumpboxProxy{}
+		pathFinder = &fakes.PathFinder{}
+		artifactStore = &fakes.ArtifactStore{}
+		fileIO = &fakes.FileIO{}
+
+		pathFinder.CommandExistsCall.Returns.Exists = true
+
+		state = storage.State{
+			EnvID: "some-env",
+			Jumpbox: storage.Jumpbox{
+				URL: "jumpboxURL:22",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress: "https://10.0.0.6:25555",
+			},
+		}
+
+		backup = commands.NewBackupDirector(logger, stateValidator, bbrCLI, sshKeyGetter, allProxyGetter, jumpboxProxy, pathFinder, artifactStore, fileIO)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state and looks for bbr", func() {
+			err := backup.CheckFastFails([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateValidator.ValidateCall.CallCount).To(Equal(1))
+			Expect(pathFinder.CommandExistsCall.Receives.Command).To(Equal("bbr"))
+		})
+
+		Context("when the state is invalid", func() {
+			BeforeEach(func() {
+				stateValidator.ValidateCall.Returns.Error = errors.New("banana")
+			})
+
+			It("returns an error", func() {
+				err := backup.CheckFastFails([]string{}, state)
+				Expect(err).To(MatchError("banana"))
+			})
+		})
+
+		Context("when there is no director", func() {
+			It("returns an error", func() {
+				err := backup.CheckFastFails([]string{}, storage.State{NoDirector: true})
+				Expect(err).To(MatchError("Invalid bbl state: a bosh director is required to back up or restore."))
+			})
+		})
+
+		Context("when bbr is not on the path", func() {
+			BeforeEach(func() {
+				pathFinder.CommandExistsCall.Returns.Exists = false
+			})
+
+			It("returns an error", func() {
+				err := backup.CheckFastFails([]string{}, state)
+				Expect(err).To(MatchError("bbr must be installed and available on your PATH."))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		BeforeEach(func() {
+			fileIO.TempDirCall.Returns.Name = "some-temp-dir"
+			fileIO.ReadDirCall.Returns.FileInfos = []os.FileInfo{
+				fakes.DirFileInfo{fakes.FileInfo{FileName: "10.0.0.6_20180101T000000Z"}},
+				fakes.DirFileInfo{fakes.FileInfo{FileName: "10.0.0.6_20180102T000000Z"}},
+				fakes.FileInfo{FileName: "10.0.0.6_20180103T000000Z"},
+				fakes.DirFileInfo{fakes.FileInfo{FileName: "unrelated"}},
+			}
+			sshKeyGetter.DirectorGetCall.Returns.PrivateKey = "director-private-key"
+			allProxyGetter.GeneratePrivateKeyCall.Returns.PrivateKey = filepath.Join("some-jumpbox-key-dir", "some-jumpbox-key")
+			allProxyGetter.BoshAllProxyCall.Returns.URL = "ssh+socks5://some-jumpbox"
+		})
+
+		It("runs bbr director backup through the jumpbox", func() {
+			err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.MkdirAllCall.Receives.Dir).To(Equal("/some/backups"))
+			Expect(fileIO.WriteFileCall.Receives).To(ContainElement(fakes.WriteFileReceive{
+				Filename: filepath.Join("some-temp-dir", "director-private-key"),
+				Contents: []byte("director-private-key"),
+				Mode:     os.FileMode(0600),
+			}))
+			Expect(allProxyGetter.BoshAllProxyCall.Receives.JumpboxURL).To(Equal("jumpboxURL:22"))
+			Expect(allProxyGetter.BoshAllProxyCall.Receives.PrivateKey).To(Equal(filepath.Join("some-jumpbox-key-dir", "some-jumpbox-key")))
+
+			Expect(bbrCLI.RunCall.Receives).To(Equal([]fakes.BBRRunReceive{{
+				WorkingDirectory: "/some/backups",
+				BOSHAllProxy:     "ssh+socks5://some-jumpbox",
+				Args: []string{
+					"director",
+					"--host", "10.0.0.6",
+					"--username", "jumpbox",
+					"--private-key-path", filepath.Join("some-temp-dir", "director-private-key"),
+					"backup",
+				},
+			}}))
+
+			Expect(logger.StepCall.Messages).To(ContainElement("backed up bosh director to /some/backups/10.0.0.6_20180102T000000Z"))
+			Expect(artifactStore.UploadCall.CallCount).To(Equal(0))
+		})
+
+		It("removes the private keys when bbr is done", func() {
+			err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.RemoveAllCall.Receives).To(ConsistOf(
+				fakes.RemoveAllReceive{Path: "some-temp-dir"},
+				fakes.RemoveAllReceive{Path: "some-jumpbox-key-dir"},
+			))
+		})
+
+		It("removes the private keys when bbr fails", func() {
+			bbrCLI.RunCall.Returns.Error = errors.New("bbr failed")
+
+			err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+			Expect(err).To(HaveOccurred())
+
+			Expect(fileIO.RemoveAllCall.CallCount).To(Equal(2))
+		})
+
+		Context("when the environment has no jumpbox", func() {
+			It("runs bbr director backup without a proxy", func() {
+				state.NoJumpbox = true
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+				Expect(bbrCLI.RunCall.Receives[0].BOSHAllProxy).To(BeEmpty())
+			})
+		})
+
+		Context("when the jumpbox trusts an ssh CA", func() {
+			BeforeEach(func() {
+				state.JumpboxConfig.SSHCA = true
+				jumpboxProxy.ProxyAddrCall.Returns.Addr = "127.0.0.1:1234"
+			})
+
+			It("runs bbr director backup through the proxy bbl runs with a certificate", func() {
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(jumpboxProxy.ProxyAddrCall.Receives.State).To(Equal(state))
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+				Expect(bbrCLI.RunCall.Receives[0].BOSHAllProxy).To(Equal("socks5://127.0.0.1:1234"))
+			})
+
+			Context("when the proxy cannot be started", func() {
+				It("returns an error", func() {
+					jumpboxProxy.ProxyAddrCall.Returns.Error = errors.New("durian")
+
+					err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+					Expect(err).To(MatchError("Backup director: Start jumpbox proxy: durian"))
+					Expect(bbrCLI.RunCall.CallCount).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a remote state bucket is configured", func() {
+			BeforeEach(func() {
+				artifactStore.IsConfiguredCall.Returns.IsConfigured = true
+			})
+
+			It("uploads the latest artifact", func() {
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(artifactStore.UploadCall.CallCount).To(Equal(1))
+				Expect(artifactStore.UploadCall.Receives.State).To(Equal(state))
+				Expect(artifactStore.UploadCall.Receives.Name).To(Equal("10.0.0.6_20180102T000000Z"))
+				Expect(artifactStore.UploadCall.Receives.SourceDir).To(Equal("/some/backups/10.0.0.6_20180102T000000Z"))
+			})
+
+			Context("when the upload fails", func() {
+				BeforeEach(func() {
+					artifactStore.UploadCall.Returns.Error = errors.New("banana")
+				})
+
+				It("returns an error", func() {
+					err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+					Expect(err).To(MatchError("Upload director backup: banana"))
+				})
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the artifact directory cannot be created", func() {
+				fileIO.MkdirAllCall.Returns.Error = errors.New("banana")
+
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).To(MatchError("Create artifact directory: banana"))
+			})
+
+			It("returns an error when the director key cannot be retrieved", func() {
+				sshKeyGetter.DirectorGetCall.Returns.Error = errors.New("banana")
+
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).To(MatchError("Backup director: Get director private key: banana"))
+			})
+
+			It("returns an error when bbr fails", func() {
+				bbrCLI.RunCall.Returns.Error = errors.New("banana")
+
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).To(MatchError("Backup director: banana"))
+			})
+
+			It("returns an error when bbr did not produce an artifact", func() {
+				fileIO.ReadDirCall.Returns.FileInfos = []os.FileInfo{}
+
+				err := backup.Execute([]string{"--artifact-path", "/some/backups"}, state)
+				Expect(err).To(MatchError("No director backup artifact was found in /some/backups"))
+			})
+		})
+	})
+})
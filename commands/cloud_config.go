@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type CloudConfig struct {
+	logger             logger
+	stateValidator     stateValidator
+	cloudConfigManager cloudConfigManager
+	boshClientProvider directorClientProvider
+}
+
+func NewCloudConfig(logger logger, stateValidator stateValidator, cloudConfigManager cloudConfigManager,
+	boshClientProvider directorClientProvider) CloudConfig {
+	return CloudConfig{
+		logger:             logger,
+		stateValidator:     stateValidator,
+		cloudConfigManager: cloudConfigManager,
+		boshClientProvider: boshClientProvider,
+	}
+}
+
+func (c CloudConfig) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := c.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if state.NoDirector {
+		return errors.New("This environment has no director, so it has no cloud config.")
+	}
+
+	if !c.cloudConfigManager.IsPresentCloudConfig() {
+		return errors.New("Invalid bbl state for bbl cloud-config, run bbl plan first.")
+	}
+
+	return nil
+}
+
+// Execute prints the cloud config that bbl up would apply. Only --diff talks
+// to the director.
+func (c CloudConfig) Execute(args []string, state storage.State) error {
+	var (
+		diff     bool
+		validate bool
+	)
+	cloudConfigFlags := flags.New("cloud-config")
+	cloudConfigFlags.Bool(&diff, "diff")
+	cloudConfigFlags.Bool(&validate, "validate")
+	err := cloudConfigFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	err = c.cloudConfigManager.GenerateVars(state)
+	if err != nil {
+		return fmt.Errorf("Generate cloud config vars: %s", err)
+	}
+
+	cloudConfig, err := c.cloudConfigManager.Interpolate()
+	if err != nil {
+		return fmt.Errorf("Interpolate cloud config: %s", err)
+	}
+
+	if validate {
+		problems, err := c.cloudConfigManager.Validate(cloudConfig)
+		if err != nil {
+			return fmt.Errorf("Validate cloud config: %s", err)
+		}
+
+		for _, problem := range problems {
+			c.logger.Println(problem)
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("The cloud config has %d problem(s).", len(problems))
+		}
+
+		if !diff {
+			c.logger.Println("The cloud config is valid.")
+		}
+	}
+
+	if diff {
+		return c.diff(cloudConfig, state)
+	}
+
+	if !validate {
+		c.logger.Printf("%s", cloudConfig)
+	}
+
+	return nil
+}
+
+func (c CloudConfig) diff(cloudConfig string, state storage.State) error {
+	if state.BOSH.IsEmpty() {
+		return errors.New("There is no director to diff against, run bbl up first.")
+	}
+
+	boshClient, err := c.boshClientProvider.Client(state)
+	if err != nil {
+		return fmt.Errorf("Create director client: %s", err)
+	}
+
+	lines, err := boshClient.DiffConfig("cloud", "default", []byte(cloudConfig))
+	if err != nil {
+		return fmt.Errorf("Diff cloud config: %s", err)
+	}
+
+	if len(lines) == 0 {
+		c.logger.Println("The director's cloud config is up to date.")
+		return nil
+	}
+
+	for _, line := range lines {
+		switch line.State {
+		case "added":
+			c.logger.Println("+ " + line.Text)
+		case "removed":
+			c.logger.Println("- " + line.Text)
+		default:
+			c.logger.Println("  " + line.Text)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloudConfig", func() {
+	var (
+		logger             *fakes.Logger
+		stateValidator     *fakes.StateValidator
+		cloudConfigManager *fakes.CloudConfigManager
+		boshClientProvider *fakes.BOSHClientProvider
+		boshClient         *fakes.BOSHClient
+		command            commands.CloudConfig
+		state              storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		cloudConfigManager = &fakes.CloudConfigManager{}
+		cloudConfigManager.IsPresentCloudConfigCall.Returns.IsPresent = true
+		cloudConfigManager.InterpolateCall.Returns.CloudConfig = "azs: []\n"
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+
+		state = storage.State{
+			IAAS:    "gcp",
+			Jumpbox: storage.Jumpbox{URL: "some-jumpbox:22"},
+			BOSH: storage.BOSH{
+				DirectorAddress:  "https://some-director:25555",
+				DirectorUsername: "some-username",
+				DirectorPassword: "some-password",
+				DirectorSSLCA:    "some-ca",
+			},
+		}
+
+		command = commands.NewCloudConfig(logger, stateValidator, cloudConfigManager, boshClientProvider)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("no state")
+
+			err := command.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("no state"))
+		})
+
+		It("requires a director", func() {
+			err := command.CheckFastFails([]string{}, storage.State{NoDirector: true})
+			Expect(err).To(MatchError("This environment has no director, so it has no cloud config."))
+		})
+
+		It("requires a planned cloud config", func() {
+			cloudConfigManager.IsPresentCloudConfigCall.Returns.IsPresent = false
+
+			err := command.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("Invalid bbl state for bbl cloud-config, run bbl plan first."))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the interpolated cloud config without contacting the director", func() {
+			err := command.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cloudConfigManager.GenerateVarsCall.Receives.State).To(Equal(state))
+			Expect(cloudConfigManager.InterpolateCall.CallCount).To(Equal(1))
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{"azs: []\n"}))
+
+			Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+			Expect(cloudConfigManager.ValidateCall.CallCount).To(Equal(0))
+		})
+
+		Context("--validate", func() {
+			It("says so when the cloud config is valid", func() {
+				err := command.Execute([]string{"--validate"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ValidateCall.Receives.CloudConfig).To(Equal("azs: []\n"))
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"The cloud config is valid."}))
+				Expect(logger.PrintfCall.CallCount).To(Equal(0))
+			})
+
+			It("prints the problems and returns an error", func() {
+				cloudConfigManager.ValidateCall.Returns.Problems = []string{
+					`compilation: az "z9" does not exist`,
+					`my-ops.yml: vm_extension "lb" does not exist`,
+				}
+
+				err := command.Execute([]string{"--validate"}, state)
+				Expect(err).To(MatchError("The cloud config has 2 problem(s)."))
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+					`compilation: az "z9" does not exist`,
+					`my-ops.yml: vm_extension "lb" does not exist`,
+				}))
+			})
+
+			It("returns an error when validation fails", func() {
+				cloudConfigManager.ValidateCall.Returns.Error = errors.New("lychee")
+
+				err := command.Execute([]string{"--validate"}, state)
+				Expect(err).To(MatchError("Validate cloud config: lychee"))
+			})
+		})
+
+		Context("--diff", func() {
+			It("prints the director's diff of the cloud config", func() {
+				boshClient.DiffConfigCall.Returns.Diff = []bosh.DiffLine{
+					{Text: "vm_types:"},
+					{Text: "- name: router-small", State: "added"},
+					{Text: "- name: small", State: "removed"},
+				}
+
+				err := command.Execute([]string{"--diff"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClientProvider.ClientCall.Receives.State).To(Equal(state))
+
+				Expect(boshClient.DiffConfigCall.Receives).To(Equal(fakes.BOSHConfigReceive{
+					Type:    "cloud",
+					Name:    "default",
+					Content: []byte("azs: []\n"),
+				}))
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+					"  vm_types:",
+					"+ - name: router-small",
+					"- - name: small",
+				}))
+			})
+
+			It("says so when there are no changes", func() {
+				err := command.Execute([]string{"--diff"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"The director's cloud config is up to date."}))
+			})
+
+			It("validates before diffing when both flags are passed", func() {
+				cloudConfigManager.ValidateCall.Returns.Problems = []string{`compilation: az "z9" does not exist`}
+
+				err := command.Execute([]string{"--validate", "--diff"}, state)
+				Expect(err).To(MatchError("The cloud config has 1 problem(s)."))
+				Expect(boshClient.DiffConfigCall.CallCount).To(Equal(0))
+			})
+
+			It("requires a deployed director", func() {
+				state.BOSH = storage.BOSH{}
+
+				err := command.Execute([]string{"--diff"}, state)
+				Expect(err).To(MatchError("There is no director to diff against, run bbl up first."))
+			})
+
+			It("returns an error when the director client cannot be created", func() {
+				boshClientProvider.ClientCall.Returns.Error = errors.New("durian")
+
+				err := command.Execute([]string{"--diff"}, state)
+				Expect(err).To(MatchError("Create director client: durian"))
+			})
+
+			It("returns an error when the diff fails", func() {
+				boshClient.DiffConfigCall.Returns.Error = errors.New("rambutan")
+
+				err := command.Execute([]string{"--diff"}, state)
+				Expect(err).To(MatchError("Diff cloud config: rambutan"))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error for unknown flags", func() {
+				err := command.Execute([]string{"--apply"}, state)
+				Expect(err).To(MatchError("flag provided but not defined: -apply"))
+			})
+
+			It("returns an error when the vars cannot be generated", func() {
+				cloudConfigManager.GenerateVarsCall.Returns.Error = errors.New("kiwi")
+
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError("Generate cloud config vars: kiwi"))
+			})
+
+			It("returns an error when the cloud config cannot be interpolated", func() {
+				cloudConfigManager.InterpolateCall.Returns.Error = errors.New("mango")
+
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError("Interpolate cloud config: mango"))
+			})
+		})
+	})
+})
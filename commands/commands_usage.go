@@ -57,6 +57,30 @@ const (
   --lb-chain                 Path to SSL certificate chain (supported when iaas="aws")
   --lb-domain                Creates a DNS zone and records for the given domain (supported when type="cf")`
 
+	DirectorUsage = `
+
+  Director options:
+  --director-blobstore       Director blobstore: "internal" or "external" (external supported when iaas="aws" or "gcp")
+  --director-database        Director database: "internal" or "external" (external supported when iaas="aws" or "gcp")
+  --director-vm-type         Director vm type, e.g. "m4.xlarge" (not supported when iaas="vsphere")
+  --director-disk-size       Director persistent disk size in gigabytes
+  --disk-encryption-key      Customer-managed key for the disks of the director and its vms: a KMS key ARN, Cloud KMS key name or Key Vault key id (supported when iaas="aws", "gcp" or "azure")
+  --azure-key-vault-id       Resource id of the Key Vault of the disk encryption key, required with --disk-encryption-key (supported when iaas="azure")
+  --jumpbox-vm-type          Jumpbox vm type, e.g. "t2.micro" (not supported when iaas="vsphere")
+  --jumpbox-disk-size        Jumpbox disk size in gigabytes
+  --jumpbox-ssh-key-type     Jumpbox ssh key type: "rsa" or "ed25519", defaults to "rsa"
+  --jumpbox-authorized-key   Additional public key for the jumpbox user, in authorized_keys format
+  --jumpbox-ssh-ca           Let bbl log in to the jumpbox with short-lived certificates signed by an ssh CA
+  --no-jumpbox               Reach the director directly instead of through a jumpbox (bbl must run inside the network, supported when iaas="aws" or "gcp")
+  --vm-types-file            YAML catalog of cloud-config vm types, given as cloud properties per IaaS or as cpu, ram and ephemeral_disk_size
+  --network-ranges-file      YAML file of reserved and static ips, as sizes or ranges, for the cloud-config networks
+  --compilation-vms-preemptible  Compile releases on spot or preemptible vms (supported when iaas="aws" or "gcp")
+  --aws-spot-bid-price       Maximum hourly price in dollars of the spot vm extension, defaults to 1.00 (supported when iaas="aws")
+  --aws-extra-region         Additional AWS region with a peered VPC whose azs the director manages through a cpi config, as region or region=cidr, can be repeated
+  --dual-stack               Add IPv6 to the networks and the cloud config next to IPv4 (supported when iaas="aws" or "gcp")
+  --dns-servers              Comma-separated dns servers for the director, the jumpbox and the cloud config networks
+  --ntp-servers              Comma-separated ntp servers for the director, the jumpbox and the vms the director creates`
+
 	PlanCommandUsage = `Populates a state directory with the latest config without applying it
 
   --iaas                     IAAS to deploy your BOSH director onto: "aws", "azure", "gcp", "vsphere"   env: $BBL_IAAS
@@ -71,7 +95,9 @@ const (
 
 	DestroyCommandUsage = `Tears down BOSH director infrastructure
 
-  [--no-confirm]       Do not ask for confirmation (optional)`
+  [--no-confirm]                    Do not ask for confirmation (optional)
+  [--delete-deployments]            Delete all deployments on the director before tearing it down (optional)
+  [--skip-if-director-unreachable]  Destroy without checking for deployments when the director cannot be reached (optional)`
 
 	CleanupLeftoversCommandUsage = `Cleans up orphaned IAAS resources
 
@@ -91,14 +117,56 @@ const (
 
 	DirectorSSHKeyCommandUsage = "Prints SSH private key for the director."
 
-	SSHCommandUsage = `Opens an SSH connection to the director or the jumpbox.
+	SSHCommandUsage = `Opens an SSH connection to the director, the jumpbox or a BOSH-deployed instance.
+
+  bbl ssh [--jumpbox | --director | <deployment> <instance-group/index>] [--command <command>]
 
   --jumpbox                Open a connection to the jumpbox
   --director               Open a connection to the director
+  --command                Run a command instead of opening an interactive session
+`
+
+	TunnelCommandUsage = `Runs a socks5 proxy to the jumpbox in the background.
+
+  bbl tunnel start|stop|status [--port <port>]
+
+  --port                   Local port for the proxy, defaults to a free port recorded for the environment
+`
+
+	JumpboxCommandUsage = `Manages additional jumpbox users and the jumpbox ssh CA.
+
+  bbl jumpbox users add <name> --public-key <public-key>
+  bbl jumpbox users remove <name>
+  bbl jumpbox users list
+  bbl jumpbox ssh-ca rotate
+
+  --public-key             Public key for the user, in authorized_keys format
+`
+
+	CloudConfigCommandUsage = `Prints the cloud config that bbl up applies to the director.
+
+  --diff                   Show how it differs from the director's current cloud config
+  --validate               Check that the azs, networks, vm types and vm extensions it refers to exist
 `
 
 	RotateCommandUsage = "Rotates SSH key for the jumpbox user."
 
+	DoctorCommandUsage = `Checks local dependencies and connectivity to the jumpbox, director, UAA and CredHub.
+
+  --json                   Print the results as JSON
+`
+
+	BackupDirectorCommandUsage = `Backs up the BOSH director with bbr.
+
+  --artifact-path          Directory to write the backup artifact into (defaults to the current directory)
+`
+
+	RestoreDirectorCommandUsage = `Restores the BOSH director from a bbr backup.
+
+  --artifact-path          Path to a local backup artifact
+  --artifact-name          Name of a backup artifact in the remote state bucket (requires --state-bucket)
+`
+
 	JumpboxAddressCommandUsage = "Prints BOSH jumpbox address"
 
 	DirectorUsernameCommandUsage = "Prints BOSH director username"
@@ -109,17 +177,20 @@ const (
 
 	DirectorCACertCommandUsage = "Prints BOSH director CA certificate"
 
-	PrintEnvCommandUsage = "Prints required BOSH environment variables"
+	PrintEnvCommandUsage = `Prints required BOSH environment variables
+
+  --tunnel                 Use the proxy started by bbl tunnel start instead of a new ssh+socks5 proxy
+`
 
 	LatestErrorCommandUsage = "Prints the output from the latest call to terraform"
 )
 
 func (Up) Usage() string {
-	return fmt.Sprintf("%s%s%s", UpCommandUsage, Credentials, LBUsage)
+	return fmt.Sprintf("%s%s%s%s", UpCommandUsage, Credentials, LBUsage, DirectorUsage)
 }
 
 func (Plan) Usage() string {
-	return fmt.Sprintf("%s%s%s", PlanCommandUsage, Credentials, LBUsage)
+	return fmt.Sprintf("%s%s%s%s", PlanCommandUsage, Credentials, LBUsage, DirectorUsage)
 }
 
 func (Destroy) Usage() string {
@@ -130,6 +201,12 @@ func (Rotate) Usage() string {
 	return fmt.Sprintf("%s%s%s", RotateCommandUsage, requiresCredentials, Credentials)
 }
 
+func (Doctor) Usage() string { return DoctorCommandUsage }
+
+func (BackupDirector) Usage() string { return BackupDirectorCommandUsage }
+
+func (RestoreDirector) Usage() string { return RestoreDirectorCommandUsage }
+
 func (LBs) Usage() string { return LBsCommandUsage }
 
 func (Outputs) Usage() string { return OutputsCommandUsage }
@@ -155,6 +232,12 @@ func (s SSH) Usage() string {
 	return SSHCommandUsage
 }
 
+func (Tunnel) Usage() string { return TunnelCommandUsage }
+
+func (Jumpbox) Usage() string { return JumpboxCommandUsage }
+
+func (CloudConfig) Usage() string { return CloudConfigCommandUsage }
+
 func (s StateQuery) Usage() string {
 	switch s.propertyName {
 	case EnvIDPropertyName:
@@ -2,42 +2,45 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type Destroy struct {
-	plan                     plan
-	logger                   logger
-	boshManager              boshManager
-	stateStore               stateStore
-	stateValidator           stateValidator
-	terraformManager         terraformManager
-	networkDeletionValidator NetworkDeletionValidator
+	plan               plan
+	logger             logger
+	boshManager        boshManager
+	stateStore         stateStore
+	stateValidator     stateValidator
+	terraformManager   terraformManager
+	boshClientProvider deploymentDeleterProvider
 }
 
 type destroyConfig struct {
-	NoConfirm bool
+	deleteDeployments         bool
+	skipIfDirectorUnreachable bool
 }
 
-type NetworkDeletionValidator interface {
-	ValidateSafeToDelete(networkName string, envID string) error
+type deploymentDeleterProvider interface {
+	Client(state storage.State) (bosh.DirectorClient, error)
 }
 
 func NewDestroy(plan plan, logger logger, boshManager boshManager, stateStore stateStore,
 	stateValidator stateValidator, terraformManager terraformManager,
-	networkDeletionValidator NetworkDeletionValidator) Destroy {
+	boshClientProvider deploymentDeleterProvider) Destroy {
 	return Destroy{
-		plan:                     plan,
-		logger:                   logger,
-		boshManager:              boshManager,
-		stateStore:               stateStore,
-		stateValidator:           stateValidator,
-		terraformManager:         terraformManager,
-		networkDeletionValidator: networkDeletionValidator,
+		plan:               plan,
+		logger:             logger,
+		boshManager:        boshManager,
+		stateStore:         stateStore,
+		stateValidator:     stateValidator,
+		terraformManager:   terraformManager,
+		boshClientProvider: boshClientProvider,
 	}
 }
 
@@ -62,39 +65,61 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return err
 	}
 
-	isPaved, _ := d.terraformManager.IsPaved()
-	if !isPaved {
+	config, err := d.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	if !hasReachableDirector(state) {
 		return nil
 	}
 
-	terraformOutputs, err := d.terraformManager.GetOutputs()
+	boshClient, err := d.boshClientProvider.Client(state)
 	if err != nil {
-		return nil
+		return d.directorUnreachable(config, "deployments", err)
 	}
 
-	var networkName string
-	switch state.IAAS {
-	case "gcp":
-		networkName = terraformOutputs.GetString("network_name")
-	case "aws":
-		networkName = terraformOutputs.GetString("vpc_id")
-	case "azure":
-		networkName = terraformOutputs.GetString("bosh_network_name")
+	tasks, err := boshClient.RunningTasks()
+	if err != nil {
+		return d.directorUnreachable(config, "running tasks", err)
 	}
 
-	if networkName == "" {
-		return nil
+	if len(tasks) > 0 {
+		descriptions := []string{}
+		for _, task := range tasks {
+			descriptions = append(descriptions, fmt.Sprintf("%d (%s)", task.ID, task.Description))
+		}
+		return fmt.Errorf("The director is still running tasks: %s. Wait for them to finish or cancel them before destroying.", strings.Join(descriptions, ", "))
 	}
 
-	err = d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
+	deployments, err := boshClient.Deployments()
 	if err != nil {
-		return err
+		return d.directorUnreachable(config, "deployments", err)
+	}
+
+	if len(deployments) > 0 && !config.deleteDeployments {
+		return fmt.Errorf("The director still has deployments: %s. Delete them first or run destroy with --delete-deployments.", strings.Join(deploymentNames(deployments), ", "))
 	}
 
 	return nil
 }
 
+// directorUnreachable fails the check, since a director that cannot be
+// reached may still have deployments, unless the user chose to skip it.
+func (d Destroy) directorUnreachable(config destroyConfig, what string, err error) error {
+	if config.skipIfDirectorUnreachable {
+		d.logger.Println(fmt.Sprintf("Unable to check the director for %s: %s", what, err))
+		return nil
+	}
+	return fmt.Errorf("Unable to check the director for %s: %s. Run destroy with --skip-if-director-unreachable to destroy without checking.", what, err)
+}
+
 func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
+	config, err := d.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
 	proceed := d.logger.Prompt(fmt.Sprintf("Are you sure you want to delete infrastructure for %q? This operation cannot be undone!", state.EnvID))
 	if !proceed {
 		d.logger.Step("exiting")
@@ -107,7 +132,6 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 			LB:   state.LB,
 		}
 
-		var err error
 		state, err = d.plan.InitializePlan(planConfig, state)
 		if err != nil {
 			return fmt.Errorf("Initialize plan during destroy: %s", err)
@@ -131,6 +155,13 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 		return err
 	}
 
+	if config.deleteDeployments && hasReachableDirector(state) {
+		err = d.deleteDeployments(state)
+		if err != nil {
+			return err
+		}
+	}
+
 	state, err = d.deleteBOSH(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerDeleteError:
@@ -189,3 +220,53 @@ func (d Destroy) deleteBOSH(state storage.State, terraformOutputs terraform.Outp
 
 	return state, nil
 }
+
+func (d Destroy) parseFlags(subcommandFlags []string) (destroyConfig, error) {
+	var config destroyConfig
+
+	destroyFlags := flags.New("destroy")
+	destroyFlags.Bool(&config.deleteDeployments, "delete-deployments")
+	destroyFlags.Bool(&config.skipIfDirectorUnreachable, "skip-if-director-unreachable")
+
+	err := destroyFlags.Parse(subcommandFlags)
+	if err != nil {
+		return destroyConfig{}, err
+	}
+
+	return config, nil
+}
+
+func (d Destroy) deleteDeployments(state storage.State) error {
+	boshClient, err := d.boshClientProvider.Client(state)
+	if err != nil {
+		return fmt.Errorf("Create bosh client: %s", err)
+	}
+
+	deployments, err := boshClient.Deployments()
+	if err != nil {
+		return fmt.Errorf("List deployments: %s", err)
+	}
+
+	for _, deployment := range deployments {
+		d.logger.Step("deleting deployment %s", deployment.Name)
+
+		err = boshClient.DeleteDeployment(deployment.Name)
+		if err != nil {
+			return fmt.Errorf("Delete deployment %s: %s", deployment.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func hasReachableDirector(state storage.State) bool {
+	return !state.NoDirector && state.BOSH.DirectorAddress != "" && (state.NoJumpbox || state.Jumpbox.URL != "")
+}
+
+func deploymentNames(deployments []bosh.Deployment) []string {
+	names := []string{}
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return names
+}
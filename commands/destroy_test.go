@@ -7,7 +7,6 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
-	"github.com/cloudfoundry/bosh-bootloader/terraform"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -17,13 +16,14 @@ var _ = Describe("Destroy", func() {
 	var (
 		destroy commands.Destroy
 
-		boshManager              *fakes.BOSHManager
-		logger                   *fakes.Logger
-		plan                     *fakes.Plan
-		stateStore               *fakes.StateStore
-		stateValidator           *fakes.StateValidator
-		terraformManager         *fakes.TerraformManager
-		networkDeletionValidator *fakes.NetworkDeletionValidator
+		boshManager        *fakes.BOSHManager
+		logger             *fakes.Logger
+		plan               *fakes.Plan
+		stateStore         *fakes.StateStore
+		stateValidator     *fakes.StateValidator
+		terraformManager   *fakes.TerraformManager
+		boshClientProvider *fakes.BOSHClientProvider
+		boshClient         *fakes.BOSHClient
 	)
 
 	BeforeEach(func() {
@@ -37,14 +37,16 @@ var _ = Describe("Destroy", func() {
 		plan = &fakes.Plan{}
 		stateStore = &fakes.StateStore{}
 		stateValidator = &fakes.StateValidator{}
-		networkDeletionValidator = &fakes.NetworkDeletionValidator{}
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
 
 		terraformManager = &fakes.TerraformManager{}
 		terraformManager.DestroyCall.Returns.BBLState = storage.State{ID: "some-state-id"}
 		terraformManager.IsPavedCall.Returns.IsPaved = true
 
 		destroy = commands.NewDestroy(plan, logger, boshManager, stateStore,
-			stateValidator, terraformManager, networkDeletionValidator)
+			stateValidator, terraformManager, boshClientProvider)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -93,137 +95,145 @@ var _ = Describe("Destroy", func() {
 			})
 		})
 
-		Context("when the environment is not paved", func() {
-			It("deletes the directory without attempting to destroy bosh or terraform", func() {
-				terraformManager.IsPavedCall.Returns.IsPaved = false
+		Context("when the environment has a director", func() {
+			var state storage.State
 
-				err := destroy.CheckFastFails([]string{}, storage.State{})
-				Expect(err).NotTo(HaveOccurred())
-				Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(0))
+			BeforeEach(func() {
+				state = storage.State{
+					IAAS: "vsphere",
+					Jumpbox: storage.Jumpbox{
+						URL: "some-jumpbox:22",
+					},
+					BOSH: storage.BOSH{
+						DirectorAddress:  "https://10.0.0.6:25555",
+						DirectorUsername: "some-username",
+						DirectorPassword: "some-password",
+						DirectorSSLCA:    "some-ca",
+					},
+				}
 			})
-		})
 
-		Context("when iaas is gcp", func() {
-			var bblState storage.State
+			It("asks the director for deployments and running tasks", func() {
+				err := destroy.CheckFastFails([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
 
-			BeforeEach(func() {
-				terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: map[string]interface{}{
-					"external_ip":        "some-external-ip",
-					"network_name":       "some-network-name",
-					"subnetwork_name":    "some-subnetwork-name",
-					"bosh_open_tag_name": "some-bosh-tag",
-					"internal_tag_name":  "some-internal-tag",
-					"director_address":   "some-director-address",
-				}}
-
-				bblState = storage.State{
-					IAAS:  "gcp",
-					EnvID: "some-env-id",
-				}
-				terraformManager.DestroyCall.Returns.BBLState = bblState
+				Expect(boshClientProvider.ClientCall.Receives.State).To(Equal(state))
+				Expect(boshClient.RunningTasksCall.CallCount).To(Equal(1))
+				Expect(boshClient.DeploymentsCall.CallCount).To(Equal(1))
 			})
 
-			Context("when there is no network name in the state", func() {
-				It("does not attempt to validate whether it is safe to delete the network", func() {
-					terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{}
+			Context("when deployments exist", func() {
+				BeforeEach(func() {
+					boshClient.DeploymentsCall.Returns.Deployments = []bosh.Deployment{
+						{Name: "cf"},
+						{Name: "concourse"},
+					}
+				})
 
-					err := destroy.CheckFastFails([]string{}, bblState)
-					Expect(err).NotTo(HaveOccurred())
+				It("returns an error naming them", func() {
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).To(MatchError("The director still has deployments: cf, concourse. Delete them first or run destroy with --delete-deployments."))
+				})
 
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+				Context("when --delete-deployments is provided", func() {
+					It("does not fast fail", func() {
+						err := destroy.CheckFastFails([]string{"--delete-deployments"}, state)
+						Expect(err).NotTo(HaveOccurred())
+					})
 				})
 			})
 
-			Context("when instances exist in the gcp network", func() {
+			Context("when tasks are running", func() {
 				BeforeEach(func() {
-					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("validation failed")
+					boshClient.RunningTasksCall.Returns.Tasks = []bosh.Task{
+						{ID: 7, Description: "create deployment"},
+					}
 				})
 
 				It("returns an error", func() {
-					err := destroy.CheckFastFails([]string{}, bblState)
-					Expect(err).To(MatchError("validation failed"))
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-network-name"))
+					err := destroy.CheckFastFails([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("The director is still running tasks: 7 (create deployment). Wait for them to finish or cancel them before destroying."))
 				})
 			})
 
-			Context("when terraform output provider fails to get terraform outputs", func() {
-				It("does not fast fail", func() {
-					terraformManager.GetOutputsCall.Returns.Error = errors.New("terraform output provider failed")
+			Context("when the director cannot be reached", func() {
+				BeforeEach(func() {
+					boshClientProvider.ClientCall.Returns.Error = errors.New("start proxy: banana")
+				})
+
+				It("fast fails", func() {
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).To(MatchError("Unable to check the director for deployments: start proxy: banana. Run destroy with --skip-if-director-unreachable to destroy without checking."))
+				})
 
-					err := destroy.CheckFastFails([]string{}, bblState)
+				It("logs a warning and does not fast fail with --skip-if-director-unreachable", func() {
+					err := destroy.CheckFastFails([]string{"--skip-if-director-unreachable"}, state)
 					Expect(err).NotTo(HaveOccurred())
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+
+					Expect(logger.PrintlnCall.Receives.Message).To(Equal("Unable to check the director for deployments: start proxy: banana"))
 				})
 			})
-		})
 
-		Context("when iaas is aws", func() {
-			var state storage.State
+			Context("when listing deployments fails", func() {
+				BeforeEach(func() {
+					boshClient.DeploymentsCall.Returns.Error = errors.New("banana")
+				})
 
-			BeforeEach(func() {
-				state = storage.State{
-					IAAS:  "aws",
-					EnvID: "some-env-id",
-				}
+				It("fast fails", func() {
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).To(MatchError("Unable to check the director for deployments: banana. Run destroy with --skip-if-director-unreachable to destroy without checking."))
+				})
 			})
 
-			Context("if BOSH deployed VMs still exist in the VPC", func() {
+			Context("when listing running tasks fails", func() {
 				BeforeEach(func() {
-					terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
-						Map: map[string]interface{}{"vpc_id": "some-vpc-id"},
-					}
-					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vpc some-vpc-id is not safe to delete")
+					boshClient.RunningTasksCall.Returns.Error = errors.New("banana")
 				})
 
-				It("fails fast", func() {
+				It("fast fails", func() {
 					err := destroy.CheckFastFails([]string{}, state)
-					Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete"))
+					Expect(err).To(MatchError("Unable to check the director for running tasks: banana. Run destroy with --skip-if-director-unreachable to destroy without checking."))
+				})
+
+				It("logs a warning and does not fast fail with --skip-if-director-unreachable", func() {
+					err := destroy.CheckFastFails([]string{"--skip-if-director-unreachable"}, state)
+					Expect(err).NotTo(HaveOccurred())
 
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-vpc-id"))
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.EnvID).To(Equal("some-env-id"))
+					Expect(logger.PrintlnCall.Receives.Message).To(Equal("Unable to check the director for running tasks: banana"))
 				})
 			})
 
-			Context("when terraform manager fails to get outputs", func() {
-				It("does not fast fail", func() {
-					terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to get outputs")
+			Context("when the environment has no jumpbox", func() {
+				It("contacts the director directly", func() {
+					state.NoJumpbox = true
+					state.Jumpbox = storage.Jumpbox{}
 
 					err := destroy.CheckFastFails([]string{}, state)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(1))
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+					Expect(boshClientProvider.ClientCall.CallCount).To(Equal(1))
+					Expect(boshClientProvider.ClientCall.Receives.State.NoJumpbox).To(BeTrue())
 				})
 			})
-		})
 
-		Context("when iaas is azure", func() {
-			It("returns an error while instances exist in the azure network", func() {
-				networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("validation failed")
-				err := destroy.CheckFastFails([]string{}, storage.State{
-					IAAS: "azure",
-				})
-				Expect(err).NotTo(HaveOccurred())
-			})
+			Context("when the environment has no director", func() {
+				It("does not contact the director", func() {
+					state.NoDirector = true
 
-			Context("when instances exist in the azure network", func() {
-				BeforeEach(func() {
-					terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
-						Map: map[string]interface{}{"bosh_network_name": "some-network-id"},
-					}
-					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("validation failed")
-				})
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).NotTo(HaveOccurred())
 
-				It("returns an error", func() {
-					err := destroy.CheckFastFails([]string{}, storage.State{
-						IAAS:  "azure",
-						EnvID: "some-env-id",
-					})
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-network-id"))
-					Expect(err).To(MatchError("validation failed"))
+					Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
 				})
 			})
 		})
+
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := destroy.CheckFastFails([]string{"--banana"}, storage.State{})
+				Expect(err).To(MatchError("flag provided but not defined: -banana"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -311,6 +321,68 @@ var _ = Describe("Destroy", func() {
 			Expect(stateStore.SetCall.Receives[0].State.BOSH).To(Equal(storage.BOSH{}))
 		})
 
+		Context("when --delete-deployments is provided", func() {
+			var state storage.State
+
+			BeforeEach(func() {
+				state = storage.State{
+					Jumpbox: storage.Jumpbox{
+						URL: "some-jumpbox:22",
+					},
+					BOSH: storage.BOSH{
+						DirectorAddress: "https://10.0.0.6:25555",
+					},
+				}
+				boshClient.DeploymentsCall.Returns.Deployments = []bosh.Deployment{
+					{Name: "cf"},
+					{Name: "concourse"},
+				}
+			})
+
+			It("deletes each deployment before deleting the director", func() {
+				err := destroy.Execute([]string{"--delete-deployments"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.StepCall.Messages).To(Equal([]string{
+					"deleting deployment cf",
+					"deleting deployment concourse",
+				}))
+				Expect(boshClient.DeleteDeploymentCall.Receives).To(Equal([]string{"cf", "concourse"}))
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(1))
+			})
+
+			It("does not delete deployments without the flag", func() {
+				err := destroy.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.DeleteDeploymentCall.CallCount).To(Equal(0))
+			})
+
+			Context("when a deployment fails to delete", func() {
+				BeforeEach(func() {
+					boshClient.DeleteDeploymentCall.Returns.Error = errors.New("task 42 error: banana")
+				})
+
+				It("returns an error and leaves the director in place", func() {
+					err := destroy.Execute([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("Delete deployment cf: task 42 error: banana"))
+
+					Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the deployments cannot be listed", func() {
+				BeforeEach(func() {
+					boshClient.DeploymentsCall.Returns.Error = errors.New("banana")
+				})
+
+				It("returns an error", func() {
+					err := destroy.Execute([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("List deployments: banana"))
+				})
+			})
+		})
+
 		Context("when the plan is not initialized", func() {
 			It("initializes the plan", func() {
 				plan.IsInitializedCall.Returns.IsInitialized = false
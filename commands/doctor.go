@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/proxy"
+)
+
+const (
+	DoctorCheckPass = "pass"
+	DoctorCheckFail = "fail"
+	DoctorCheckSkip = "skip"
+	DoctorCheckInfo = "info"
+)
+
+type Doctor struct {
+	logger           logger
+	stateValidator   stateValidator
+	pathFinder       versionPathFinder
+	boshManager      boshManager
+	terraformManager terraformManager
+	sshKeyGetter     sshKeyGetter
+	hostKeyGetter    hostKeyGetter
+	clientProvider   endpointCheckerProvider
+	credhubGetter    credhubGetter
+}
+
+type DoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type versionPathFinder interface {
+	CommandExists(string) bool
+	CommandVersion(string, ...string) (string, error)
+}
+
+type hostKeyGetter interface {
+	Get(username, privateKey, serverURL string) (ssh.PublicKey, error)
+	Pin(serverURL, hostKey string)
+}
+
+type endpointCheckerProvider interface {
+	Dialer(state storage.State) (proxy.Dialer, error)
+	EndpointChecker(dialer proxy.Dialer, caCerts []byte) bosh.EndpointChecker
+}
+
+func NewDoctor(logger logger, stateValidator stateValidator, pathFinder versionPathFinder, boshManager boshManager,
+	terraformManager terraformManager, sshKeyGetter sshKeyGetter, hostKeyGetter hostKeyGetter,
+	clientProvider endpointCheckerProvider, credhubGetter credhubGetter) Doctor {
+	return Doctor{
+		logger:           logger,
+		stateValidator:   stateValidator,
+		pathFinder:       pathFinder,
+		boshManager:      boshManager,
+		terraformManager: terraformManager,
+		sshKeyGetter:     sshKeyGetter,
+		hostKeyGetter:    hostKeyGetter,
+		clientProvider:   clientProvider,
+		credhubGetter:    credhubGetter,
+	}
+}
+
+func (d Doctor) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return d.stateValidator.Validate()
+}
+
+func (d Doctor) Execute(args []string, state storage.State) error {
+	var jsonOutput bool
+	doctorFlags := flags.New("doctor")
+	doctorFlags.Bool(&jsonOutput, "json")
+	err := doctorFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	checks := d.binaryChecks()
+	checks = append(checks, d.environmentChecks(state)...)
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return err // not tested
+		}
+		d.logger.Println(string(output))
+	} else {
+		d.logger.Println(formatDoctorChecks(checks))
+	}
+
+	failures := 0
+	for _, check := range checks {
+		if check.Status == DoctorCheckFail {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("bbl doctor found %d failing check(s)", failures)
+	}
+
+	return nil
+}
+
+func (d Doctor) binaryChecks() []DoctorCheck {
+	checks := []DoctorCheck{}
+
+	boshPath := d.boshManager.Path()
+	if d.pathFinder.CommandExists(boshPath) {
+		version, err := d.boshManager.Version()
+		checks = append(checks, versionCheck("bosh cli", version, err))
+	} else {
+		checks = append(checks, failCheck("bosh cli", fmt.Sprintf("%s was not found on your PATH", boshPath)))
+	}
+
+	version, err := d.terraformManager.Version()
+	checks = append(checks, versionCheck("terraform", version, err))
+
+	if d.pathFinder.CommandExists("ssh") {
+		version, err := d.pathFinder.CommandVersion("ssh", "-V")
+		checks = append(checks, versionCheck("ssh", version, err))
+	} else {
+		checks = append(checks, failCheck("ssh", "ssh was not found on your PATH"))
+	}
+
+	// bbl tunnels in-process, so nc is only needed by bosh ssh through
+	// BOSH_ALL_PROXY and its absence is not a failure.
+	switch {
+	case d.pathFinder.CommandExists("connect-proxy"):
+		checks = append(checks, DoctorCheck{Name: "nc", Status: DoctorCheckPass, Detail: "using connect-proxy"})
+	case d.pathFinder.CommandExists("nc"):
+		checks = append(checks, DoctorCheck{Name: "nc", Status: DoctorCheckPass, Detail: "found on PATH"})
+	default:
+		checks = append(checks, DoctorCheck{Name: "nc", Status: DoctorCheckInfo, Detail: "neither nc nor connect-proxy was found on your PATH, bosh ssh through BOSH_ALL_PROXY needs one of them"})
+	}
+
+	return checks
+}
+
+func (d Doctor) environmentChecks(state storage.State) []DoctorCheck {
+	names := []string{"jumpbox ssh", "socks5 proxy", "director info", "uaa token", "credhub"}
+
+	var checks []DoctorCheck
+	switch {
+	case state.NoJumpbox:
+		checks = skipChecks(names[:2], "environment has no jumpbox")
+	case state.Jumpbox.URL == "":
+		return skipChecks(names, "no jumpbox in bbl state")
+	default:
+		checks = []DoctorCheck{d.jumpboxSSHCheck(state)}
+	}
+
+	dialer, err := d.clientProvider.Dialer(state)
+	if err != nil {
+		checks = append(checks, failCheck("socks5 proxy", err.Error()))
+		return append(checks, skipChecks(names[2:], "socks5 proxy is unavailable")...)
+	}
+	if !state.NoJumpbox {
+		checks = append(checks, DoctorCheck{Name: "socks5 proxy", Status: DoctorCheckPass})
+	}
+
+	if state.NoDirector || state.BOSH.DirectorAddress == "" {
+		return append(checks, skipChecks(names[2:], "no director in bbl state")...)
+	}
+
+	caCerts := state.BOSH.DirectorSSLCA
+	credhubCerts, err := d.credhubGetter.GetCerts()
+	if err == nil {
+		caCerts = fmt.Sprintf("%s\n%s", caCerts, credhubCerts)
+	}
+	checker := d.clientProvider.EndpointChecker(dialer, []byte(caCerts))
+
+	checks = append(checks, endpointCheck("director info", checker.Get(fmt.Sprintf("%s/info", state.BOSH.DirectorAddress))))
+
+	host := strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
+	err = checker.UAAToken(fmt.Sprintf("https://%s:8443", host), state.BOSH.DirectorUsername, state.BOSH.DirectorPassword)
+	checks = append(checks, endpointCheck("uaa token", err))
+
+	credhubServer, err := d.credhubGetter.GetServer()
+	if err != nil {
+		credhubServer = fmt.Sprintf("https://%s:8844", host)
+	}
+	checks = append(checks, endpointCheck("credhub", checker.Get(fmt.Sprintf("%s/info", credhubServer))))
+
+	return checks
+}
+
+// jumpboxSSHCheck logs in with the jumpbox key. Jumpboxes that trust an ssh
+// CA no longer authorize it, and the socks5 proxy check logs in to them with
+// a certificate instead.
+func (d Doctor) jumpboxSSHCheck(state storage.State) DoctorCheck {
+	if state.JumpboxConfig.SSHCA {
+		return DoctorCheck{Name: "jumpbox ssh", Status: DoctorCheckSkip, Detail: "jumpbox only accepts ssh certificates, see socks5 proxy"}
+	}
+
+	privateKey, err := d.sshKeyGetter.Get("jumpbox")
+	if err != nil {
+		return failCheck("jumpbox ssh", fmt.Sprintf("get jumpbox private key: %s", err))
+	}
+
+	d.hostKeyGetter.Pin(state.Jumpbox.URL, state.Jumpbox.HostKey)
+	_, err = d.hostKeyGetter.Get("jumpbox", privateKey, state.Jumpbox.URL)
+	if err != nil {
+		return failCheck("jumpbox ssh", err.Error())
+	}
+
+	return DoctorCheck{Name: "jumpbox ssh", Status: DoctorCheckPass, Detail: state.Jumpbox.URL}
+}
+
+func formatDoctorChecks(checks []DoctorCheck) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "CHECK\tSTATUS\tDETAIL")
+	for _, check := range checks {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", check.Name, check.Status, check.Detail)
+	}
+	writer.Flush()
+
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func versionCheck(name, version string, err error) DoctorCheck {
+	if err != nil {
+		return failCheck(name, fmt.Sprintf("could not determine version: %s", err))
+	}
+	return DoctorCheck{Name: name, Status: DoctorCheckPass, Detail: version}
+}
+
+func endpointCheck(name string, err error) DoctorCheck {
+	if err != nil {
+		return failCheck(name, err.Error())
+	}
+	return DoctorCheck{Name: name, Status: DoctorCheckPass}
+}
+
+func failCheck(name, detail string) DoctorCheck {
+	return DoctorCheck{Name: name, Status: DoctorCheckFail, Detail: detail}
+}
+
+func skipChecks(names []string, detail string) []DoctorCheck {
+	checks := []DoctorCheck{}
+	for _, name := range names {
+		checks = append(checks, DoctorCheck{Name: name, Status: DoctorCheckSkip, Detail: detail})
+	}
+	return checks
+}
@@ -0,0 +1,245 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor", func() {
+	var (
+		doctor           commands.Doctor
+		logger           *fakes.Logger
+		stateValidator   *fakes.StateValidator
+		pathFinder       *fakes.PathFinder
+		boshManager      *fakes.BOSHManager
+		terraformManager *fakes.TerraformManager
+		sshKeyGetter     *fakes.FancySSHKeyGetter
+		hostKeyGetter    *fakes.HostKeyGetter
+		clientProvider   *fakes.BOSHClientProvider
+		endpointChecker  *fakes.EndpointChecker
+		credhubGetter    *fakes.CredhubGetter
+		dialer           *fakes.Dialer
+		state            storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		pathFinder = &fakes.PathFinder{}
+		boshManager = &fakes.BOSHManager{}
+		terraformManager = &fakes.TerraformManager{}
+		sshKeyGetter = &fakes.FancySSHKeyGetter{}
+		hostKeyGetter = &fakes.HostKeyGetter{}
+		clientProvider = &fakes.BOSHClientProvider{}
+		endpointChecker = &fakes.EndpointChecker{}
+		credhubGetter = &fakes.CredhubGetter{}
+		dialer = &fakes.Dialer{}
+
+		pathFinder.CommandExistsCall.Fake = func(command string) bool {
+			return command != "connect-proxy"
+		}
+		pathFinder.CommandVersionCall.Returns.Version = "OpenSSH_7.6p1"
+		boshManager.PathCall.Returns.Path = "bosh"
+		boshManager.VersionCall.Returns.Version = "2.0.48"
+		terraformManager.VersionCall.Returns.Version = "0.11.7"
+		sshKeyGetter.JumpboxGetCall.Returns.PrivateKey = "jumpbox-private-key"
+		clientProvider.DialerCall.Returns.Dialer = dialer
+		clientProvider.EndpointCheckerCall.Returns.EndpointChecker = endpointChecker
+		credhubGetter.GetCertsCall.Returns.Certs = "some-credhub-certs"
+		credhubGetter.GetServerCall.Returns.Server = "https://10.0.0.6:8844"
+
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{
+				URL:     "some-jumpbox:22",
+				HostKey: "ssh-ed25519 some-host-key",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress:  "https://10.0.0.6:25555",
+				DirectorUsername: "some-username",
+				DirectorPassword: "some-password",
+				DirectorSSLCA:    "some-director-ca",
+			},
+		}
+
+		doctor = commands.NewDoctor(logger, stateValidator, pathFinder, boshManager, terraformManager, sshKeyGetter, hostKeyGetter, clientProvider, credhubGetter)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("banana")
+
+			err := doctor.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints a table of passing checks", func() {
+			err := doctor.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Messages).To(ConsistOf(
+				"CHECK          STATUS  DETAIL\n" +
+					"bosh cli       pass    2.0.48\n" +
+					"terraform      pass    0.11.7\n" +
+					"ssh            pass    OpenSSH_7.6p1\n" +
+					"nc             pass    found on PATH\n" +
+					"jumpbox ssh    pass    some-jumpbox:22\n" +
+					"socks5 proxy   pass\n" +
+					"director info  pass\n" +
+					"uaa token      pass\n" +
+					"credhub        pass",
+			))
+		})
+
+		It("checks each endpoint through the jumpbox", func() {
+			err := doctor.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pathFinder.CommandVersionCall.Receives.Command).To(Equal("ssh"))
+			Expect(pathFinder.CommandVersionCall.Receives.VersionArgs).To(Equal([]string{"-V"}))
+
+			Expect(hostKeyGetter.PinCall.Receives.ServerURL).To(Equal("some-jumpbox:22"))
+			Expect(hostKeyGetter.PinCall.Receives.HostKey).To(Equal("ssh-ed25519 some-host-key"))
+			Expect(hostKeyGetter.GetCall.Receives.Username).To(Equal("jumpbox"))
+			Expect(hostKeyGetter.GetCall.Receives.PrivateKey).To(Equal("jumpbox-private-key"))
+			Expect(hostKeyGetter.GetCall.Receives.ServerURL).To(Equal("some-jumpbox:22"))
+
+			Expect(clientProvider.DialerCall.Receives.State).To(Equal(state))
+			Expect(clientProvider.EndpointCheckerCall.Receives.Dialer).To(Equal(dialer))
+			Expect(string(clientProvider.EndpointCheckerCall.Receives.CACerts)).To(Equal("some-director-ca\nsome-credhub-certs"))
+
+			Expect(endpointChecker.GetCall.Receives).To(Equal([]string{
+				"https://10.0.0.6:25555/info",
+				"https://10.0.0.6:8844/info",
+			}))
+			Expect(endpointChecker.UAATokenCall.Receives.UAAAddress).To(Equal("https://10.0.0.6:8443"))
+			Expect(endpointChecker.UAATokenCall.Receives.ClientID).To(Equal("some-username"))
+			Expect(endpointChecker.UAATokenCall.Receives.ClientSecret).To(Equal("some-password"))
+		})
+
+		Context("when --json is provided", func() {
+			It("prints the checks as json", func() {
+				err := doctor.Execute([]string{"--json"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				var checks []commands.DoctorCheck
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &checks)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checks).To(HaveLen(9))
+				Expect(checks[0]).To(Equal(commands.DoctorCheck{Name: "bosh cli", Status: "pass", Detail: "2.0.48"}))
+				Expect(checks[8]).To(Equal(commands.DoctorCheck{Name: "credhub", Status: "pass"}))
+			})
+		})
+
+		Context("when checks fail", func() {
+			BeforeEach(func() {
+				pathFinder.CommandExistsCall.Fake = func(command string) bool {
+					return command == "bosh"
+				}
+				endpointChecker.UAATokenCall.Returns.Error = errors.New("unauthorized")
+			})
+
+			It("reports the failures and returns an error", func() {
+				err := doctor.Execute([]string{"--json"}, state)
+				Expect(err).To(MatchError("bbl doctor found 2 failing check(s)"))
+
+				var checks []commands.DoctorCheck
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &checks)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checks).To(ContainElement(commands.DoctorCheck{Name: "ssh", Status: "fail", Detail: "ssh was not found on your PATH"}))
+				Expect(checks).To(ContainElement(commands.DoctorCheck{Name: "nc", Status: "info", Detail: "neither nc nor connect-proxy was found on your PATH, bosh ssh through BOSH_ALL_PROXY needs one of them"}))
+				Expect(checks).To(ContainElement(commands.DoctorCheck{Name: "uaa token", Status: "fail", Detail: "unauthorized"}))
+			})
+		})
+
+		Context("when the socks5 proxy cannot be started", func() {
+			BeforeEach(func() {
+				clientProvider.DialerCall.Returns.Error = errors.New("ssh dial: timeout")
+			})
+
+			It("skips the director checks", func() {
+				err := doctor.Execute([]string{"--json"}, state)
+				Expect(err).To(MatchError("bbl doctor found 1 failing check(s)"))
+
+				var checks []commands.DoctorCheck
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &checks)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checks[5:]).To(Equal([]commands.DoctorCheck{
+					{Name: "socks5 proxy", Status: "fail", Detail: "ssh dial: timeout"},
+					{Name: "director info", Status: "skip", Detail: "socks5 proxy is unavailable"},
+					{Name: "uaa token", Status: "skip", Detail: "socks5 proxy is unavailable"},
+					{Name: "credhub", Status: "skip", Detail: "socks5 proxy is unavailable"},
+				}))
+				Expect(endpointChecker.GetCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the environment was planned with --no-jumpbox", func() {
+			BeforeEach(func() {
+				state.NoJumpbox = true
+				state.Jumpbox = storage.Jumpbox{}
+			})
+
+			It("skips the jumpbox checks and checks each endpoint directly", func() {
+				err := doctor.Execute([]string{"--json"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				var checks []commands.DoctorCheck
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &checks)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checks[4:]).To(Equal([]commands.DoctorCheck{
+					{Name: "jumpbox ssh", Status: "skip", Detail: "environment has no jumpbox"},
+					{Name: "socks5 proxy", Status: "skip", Detail: "environment has no jumpbox"},
+					{Name: "director info", Status: "pass"},
+					{Name: "uaa token", Status: "pass"},
+					{Name: "credhub", Status: "pass"},
+				}))
+				Expect(hostKeyGetter.GetCall.CallCount).To(Equal(0))
+				Expect(clientProvider.DialerCall.Receives.State.NoJumpbox).To(BeTrue())
+			})
+		})
+
+		Context("when the jumpbox trusts an ssh CA", func() {
+			BeforeEach(func() {
+				state.JumpboxConfig.SSHCA = true
+			})
+
+			It("leaves the login to the socks5 proxy check, which uses a certificate", func() {
+				err := doctor.Execute([]string{"--json"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				var checks []commands.DoctorCheck
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &checks)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(checks[4:6]).To(Equal([]commands.DoctorCheck{
+					{Name: "jumpbox ssh", Status: "skip", Detail: "jumpbox only accepts ssh certificates, see socks5 proxy"},
+					{Name: "socks5 proxy", Status: "pass"},
+				}))
+				Expect(hostKeyGetter.GetCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when there is no jumpbox", func() {
+			It("skips the environment checks", func() {
+				err := doctor.Execute([]string{"--json"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(hostKeyGetter.GetCall.CallCount).To(Equal(0))
+				Expect(clientProvider.DialerCall.CallCount).To(Equal(0))
+			})
+		})
+	})
+})
@@ -21,6 +21,7 @@ type up interface {
 }
 
 type terraformManager interface {
+	Version() (string, error)
 	ValidateVersion() error
 	GetOutputs() (terraform.Outputs, error)
 	Setup(storage.State) error
@@ -81,6 +82,14 @@ type lbArgsHandler interface {
 	Merge(storage.LB, storage.LB) storage.LB
 }
 
+type vmTypesReader interface {
+	Read(path string) ([]storage.VMType, error)
+}
+
+type networkRangesReader interface {
+	Read(path string) ([]storage.NetworkRanges, error)
+}
+
 type createLBsCmd interface {
 	Execute(state storage.State) error
 }
@@ -104,6 +113,7 @@ type cloudConfigManager interface {
 	Initialize(state storage.State) error
 	GenerateVars(state storage.State) error
 	Interpolate() (string, error)
+	Validate(cloudConfig string) ([]string, error)
 	IsPresentCloudConfig() bool
 	IsPresentCloudConfigVars() bool
 }
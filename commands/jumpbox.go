@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+var jumpboxUserNameRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// Users the jumpbox deployment already has, which bbl jumpbox users must not
+// replace.
+var reservedJumpboxUsers = []string{"jumpbox", "root", "vcap"}
+
+type Jumpbox struct {
+	logger           logger
+	stateValidator   stateValidator
+	stateStore       stateStore
+	boshManager      boshManager
+	terraformManager terraformManager
+	hostKeyScanner   hostKeyScanner
+	sshCADeleter     sshKeyDeleter
+}
+
+func NewJumpbox(logger logger, stateValidator stateValidator, stateStore stateStore, boshManager boshManager,
+	terraformManager terraformManager, hostKeyScanner hostKeyScanner, sshCADeleter sshKeyDeleter) Jumpbox {
+	return Jumpbox{
+		logger:           logger,
+		stateValidator:   stateValidator,
+		stateStore:       stateStore,
+		boshManager:      boshManager,
+		terraformManager: terraformManager,
+		hostKeyScanner:   hostKeyScanner,
+		sshCADeleter:     sshCADeleter,
+	}
+}
+
+func (j Jumpbox) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := j.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if state.NoJumpbox {
+		return errors.New("This environment has no jumpbox.")
+	}
+
+	if state.Jumpbox.URL == "" {
+		return errors.New("Invalid bbl state for bbl jumpbox, run bbl up first.")
+	}
+
+	return nil
+}
+
+func (j Jumpbox) Execute(args []string, state storage.State) error {
+	if len(args) == 0 {
+		return errors.New("This command requires a subcommand: users or ssh-ca.")
+	}
+
+	switch args[0] {
+	case "users":
+		return j.users(args[1:], state)
+	case "ssh-ca":
+		return j.sshCA(args[1:], state)
+	}
+
+	return fmt.Errorf("Unknown subcommand %q, use users or ssh-ca.", args[0])
+}
+
+func (j Jumpbox) users(args []string, state storage.State) error {
+	if len(args) == 0 {
+		return errors.New("This command requires a subcommand: add, remove or list.")
+	}
+
+	switch args[0] {
+	case "add":
+		return j.addUser(args[1:], state)
+	case "remove":
+		return j.removeUser(args[1:], state)
+	case "list":
+		return j.listUsers(state)
+	}
+
+	return fmt.Errorf("Unknown subcommand %q, use add, remove or list.", args[0])
+}
+
+func (j Jumpbox) sshCA(args []string, state storage.State) error {
+	if len(args) != 1 || args[0] != "rotate" {
+		return errors.New("This command requires a subcommand: rotate.")
+	}
+
+	if !state.JumpboxConfig.SSHCA {
+		return errors.New("This jumpbox has no ssh CA, run bbl plan --jumpbox-ssh-ca and bbl up to add one.")
+	}
+
+	err := j.sshCADeleter.Delete()
+	if err != nil {
+		return fmt.Errorf("Delete jumpbox ssh CA: %s", err)
+	}
+
+	err = j.updateJumpbox(state)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Println("Rotated the jumpbox ssh CA, certificates signed by the old CA are no longer accepted.")
+	return nil
+}
+
+func (j Jumpbox) addUser(args []string, state storage.State) error {
+	var publicKey string
+	userFlags := flags.New("jumpbox users add")
+	userFlags.String(&publicKey, "public-key", "")
+	positional, err := parseInterspersedArgs(userFlags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("bbl jumpbox users add requires a user name.")
+	}
+	name := positional[0]
+
+	err = validateJumpboxUserName(name)
+	if err != nil {
+		return err
+	}
+
+	if publicKey == "" {
+		return errors.New("--public-key is required.")
+	}
+
+	err = validateAuthorizedKey("--public-key", publicKey)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range state.JumpboxConfig.Users {
+		if user.Name == name {
+			return fmt.Errorf("Jumpbox user %q already exists, remove it first to change its key.", name)
+		}
+	}
+
+	state.JumpboxConfig.Users = append(state.JumpboxConfig.Users, storage.JumpboxUser{
+		Name:      name,
+		PublicKey: strings.TrimSpace(publicKey),
+	})
+
+	err = j.updateJumpbox(state)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Printf("Added jumpbox user %s.\n", name)
+	return nil
+}
+
+func (j Jumpbox) removeUser(args []string, state storage.State) error {
+	if len(args) != 1 {
+		return errors.New("bbl jumpbox users remove requires a user name.")
+	}
+	name := args[0]
+
+	users := []storage.JumpboxUser{}
+	for _, user := range state.JumpboxConfig.Users {
+		if user.Name != name {
+			users = append(users, user)
+		}
+	}
+
+	if len(users) == len(state.JumpboxConfig.Users) {
+		return fmt.Errorf("Jumpbox user %q does not exist.", name)
+	}
+
+	state.JumpboxConfig.Users = users
+	if len(users) == 0 {
+		state.JumpboxConfig.Users = nil
+	}
+
+	err := j.updateJumpbox(state)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Printf("Removed jumpbox user %s.\n", name)
+	return nil
+}
+
+func (j Jumpbox) listUsers(state storage.State) error {
+	if len(state.JumpboxConfig.Users) == 0 {
+		j.logger.Println("No jumpbox users.")
+		return nil
+	}
+
+	for _, user := range state.JumpboxConfig.Users {
+		j.logger.Printf("%s\t%s\n", user.Name, user.PublicKey)
+	}
+	return nil
+}
+
+// updateJumpbox saves the state before running create-env, so a failed
+// deploy can be retried with bbl up.
+func (j Jumpbox) updateJumpbox(state storage.State) error {
+	err := j.stateStore.Set(state)
+	if err != nil {
+		return fmt.Errorf("Save state: %s", err)
+	}
+
+	err = j.boshManager.InitializeJumpbox(state)
+	if err != nil {
+		return fmt.Errorf("Bosh manager initialize jumpbox: %s", err)
+	}
+
+	terraformOutputs, err := j.terraformManager.GetOutputs()
+	if err != nil {
+		return fmt.Errorf("Parse terraform outputs: %s", err)
+	}
+
+	_, err = createJumpbox(j.logger, j.boshManager, j.stateStore, j.hostKeyScanner, state, terraformOutputs)
+	return err
+}
+
+func validateJumpboxUserName(name string) error {
+	for _, reserved := range reservedJumpboxUsers {
+		if name == reserved {
+			return fmt.Errorf("Jumpbox user %q is reserved.", name)
+		}
+	}
+
+	if !jumpboxUserNameRegexp.MatchString(name) {
+		return fmt.Errorf("Jumpbox user %q is not a valid user name, use lowercase letters, digits, _ and -.", name)
+	}
+
+	return nil
+}
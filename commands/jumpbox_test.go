@@ -0,0 +1,272 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+	"golang.org/x/net/proxy"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Jumpbox", func() {
+	var (
+		logger           *fakes.Logger
+		stateValidator   *fakes.StateValidator
+		stateStore       *fakes.StateStore
+		boshManager      *fakes.BOSHManager
+		terraformManager *fakes.TerraformManager
+		hostKeyScanner   *fakes.HostKeyScanner
+		sshCADeleter     *fakes.SSHKeyDeleter
+		jumpbox          commands.Jumpbox
+		state            storage.State
+		alice            storage.JumpboxUser
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		stateStore = &fakes.StateStore{}
+		boshManager = &fakes.BOSHManager{}
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: map[string]interface{}{"some-key": "some-value"}}
+		hostKeyScanner = &fakes.HostKeyScanner{}
+		hostKeyScanner.ScanCall.Returns.HostKeys = map[string]string{"some-jumpbox:22": "ssh-rsa some-host-key"}
+		sshCADeleter = &fakes.SSHKeyDeleter{}
+
+		alice = storage.JumpboxUser{Name: "alice", PublicKey: "ssh-ed25519 AAAA alice@example.com"}
+		state = storage.State{
+			IAAS:          "gcp",
+			Jumpbox:       storage.Jumpbox{URL: "some-jumpbox:22"},
+			JumpboxConfig: storage.JumpboxConfig{Users: []storage.JumpboxUser{alice}},
+		}
+		boshManager.CreateJumpboxCall.Returns.State = storage.State{Jumpbox: storage.Jumpbox{URL: "some-jumpbox:22"}}
+
+		jumpbox = commands.NewJumpbox(logger, stateValidator, stateStore, boshManager, terraformManager, hostKeyScanner, sshCADeleter)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("no state")
+
+			err := jumpbox.CheckFastFails([]string{"users", "list"}, state)
+			Expect(err).To(MatchError("no state"))
+		})
+
+		It("requires a jumpbox", func() {
+			err := jumpbox.CheckFastFails([]string{"users", "list"}, storage.State{})
+			Expect(err).To(MatchError("Invalid bbl state for bbl jumpbox, run bbl up first."))
+
+			err = jumpbox.CheckFastFails([]string{"users", "list"}, storage.State{NoJumpbox: true})
+			Expect(err).To(MatchError("This environment has no jumpbox."))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("requires a subcommand", func() {
+			err := jumpbox.Execute([]string{}, state)
+			Expect(err).To(MatchError("This command requires a subcommand: users or ssh-ca."))
+
+			err = jumpbox.Execute([]string{"groups"}, state)
+			Expect(err).To(MatchError(`Unknown subcommand "groups", use users or ssh-ca.`))
+
+			err = jumpbox.Execute([]string{"users"}, state)
+			Expect(err).To(MatchError("This command requires a subcommand: add, remove or list."))
+
+			err = jumpbox.Execute([]string{"users", "rename"}, state)
+			Expect(err).To(MatchError(`Unknown subcommand "rename", use add, remove or list.`))
+		})
+
+		Context("users add", func() {
+			It("adds the user and updates the jumpbox", func() {
+				err := jumpbox.Execute([]string{"users", "add", "bob", "--public-key", "ssh-rsa BBBB bob@example.com\n"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedUsers := []storage.JumpboxUser{alice, {Name: "bob", PublicKey: "ssh-rsa BBBB bob@example.com"}}
+				Expect(stateStore.SetCall.Receives[0].State.JumpboxConfig.Users).To(Equal(expectedUsers))
+
+				Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(1))
+				Expect(boshManager.InitializeJumpboxCall.Receives.State.JumpboxConfig.Users).To(Equal(expectedUsers))
+
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(1))
+				Expect(boshManager.CreateJumpboxCall.Receives.State.JumpboxConfig.Users).To(Equal(expectedUsers))
+				Expect(boshManager.CreateJumpboxCall.Receives.TerraformOutputs).To(Equal(terraform.Outputs{Map: map[string]interface{}{"some-key": "some-value"}}))
+
+				Expect(hostKeyScanner.ScanCall.Receives.Dialers).To(Equal([]proxy.Dialer{proxy.Direct}))
+				Expect(hostKeyScanner.ScanCall.Receives.Addrs).To(Equal([]string{"some-jumpbox:22"}))
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(3))
+				Expect(stateStore.SetCall.Receives[1].State.Jumpbox).To(Equal(storage.Jumpbox{
+					URL: "some-jumpbox:22",
+				}))
+				Expect(stateStore.SetCall.Receives[2].State.Jumpbox).To(Equal(storage.Jumpbox{
+					URL:     "some-jumpbox:22",
+					HostKey: "ssh-rsa some-host-key",
+				}))
+
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Added jumpbox user bob.\n"))
+			})
+
+			It("accepts the flag before the user name", func() {
+				err := jumpbox.Execute([]string{"users", "add", "--public-key", "ssh-rsa BBBB", "bob"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateStore.SetCall.Receives[0].State.JumpboxConfig.Users).To(ContainElement(storage.JumpboxUser{
+					Name:      "bob",
+					PublicKey: "ssh-rsa BBBB",
+				}))
+			})
+
+			expectAddError := func(args []string, message string) {
+				err := jumpbox.Execute(append([]string{"users", "add"}, args...), state)
+				Expect(err).To(MatchError(message))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(0))
+			}
+
+			It("validates the arguments", func() {
+				expectAddError([]string{"--public-key", "ssh-rsa BBBB"},
+					"bbl jumpbox users add requires a user name.")
+				expectAddError([]string{"bob", "carol", "--public-key", "ssh-rsa BBBB"},
+					"bbl jumpbox users add requires a user name.")
+				expectAddError([]string{"bob"},
+					"--public-key is required.")
+				expectAddError([]string{"bob", "--public-key", "not-a-key"},
+					`--public-key must be a single public key in authorized_keys format, e.g. "ssh-ed25519 AAAA... user@host"`)
+				expectAddError([]string{"Bob", "--public-key", "ssh-rsa BBBB"},
+					`Jumpbox user "Bob" is not a valid user name, use lowercase letters, digits, _ and -.`)
+				expectAddError([]string{"jumpbox", "--public-key", "ssh-rsa BBBB"},
+					`Jumpbox user "jumpbox" is reserved.`)
+				expectAddError([]string{"alice", "--public-key", "ssh-rsa BBBB"},
+					`Jumpbox user "alice" already exists, remove it first to change its key.`)
+			})
+		})
+
+		Context("users remove", func() {
+			It("removes the user and updates the jumpbox", func() {
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateStore.SetCall.Receives[0].State.JumpboxConfig.Users).To(BeNil())
+				Expect(boshManager.InitializeJumpboxCall.Receives.State.JumpboxConfig.Users).To(BeNil())
+				Expect(boshManager.CreateJumpboxCall.Receives.State.JumpboxConfig.Users).To(BeNil())
+				Expect(stateStore.SetCall.Receives[2].State.Jumpbox.HostKey).To(Equal("ssh-rsa some-host-key"))
+
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Removed jumpbox user alice.\n"))
+			})
+
+			It("requires an existing user", func() {
+				err := jumpbox.Execute([]string{"users", "remove", "bob"}, state)
+				Expect(err).To(MatchError(`Jumpbox user "bob" does not exist.`))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+
+				err = jumpbox.Execute([]string{"users", "remove"}, state)
+				Expect(err).To(MatchError("bbl jumpbox users remove requires a user name."))
+			})
+		})
+
+		Context("users list", func() {
+			It("prints the users and their keys", func() {
+				err := jumpbox.Execute([]string{"users", "list"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(Equal([]string{"alice\tssh-ed25519 AAAA alice@example.com\n"}))
+			})
+
+			It("says so when there are no users", func() {
+				err := jumpbox.Execute([]string{"users", "list"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"No jumpbox users."}))
+			})
+		})
+
+		Context("ssh-ca rotate", func() {
+			BeforeEach(func() {
+				state.JumpboxConfig.SSHCA = true
+			})
+
+			It("deletes the CA and updates the jumpbox", func() {
+				err := jumpbox.Execute([]string{"ssh-ca", "rotate"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshCADeleter.DeleteCall.CallCount).To(Equal(1))
+				Expect(boshManager.InitializeJumpboxCall.Receives.State.JumpboxConfig.SSHCA).To(BeTrue())
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(1))
+				Expect(stateStore.SetCall.Receives[2].State.Jumpbox.HostKey).To(Equal("ssh-rsa some-host-key"))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("Rotated the jumpbox ssh CA, certificates signed by the old CA are no longer accepted."))
+			})
+
+			It("requires the rotate subcommand", func() {
+				err := jumpbox.Execute([]string{"ssh-ca"}, state)
+				Expect(err).To(MatchError("This command requires a subcommand: rotate."))
+			})
+
+			It("requires an ssh CA", func() {
+				state.JumpboxConfig.SSHCA = false
+
+				err := jumpbox.Execute([]string{"ssh-ca", "rotate"}, state)
+				Expect(err).To(MatchError("This jumpbox has no ssh CA, run bbl plan --jumpbox-ssh-ca and bbl up to add one."))
+				Expect(sshCADeleter.DeleteCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the CA cannot be deleted", func() {
+				sshCADeleter.DeleteCall.Returns.Error = errors.New("quince")
+
+				err := jumpbox.Execute([]string{"ssh-ca", "rotate"}, state)
+				Expect(err).To(MatchError("Delete jumpbox ssh CA: quince"))
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the state cannot be saved", func() {
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("kiwi")}}
+
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).To(MatchError("Save state: kiwi"))
+			})
+
+			It("returns an error when the jumpbox cannot be planned", func() {
+				boshManager.InitializeJumpboxCall.Returns.Error = errors.New("lime")
+
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).To(MatchError("Bosh manager initialize jumpbox: lime"))
+			})
+
+			It("returns an error when the terraform outputs cannot be read", func() {
+				terraformManager.GetOutputsCall.Returns.Error = errors.New("mango")
+
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).To(MatchError("Parse terraform outputs: mango"))
+			})
+
+			It("saves the state and returns an error when create-env fails", func() {
+				createErr := bosh.NewManagerCreateError(storage.State{Jumpbox: storage.Jumpbox{URL: "some-jumpbox:22"}}, errors.New("papaya"))
+				boshManager.CreateJumpboxCall.Returns.Error = createErr
+
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).To(MatchError("Create jumpbox: papaya"))
+				Expect(stateStore.SetCall.Receives[1].State).To(Equal(createErr.State()))
+			})
+
+			It("warns and keeps the saved jumpbox when the host key cannot be read", func() {
+				hostKeyScanner.ScanCall.Returns.Errors = map[string]error{"some-jumpbox:22": errors.New("guava")}
+
+				err := jumpbox.Execute([]string{"users", "remove", "alice"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(2))
+				Expect(stateStore.SetCall.Receives[1].State.Jumpbox.URL).To(Equal("some-jumpbox:22"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement(
+					"Unable to record the jumpbox host key, connections will not verify it until the next bbl up: guava"))
+			})
+		})
+	})
+})
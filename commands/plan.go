@@ -1,10 +1,17 @@
 package commands
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -13,22 +20,61 @@ type patchDetector interface {
 }
 
 type Plan struct {
-	boshManager        boshManager
-	cloudConfigManager cloudConfigManager
-	stateStore         stateStore
-	patchDetector      patchDetector
-	envIDManager       envIDManager
-	terraformManager   terraformManager
-	lbArgsHandler      lbArgsHandler
-	logger             logger
-	bblVersion         string
+	boshManager         boshManager
+	cloudConfigManager  cloudConfigManager
+	stateStore          stateStore
+	patchDetector       patchDetector
+	envIDManager        envIDManager
+	terraformManager    terraformManager
+	lbArgsHandler       lbArgsHandler
+	vmTypesReader       vmTypesReader
+	networkRangesReader networkRangesReader
+	logger              logger
+	bblVersion          string
 }
 
 type PlanConfig struct {
-	Name string
-	LB   storage.LB
+	Name      string
+	LB        storage.LB
+	Director  storage.Director
+	Jumpbox   storage.JumpboxConfig
+	NoJumpbox bool
+	DualStack bool
+	VMTypes   []storage.VMType
+
+	NetworkRanges       []storage.NetworkRanges
+	DNSServers          []string
+	NTPServers          []string
+	AWSExtraRegions     []string
+	AWSExtraRegionCIDRs map[string]string
+
+	CompilationVMsPreemptible bool
+	SpotBidPrice              float64
+}
+
+var vmTypeFormats = map[string]*regexp.Regexp{
+	"aws":       regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9]+$`),
+	"azure":     regexp.MustCompile(`^(Standard|Basic)_[A-Za-z0-9_]+$`),
+	"gcp":       regexp.MustCompile(`^[a-z][a-z0-9-]*$`),
+	"openstack": regexp.MustCompile(`^\S+$`),
 }
 
+var awsRegionFormat = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-[0-9]+$`)
+
+// awsVPCCIDR is the default vpc_cidr of the director's VPC in
+// terraform/aws/templates/base.tf.
+const awsVPCCIDR = "10.0.0.0/16"
+
+var kmsKeyARNFormat = regexp.MustCompile(`^arn:aws[a-z-]*:kms:([a-z0-9-]+):[0-9]{12}:key/[A-Za-z0-9-]+$`)
+
+var gcpKMSKeyNameFormat = regexp.MustCompile(`^projects/[^/]+/locations/([^/]+)/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+var azureKeyVaultKeyIDFormat = regexp.MustCompile(`^https://([a-z0-9-]+)\.vault\.azure\.net/keys/[^/]+/[A-Za-z0-9]+$`)
+
+var azureKeyVaultIDFormat = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.KeyVault/vaults/([a-z0-9-]+)$`)
+
+var ntpServerFormat = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.:-]*$`)
+
 func NewPlan(boshManager boshManager,
 	cloudConfigManager cloudConfigManager,
 	stateStore stateStore,
@@ -36,19 +82,23 @@ func NewPlan(boshManager boshManager,
 	envIDManager envIDManager,
 	terraformManager terraformManager,
 	lbArgsHandler lbArgsHandler,
+	vmTypesReader vmTypesReader,
+	networkRangesReader networkRangesReader,
 	logger logger,
 	bblVersion string,
 ) Plan {
 	return Plan{
-		boshManager:        boshManager,
-		cloudConfigManager: cloudConfigManager,
-		stateStore:         stateStore,
-		patchDetector:      patchDetector,
-		envIDManager:       envIDManager,
-		terraformManager:   terraformManager,
-		lbArgsHandler:      lbArgsHandler,
-		logger:             logger,
-		bblVersion:         bblVersion,
+		boshManager:         boshManager,
+		cloudConfigManager:  cloudConfigManager,
+		stateStore:          stateStore,
+		patchDetector:       patchDetector,
+		envIDManager:        envIDManager,
+		terraformManager:    terraformManager,
+		lbArgsHandler:       lbArgsHandler,
+		vmTypesReader:       vmTypesReader,
+		networkRangesReader: networkRangesReader,
+		logger:              logger,
+		bblVersion:          bblVersion,
 	}
 }
 
@@ -70,13 +120,33 @@ func (p Plan) CheckFastFails(args []string, state storage.State) error {
 		return fmt.Errorf("The director name cannot be changed for an existing environment. Current name is %s.", state.EnvID)
 	}
 
+	if config.NoJumpbox && !state.Jumpbox.IsEmpty() {
+		return errors.New("The jumpbox cannot be removed from an existing environment.")
+	}
+
+	if !state.BOSH.IsEmpty() {
+		if err := checkDirectorOptionUnchanged("blobstore", config.Director.Blobstore, state.Director.Blobstore); err != nil {
+			return err
+		}
+		if err := checkDirectorOptionUnchanged("database", config.Director.Database, state.Director.Database); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (p Plan) ParseArgs(args []string, state storage.State) (PlanConfig, error) {
 	var (
-		config PlanConfig
-		lbArgs LBArgs
+		config            PlanConfig
+		lbArgs            LBArgs
+		directorDiskSize  string
+		jumpboxDiskSize   string
+		vmTypesFile       string
+		networkRangesFile string
+		dnsServers        string
+		ntpServers        string
+		spotBidPrice      string
 	)
 	planFlags := flags.New("up")
 	planFlags.String(&config.Name, "name", os.Getenv("BBL_ENV_NAME"))
@@ -87,12 +157,112 @@ func (p Plan) ParseArgs(args []string, state storage.State) (PlanConfig, error)
 	if state.IAAS == "aws" {
 		planFlags.String(&lbArgs.ChainPath, "lb-chain", "")
 	}
+	planFlags.String(&config.Director.Blobstore, "director-blobstore", "")
+	planFlags.String(&config.Director.Database, "director-database", "")
+	planFlags.String(&config.Director.VMType, "director-vm-type", "")
+	planFlags.String(&directorDiskSize, "director-disk-size", "")
+	planFlags.String(&config.Director.DiskEncryptionKey, "disk-encryption-key", "")
+	planFlags.String(&config.Director.DiskEncryptionKeyVaultID, "azure-key-vault-id", "")
+	planFlags.String(&config.Jumpbox.VMType, "jumpbox-vm-type", "")
+	planFlags.String(&jumpboxDiskSize, "jumpbox-disk-size", "")
+	planFlags.String(&config.Jumpbox.SSHKeyType, "jumpbox-ssh-key-type", "")
+	planFlags.String(&config.Jumpbox.AuthorizedKey, "jumpbox-authorized-key", "")
+	planFlags.Bool(&config.Jumpbox.SSHCA, "jumpbox-ssh-ca")
+	planFlags.Bool(&config.NoJumpbox, "no-jumpbox")
+	planFlags.Bool(&config.DualStack, "dual-stack")
+	planFlags.Bool(&config.CompilationVMsPreemptible, "compilation-vms-preemptible")
+	planFlags.String(&spotBidPrice, "aws-spot-bid-price", "")
+	planFlags.String(&dnsServers, "dns-servers", "")
+	planFlags.String(&ntpServers, "ntp-servers", "")
+	planFlags.String(&vmTypesFile, "vm-types-file", "")
+	planFlags.String(&networkRangesFile, "network-ranges-file", "")
+	planFlags.StringSlice(&config.AWSExtraRegions, "aws-extra-region")
 
 	err := planFlags.Parse(args)
 	if err != nil {
 		return PlanConfig{}, err
 	}
 
+	if err := validateVMType("director", config.Director.VMType, state.IAAS); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateVMType("jumpbox", config.Jumpbox.VMType, state.IAAS); err != nil {
+		return PlanConfig{}, err
+	}
+	if config.Director.DiskSize, err = parseDiskSize("director", directorDiskSize); err != nil {
+		return PlanConfig{}, err
+	}
+	if config.Jumpbox.DiskSize, err = parseDiskSize("jumpbox", jumpboxDiskSize); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateSSHKeyType(config.Jumpbox.SSHKeyType); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateAuthorizedKey("--jumpbox-authorized-key", config.Jumpbox.AuthorizedKey); err != nil {
+		return PlanConfig{}, err
+	}
+
+	if err := validateDirectorOption("blobstore", config.Director.Blobstore, state.IAAS); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateDirectorOption("database", config.Director.Database, state.IAAS); err != nil {
+		return PlanConfig{}, err
+	}
+
+	if config.AWSExtraRegions, config.AWSExtraRegionCIDRs, err = parseExtraRegions(config.AWSExtraRegions, state); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateDiskEncryptionKey(config.Director.DiskEncryptionKey, state); err != nil {
+		return PlanConfig{}, err
+	}
+	if err := validateKeyVaultID(config.Director.DiskEncryptionKey, config.Director.DiskEncryptionKeyVaultID, state); err != nil {
+		return PlanConfig{}, err
+	}
+	// The kms key only exists in the region of the director, so the
+	// availability zones of the extra regions could not use it.
+	encrypted := config.Director.DiskEncryptionKey != "" || state.Director.DiskEncryptionKey != ""
+	if encrypted && (len(config.AWSExtraRegions) > 0 || len(state.AWS.ExtraRegions) > 0) {
+		return PlanConfig{}, errors.New("--disk-encryption-key is not supported with --aws-extra-region")
+	}
+
+	if config.NoJumpbox && state.IAAS != "aws" && state.IAAS != "gcp" {
+		return PlanConfig{}, errors.New("--no-jumpbox is only supported on aws and gcp")
+	}
+	if config.DualStack && state.IAAS != "aws" && state.IAAS != "gcp" {
+		return PlanConfig{}, errors.New("--dual-stack is only supported on aws and gcp")
+	}
+	if config.CompilationVMsPreemptible && state.IAAS != "aws" && state.IAAS != "gcp" {
+		return PlanConfig{}, errors.New("--compilation-vms-preemptible is only supported on aws and gcp")
+	}
+	if config.SpotBidPrice, err = parseSpotBidPrice(spotBidPrice, state.IAAS); err != nil {
+		return PlanConfig{}, err
+	}
+
+	config.DNSServers, err = parseServers("dns", dnsServers, func(server string) bool {
+		return net.ParseIP(server) != nil
+	}, "an ip address")
+	if err != nil {
+		return PlanConfig{}, err
+	}
+	config.NTPServers, err = parseServers("ntp", ntpServers, ntpServerFormat.MatchString, "a hostname or ip address")
+	if err != nil {
+		return PlanConfig{}, err
+	}
+
+	if vmTypesFile != "" {
+		config.VMTypes, err = p.vmTypesReader.Read(vmTypesFile)
+		if err != nil {
+			return PlanConfig{}, err
+		}
+	}
+
+	if networkRangesFile != "" {
+		config.NetworkRanges, err = p.networkRangesReader.Read(networkRangesFile)
+		if err != nil {
+			return PlanConfig{}, err
+		}
+	}
+
 	if (lbArgs != LBArgs{}) {
 		lbState, err := p.lbArgsHandler.GetLBState(state.IAAS, lbArgs)
 		if err != nil {
@@ -118,6 +288,67 @@ func (p Plan) InitializePlan(config PlanConfig, state storage.State) (storage.St
 	state.BBLVersion = p.bblVersion
 	state.LB = config.LB
 	state.NoDirector = false
+	if config.Director.Blobstore != "" {
+		state.Director.Blobstore = config.Director.Blobstore
+	}
+	if config.Director.Database != "" {
+		state.Director.Database = config.Director.Database
+	}
+	if config.Director.VMType != "" {
+		state.Director.VMType = config.Director.VMType
+	}
+	if config.Director.DiskSize != 0 {
+		state.Director.DiskSize = config.Director.DiskSize
+	}
+	if config.Director.DiskEncryptionKey != "" {
+		state.Director.DiskEncryptionKey = config.Director.DiskEncryptionKey
+	}
+	if config.Director.DiskEncryptionKeyVaultID != "" {
+		state.Director.DiskEncryptionKeyVaultID = config.Director.DiskEncryptionKeyVaultID
+	}
+	if config.NoJumpbox {
+		state.NoJumpbox = true
+	}
+	if config.DualStack {
+		state.Network.DualStack = true
+	}
+	if config.DNSServers != nil {
+		state.Network.DNSServers = config.DNSServers
+	}
+	if config.NTPServers != nil {
+		state.Network.NTPServers = config.NTPServers
+	}
+	if config.Jumpbox.VMType != "" {
+		state.JumpboxConfig.VMType = config.Jumpbox.VMType
+	}
+	if config.Jumpbox.DiskSize != 0 {
+		state.JumpboxConfig.DiskSize = config.Jumpbox.DiskSize
+	}
+	if config.Jumpbox.SSHKeyType != "" {
+		state.JumpboxConfig.SSHKeyType = config.Jumpbox.SSHKeyType
+	}
+	if config.Jumpbox.AuthorizedKey != "" {
+		state.JumpboxConfig.AuthorizedKey = strings.TrimSpace(config.Jumpbox.AuthorizedKey)
+	}
+	if config.Jumpbox.SSHCA {
+		state.JumpboxConfig.SSHCA = true
+	}
+	if config.VMTypes != nil {
+		state.CloudConfig.VMTypes = config.VMTypes
+	}
+	if config.CompilationVMsPreemptible {
+		state.CloudConfig.CompilationVMsPreemptible = true
+	}
+	if config.SpotBidPrice != 0 {
+		state.CloudConfig.SpotBidPrice = config.SpotBidPrice
+	}
+	if config.NetworkRanges != nil {
+		state.CloudConfig.NetworkRanges = config.NetworkRanges
+	}
+	if config.AWSExtraRegions != nil {
+		state.AWS.ExtraRegions = config.AWSExtraRegions
+		state.AWS.ExtraRegionCIDRs = config.AWSExtraRegionCIDRs
+	}
 
 	var err error
 	state, err = p.envIDManager.Sync(state, config.Name)
@@ -138,8 +369,10 @@ func (p Plan) InitializePlan(config PlanConfig, state storage.State) (storage.St
 		return storage.State{}, fmt.Errorf("Cloud config manager initialize: %s", err)
 	}
 
-	if err := p.boshManager.InitializeJumpbox(state); err != nil {
-		return storage.State{}, fmt.Errorf("Bosh manager initialize jumpbox: %s", err)
+	if !state.NoJumpbox {
+		if err := p.boshManager.InitializeJumpbox(state); err != nil {
+			return storage.State{}, fmt.Errorf("Bosh manager initialize jumpbox: %s", err)
+		}
 	}
 
 	if err := p.boshManager.InitializeDirector(state); err != nil {
@@ -157,3 +390,280 @@ func (p Plan) IsInitialized(state storage.State) bool {
 	// If it is older than bbl v5.4.0 with schema 13, we want to re-initialize.
 	return state.Version >= 13
 }
+
+func validateDirectorOption(option, value, iaas string) error {
+	switch value {
+	case "", storage.DirectorInternal:
+		return nil
+	case storage.DirectorExternal:
+		if iaas != "aws" && iaas != "gcp" {
+			return fmt.Errorf("--director-%s external is only supported on aws and gcp", option)
+		}
+		return nil
+	default:
+		return fmt.Errorf("--director-%s must be internal or external", option)
+	}
+}
+
+func checkDirectorOptionUnchanged(option, value, current string) error {
+	if current == "" {
+		current = storage.DirectorInternal
+	}
+	if value != "" && value != current {
+		return fmt.Errorf("The director %s cannot be changed for an existing environment. Current %s is %s.", option, option, current)
+	}
+	return nil
+}
+
+func validateVMType(deployment, vmType, iaas string) error {
+	if vmType == "" {
+		return nil
+	}
+
+	format, ok := vmTypeFormats[iaas]
+	if !ok {
+		return fmt.Errorf("--%s-vm-type is not supported on %s, use an ops file to size the vm", deployment, iaas)
+	}
+
+	if !format.MatchString(vmType) {
+		return fmt.Errorf("--%s-vm-type %q is not a valid %s instance type", deployment, vmType, iaas)
+	}
+
+	return nil
+}
+
+// parseExtraRegions splits each --aws-extra-region into the region and the
+// range of its VPC. Regions passed without a range keep the one they have in
+// the state, and new ones get the first free 10.N.0.0/16, so ranges do not
+// move when regions are added or removed. Ranges may not overlap each other
+// or the director's VPC.
+func parseExtraRegions(values []string, state storage.State) ([]string, map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+
+	if state.IAAS != "aws" {
+		return nil, nil, errors.New("--aws-extra-region is only supported on aws")
+	}
+
+	_, directorCIDR, _ := net.ParseCIDR(awsVPCCIDR)
+	taken := []*net.IPNet{directorCIDR}
+
+	regions := []string{}
+	cidrs := map[string]string{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		region := parts[0]
+
+		if !awsRegionFormat.MatchString(region) {
+			return nil, nil, fmt.Errorf("--aws-extra-region %q is not a valid aws region", region)
+		}
+		if region == state.AWS.Region {
+			return nil, nil, fmt.Errorf("--aws-extra-region %s is the region of the director", region)
+		}
+		if _, ok := cidrs[region]; ok {
+			return nil, nil, fmt.Errorf("--aws-extra-region %s is passed more than once", region)
+		}
+
+		cidr := state.AWS.ExtraRegionCIDRs[region]
+		if len(parts) == 2 {
+			cidr = parts[1]
+		}
+		regions = append(regions, region)
+		cidrs[region] = cidr
+
+		if cidr == "" {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--aws-extra-region %s has an invalid range %q", region, cidr)
+		}
+		if ones, _ := network.Mask.Size(); network.IP.To4() == nil || ones < 16 || ones > 20 {
+			return nil, nil, fmt.Errorf("--aws-extra-region %s needs an IPv4 range from /16 to /20, found %q", region, cidr)
+		}
+		for _, other := range taken {
+			if network.Contains(other.IP) || other.Contains(network.IP) {
+				return nil, nil, fmt.Errorf("--aws-extra-region %s range %s overlaps %s", region, cidr, other)
+			}
+		}
+		taken = append(taken, network)
+	}
+
+	for _, region := range regions {
+		if cidrs[region] != "" {
+			continue
+		}
+
+		for n := 1; n < 256; n++ {
+			_, network, _ := net.ParseCIDR(fmt.Sprintf("10.%d.0.0/16", n))
+
+			free := true
+			for _, other := range taken {
+				if network.Contains(other.IP) || other.Contains(network.IP) {
+					free = false
+					break
+				}
+			}
+
+			if free {
+				cidrs[region] = network.String()
+				taken = append(taken, network)
+				break
+			}
+		}
+	}
+
+	return regions, cidrs, nil
+}
+
+// validateDiskEncryptionKey checks that the key can encrypt disks in the
+// region of the director. EBS volumes can only use a kms key of their own
+// region and persistent disks a cloud kms key of their region or a global
+// one. Azure disk encryption sets need a versioned key vault key id.
+func validateDiskEncryptionKey(key string, state storage.State) error {
+	if key == "" {
+		return nil
+	}
+
+	switch state.IAAS {
+	case "aws":
+		match := kmsKeyARNFormat.FindStringSubmatch(key)
+		if match == nil {
+			return fmt.Errorf("--disk-encryption-key %q is not a kms key arn, e.g. arn:aws:kms:%s:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab", key, state.AWS.Region)
+		}
+		if match[1] != state.AWS.Region {
+			return fmt.Errorf("--disk-encryption-key must be a kms key in %s, the region of the director", state.AWS.Region)
+		}
+	case "gcp":
+		match := gcpKMSKeyNameFormat.FindStringSubmatch(key)
+		if match == nil {
+			return fmt.Errorf("--disk-encryption-key %q is not a cloud kms key name, e.g. projects/some-project/locations/%s/keyRings/some-key-ring/cryptoKeys/some-key", key, state.GCP.Region)
+		}
+		if match[1] != state.GCP.Region && match[1] != "global" {
+			return fmt.Errorf("--disk-encryption-key must be a cloud kms key in %s, the region of the director, or global", state.GCP.Region)
+		}
+	case "azure":
+		if !azureKeyVaultKeyIDFormat.MatchString(key) {
+			return fmt.Errorf("--disk-encryption-key %q is not a versioned key vault key id, e.g. https://some-vault.vault.azure.net/keys/some-key/0123456789abcdef0123456789abcdef", key)
+		}
+	default:
+		return errors.New("--disk-encryption-key is only supported on aws, gcp and azure")
+	}
+
+	return nil
+}
+
+// validateKeyVaultID checks that an Azure disk encryption key comes with the
+// resource id of its vault, which terraform needs to let the disk encryption
+// set use the key.
+func validateKeyVaultID(key, vaultID string, state storage.State) error {
+	if state.IAAS != "azure" {
+		if vaultID != "" {
+			return errors.New("--azure-key-vault-id is only supported on azure")
+		}
+		return nil
+	}
+
+	if key == "" {
+		key = state.Director.DiskEncryptionKey
+	}
+	if vaultID == "" {
+		vaultID = state.Director.DiskEncryptionKeyVaultID
+	}
+
+	switch {
+	case key == "" && vaultID == "":
+		return nil
+	case key == "":
+		return errors.New("--azure-key-vault-id is only used with --disk-encryption-key")
+	case vaultID == "":
+		return errors.New("--disk-encryption-key needs --azure-key-vault-id, the resource id of the key vault of the key")
+	}
+
+	match := azureKeyVaultIDFormat.FindStringSubmatch(vaultID)
+	if match == nil {
+		return fmt.Errorf("--azure-key-vault-id %q is not a key vault resource id, e.g. /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/some-group/providers/Microsoft.KeyVault/vaults/some-vault", vaultID)
+	}
+	if keyMatch := azureKeyVaultKeyIDFormat.FindStringSubmatch(key); keyMatch != nil && !strings.EqualFold(keyMatch[1], match[1]) {
+		return fmt.Errorf("--azure-key-vault-id names the vault %s, but the disk encryption key is in %s", match[1], keyMatch[1])
+	}
+
+	return nil
+}
+
+// parseServers splits a comma-separated --dns-servers or --ntp-servers value.
+func parseServers(kind, value string, valid func(string) bool, description string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	servers := []string{}
+	for _, server := range strings.Split(value, ",") {
+		server = strings.TrimSpace(server)
+		if !valid(server) {
+			return nil, fmt.Errorf("--%s-servers %q is not %s", kind, server, description)
+		}
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+func parseDiskSize(deployment, diskSize string) (int, error) {
+	if diskSize == "" {
+		return 0, nil
+	}
+
+	size, err := strconv.Atoi(diskSize)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("--%s-disk-size must be a positive number of gigabytes", deployment)
+	}
+
+	return size, nil
+}
+
+func parseSpotBidPrice(price, iaas string) (float64, error) {
+	if price == "" {
+		return 0, nil
+	}
+
+	if iaas != "aws" {
+		return 0, errors.New("--aws-spot-bid-price is only supported on aws")
+	}
+
+	bid, err := strconv.ParseFloat(price, 64)
+	if err != nil || bid <= 0 {
+		return 0, errors.New("--aws-spot-bid-price must be a positive number of dollars an hour")
+	}
+
+	return bid, nil
+}
+
+func validateSSHKeyType(keyType string) error {
+	switch keyType {
+	case "", ssh.KeyTypeRSA, ssh.KeyTypeEd25519:
+		return nil
+	}
+
+	return fmt.Errorf("--jumpbox-ssh-key-type %q is not supported, use %q or %q", keyType, ssh.KeyTypeRSA, ssh.KeyTypeEd25519)
+}
+
+// validateAuthorizedKey only checks the shape of the key, so key types that
+// are newer than the ssh library, such as hardware-backed keys, are allowed.
+func validateAuthorizedKey(flag, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	key = strings.TrimSpace(key)
+	fields := strings.Fields(key)
+	if len(fields) >= 2 && !strings.Contains(key, "\n") {
+		if _, err := base64.StdEncoding.DecodeString(fields[1]); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(`%s must be a single public key in authorized_keys format, e.g. "ssh-ed25519 AAAA... user@host"`, flag)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -21,6 +22,8 @@ var _ = Describe("Plan", func() {
 		cloudConfigManager *fakes.CloudConfigManager
 		envIDManager       *fakes.EnvIDManager
 		lbArgsHandler      *fakes.LBArgsHandler
+		vmTypesReader      *fakes.VMTypesReader
+		rangesReader       *fakes.NetworkRangesReader
 		logger             *fakes.Logger
 		stateStore         *fakes.StateStore
 		terraformManager   *fakes.TerraformManager
@@ -33,6 +36,8 @@ var _ = Describe("Plan", func() {
 		cloudConfigManager = &fakes.CloudConfigManager{}
 		envIDManager = &fakes.EnvIDManager{}
 		lbArgsHandler = &fakes.LBArgsHandler{}
+		vmTypesReader = &fakes.VMTypesReader{}
+		rangesReader = &fakes.NetworkRangesReader{}
 		logger = &fakes.Logger{}
 		stateStore = &fakes.StateStore{}
 		terraformManager = &fakes.TerraformManager{}
@@ -49,6 +54,8 @@ var _ = Describe("Plan", func() {
 			envIDManager,
 			terraformManager,
 			lbArgsHandler,
+			vmTypesReader,
+			rangesReader,
 			logger,
 			bblVersion,
 		)
@@ -132,6 +139,298 @@ var _ = Describe("Plan", func() {
 			})
 		})
 
+		Context("when director flags are passed", func() {
+			It("sets the director options on the state", func() {
+				err := command.Execute([]string{
+					"--director-blobstore", "external",
+					"--director-database", "external",
+				}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director).To(Equal(storage.Director{
+					Blobstore: "external",
+					Database:  "external",
+				}))
+			})
+
+			It("keeps the director options already in the state", func() {
+				err := command.Execute([]string{
+					"--director-database", "external",
+				}, storage.State{IAAS: "aws", Director: storage.Director{Blobstore: "external"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director).To(Equal(storage.Director{
+					Blobstore: "external",
+					Database:  "external",
+				}))
+			})
+		})
+
+		Context("when sizing flags are passed", func() {
+			It("sets the director and jumpbox sizes on the state", func() {
+				err := command.Execute([]string{
+					"--director-vm-type", "m4.2xlarge",
+					"--director-disk-size", "100",
+					"--jumpbox-vm-type", "t2.small",
+					"--jumpbox-disk-size", "20",
+				}, storage.State{IAAS: "aws", Director: storage.Director{VMType: "m4.xlarge"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director).To(Equal(storage.Director{
+					VMType:   "m4.2xlarge",
+					DiskSize: 100,
+				}))
+				Expect(envIDManager.SyncCall.Receives.State.JumpboxConfig).To(Equal(storage.JumpboxConfig{
+					VMType:   "t2.small",
+					DiskSize: 20,
+				}))
+			})
+		})
+
+		Context("when jumpbox key flags are passed", func() {
+			It("sets the key type and authorized key on the state", func() {
+				err := command.Execute([]string{
+					"--jumpbox-ssh-key-type", "ed25519",
+					"--jumpbox-authorized-key", "ssh-ed25519 AAAA some-user@some-host\n",
+					"--jumpbox-ssh-ca",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.JumpboxConfig).To(Equal(storage.JumpboxConfig{
+					SSHKeyType:    "ed25519",
+					AuthorizedKey: "ssh-ed25519 AAAA some-user@some-host",
+					SSHCA:         true,
+				}))
+			})
+		})
+
+		Context("when --vm-types-file is passed", func() {
+			It("sets the vm type catalog on the state", func() {
+				vmTypes := []storage.VMType{{Name: "router-small", CPU: 2, RAM: 4096}}
+				vmTypesReader.ReadCall.Returns.VMTypes = vmTypes
+
+				err := command.Execute([]string{"--vm-types-file", "some-catalog.yml"}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(vmTypesReader.ReadCall.Receives.Path).To(Equal("some-catalog.yml"))
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.VMTypes).To(Equal(vmTypes))
+			})
+
+			It("keeps the catalog already in the state when it is not passed", func() {
+				vmTypes := []storage.VMType{{Name: "router-small", CPU: 2, RAM: 4096}}
+
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", CloudConfig: storage.CloudConfig{VMTypes: vmTypes}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(vmTypesReader.ReadCall.CallCount).To(Equal(0))
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.VMTypes).To(Equal(vmTypes))
+			})
+		})
+
+		Context("when --network-ranges-file is passed", func() {
+			It("sets the network ranges on the state", func() {
+				networkRanges := []storage.NetworkRanges{{Network: "default", StaticSize: 200}}
+				rangesReader.ReadCall.Returns.NetworkRanges = networkRanges
+
+				err := command.Execute([]string{"--network-ranges-file", "some-ranges.yml"}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rangesReader.ReadCall.Receives.Path).To(Equal("some-ranges.yml"))
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.NetworkRanges).To(Equal(networkRanges))
+			})
+
+			It("keeps the network ranges already in the state when it is not passed", func() {
+				networkRanges := []storage.NetworkRanges{{Network: "default", StaticSize: 200}}
+
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", CloudConfig: storage.CloudConfig{NetworkRanges: networkRanges}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rangesReader.ReadCall.CallCount).To(Equal(0))
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.NetworkRanges).To(Equal(networkRanges))
+			})
+		})
+
+		Context("when --aws-extra-region is passed", func() {
+			It("sets the extra regions on the state", func() {
+				err := command.Execute([]string{
+					"--aws-extra-region", "us-west-2",
+					"--aws-extra-region", "eu-west-1",
+				}, storage.State{IAAS: "aws", AWS: storage.AWS{Region: "us-east-1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.AWS).To(Equal(storage.AWS{
+					Region:       "us-east-1",
+					ExtraRegions: []string{"us-west-2", "eu-west-1"},
+					ExtraRegionCIDRs: map[string]string{
+						"us-west-2": "10.1.0.0/16",
+						"eu-west-1": "10.2.0.0/16",
+					},
+				}))
+			})
+
+			It("keeps the range of each region already in the state and gives new regions a free one", func() {
+				err := command.Execute([]string{
+					"--aws-extra-region", "eu-west-1",
+					"--aws-extra-region", "ap-south-1",
+				}, storage.State{IAAS: "aws", AWS: storage.AWS{
+					Region:           "us-east-1",
+					ExtraRegions:     []string{"us-west-2", "eu-west-1"},
+					ExtraRegionCIDRs: map[string]string{"us-west-2": "10.1.0.0/16", "eu-west-1": "10.2.0.0/16"},
+				}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.AWS.ExtraRegionCIDRs).To(Equal(map[string]string{
+					"eu-west-1":  "10.2.0.0/16",
+					"ap-south-1": "10.1.0.0/16",
+				}))
+			})
+
+			It("uses the range passed with a region", func() {
+				err := command.Execute([]string{
+					"--aws-extra-region", "us-west-2=172.16.0.0/16",
+					"--aws-extra-region", "eu-west-1",
+				}, storage.State{IAAS: "aws", AWS: storage.AWS{Region: "us-east-1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.AWS.ExtraRegions).To(Equal([]string{"us-west-2", "eu-west-1"}))
+				Expect(envIDManager.SyncCall.Receives.State.AWS.ExtraRegionCIDRs).To(Equal(map[string]string{
+					"us-west-2": "172.16.0.0/16",
+					"eu-west-1": "10.1.0.0/16",
+				}))
+			})
+
+			It("keeps the extra regions already in the state when it is not passed", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", AWS: storage.AWS{ExtraRegions: []string{"us-west-2"}}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.AWS.ExtraRegions).To(Equal([]string{"us-west-2"}))
+			})
+		})
+
+		Context("when --disk-encryption-key is passed", func() {
+			const keyARN = "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+			It("sets the key on the state", func() {
+				err := command.Execute([]string{"--disk-encryption-key", keyARN}, storage.State{IAAS: "aws", AWS: storage.AWS{Region: "us-east-1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director.DiskEncryptionKey).To(Equal(keyARN))
+			})
+
+			It("keeps the key already in the state when it is not passed", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", Director: storage.Director{DiskEncryptionKey: keyARN}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director.DiskEncryptionKey).To(Equal(keyARN))
+			})
+
+			It("sets the azure key vault id on the state", func() {
+				const vaultID = "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/some-vault"
+
+				err := command.Execute([]string{
+					"--disk-encryption-key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd",
+					"--azure-key-vault-id", vaultID,
+				}, storage.State{IAAS: "azure"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Director.DiskEncryptionKeyVaultID).To(Equal(vaultID))
+			})
+		})
+
+		Context("when --dual-stack is passed", func() {
+			It("records it on the state", func() {
+				err := command.Execute([]string{"--dual-stack"}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.DualStack).To(BeTrue())
+			})
+
+			It("keeps dual stack on when it is not passed again", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "gcp", Network: storage.Network{DualStack: true}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.DualStack).To(BeTrue())
+			})
+		})
+
+		Context("when --aws-spot-bid-price is passed", func() {
+			It("sets the spot bid price on the state", func() {
+				err := command.Execute([]string{"--aws-spot-bid-price", "0.25"}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.SpotBidPrice).To(Equal(0.25))
+			})
+
+			It("keeps the spot bid price already in the state when it is not passed", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", CloudConfig: storage.CloudConfig{SpotBidPrice: 0.5}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.SpotBidPrice).To(Equal(0.5))
+			})
+		})
+
+		Context("when --compilation-vms-preemptible is passed", func() {
+			It("records it on the state", func() {
+				err := command.Execute([]string{"--compilation-vms-preemptible"}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.CompilationVMsPreemptible).To(BeTrue())
+			})
+
+			It("keeps preemptible compilation vms when it is not passed again", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "aws", CloudConfig: storage.CloudConfig{CompilationVMsPreemptible: true}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfig.CompilationVMsPreemptible).To(BeTrue())
+			})
+		})
+
+		Context("when --dns-servers and --ntp-servers are passed", func() {
+			It("sets the servers on the state", func() {
+				err := command.Execute([]string{
+					"--dns-servers", "10.0.0.2, 10.0.0.3",
+					"--ntp-servers", "ntp.corp.example.com,10.0.0.4",
+				}, storage.State{IAAS: "vsphere"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network).To(Equal(storage.Network{
+					DNSServers: []string{"10.0.0.2", "10.0.0.3"},
+					NTPServers: []string{"ntp.corp.example.com", "10.0.0.4"},
+				}))
+			})
+
+			It("keeps the servers already in the state when they are not passed", func() {
+				err := command.Execute([]string{}, storage.State{Network: storage.Network{DNSServers: []string{"10.0.0.2"}}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.DNSServers).To(Equal([]string{"10.0.0.2"}))
+			})
+		})
+
+		Context("when --no-jumpbox is passed", func() {
+			It("records it on the state and does not initialize a jumpbox", func() {
+				envIDManager.SyncCall.Returns.State = storage.State{ID: "synced-state-id", NoJumpbox: true}
+
+				err := command.Execute([]string{"--no-jumpbox"}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.NoJumpbox).To(BeTrue())
+				Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(0))
+				Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(1))
+			})
+		})
+
+		Context("when the state has no jumpbox", func() {
+			It("does not initialize a jumpbox", func() {
+				envIDManager.SyncCall.Returns.State = storage.State{ID: "synced-state-id", NoJumpbox: true}
+
+				err := command.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(0))
+			})
+		})
+
 		Describe("failure cases", func() {
 			It("returns an error if state store set fails", func() {
 				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("peach")}}
@@ -246,6 +545,44 @@ var _ = Describe("Plan", func() {
 				})
 			})
 		})
+
+		Context("when bbl-state contains a director", func() {
+			var state storage.State
+
+			BeforeEach(func() {
+				state = storage.State{
+					IAAS: "aws",
+					BOSH: storage.BOSH{DirectorName: "some-director"},
+				}
+			})
+
+			It("returns no error when the director options are unchanged", func() {
+				err := command.CheckFastFails([]string{"--director-blobstore", "internal"}, state)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when the blobstore changes", func() {
+				err := command.CheckFastFails([]string{"--director-blobstore", "external"}, state)
+				Expect(err).To(MatchError("The director blobstore cannot be changed for an existing environment. Current blobstore is internal."))
+			})
+
+			It("returns an error when the database changes", func() {
+				state.Director.Database = "external"
+
+				err := command.CheckFastFails([]string{"--director-database", "internal"}, state)
+				Expect(err).To(MatchError("The director database cannot be changed for an existing environment. Current database is external."))
+			})
+		})
+
+		Context("when bbl-state contains a jumpbox", func() {
+			It("returns an error when --no-jumpbox is passed", func() {
+				err := command.CheckFastFails([]string{"--no-jumpbox"}, storage.State{
+					IAAS:    "aws",
+					Jumpbox: storage.Jumpbox{URL: "some-jumpbox-url"},
+				})
+				Expect(err).To(MatchError("The jumpbox cannot be removed from an existing environment."))
+			})
+		})
 	})
 
 	Describe("ParseArgs", func() {
@@ -336,6 +673,45 @@ var _ = Describe("Plan", func() {
 			})
 		})
 
+		Context("when director flags are passed", func() {
+			It("passes the director options in the config", func() {
+				config, err := command.ParseArgs([]string{
+					"--director-blobstore", "external",
+					"--director-database", "internal",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config.Director).To(Equal(storage.Director{
+					Blobstore: "external",
+					Database:  "internal",
+				}))
+			})
+
+			It("passes the sizing options in the config", func() {
+				config, err := command.ParseArgs([]string{
+					"--director-vm-type", "n1-standard-4",
+					"--director-disk-size", "128",
+					"--jumpbox-vm-type", "g1-small",
+				}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config.Director).To(Equal(storage.Director{
+					VMType:   "n1-standard-4",
+					DiskSize: 128,
+				}))
+				Expect(config.Jumpbox).To(Equal(storage.JumpboxConfig{
+					VMType: "g1-small",
+				}))
+			})
+
+			It("passes the no-jumpbox option in the config", func() {
+				config, err := command.ParseArgs([]string{"--no-jumpbox"}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(config.NoJumpbox).To(BeTrue())
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when undefined flags are passed", func() {
 				It("returns an error", func() {
@@ -343,6 +719,235 @@ var _ = Describe("Plan", func() {
 					Expect(err).To(MatchError("flag provided but not defined: -foo"))
 				})
 			})
+
+			DescribeTable("when an extra region is invalid",
+				func(iaas string, args []string, message string) {
+					_, err := command.ParseArgs(args, storage.State{IAAS: iaas, AWS: storage.AWS{Region: "us-east-1"}})
+					Expect(err).To(MatchError(message))
+				},
+				Entry("not on aws", "gcp", []string{"--aws-extra-region", "us-west-2"},
+					"--aws-extra-region is only supported on aws"),
+				Entry("not a region", "aws", []string{"--aws-extra-region", "us-west-2a"},
+					`--aws-extra-region "us-west-2a" is not a valid aws region`),
+				Entry("the director's region", "aws", []string{"--aws-extra-region", "us-east-1"},
+					"--aws-extra-region us-east-1 is the region of the director"),
+				Entry("passed twice", "aws", []string{"--aws-extra-region", "us-west-2", "--aws-extra-region", "us-west-2=10.5.0.0/16"},
+					"--aws-extra-region us-west-2 is passed more than once"),
+				Entry("an invalid range", "aws", []string{"--aws-extra-region", "us-west-2=10.5.0.0"},
+					`--aws-extra-region us-west-2 has an invalid range "10.5.0.0"`),
+				Entry("a range that is too small", "aws", []string{"--aws-extra-region", "us-west-2=10.5.0.0/24"},
+					`--aws-extra-region us-west-2 needs an IPv4 range from /16 to /20, found "10.5.0.0/24"`),
+				Entry("a range that overlaps the director's vpc", "aws", []string{"--aws-extra-region", "us-west-2=10.0.16.0/20"},
+					"--aws-extra-region us-west-2 range 10.0.16.0/20 overlaps 10.0.0.0/16"),
+				Entry("ranges that overlap each other", "aws", []string{"--aws-extra-region", "us-west-2=10.5.0.0/16", "--aws-extra-region", "eu-west-1=10.5.32.0/20"},
+					"--aws-extra-region eu-west-1 range 10.5.32.0/20 overlaps 10.5.0.0/16"),
+			)
+
+			DescribeTable("when the disk encryption key is invalid",
+				func(iaas, key, message string) {
+					_, err := command.ParseArgs([]string{"--disk-encryption-key", key}, storage.State{
+						IAAS: iaas,
+						AWS:  storage.AWS{Region: "us-east-1"},
+						GCP:  storage.GCP{Region: "us-east1"},
+					})
+					Expect(err).To(MatchError(message))
+				},
+				Entry("an unsupported iaas", "vsphere", "some-key",
+					"--disk-encryption-key is only supported on aws, gcp and azure"),
+				Entry("not a key arn", "aws", "alias/some-key",
+					`--disk-encryption-key "alias/some-key" is not a kms key arn, e.g. arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab`),
+				Entry("a key of another region", "aws", "arn:aws:kms:us-west-2:111122223333:key/1234abcd",
+					"--disk-encryption-key must be a kms key in us-east-1, the region of the director"),
+				Entry("not a cloud kms key name", "gcp", "some-key",
+					`--disk-encryption-key "some-key" is not a cloud kms key name, e.g. projects/some-project/locations/us-east1/keyRings/some-key-ring/cryptoKeys/some-key`),
+				Entry("a cloud kms key of another region", "gcp", "projects/p/locations/europe-west1/keyRings/r/cryptoKeys/k",
+					"--disk-encryption-key must be a cloud kms key in us-east1, the region of the director, or global"),
+				Entry("an unversioned key vault key id", "azure", "https://some-vault.vault.azure.net/keys/some-key",
+					`--disk-encryption-key "https://some-vault.vault.azure.net/keys/some-key" is not a versioned key vault key id, e.g. https://some-vault.vault.azure.net/keys/some-key/0123456789abcdef0123456789abcdef`),
+			)
+
+			DescribeTable("when the disk encryption key is valid",
+				func(iaas, key string) {
+					config, err := command.ParseArgs([]string{"--disk-encryption-key", key}, storage.State{
+						IAAS:     iaas,
+						AWS:      storage.AWS{Region: "us-east-1"},
+						GCP:      storage.GCP{Region: "us-east1"},
+						Director: storage.Director{DiskEncryptionKeyVaultID: "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/some-vault"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config.Director.DiskEncryptionKey).To(Equal(key))
+				},
+				Entry("a kms key arn", "aws", "arn:aws:kms:us-east-1:111122223333:key/1234abcd"),
+				Entry("a cloud kms key of the region", "gcp", "projects/p/locations/us-east1/keyRings/r/cryptoKeys/k"),
+				Entry("a global cloud kms key", "gcp", "projects/p/locations/global/keyRings/r/cryptoKeys/k"),
+				Entry("a versioned key vault key id", "azure", "https://some-vault.vault.azure.net/keys/some-key/0123456789abcdef"),
+			)
+
+			DescribeTable("when the azure key vault id is invalid",
+				func(iaas string, args []string, state storage.State, message string) {
+					state.IAAS = iaas
+
+					_, err := command.ParseArgs(args, state)
+					Expect(err).To(MatchError(message))
+				},
+				Entry("not on azure", "gcp", []string{"--azure-key-vault-id", "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/some-vault"}, storage.State{},
+					"--azure-key-vault-id is only supported on azure"),
+				Entry("missing", "azure", []string{"--disk-encryption-key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd"}, storage.State{},
+					"--disk-encryption-key needs --azure-key-vault-id, the resource id of the key vault of the key"),
+				Entry("missing for a key in the state", "azure", []string{}, storage.State{Director: storage.Director{DiskEncryptionKey: "https://some-vault.vault.azure.net/keys/some-key/0123abcd"}},
+					"--disk-encryption-key needs --azure-key-vault-id, the resource id of the key vault of the key"),
+				Entry("without a key", "azure", []string{"--azure-key-vault-id", "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/some-vault"}, storage.State{},
+					"--azure-key-vault-id is only used with --disk-encryption-key"),
+				Entry("not a resource id", "azure", []string{"--disk-encryption-key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd", "--azure-key-vault-id", "some-vault"}, storage.State{},
+					`--azure-key-vault-id "some-vault" is not a key vault resource id, e.g. /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/some-group/providers/Microsoft.KeyVault/vaults/some-vault`),
+				Entry("another vault than the key's", "azure", []string{"--disk-encryption-key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd", "--azure-key-vault-id", "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/other-vault"}, storage.State{},
+					"--azure-key-vault-id names the vault other-vault, but the disk encryption key is in some-vault"),
+			)
+
+			It("keeps the azure key vault id", func() {
+				config, err := command.ParseArgs([]string{
+					"--disk-encryption-key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd",
+					"--azure-key-vault-id", "/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/Some-Vault",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Director.DiskEncryptionKeyVaultID).To(Equal("/subscriptions/s/resourceGroups/g/providers/Microsoft.KeyVault/vaults/Some-Vault"))
+			})
+
+			DescribeTable("when the disk encryption key is combined with extra regions",
+				func(args []string, state storage.State) {
+					state.IAAS = "aws"
+					state.AWS.Region = "us-east-1"
+
+					_, err := command.ParseArgs(args, state)
+					Expect(err).To(MatchError("--disk-encryption-key is not supported with --aws-extra-region"))
+				},
+				Entry("both passed", []string{"--disk-encryption-key", "arn:aws:kms:us-east-1:111122223333:key/1234abcd", "--aws-extra-region", "us-west-2"},
+					storage.State{}),
+				Entry("a key in the state", []string{"--aws-extra-region", "us-west-2"},
+					storage.State{Director: storage.Director{DiskEncryptionKey: "arn:aws:kms:us-east-1:111122223333:key/1234abcd"}}),
+				Entry("extra regions in the state", []string{"--disk-encryption-key", "arn:aws:kms:us-east-1:111122223333:key/1234abcd"},
+					storage.State{AWS: storage.AWS{ExtraRegions: []string{"us-west-2"}}}),
+			)
+
+			Context("when --no-jumpbox is passed on an unsupported iaas", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--no-jumpbox"}, storage.State{IAAS: "openstack"})
+					Expect(err).To(MatchError("--no-jumpbox is only supported on aws and gcp"))
+				})
+			})
+
+			Context("when --dual-stack is passed on an unsupported iaas", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--dual-stack"}, storage.State{IAAS: "azure"})
+					Expect(err).To(MatchError("--dual-stack is only supported on aws and gcp"))
+				})
+			})
+
+			DescribeTable("when the spot bid price is invalid",
+				func(iaas, price, message string) {
+					_, err := command.ParseArgs([]string{"--aws-spot-bid-price", price}, storage.State{IAAS: iaas})
+					Expect(err).To(MatchError(message))
+				},
+				Entry("not on aws", "gcp", "0.25", "--aws-spot-bid-price is only supported on aws"),
+				Entry("not a number", "aws", "cheap", "--aws-spot-bid-price must be a positive number of dollars an hour"),
+				Entry("not positive", "aws", "0", "--aws-spot-bid-price must be a positive number of dollars an hour"),
+			)
+
+			Context("when --compilation-vms-preemptible is passed on an unsupported iaas", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--compilation-vms-preemptible"}, storage.State{IAAS: "vsphere"})
+					Expect(err).To(MatchError("--compilation-vms-preemptible is only supported on aws and gcp"))
+				})
+			})
+
+			DescribeTable("when a dns or ntp server is invalid",
+				func(args []string, message string) {
+					_, err := command.ParseArgs(args, storage.State{})
+					Expect(err).To(MatchError(message))
+				},
+				Entry("dns server is a hostname", []string{"--dns-servers", "dns.corp.example.com"},
+					`--dns-servers "dns.corp.example.com" is not an ip address`),
+				Entry("empty dns server", []string{"--dns-servers", "10.0.0.2,"},
+					`--dns-servers "" is not an ip address`),
+				Entry("ntp server with a space", []string{"--ntp-servers", "ntp corp"},
+					`--ntp-servers "ntp corp" is not a hostname or ip address`),
+			)
+
+			Context("when the vm types file is invalid", func() {
+				It("returns an error", func() {
+					vmTypesReader.ReadCall.Returns.Error = errors.New("Vm type \"a\" is defined more than once.")
+
+					_, err := command.ParseArgs([]string{"--vm-types-file", "some-catalog.yml"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError("Vm type \"a\" is defined more than once."))
+				})
+			})
+
+			Context("when the network ranges file is invalid", func() {
+				It("returns an error", func() {
+					rangesReader.ReadCall.Returns.Error = errors.New("Network \"a\" needs reserved or static ips.")
+
+					_, err := command.ParseArgs([]string{"--network-ranges-file", "some-ranges.yml"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError("Network \"a\" needs reserved or static ips."))
+				})
+			})
+
+			Context("when a director option is not internal or external", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-blobstore", "s3"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError("--director-blobstore must be internal or external"))
+				})
+			})
+
+			Context("when an external director option is not supported on the iaas", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-database", "external"}, storage.State{IAAS: "azure"})
+					Expect(err).To(MatchError("--director-database external is only supported on aws and gcp"))
+				})
+			})
+
+			Context("when a vm type does not match the iaas", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-vm-type", "n1-standard-4"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError(`--director-vm-type "n1-standard-4" is not a valid aws instance type`))
+
+					_, err = command.ParseArgs([]string{"--jumpbox-vm-type", "m4.large"}, storage.State{IAAS: "azure"})
+					Expect(err).To(MatchError(`--jumpbox-vm-type "m4.large" is not a valid azure instance type`))
+				})
+			})
+
+			Context("when a vm type is passed on vsphere", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--jumpbox-vm-type", "large"}, storage.State{IAAS: "vsphere"})
+					Expect(err).To(MatchError("--jumpbox-vm-type is not supported on vsphere, use an ops file to size the vm"))
+				})
+			})
+
+			Context("when the jumpbox ssh key type is not supported", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--jumpbox-ssh-key-type", "dsa"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError(`--jumpbox-ssh-key-type "dsa" is not supported, use "rsa" or "ed25519"`))
+				})
+			})
+
+			Context("when the jumpbox authorized key is not a public key", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--jumpbox-authorized-key", "/home/user/.ssh/id_ed25519.pub"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError(`--jumpbox-authorized-key must be a single public key in authorized_keys format, e.g. "ssh-ed25519 AAAA... user@host"`))
+
+					_, err = command.ParseArgs([]string{"--jumpbox-authorized-key", "ssh-ed25519 AAAA\nssh-rsa AAAA"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when a disk size is not a positive number", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-disk-size", "64GB"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError("--director-disk-size must be a positive number of gigabytes"))
+
+					_, err = command.ParseArgs([]string{"--jumpbox-disk-size", "0"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError("--jumpbox-disk-size must be a positive number of gigabytes"))
+				})
+			})
 		})
 	})
 
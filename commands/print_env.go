@@ -1,20 +1,25 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 type PrintEnv struct {
-	stateValidator   stateValidator
-	logger           logger
-	stderrLogger     logger
-	allProxyGetter   allProxyGetter
-	terraformManager terraformManager
-	credhubGetter    credhubGetter
-	fs               fs
+	stateValidator    stateValidator
+	logger            logger
+	stderrLogger      logger
+	allProxyGetter    allProxyGetter
+	terraformManager  terraformManager
+	credhubGetter     credhubGetter
+	tunnel            tunnelStatusGetter
+	fs                fs
+	certificateGetter sshCertificateGetter
 }
 
 type envSetter interface {
@@ -44,15 +49,19 @@ func NewPrintEnv(
 	allProxyGetter allProxyGetter,
 	credhubGetter credhubGetter,
 	terraformManager terraformManager,
-	fs fs) PrintEnv {
+	tunnel tunnelStatusGetter,
+	fs fs,
+	certificateGetter sshCertificateGetter) PrintEnv {
 	return PrintEnv{
-		stateValidator:   stateValidator,
-		logger:           logger,
-		stderrLogger:     stderrLogger,
-		allProxyGetter:   allProxyGetter,
-		terraformManager: terraformManager,
-		credhubGetter:    credhubGetter,
-		fs:               fs,
+		stateValidator:    stateValidator,
+		logger:            logger,
+		stderrLogger:      stderrLogger,
+		allProxyGetter:    allProxyGetter,
+		terraformManager:  terraformManager,
+		credhubGetter:     credhubGetter,
+		tunnel:            tunnel,
+		fs:                fs,
+		certificateGetter: certificateGetter,
 	}
 }
 
@@ -66,6 +75,14 @@ func (p PrintEnv) CheckFastFails(subcommandFlags []string, state storage.State)
 }
 
 func (p PrintEnv) Execute(args []string, state storage.State) error {
+	var useTunnel bool
+	printEnvFlags := flags.New("print-env")
+	printEnvFlags.Bool(&useTunnel, "tunnel")
+	err := printEnvFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
 	if state.NoDirector {
 		terraformOutputs, err := p.terraformManager.GetOutputs()
 		if err != nil {
@@ -104,6 +121,46 @@ func (p PrintEnv) Execute(args []string, state storage.State) error {
 		p.stderrLogger.Println("No credhub certs found.")
 	}
 
+	if state.NoJumpbox {
+		return nil
+	}
+
+	privateKey, certificate, err := p.certificateGetter.Get()
+	if err != nil {
+		return fmt.Errorf("Get jumpbox ssh certificate: %s", err)
+	}
+
+	// The bosh and credhub CLIs cannot log in with a certificate, so they
+	// go through bbl tunnel instead.
+	if useTunnel || certificate != "" {
+		status, err := p.tunnel.Status()
+		if err != nil {
+			return fmt.Errorf("Get tunnel status: %s", err)
+		}
+		if !status.Running {
+			return errors.New("The tunnel is not running, start it with bbl tunnel start.")
+		}
+
+		if certificate != "" {
+			dir, err := p.fs.TempDir("", "bosh-jumpbox")
+			if err != nil {
+				return fmt.Errorf("Create temp directory: %s", err)
+			}
+
+			privateKeyPath := filepath.Join(dir, "bosh_jumpbox_private.key")
+			err = writePrivateKey(p.fs, privateKeyPath, privateKey, certificate)
+			if err != nil {
+				return err
+			}
+
+			p.logger.Println(fmt.Sprintf("export JUMPBOX_PRIVATE_KEY=%s", privateKeyPath))
+		}
+
+		p.logger.Println(fmt.Sprintf("export BOSH_ALL_PROXY=socks5://127.0.0.1:%d", status.Port))
+		p.logger.Println(fmt.Sprintf("export CREDHUB_PROXY=socks5://127.0.0.1:%d", status.Port))
+		return nil
+	}
+
 	privateKeyPath, err := p.allProxyGetter.GeneratePrivateKey()
 	if err != nil {
 		return err
@@ -2,9 +2,11 @@ package commands_test
 
 import (
 	"errors"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 	. "github.com/onsi/ginkgo"
@@ -19,7 +21,9 @@ var _ = Describe("PrintEnv", func() {
 		terraformManager *fakes.TerraformManager
 		allProxyGetter   *fakes.AllProxyGetter
 		credhubGetter    *fakes.CredhubGetter
+		tunnelProcess    *fakes.TunnelProcess
 		fileIO           *fakes.FileIO
+		certGetter       *fakes.SSHCertificateGetter
 		printEnv         commands.PrintEnv
 		state            storage.State
 	)
@@ -37,7 +41,9 @@ var _ = Describe("PrintEnv", func() {
 		credhubGetter.GetCertsCall.Returns.Certs = "some-credhub-certs"
 		credhubGetter.GetPasswordCall.Returns.Password = "some-credhub-password"
 
+		tunnelProcess = &fakes.TunnelProcess{}
 		fileIO = &fakes.FileIO{}
+		certGetter = &fakes.SSHCertificateGetter{}
 
 		state = storage.State{
 			BOSH: storage.BOSH{
@@ -51,7 +57,7 @@ var _ = Describe("PrintEnv", func() {
 			},
 		}
 
-		printEnv = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, fileIO)
+		printEnv = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, tunnelProcess, fileIO, certGetter)
 	})
 	Describe("CheckFastFails", func() {
 		Context("when the state does not exist", func() {
@@ -90,6 +96,97 @@ var _ = Describe("PrintEnv", func() {
 			Expect(logger.PrintlnCall.Messages).To(ContainElement(`export BOSH_ALL_PROXY=ipfs://some-domain-with?private_key=the-key-path`))
 		})
 
+		Context("when there is no jumpbox", func() {
+			It("prints the environment without a proxy", func() {
+				state.NoJumpbox = true
+				err := printEnv.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ENVIRONMENT=some-director-address"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_SERVER=some-credhub-server"))
+				for _, message := range logger.PrintlnCall.Messages {
+					Expect(message).NotTo(ContainSubstring("BOSH_ALL_PROXY"))
+					Expect(message).NotTo(ContainSubstring("CREDHUB_PROXY"))
+					Expect(message).NotTo(ContainSubstring("JUMPBOX_PRIVATE_KEY"))
+				}
+			})
+		})
+
+		Context("when --tunnel is passed", func() {
+			It("prints the address of the running tunnel as the proxy", func() {
+				tunnelProcess.StatusCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 1080}
+
+				err := printEnv.Execute([]string{"--tunnel"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ENVIRONMENT=some-director-address"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ALL_PROXY=socks5://127.0.0.1:1080"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_PROXY=socks5://127.0.0.1:1080"))
+				for _, message := range logger.PrintlnCall.Messages {
+					Expect(message).NotTo(ContainSubstring("JUMPBOX_PRIVATE_KEY"))
+				}
+			})
+
+			Context("when the tunnel is not running", func() {
+				It("returns an error", func() {
+					err := printEnv.Execute([]string{"--tunnel"}, state)
+					Expect(err).To(MatchError("The tunnel is not running, start it with bbl tunnel start."))
+				})
+			})
+
+			Context("when the tunnel status cannot be read", func() {
+				It("returns an error", func() {
+					tunnelProcess.StatusCall.Returns.Error = errors.New("fennel")
+
+					err := printEnv.Execute([]string{"--tunnel"}, state)
+					Expect(err).To(MatchError("Get tunnel status: fennel"))
+				})
+			})
+		})
+
+		Context("when the jumpbox trusts an ssh CA", func() {
+			BeforeEach(func() {
+				certGetter.GetCall.Returns.PrivateKey = "short-lived-private-key"
+				certGetter.GetCall.Returns.Certificate = "some-certificate"
+				fileIO.TempDirCall.Returns.Name = "some-temp-dir"
+			})
+
+			It("prints a short-lived key and uses the tunnel as the proxy", func() {
+				tunnelProcess.StatusCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 1080}
+
+				err := printEnv.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+
+				privateKeyPath := filepath.Join("some-temp-dir", "bosh_jumpbox_private.key")
+				Expect(fileIO.WriteFileCall.Receives[0].Filename).To(Equal(privateKeyPath))
+				Expect(fileIO.WriteFileCall.Receives[0].Contents).To(Equal([]byte("short-lived-private-key")))
+				Expect(fileIO.WriteFileCall.Receives[1].Filename).To(Equal(privateKeyPath + "-cert.pub"))
+				Expect(fileIO.WriteFileCall.Receives[1].Contents).To(Equal([]byte("some-certificate")))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export JUMPBOX_PRIVATE_KEY=" + privateKeyPath))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ALL_PROXY=socks5://127.0.0.1:1080"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_PROXY=socks5://127.0.0.1:1080"))
+			})
+
+			It("requires the tunnel", func() {
+				err := printEnv.Execute([]string{}, state)
+				Expect(err).To(MatchError("The tunnel is not running, start it with bbl tunnel start."))
+			})
+
+			It("returns an error when the certificate cannot be issued", func() {
+				certGetter.GetCall.Returns.Error = errors.New("salak")
+
+				err := printEnv.Execute([]string{}, state)
+				Expect(err).To(MatchError("Get jumpbox ssh certificate: salak"))
+			})
+		})
+
 		Context("when there is no director", func() {
 			BeforeEach(func() {
 				terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type RestoreDirector struct {
+	logger         logger
+	stateValidator stateValidator
+	bbr            directorBBR
+	artifactStore  artifactStore
+	fs             backupFs
+}
+
+func NewRestoreDirector(logger logger, stateValidator stateValidator, bbrCLI bbrCLI, sshKeyGetter sshKeyGetter,
+	allProxyGetter allProxyGetter, jumpboxProxy jumpboxProxy, pathFinder pathFinder, artifactStore artifactStore,
+	fs backupFs) RestoreDirector {
+	return RestoreDirector{
+		logger:         logger,
+		stateValidator: stateValidator,
+		bbr: directorBBR{
+			cli:            bbrCLI,
+			sshKeyGetter:   sshKeyGetter,
+			allProxyGetter: allProxyGetter,
+			jumpboxProxy:   jumpboxProxy,
+			pathFinder:     pathFinder,
+			fs:             fs,
+		},
+		artifactStore: artifactStore,
+		fs:            fs,
+	}
+}
+
+func (r RestoreDirector) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := r.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return r.bbr.checkFastFails(state)
+}
+
+func (r RestoreDirector) Execute(args []string, state storage.State) error {
+	var (
+		artifactPath string
+		artifactName string
+	)
+	restoreFlags := flags.New("restore-director")
+	restoreFlags.String(&artifactPath, "artifact-path", "")
+	restoreFlags.String(&artifactName, "artifact-name", "")
+	err := restoreFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if (artifactPath == "") == (artifactName == "") {
+		return errors.New("This command requires exactly one of the --artifact-path or --artifact-name flags.")
+	}
+
+	if artifactName != "" {
+		if !r.artifactStore.IsConfigured() {
+			return errors.New("--artifact-name requires a remote state bucket. Provide --state-bucket or BBL_STATE_BUCKET.")
+		}
+
+		artifactPath, err = r.fs.TempDir("", artifactName)
+		if err != nil {
+			return fmt.Errorf("Create temp directory: %s", err)
+		}
+		defer r.fs.RemoveAll(artifactPath)
+
+		r.logger.Step("downloading director backup %s from the remote state bucket", artifactName)
+		err = r.artifactStore.Download(state, artifactName, artifactPath)
+		if err != nil {
+			return fmt.Errorf("Download director backup: %s", err)
+		}
+	}
+
+	err = r.verifyArtifact(artifactPath)
+	if err != nil {
+		return err
+	}
+
+	proceed := r.logger.Prompt(fmt.Sprintf("Are you sure you want to restore the director for %q from %s? This will overwrite the director's current state!", state.EnvID, artifactPath))
+	if !proceed {
+		r.logger.Step("exiting")
+		return nil
+	}
+
+	r.logger.Step("restoring bosh director")
+	err = r.bbr.run(state, filepath.Dir(artifactPath), "restore", "--artifact-path", artifactPath)
+	if err != nil {
+		return fmt.Errorf("Restore director: %s", err)
+	}
+	r.logger.Step("restored bosh director")
+
+	return nil
+}
+
+func (r RestoreDirector) verifyArtifact(artifactPath string) error {
+	info, err := r.fs.Stat(artifactPath)
+	if err != nil {
+		return fmt.Errorf("Director backup artifact %s could not be read: %s", artifactPath, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("Director backup artifact %s is not a directory.", artifactPath)
+	}
+
+	_, err = r.fs.Stat(filepath.Join(artifactPath, "metadata"))
+	if err != nil {
+		return fmt.Errorf("Director backup artifact %s is missing its bbr metadata file.", artifactPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RestoreDirector", func() {
+	var (
+		restore        commands.RestoreDirector
+		logger         *fakes.Logger
+		stateValidator *fakes.StateValidator
+		bbrCLI         *fakes.BBRCLI
+		sshKeyGetter   *fakes.FancySSHKeyGetter
+		allProxyGetter *fakes.AllProxyGetter
+		jumpboxProxy   *fakes.JumpboxProxy
+		pathFinder     *fakes.PathFinder
+		artifactStore  *fakes.ArtifactStore
+		fileIO         *fakes.FileIO
+		state          storage.State
+		statted        []string
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		bbrCLI = &fakes.BBRCLI{}
+		sshKeyGetter = &fakes.FancySSHKeyGetter{}
+		allProxyGetter = &fakes.AllProxyGetter{}
+		jumpboxProxy = &fakes.JumpboxProxy{}
+		pathFinder = &fakes.PathFinder{}
+		artifactStore = &fakes.ArtifactStore{}
+		fileIO = &fakes.FileIO{}
+
+		pathFinder.CommandExistsCall.Returns.Exists = true
+		logger.PromptCall.Returns.Proceed = true
+
+		state = storage.State{
+			EnvID: "some-env",
+			Jumpbox: storage.Jumpbox{
+				URL: "jumpboxURL:22",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress: "https://10.0.0.6:25555",
+			},
+		}
+
+		statted = []string{}
+		fileIO.StatCall.Fake = func(name string) (os.FileInfo, error) {
+			statted = append(statted, name)
+			return fakes.DirFileInfo{}, nil
+		}
+		fileIO.TempDirCall.Returns.Name = "some-temp-dir"
+		sshKeyGetter.DirectorGetCall.Returns.PrivateKey = "director-private-key"
+		allProxyGetter.BoshAllProxyCall.Returns.URL = "ssh+socks5://some-jumpbox"
+
+		restore = commands.NewRestoreDirector(logger, stateValidator, bbrCLI, sshKeyGetter, allProxyGetter, jumpboxProxy, pathFinder, artifactStore, fileIO)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state and looks for bbr", func() {
+			err := restore.CheckFastFails([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateValidator.ValidateCall.CallCount).To(Equal(1))
+			Expect(pathFinder.CommandExistsCall.Receives.Command).To(Equal("bbr"))
+		})
+
+		Context("when there is no director", func() {
+			It("returns an error", func() {
+				err := restore.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("Invalid bbl state: a bosh director is required to back up or restore."))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("verifies the artifact and runs bbr director restore", func() {
+			err := restore.Execute([]string{"--artifact-path", "/some/backups/10.0.0.6_20180102T000000Z"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statted).To(Equal([]string{
+				"/some/backups/10.0.0.6_20180102T000000Z",
+				"/some/backups/10.0.0.6_20180102T000000Z/metadata",
+			}))
+			Expect(logger.PromptCall.CallCount).To(Equal(1))
+
+			Expect(bbrCLI.RunCall.Receives).To(Equal([]fakes.BBRRunReceive{{
+				WorkingDirectory: "/some/backups",
+				BOSHAllProxy:     "ssh+socks5://some-jumpbox",
+				Args: []string{
+					"director",
+					"--host", "10.0.0.6",
+					"--username", "jumpbox",
+					"--private-key-path", filepath.Join("some-temp-dir", "director-private-key"),
+					"restore",
+					"--artifact-path", "/some/backups/10.0.0.6_20180102T000000Z",
+				},
+			}}))
+		})
+
+		Context("when the user does not confirm", func() {
+			BeforeEach(func() {
+				logger.PromptCall.Returns.Proceed = false
+			})
+
+			It("does not restore", func() {
+				err := restore.Execute([]string{"--artifact-path", "/some/backups/artifact"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbrCLI.RunCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when --artifact-name is provided", func() {
+			BeforeEach(func() {
+				artifactStore.IsConfiguredCall.Returns.IsConfigured = true
+			})
+
+			It("downloads the artifact from the remote state bucket", func() {
+				err := restore.Execute([]string{"--artifact-name", "10.0.0.6_20180102T000000Z"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(artifactStore.DownloadCall.Receives.State).To(Equal(state))
+				Expect(artifactStore.DownloadCall.Receives.Name).To(Equal("10.0.0.6_20180102T000000Z"))
+				Expect(artifactStore.DownloadCall.Receives.DestDir).To(Equal("some-temp-dir"))
+
+				Expect(bbrCLI.RunCall.Receives[0].Args).To(ContainElement("some-temp-dir"))
+			})
+
+			It("removes the downloaded artifact", func() {
+				fileIO.TempDirCall.Returns.Name = "some-artifact-dir"
+
+				err := restore.Execute([]string{"--artifact-name", "10.0.0.6_20180102T000000Z"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.RemoveAllCall.Receives).To(ContainElement(fakes.RemoveAllReceive{Path: "some-artifact-dir"}))
+			})
+
+			It("removes the artifact directory when the download fails", func() {
+				fileIO.TempDirCall.Returns.Name = "some-artifact-dir"
+				artifactStore.DownloadCall.Returns.Error = errors.New("banana")
+
+				err := restore.Execute([]string{"--artifact-name", "10.0.0.6_20180102T000000Z"}, state)
+				Expect(err).To(HaveOccurred())
+
+				Expect(fileIO.RemoveAllCall.Receives).To(Equal([]fakes.RemoveAllReceive{{Path: "some-artifact-dir"}}))
+			})
+
+			Context("when no remote state bucket is configured", func() {
+				BeforeEach(func() {
+					artifactStore.IsConfiguredCall.Returns.IsConfigured = false
+				})
+
+				It("returns an error", func() {
+					err := restore.Execute([]string{"--artifact-name", "some-artifact"}, state)
+					Expect(err).To(MatchError("--artifact-name requires a remote state bucket. Provide --state-bucket or BBL_STATE_BUCKET."))
+				})
+			})
+
+			Context("when the download fails", func() {
+				BeforeEach(func() {
+					artifactStore.DownloadCall.Returns.Error = errors.New("banana")
+				})
+
+				It("returns an error", func() {
+					err := restore.Execute([]string{"--artifact-name", "some-artifact"}, state)
+					Expect(err).To(MatchError("Download director backup: banana"))
+				})
+			})
+		})
+
+		Context("failure cases", func() {
+			It("requires exactly one artifact flag", func() {
+				err := restore.Execute([]string{}, state)
+				Expect(err).To(MatchError("This command requires exactly one of the --artifact-path or --artifact-name flags."))
+
+				err = restore.Execute([]string{"--artifact-path", "a", "--artifact-name", "b"}, state)
+				Expect(err).To(MatchError("This command requires exactly one of the --artifact-path or --artifact-name flags."))
+			})
+
+			It("returns an error when the artifact does not exist", func() {
+				fileIO.StatCall.Fake = func(name string) (os.FileInfo, error) {
+					return nil, errors.New("banana")
+				}
+
+				err := restore.Execute([]string{"--artifact-path", "/some/artifact"}, state)
+				Expect(err).To(MatchError("Director backup artifact /some/artifact could not be read: banana"))
+				Expect(bbrCLI.RunCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the artifact is not a directory", func() {
+				fileIO.StatCall.Fake = func(name string) (os.FileInfo, error) {
+					return fakes.FileInfo{}, nil
+				}
+
+				err := restore.Execute([]string{"--artifact-path", "/some/artifact"}, state)
+				Expect(err).To(MatchError("Director backup artifact /some/artifact is not a directory."))
+			})
+
+			It("returns an error when the artifact has no metadata", func() {
+				fileIO.StatCall.Fake = func(name string) (os.FileInfo, error) {
+					if name == "/some/artifact" {
+						return fakes.DirFileInfo{}, nil
+					}
+					return nil, errors.New("not found")
+				}
+
+				err := restore.Execute([]string{"--artifact-path", "/some/artifact"}, state)
+				Expect(err).To(MatchError("Director backup artifact /some/artifact is missing its bbr metadata file."))
+			})
+
+			It("returns an error when bbr fails", func() {
+				bbrCLI.RunCall.Returns.Error = errors.New("banana")
+
+				err := restore.Execute([]string{"--artifact-path", "/some/artifact"}, state)
+				Expect(err).To(MatchError("Restore director: banana"))
+			})
+		})
+	})
+})
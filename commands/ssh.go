@@ -1,38 +1,49 @@
 package commands
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"os/exec"
+	"net"
 	"path/filepath"
 	"strings"
-	"syscall"
-	"time"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 type SSH struct {
-	cli           sshCLI
-	keyGetter     sshKeyGetter
-	pathFinder    pathFinder
-	tempDirWriter tempDirWriter
-	randomPort    randomPort
+	cli                sshCLI
+	keyGetter          sshKeyGetter
+	tempDirWriter      tempDirWriter
+	tunnelOpener       tunnelOpener
+	boshClientProvider directorClientProvider
+	keyGenerator       sshKeyGenerator
+	certificateGetter  sshCertificateGetter
 }
 
 type sshCLI interface {
 	Run([]string) error
-	Start([]string) (*exec.Cmd, error)
 }
 
-type pathFinder interface {
-	CommandExists(string) bool
+type tunnelOpener interface {
+	Open(state storage.State, target string) (ssh.Tunnel, error)
 }
 
-type randomPort interface {
-	GetPort() (string, error)
+type directorClientProvider interface {
+	Client(state storage.State) (bosh.DirectorClient, error)
+}
+
+type sshCertificateGetter interface {
+	Get() (privateKey string, certificate string, err error)
+}
+
+type sshKeyGenerator interface {
+	Generate() (privateKey string, publicKey string, err error)
 }
 
 type tempDirWriter interface {
@@ -40,17 +51,27 @@ type tempDirWriter interface {
 	fileio.TempDirer
 }
 
-func NewSSH(sshCLI sshCLI, sshKeyGetter sshKeyGetter, pathFinder pathFinder, tempDirWriter tempDirWriter, randomPort randomPort) SSH {
+func NewSSH(sshCLI sshCLI, sshKeyGetter sshKeyGetter, tempDirWriter tempDirWriter, tunnelOpener tunnelOpener,
+	boshClientProvider directorClientProvider, keyGenerator sshKeyGenerator, certificateGetter sshCertificateGetter) SSH {
 	return SSH{
-		cli:           sshCLI,
-		keyGetter:     sshKeyGetter,
-		pathFinder:    pathFinder,
-		tempDirWriter: tempDirWriter,
-		randomPort:    randomPort,
+		cli:                sshCLI,
+		keyGetter:          sshKeyGetter,
+		tempDirWriter:      tempDirWriter,
+		tunnelOpener:       tunnelOpener,
+		boshClientProvider: boshClientProvider,
+		keyGenerator:       keyGenerator,
+		certificateGetter:  certificateGetter,
 	}
 }
 
 func (s SSH) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if state.NoJumpbox {
+		if state.BOSH.DirectorAddress == "" {
+			return errors.New("Invalid bbl state for bbl ssh.")
+		}
+		return nil
+	}
+
 	if len(state.Jumpbox.URL) == 0 {
 		return errors.New("Invalid bbl state for bbl ssh.")
 	}
@@ -62,17 +83,23 @@ func (s SSH) Execute(args []string, state storage.State) error {
 	var (
 		jumpbox  bool
 		director bool
+		command  string
 	)
 	sshFlags := flags.New("ssh")
 	sshFlags.Bool(&jumpbox, "jumpbox")
 	sshFlags.Bool(&director, "director")
-	err := sshFlags.Parse(args)
+	sshFlags.String(&command, "command", "")
+	instance, err := parseInterspersedArgs(sshFlags, args)
 	if err != nil {
 		return err
 	}
 
-	if !jumpbox && !director {
-		return fmt.Errorf("This command requires the --jumpbox or --director flag.")
+	if len(instance) > 0 && (jumpbox || director) {
+		return errors.New("Pass either --jumpbox, --director or a deployment and instance.")
+	}
+
+	if len(instance) == 0 && !jumpbox && !director {
+		return fmt.Errorf("This command requires the --jumpbox or --director flag, or a deployment and instance.")
 	}
 
 	tempDir, err := s.tempDirWriter.TempDir("", "")
@@ -80,27 +107,20 @@ func (s SSH) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Create temp directory: %s", err)
 	}
 
-	jumpboxKey, err := s.keyGetter.Get("jumpbox")
-	if err != nil {
-		return fmt.Errorf("Get jumpbox private key: %s", err)
+	if len(instance) > 0 {
+		return s.sshInstance(tempDir, instance, command, state)
 	}
 
-	jumpboxKeyPath := filepath.Join(tempDir, "jumpbox-private-key")
+	if state.NoJumpbox {
+		if jumpbox {
+			return errors.New("This environment has no jumpbox, use --director to ssh to the director directly.")
+		}
 
-	err = s.tempDirWriter.WriteFile(jumpboxKeyPath, []byte(jumpboxKey), 0600)
-	if err != nil {
-		return fmt.Errorf("Write private key file: %s", err)
+		return s.sshDirectly(tempDir, command, state)
 	}
 
-	jumpboxURL := strings.Split(state.Jumpbox.URL, ":")[0]
-
 	if jumpbox {
-		return s.cli.Run([]string{
-			"-tt",
-			"-o", "ServerAliveInterval=300",
-			fmt.Sprintf("jumpbox@%s", jumpboxURL),
-			"-i", jumpboxKeyPath,
-		})
+		return s.sshJumpbox(tempDir, command, state)
 	}
 
 	directorPrivateKey, err := s.keyGetter.Get("director")
@@ -115,53 +135,254 @@ func (s SSH) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Write private key file: %s", err)
 	}
 
-	port, err := s.randomPort.GetPort()
+	ip := directorIP(state)
+
+	fmt.Println("opening a tunnel through your jumpbox")
+	tunnel, err := s.tunnelOpener.Open(state, net.JoinHostPort(ip, "22"))
+	if err != nil {
+		return fmt.Errorf("Open tunnel to jumpbox: %s", err)
+	}
+	defer tunnel.Close()
+
+	tunnelHost, port, err := net.SplitHostPort(tunnel.Addr())
 	if err != nil {
-		return fmt.Errorf("Open proxy port: %s", err)
+		return fmt.Errorf("Parse tunnel address: %s", err)
 	}
 
-	fmt.Println("checking host key")
-	err = s.cli.Run([]string{
-		"-T",
-		fmt.Sprintf("jumpbox@%s", jumpboxURL),
-		"-i", jumpboxKeyPath,
-		"echo", "host key confirmed",
-	})
+	hostKeyOptions, err := s.hostKeyOptions(tempDir, ip, state.BOSH.DirectorHostKey)
 	if err != nil {
-		return fmt.Errorf("unable to verify host key fingerprint: %s", err)
+		return err
 	}
 
-	fmt.Println("opening a tunnel through your jumpbox")
-	backgroundTunnel, err := s.cli.Start([]string{
-		"-4",
-		"-D", port,
-		"-nNC",
-		fmt.Sprintf("jumpbox@%s", jumpboxURL),
-		"-i", jumpboxKeyPath,
-	})
+	return s.cli.Run(withCommand(command, append(hostKeyOptions,
+		"-o", "ServerAliveInterval=300",
+		"-o", fmt.Sprintf("HostKeyAlias=%s", ip),
+		"-p", port,
+		"-i", directorKeyPath,
+		fmt.Sprintf("jumpbox@%s", tunnelHost),
+	)))
+}
+
+// sshInstance sets up a temporary user on a BOSH-deployed instance through
+// the director and connects to it through the jumpbox.
+func (s SSH) sshInstance(tempDir string, instance []string, command string, state storage.State) (err error) {
+	if len(instance) != 2 || !strings.Contains(instance[1], "/") {
+		return errors.New("bbl ssh requires a deployment and an instance, e.g. bbl ssh cf router/0.")
+	}
+
+	deployment := instance[0]
+	slug := strings.SplitN(instance[1], "/", 2)
+	target := bosh.SSHTarget{Job: slug[0], IDs: []string{slug[1]}}
+
+	boshClient, err := s.boshClientProvider.Client(state)
 	if err != nil {
-		return fmt.Errorf("Open tunnel to jumpbox: %s", err)
+		return fmt.Errorf("Create director client: %s", err)
+	}
+
+	privateKey, publicKey, err := s.keyGenerator.Generate()
+	if err != nil {
+		return fmt.Errorf("Generate ssh key: %s", err)
+	}
+
+	keyPath := filepath.Join(tempDir, "instance-private-key")
+
+	err = s.tempDirWriter.WriteFile(keyPath, []byte(privateKey), 0600)
+	if err != nil {
+		return fmt.Errorf("Write private key file: %s", err)
+	}
+
+	user, err := randomSSHUser()
+	if err != nil {
+		return fmt.Errorf("Generate ssh user: %s", err) // not tested
+	}
+
+	hosts, err := boshClient.SetupSSH(deployment, target, user, publicKey)
+	if err != nil {
+		return fmt.Errorf("Set up ssh on %s/%s: %s", deployment, instance[1], err)
 	}
 	defer func() {
-		if backgroundTunnel != nil {
-			backgroundTunnel.Process.Signal(syscall.SIGINT)
-		} // removing this will break the acceptance test
+		cleanupErr := boshClient.CleanupSSH(deployment, target, user)
+		if cleanupErr != nil && err == nil {
+			err = fmt.Errorf("Clean up ssh on %s/%s: %s", deployment, instance[1], cleanupErr)
+		}
 	}()
 
-	proxyCommandPrefix := "nc -x"
-	if s.pathFinder.CommandExists("connect-proxy") {
-		proxyCommandPrefix = "connect-proxy -S"
+	if len(hosts) == 0 {
+		return fmt.Errorf("No instance %s found in deployment %s.", instance[1], deployment)
 	}
+	host := hosts[0]
 
-	ip := strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
+	tunnel, err := s.tunnelOpener.Open(state, net.JoinHostPort(host.IP, "22"))
+	if err != nil {
+		return fmt.Errorf("Open tunnel to jumpbox: %s", err)
+	}
+	defer tunnel.Close()
 
-	time.Sleep(2 * time.Second) // make sure we give that tunnel a moment to open
-	return s.cli.Run([]string{
-		"-tt",
-		"-o", "StrictHostKeyChecking=no",
+	tunnelHost, port, err := net.SplitHostPort(tunnel.Addr())
+	if err != nil {
+		return fmt.Errorf("Parse tunnel address: %s", err)
+	}
+
+	// The director reports the instance host key, so it can be verified
+	// without trusting it on first use.
+	hostKeyOptions, err := s.hostKeyOptions(tempDir, host.IP, host.HostPublicKey)
+	if err != nil {
+		return err
+	}
+
+	return s.cli.Run(withCommand(command, append(hostKeyOptions,
+		"-o", "ServerAliveInterval=300",
+		"-o", fmt.Sprintf("HostKeyAlias=%s", host.IP),
+		"-p", port,
+		"-i", keyPath,
+		fmt.Sprintf("%s@%s", user, tunnelHost),
+	)))
+}
+
+func (s SSH) sshJumpbox(tempDir, command string, state storage.State) error {
+	jumpboxKey, certificate, err := s.certificateGetter.Get()
+	if err != nil {
+		return fmt.Errorf("Get jumpbox ssh certificate: %s", err)
+	}
+
+	if certificate == "" {
+		jumpboxKey, err = s.keyGetter.Get("jumpbox")
+		if err != nil {
+			return fmt.Errorf("Get jumpbox private key: %s", err)
+		}
+	}
+
+	jumpboxKeyPath := filepath.Join(tempDir, "jumpbox-private-key")
+
+	err = writePrivateKey(s.tempDirWriter, jumpboxKeyPath, jumpboxKey, certificate)
+	if err != nil {
+		return err
+	}
+
+	jumpboxURL := strings.Split(state.Jumpbox.URL, ":")[0]
+
+	var hostKeyOptions []string
+	if state.Jumpbox.HostKey != "" {
+		hostKeyOptions, err = s.hostKeyOptions(tempDir, jumpboxURL, state.Jumpbox.HostKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.cli.Run(withCommand(command, append(hostKeyOptions,
+		"-o", "ServerAliveInterval=300",
+		fmt.Sprintf("jumpbox@%s", jumpboxURL),
+		"-i", jumpboxKeyPath,
+	)))
+}
+
+func (s SSH) sshDirectly(tempDir, command string, state storage.State) error {
+	directorPrivateKey, err := s.keyGetter.Get("director")
+	if err != nil {
+		return fmt.Errorf("Get director private key: %s", err)
+	}
+
+	directorKeyPath := filepath.Join(tempDir, "director-private-key")
+
+	err = s.tempDirWriter.WriteFile(directorKeyPath, []byte(directorPrivateKey), 0600)
+	if err != nil {
+		return fmt.Errorf("Write private key file: %s", err)
+	}
+
+	ip := directorIP(state)
+
+	hostKeyOptions, err := s.hostKeyOptions(tempDir, ip, state.BOSH.DirectorHostKey)
+	if err != nil {
+		return err
+	}
+
+	return s.cli.Run(withCommand(command, append(hostKeyOptions,
 		"-o", "ServerAliveInterval=300",
-		"-o", fmt.Sprintf("ProxyCommand=%s localhost:%s %%h %%p", proxyCommandPrefix, port),
 		"-i", directorKeyPath,
 		fmt.Sprintf("jumpbox@%s", ip),
-	})
+	)))
+}
+
+// writePrivateKey writes a private key for ssh -i, and its certificate, if
+// it has one, next to it where ssh looks for it.
+func writePrivateKey(writer fileio.FileWriter, keyPath, privateKey, certificate string) error {
+	err := writer.WriteFile(keyPath, []byte(privateKey), 0600)
+	if err != nil {
+		return fmt.Errorf("Write private key file: %s", err)
+	}
+
+	if certificate == "" {
+		return nil
+	}
+
+	err = writer.WriteFile(keyPath+"-cert.pub", []byte(certificate), 0600)
+	if err != nil {
+		return fmt.Errorf("Write certificate file: %s", err)
+	}
+
+	return nil
+}
+
+// hostKeyOptions writes a known_hosts file with the host key recorded for
+// host, so ssh refuses to connect if it presents any other key. Hosts
+// without a recorded key are not checked.
+func (s SSH) hostKeyOptions(tempDir, host, hostKey string) ([]string, error) {
+	if hostKey == "" {
+		return []string{"-o", "StrictHostKeyChecking=no"}, nil
+	}
+
+	knownHostsPath := filepath.Join(tempDir, "known_hosts")
+
+	err := s.tempDirWriter.WriteFile(knownHostsPath, []byte(fmt.Sprintf("%s %s\n", host, strings.TrimSpace(hostKey))), 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Write known hosts file: %s", err)
+	}
+
+	return []string{
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", fmt.Sprintf("UserKnownHostsFile=%s", knownHostsPath),
+	}, nil
+}
+
+// parseInterspersedArgs parses flags given before, between or after the
+// positional arguments, and returns the positional ones.
+func parseInterspersedArgs(sshFlags flags.Flags, args []string) ([]string, error) {
+	var positional []string
+	for {
+		err := sshFlags.Parse(args)
+		if err != nil {
+			return nil, err
+		}
+
+		args = sshFlags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// withCommand requests a tty for an interactive session, or runs the given
+// command without one.
+func withCommand(command string, args []string) []string {
+	if command == "" {
+		return append([]string{"-tt"}, args...)
+	}
+	return append(args, command)
+}
+
+func randomSSHUser() (string, error) {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("bbl_%s", hex.EncodeToString(suffix)), nil
+}
+
+func directorIP(state storage.State) string {
+	return strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
 }
@@ -38,7 +38,16 @@ func NewDirectorSSHKey(logger logger, stateValidator stateValidator, sshKeyGette
 }
 
 func (s SSHKey) CheckFastFails(subcommandFlags []string, state storage.State) error {
-	return s.stateValidator.Validate()
+	err := s.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if !s.Director && state.JumpboxConfig.SSHCA {
+		return errors.New("The jumpbox only accepts ssh certificates, use bbl ssh --jumpbox or bbl print-env to reach it.")
+	}
+
+	return nil
 }
 
 func (s SSHKey) Execute(subcommandFlags []string, state storage.State) error {
@@ -43,6 +43,24 @@ var _ = Describe("SSHKey", func() {
 				Expect(err).To(MatchError("state validator failed"))
 			})
 		})
+
+		Context("when the jumpbox trusts an ssh CA", func() {
+			BeforeEach(func() {
+				incomingState.JumpboxConfig.SSHCA = true
+			})
+
+			It("returns an error, since the jumpbox key is no longer authorized", func() {
+				err := sshKeyCommand.CheckFastFails([]string{}, incomingState)
+				Expect(err).To(MatchError("The jumpbox only accepts ssh certificates, use bbl ssh --jumpbox or bbl print-env to reach it."))
+			})
+
+			It("still prints the director key", func() {
+				sshKeyCommand = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter)
+
+				err := sshKeyCommand.CheckFastFails([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -17,20 +18,30 @@ var _ = Describe("SSH", func() {
 	var (
 		ssh          commands.SSH
 		sshCLI       *fakes.SSHCLI
-		pathFinder   *fakes.PathFinder
 		sshKeyGetter *fakes.FancySSHKeyGetter
 		fileIO       *fakes.FileIO
-		randomPort   *fakes.RandomPort
+		tunnelOpener *fakes.TunnelOpener
+		tunnel       *fakes.Tunnel
+		boshClient   *fakes.BOSHClient
+		clientProv   *fakes.BOSHClientProvider
+		keyGenerator *fakes.SSHKeyGenerator
+		certGetter   *fakes.SSHCertificateGetter
 	)
 
 	BeforeEach(func() {
 		sshCLI = &fakes.SSHCLI{}
 		sshKeyGetter = &fakes.FancySSHKeyGetter{}
-		pathFinder = &fakes.PathFinder{}
 		fileIO = &fakes.FileIO{}
-		randomPort = &fakes.RandomPort{}
-
-		ssh = commands.NewSSH(sshCLI, sshKeyGetter, pathFinder, fileIO, randomPort)
+		tunnel = &fakes.Tunnel{}
+		tunnelOpener = &fakes.TunnelOpener{}
+		tunnelOpener.OpenCall.Returns.Tunnel = tunnel
+		boshClient = &fakes.BOSHClient{}
+		clientProv = &fakes.BOSHClientProvider{}
+		clientProv.ClientCall.Returns.Client = boshClient
+		keyGenerator = &fakes.SSHKeyGenerator{}
+		certGetter = &fakes.SSHCertificateGetter{}
+
+		ssh = commands.NewSSH(sshCLI, sshKeyGetter, fileIO, tunnelOpener, clientProv, keyGenerator, certGetter)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -45,6 +56,19 @@ var _ = Describe("SSH", func() {
 				Expect(err).To(MatchError("Invalid bbl state for bbl ssh."))
 			})
 		})
+
+		Context("when the environment has no jumpbox", func() {
+			It("checks the bbl state for the director address", func() {
+				err := ssh.CheckFastFails([]string{""}, storage.State{
+					NoJumpbox: true,
+					BOSH:      storage.BOSH{DirectorAddress: "https://some-director:25555"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ssh.CheckFastFails([]string{""}, storage.State{NoJumpbox: true})
+				Expect(err).To(MatchError("Invalid bbl state for bbl ssh."))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -56,7 +80,6 @@ var _ = Describe("SSH", func() {
 			fileIO.TempDirCall.Returns.Name = "some-temp-dir"
 			sshKeyGetter.JumpboxGetCall.Returns.PrivateKey = "jumpbox-private-key"
 			jumpboxPrivateKeyPath = filepath.Join("some-temp-dir", "jumpbox-private-key")
-			pathFinder.CommandExistsCall.Returns.Exists = false
 
 			state = storage.State{
 				Jumpbox: storage.Jumpbox{
@@ -74,41 +97,22 @@ var _ = Describe("SSH", func() {
 			BeforeEach(func() {
 				sshKeyGetter.DirectorGetCall.Returns.PrivateKey = "director-private-key"
 				directorPrivateKeyPath = filepath.Join("some-temp-dir", "director-private-key")
-				randomPort.GetPortCall.Returns.Port = "60000"
-			})
-
-			It("preemptively sshes to confirm host keys", func() {
-				err := ssh.Execute([]string{"--director"}, state)
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
-					"-T",
-					"jumpbox@jumpboxURL",
-					"-i",
-					"some-temp-dir/jumpbox-private-key",
-					"echo",
-					"host key confirmed",
-				))
+				tunnel.AddrCall.Returns.Addr = "127.0.0.1:60000"
 			})
 
 			It("opens a tunnel through the jumpbox", func() {
 				err := ssh.Execute([]string{"--director"}, state)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(sshKeyGetter.JumpboxGetCall.CallCount).To(Equal(1))
-				Expect(fileIO.WriteFileCall.Receives).To(ContainElement(
-					fakes.WriteFileReceive{
-						Filename: jumpboxPrivateKeyPath,
-						Contents: []byte("jumpbox-private-key"),
-						Mode:     os.FileMode(0600),
-					},
-				))
-				Expect(sshCLI.StartCall.Receives[0]).To(ConsistOf(
-					"-4", "-D", "60000", "-nNC", "jumpbox@jumpboxURL", "-i", jumpboxPrivateKeyPath,
-				))
+				Expect(tunnelOpener.OpenCall.CallCount).To(Equal(1))
+				Expect(tunnelOpener.OpenCall.Receives.State).To(Equal(state))
+				Expect(tunnelOpener.OpenCall.Receives.Target).To(Equal("directorURL:22"))
+
+				Expect(sshKeyGetter.JumpboxGetCall.CallCount).To(Equal(0))
+				Expect(sshCLI.StartCall.CallCount).To(Equal(0))
 			})
 
-			It("sshes through the tunnel", func() {
+			It("sshes through the tunnel and closes it", func() {
 				err := ssh.Execute([]string{"--director"}, state)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -122,36 +126,65 @@ var _ = Describe("SSH", func() {
 					},
 				))
 
-				Expect(sshCLI.RunCall.Receives[1]).To(ConsistOf(
+				Expect(sshCLI.RunCall.Receives).To(HaveLen(1))
+				Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
 					"-tt",
 					"-o", "StrictHostKeyChecking=no",
 					"-o", "ServerAliveInterval=300",
-					"-o", "ProxyCommand=nc -x localhost:60000 %h %p",
+					"-o", "HostKeyAlias=directorURL",
+					"-p", "60000",
 					"-i", directorPrivateKeyPath,
-					"jumpbox@directorURL",
+					"jumpbox@127.0.0.1",
 				))
+
+				Expect(tunnel.CloseCall.CallCount).To(Equal(1))
 			})
 
-			Context("when connect-proxy is found", func() {
-				BeforeEach(func() {
-					pathFinder.CommandExistsCall.Returns.Exists = true
-				})
+			It("sshes to the loopback address the tunnel listens on", func() {
+				tunnel.AddrCall.Returns.Addr = "[::1]:60000"
+
+				err := ssh.Execute([]string{"--director"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshCLI.RunCall.Receives[0]).To(ContainElement("jumpbox@::1"))
+				Expect(sshCLI.RunCall.Receives[0]).To(ContainElement("60000"))
+			})
+
+			Context("when the director host key is recorded", func() {
+				It("only accepts that key", func() {
+					state.BOSH.DirectorHostKey = "ssh-rsa director-host-key"
 
-				It("uses connect-proxy instead of netcat", func() {
 					err := ssh.Execute([]string{"--director"}, state)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(pathFinder.CommandExistsCall.Receives.Command).To(Equal("connect-proxy"))
+					knownHostsPath := filepath.Join("some-temp-dir", "known_hosts")
+					Expect(fileIO.WriteFileCall.Receives).To(ContainElement(
+						fakes.WriteFileReceive{
+							Filename: knownHostsPath,
+							Contents: []byte("directorURL ssh-rsa director-host-key\n"),
+							Mode:     os.FileMode(0600),
+						},
+					))
 
-					Expect(sshCLI.RunCall.Receives[1]).To(ConsistOf(
+					Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
 						"-tt",
-						"-o", "StrictHostKeyChecking=no",
+						"-o", "StrictHostKeyChecking=yes",
+						"-o", "UserKnownHostsFile="+knownHostsPath,
 						"-o", "ServerAliveInterval=300",
-						"-o", "ProxyCommand=connect-proxy -S localhost:60000 %h %p",
+						"-o", "HostKeyAlias=directorURL",
+						"-p", "60000",
 						"-i", directorPrivateKeyPath,
-						"jumpbox@directorURL",
+						"jumpbox@127.0.0.1",
 					))
 				})
+
+				It("returns an error when the known hosts file cannot be written", func() {
+					state.BOSH.DirectorHostKey = "ssh-rsa director-host-key"
+					fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{}, {Error: errors.New("quince")}}
+
+					err := ssh.Execute([]string{"--director"}, state)
+					Expect(err).To(MatchError("Write known hosts file: quince"))
+				})
 			})
 
 			Context("failure cases", func() {
@@ -185,25 +218,61 @@ var _ = Describe("SSH", func() {
 					})
 				})
 
-				Context("when random port fails to return a port", func() {
+				Context("when the tunnel to the jumpbox cannot be opened", func() {
 					It("returns the error", func() {
-						randomPort.GetPortCall.Returns.Error = errors.New("prune")
+						tunnelOpener.OpenCall.Returns.Error = errors.New("lignonberry")
 
 						err := ssh.Execute([]string{"--director"}, state)
 
-						Expect(err).To(MatchError("Open proxy port: prune"))
+						Expect(err).To(MatchError("Open tunnel to jumpbox: lignonberry"))
 					})
 				})
+			})
+		})
 
-				Context("when the ssh command fails to open a tunnel to the jumpbox", func() {
-					It("returns the error", func() {
-						sshCLI.StartCall.Returns = []fakes.SSHStartReturn{{Error: errors.New("lignonberry")}}
+		Context("when the environment has no jumpbox", func() {
+			BeforeEach(func() {
+				sshKeyGetter.DirectorGetCall.Returns.PrivateKey = "director-private-key"
+				state.NoJumpbox = true
+				state.Jumpbox = storage.Jumpbox{}
+			})
 
-						err := ssh.Execute([]string{"--director"}, state)
+			It("sshes to the director directly", func() {
+				err := ssh.Execute([]string{"--director"}, state)
+				Expect(err).NotTo(HaveOccurred())
 
-						Expect(err).To(MatchError("Open tunnel to jumpbox: lignonberry"))
-					})
-				})
+				Expect(sshKeyGetter.JumpboxGetCall.CallCount).To(Equal(0))
+				Expect(sshCLI.StartCall.CallCount).To(Equal(0))
+				Expect(sshCLI.RunCall.Receives).To(HaveLen(1))
+				Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
+					"-tt",
+					"-o", "StrictHostKeyChecking=no",
+					"-o", "ServerAliveInterval=300",
+					"-i", filepath.Join("some-temp-dir", "director-private-key"),
+					"jumpbox@directorURL",
+				))
+			})
+
+			It("only accepts the recorded director host key", func() {
+				state.BOSH.DirectorHostKey = "ssh-rsa director-host-key"
+
+				err := ssh.Execute([]string{"--director"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				knownHostsPath := filepath.Join("some-temp-dir", "known_hosts")
+				Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
+					"-tt",
+					"-o", "StrictHostKeyChecking=yes",
+					"-o", "UserKnownHostsFile="+knownHostsPath,
+					"-o", "ServerAliveInterval=300",
+					"-i", filepath.Join("some-temp-dir", "director-private-key"),
+					"jumpbox@directorURL",
+				))
+			})
+
+			It("returns an error for --jumpbox", func() {
+				err := ssh.Execute([]string{"--jumpbox"}, state)
+				Expect(err).To(MatchError("This environment has no jumpbox, use --director to ssh to the director directly."))
 			})
 		})
 
@@ -227,6 +296,66 @@ var _ = Describe("SSH", func() {
 				))
 			})
 
+			Context("when the jumpbox host key is recorded", func() {
+				It("only accepts that key", func() {
+					state.Jumpbox.HostKey = "ssh-rsa jumpbox-host-key"
+
+					err := ssh.Execute([]string{"--jumpbox"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					knownHostsPath := filepath.Join("some-temp-dir", "known_hosts")
+					Expect(fileIO.WriteFileCall.Receives[1]).To(Equal(fakes.WriteFileReceive{
+						Filename: knownHostsPath,
+						Contents: []byte("jumpboxURL ssh-rsa jumpbox-host-key\n"),
+						Mode:     os.FileMode(0600),
+					}))
+
+					Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
+						"-tt",
+						"-o", "StrictHostKeyChecking=yes",
+						"-o", "UserKnownHostsFile="+knownHostsPath,
+						"-o", "ServerAliveInterval=300",
+						"jumpbox@jumpboxURL",
+						"-i", jumpboxPrivateKeyPath,
+					))
+				})
+			})
+
+			Context("when the jumpbox trusts an ssh CA", func() {
+				BeforeEach(func() {
+					certGetter.GetCall.Returns.PrivateKey = "short-lived-private-key"
+					certGetter.GetCall.Returns.Certificate = "ssh-ed25519-cert-v01@openssh.com some-certificate"
+				})
+
+				It("logs in with a short-lived key and certificate", func() {
+					err := ssh.Execute([]string{"--jumpbox"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sshKeyGetter.JumpboxGetCall.CallCount).To(Equal(0))
+					Expect(fileIO.WriteFileCall.Receives).To(Equal([]fakes.WriteFileReceive{
+						{
+							Filename: jumpboxPrivateKeyPath,
+							Contents: []byte("short-lived-private-key"),
+							Mode:     os.FileMode(0600),
+						},
+						{
+							Filename: jumpboxPrivateKeyPath + "-cert.pub",
+							Contents: []byte("ssh-ed25519-cert-v01@openssh.com some-certificate"),
+							Mode:     os.FileMode(0600),
+						},
+					}))
+
+					Expect(sshCLI.RunCall.Receives[0]).To(ContainElement(jumpboxPrivateKeyPath))
+				})
+
+				It("returns an error when the certificate cannot be issued", func() {
+					certGetter.GetCall.Returns.Error = errors.New("durian")
+
+					err := ssh.Execute([]string{"--jumpbox"}, state)
+					Expect(err).To(MatchError("Get jumpbox ssh certificate: durian"))
+				})
+			})
+
 			Context("when ssh key getter fails to get the jumpbox ssh private key", func() {
 				It("returns the error", func() {
 					sshKeyGetter.JumpboxGetCall.Returns.Error = errors.New("fig")
@@ -248,10 +377,171 @@ var _ = Describe("SSH", func() {
 			})
 		})
 
+		Context("with a deployment and instance", func() {
+			var instancePrivateKeyPath string
+
+			BeforeEach(func() {
+				state.BOSH.DirectorUsername = "some-username"
+				state.BOSH.DirectorPassword = "some-password"
+				state.BOSH.DirectorSSLCA = "some-ca"
+
+				keyGenerator.GenerateCall.Returns.PrivateKey = "instance-private-key"
+				keyGenerator.GenerateCall.Returns.PublicKey = "ssh-rsa instance-public-key"
+				instancePrivateKeyPath = filepath.Join("some-temp-dir", "instance-private-key")
+
+				boshClient.SetupSSHCall.Returns.Hosts = []bosh.SSHHost{{
+					IP:            "10.0.16.5",
+					HostPublicKey: "ssh-rsa some-host-key",
+				}}
+				tunnel.AddrCall.Returns.Addr = "127.0.0.1:60000"
+			})
+
+			It("sets up a temporary user through the director and sshes to the instance through the jumpbox", func() {
+				err := ssh.Execute([]string{"cf", "router/0"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(clientProv.ClientCall.Receives.State).To(Equal(state))
+
+				Expect(fileIO.WriteFileCall.Receives).To(ContainElement(
+					fakes.WriteFileReceive{
+						Filename: instancePrivateKeyPath,
+						Contents: []byte("instance-private-key"),
+						Mode:     os.FileMode(0600),
+					},
+				))
+
+				Expect(boshClient.SetupSSHCall.Receives.Deployment).To(Equal("cf"))
+				Expect(boshClient.SetupSSHCall.Receives.Target).To(Equal(bosh.SSHTarget{Job: "router", IDs: []string{"0"}}))
+				Expect(boshClient.SetupSSHCall.Receives.User).To(MatchRegexp("^bbl_[0-9a-f]{16}$"))
+				Expect(boshClient.SetupSSHCall.Receives.PublicKey).To(Equal("ssh-rsa instance-public-key"))
+				user := boshClient.SetupSSHCall.Receives.User
+
+				Expect(tunnelOpener.OpenCall.Receives.State).To(Equal(state))
+				Expect(tunnelOpener.OpenCall.Receives.Target).To(Equal("10.0.16.5:22"))
+
+				knownHostsPath := filepath.Join("some-temp-dir", "known_hosts")
+				Expect(fileIO.WriteFileCall.Receives).To(ContainElement(
+					fakes.WriteFileReceive{
+						Filename: knownHostsPath,
+						Contents: []byte("10.0.16.5 ssh-rsa some-host-key\n"),
+						Mode:     os.FileMode(0600),
+					},
+				))
+
+				Expect(sshCLI.RunCall.Receives).To(HaveLen(1))
+				Expect(sshCLI.RunCall.Receives[0]).To(ConsistOf(
+					"-tt",
+					"-o", "StrictHostKeyChecking=yes",
+					"-o", "UserKnownHostsFile="+knownHostsPath,
+					"-o", "ServerAliveInterval=300",
+					"-o", "HostKeyAlias=10.0.16.5",
+					"-p", "60000",
+					"-i", instancePrivateKeyPath,
+					user+"@127.0.0.1",
+				))
+
+				Expect(tunnel.CloseCall.CallCount).To(Equal(1))
+
+				Expect(boshClient.CleanupSSHCall.CallCount).To(Equal(1))
+				Expect(boshClient.CleanupSSHCall.Receives.Deployment).To(Equal("cf"))
+				Expect(boshClient.CleanupSSHCall.Receives.Target).To(Equal(bosh.SSHTarget{Job: "router", IDs: []string{"0"}}))
+				Expect(boshClient.CleanupSSHCall.Receives.User).To(Equal(user))
+			})
+
+			It("runs the given command without a tty", func() {
+				err := ssh.Execute([]string{"cf", "router/0", "--command", "uptime"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshCLI.RunCall.Receives[0]).NotTo(ContainElement("-tt"))
+				Expect(sshCLI.RunCall.Receives[0][len(sshCLI.RunCall.Receives[0])-1]).To(Equal("uptime"))
+			})
+
+			Context("when the director does not report a host key", func() {
+				It("does not check the host key", func() {
+					boshClient.SetupSSHCall.Returns.Hosts = []bosh.SSHHost{{IP: "10.0.16.5"}}
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(sshCLI.RunCall.Receives[0]).To(ContainElement("StrictHostKeyChecking=no"))
+				})
+			})
+
+			Context("failure cases", func() {
+				It("returns an error when the instance is not a group and index", func() {
+					err := ssh.Execute([]string{"cf", "router"}, state)
+					Expect(err).To(MatchError("bbl ssh requires a deployment and an instance, e.g. bbl ssh cf router/0."))
+				})
+
+				It("returns an error when --director is also passed", func() {
+					err := ssh.Execute([]string{"--director", "cf", "router/0"}, state)
+					Expect(err).To(MatchError("Pass either --jumpbox, --director or a deployment and instance."))
+				})
+
+				It("returns an error when the director client cannot be created", func() {
+					clientProv.ClientCall.Returns.Error = errors.New("quince")
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("Create director client: quince"))
+				})
+
+				It("returns an error when the key cannot be generated", func() {
+					keyGenerator.GenerateCall.Returns.Error = errors.New("guava")
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("Generate ssh key: guava"))
+				})
+
+				It("returns an error when ssh cannot be set up", func() {
+					boshClient.SetupSSHCall.Returns.Error = errors.New("papaya")
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("Set up ssh on cf/router/0: papaya"))
+					Expect(boshClient.CleanupSSHCall.CallCount).To(Equal(0))
+				})
+
+				It("returns an error when no instance is found", func() {
+					boshClient.SetupSSHCall.Returns.Hosts = []bosh.SSHHost{}
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("No instance router/0 found in deployment cf."))
+				})
+
+				It("cleans up and returns an error when the tunnel cannot be opened", func() {
+					tunnelOpener.OpenCall.Returns.Error = errors.New("lychee")
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("Open tunnel to jumpbox: lychee"))
+					Expect(boshClient.CleanupSSHCall.CallCount).To(Equal(1))
+				})
+
+				It("returns an error when the cleanup fails", func() {
+					boshClient.CleanupSSHCall.Returns.Error = errors.New("durian")
+
+					err := ssh.Execute([]string{"cf", "router/0"}, state)
+					Expect(err).To(MatchError("Clean up ssh on cf/router/0: durian"))
+				})
+			})
+		})
+
+		Context("--command", func() {
+			It("runs the command on the jumpbox without a tty", func() {
+				err := ssh.Execute([]string{"--jumpbox", "--command", "uptime"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshCLI.RunCall.Receives[0]).To(Equal([]string{
+					"-o", "ServerAliveInterval=300",
+					"jumpbox@jumpboxURL",
+					"-i", jumpboxPrivateKeyPath,
+					"uptime",
+				}))
+			})
+		})
+
 		Context("when the user does not provide a flag", func() {
 			It("returns an error", func() {
 				err := ssh.Execute([]string{}, storage.State{})
-				Expect(err).To(MatchError("This command requires the --jumpbox or --director flag."))
+				Expect(err).To(MatchError("This command requires the --jumpbox or --director flag, or a deployment and instance."))
 			})
 		})
 
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// defaultTunnelPort lets bbl tunnel start pick a free port, so tunnels of
+// different environments do not collide. The port is recorded per state
+// directory for bbl print-env.
+const defaultTunnelPort = "0"
+
+type Tunnel struct {
+	logger         logger
+	stateValidator stateValidator
+	sshKeyGetter   sshKeyGetter
+	tunnelProcess  tunnelProcess
+	tunnelServer   tunnelServer
+}
+
+type tunnelStatusGetter interface {
+	Status() (ssh.TunnelStatus, error)
+}
+
+type tunnelProcess interface {
+	tunnelStatusGetter
+	Start(port int) (ssh.TunnelStatus, error)
+	Stop() error
+	Lock() (func(), error)
+	Ready(fd, port int) error
+}
+
+type tunnelServer interface {
+	Serve(listener net.Listener, jumpbox storage.Jumpbox, privateKey string, ready func() error) error
+}
+
+func NewTunnel(logger logger, stateValidator stateValidator, sshKeyGetter sshKeyGetter,
+	tunnelProcess tunnelProcess, tunnelServer tunnelServer) Tunnel {
+	return Tunnel{
+		logger:         logger,
+		stateValidator: stateValidator,
+		sshKeyGetter:   sshKeyGetter,
+		tunnelProcess:  tunnelProcess,
+		tunnelServer:   tunnelServer,
+	}
+}
+
+func (t Tunnel) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := t.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if len(subcommandFlags) > 0 && (subcommandFlags[0] == "start" || subcommandFlags[0] == "run") {
+		if state.NoJumpbox {
+			return errors.New("This environment has no jumpbox, the director can be reached directly.")
+		}
+
+		if state.Jumpbox.URL == "" {
+			return errors.New("Invalid bbl state for bbl tunnel.")
+		}
+	}
+
+	return nil
+}
+
+func (t Tunnel) Execute(args []string, state storage.State) error {
+	if len(args) == 0 {
+		return errors.New("This command requires a subcommand: start, stop or status.")
+	}
+
+	var (
+		portFlag    string
+		readyFDFlag string
+	)
+	tunnelFlags := flags.New("tunnel")
+	tunnelFlags.String(&portFlag, "port", defaultTunnelPort)
+	tunnelFlags.String(&readyFDFlag, "ready-fd", "0")
+	err := tunnelFlags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	port, err := strconv.Atoi(portFlag)
+	if err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("--port %q is not a valid port number", portFlag)
+	}
+
+	readyFD, err := strconv.Atoi(readyFDFlag)
+	if err != nil || readyFD < 0 {
+		return fmt.Errorf("--ready-fd %q is not a valid file descriptor", readyFDFlag)
+	}
+
+	switch args[0] {
+	case "start":
+		return t.start(port)
+	case "stop":
+		return t.stop()
+	case "status":
+		return t.status()
+	case "run":
+		return t.run(port, readyFD, state)
+	}
+
+	return fmt.Errorf("Unknown subcommand %q, use start, stop or status.", args[0])
+}
+
+func (t Tunnel) start(port int) error {
+	status, err := t.tunnelProcess.Status()
+	if err != nil {
+		return fmt.Errorf("Get tunnel status: %s", err)
+	}
+
+	if status.Running {
+		t.logger.Printf("Tunnel is already running on 127.0.0.1:%d (pid %d).\n", status.Port, status.PID)
+		return nil
+	}
+
+	status, err = t.tunnelProcess.Start(port)
+	if err != nil {
+		return fmt.Errorf("Start tunnel: %s", err)
+	}
+
+	t.logger.Printf("Tunnel started on 127.0.0.1:%d (pid %d).\n", status.Port, status.PID)
+	return nil
+}
+
+func (t Tunnel) stop() error {
+	status, err := t.tunnelProcess.Status()
+	if err != nil {
+		return fmt.Errorf("Get tunnel status: %s", err)
+	}
+
+	err = t.tunnelProcess.Stop()
+	if err != nil {
+		return fmt.Errorf("Stop tunnel: %s", err)
+	}
+
+	if !status.Running {
+		t.logger.Println("Tunnel is not running.")
+		return nil
+	}
+
+	t.logger.Println("Tunnel stopped.")
+	return nil
+}
+
+func (t Tunnel) status() error {
+	status, err := t.tunnelProcess.Status()
+	if err != nil {
+		return fmt.Errorf("Get tunnel status: %s", err)
+	}
+
+	if !status.Running {
+		t.logger.Println("Tunnel is not running.")
+		return nil
+	}
+
+	t.logger.Printf("Tunnel is running on 127.0.0.1:%d (pid %d).\n", status.Port, status.PID)
+	return nil
+}
+
+// run serves the proxy in the foreground. bbl tunnel start runs it in a
+// background process and waits for it to report on readyFD.
+func (t Tunnel) run(port, readyFD int, state storage.State) error {
+	release, err := t.tunnelProcess.Lock()
+	if err != nil {
+		return fmt.Errorf("Lock tunnel: %s", err)
+	}
+	defer release()
+
+	privateKey, err := t.sshKeyGetter.Get("jumpbox")
+	if err != nil {
+		return fmt.Errorf("Get jumpbox private key: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("Listen on port %d: %s", port, err)
+	}
+	defer listener.Close()
+
+	port = listener.Addr().(*net.TCPAddr).Port
+	ready := func() error {
+		t.logger.Printf("Tunnel is running on 127.0.0.1:%d.\n", port)
+		if readyFD == 0 {
+			return nil
+		}
+		return t.tunnelProcess.Ready(readyFD, port)
+	}
+
+	return t.tunnelServer.Serve(listener, state.Jumpbox, privateKey, ready)
+}
@@ -0,0 +1,224 @@
+package commands_test
+
+import (
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tunnel", func() {
+	var (
+		logger         *fakes.Logger
+		stateValidator *fakes.StateValidator
+		sshKeyGetter   *fakes.SSHKeyGetter
+		tunnelProcess  *fakes.TunnelProcess
+		tunnelServer   *fakes.TunnelServer
+		tunnel         commands.Tunnel
+		state          storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		sshKeyGetter = &fakes.SSHKeyGetter{}
+		sshKeyGetter.GetCall.Returns.PrivateKey = "some-jumpbox-key"
+		tunnelProcess = &fakes.TunnelProcess{}
+		tunnelServer = &fakes.TunnelServer{}
+
+		state = storage.State{Jumpbox: storage.Jumpbox{URL: "some-jumpbox:22"}}
+
+		tunnel = commands.NewTunnel(logger, stateValidator, sshKeyGetter, tunnelProcess, tunnelServer)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("no state")
+
+			err := tunnel.CheckFastFails([]string{"status"}, state)
+			Expect(err).To(MatchError("no state"))
+		})
+
+		It("requires a jumpbox to start the tunnel", func() {
+			err := tunnel.CheckFastFails([]string{"start"}, storage.State{})
+			Expect(err).To(MatchError("Invalid bbl state for bbl tunnel."))
+
+			err = tunnel.CheckFastFails([]string{"start"}, storage.State{NoJumpbox: true})
+			Expect(err).To(MatchError("This environment has no jumpbox, the director can be reached directly."))
+		})
+
+		It("does not require a jumpbox to stop the tunnel", func() {
+			err := tunnel.CheckFastFails([]string{"stop"}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Execute", func() {
+		Context("start", func() {
+			It("starts the tunnel on a free port by default", func() {
+				tunnelProcess.StartCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 41080}
+
+				err := tunnel.Execute([]string{"start"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelProcess.StartCall.Receives.Port).To(Equal(0))
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Tunnel started on 127.0.0.1:41080 (pid 42).\n"))
+			})
+
+			It("starts the tunnel on the given port", func() {
+				err := tunnel.Execute([]string{"start", "--port", "6000"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelProcess.StartCall.Receives.Port).To(Equal(6000))
+			})
+
+			It("does not start a second tunnel", func() {
+				tunnelProcess.StatusCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 1080}
+
+				err := tunnel.Execute([]string{"start"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelProcess.StartCall.CallCount).To(Equal(0))
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Tunnel is already running on 127.0.0.1:1080 (pid 42).\n"))
+			})
+
+			Context("when the tunnel fails to start", func() {
+				It("returns an error", func() {
+					tunnelProcess.StartCall.Returns.Error = errors.New("plum")
+
+					err := tunnel.Execute([]string{"start"}, state)
+					Expect(err).To(MatchError("Start tunnel: plum"))
+				})
+			})
+
+			Context("when the port is not a number", func() {
+				It("returns an error", func() {
+					err := tunnel.Execute([]string{"start", "--port", "socks"}, state)
+					Expect(err).To(MatchError(`--port "socks" is not a valid port number`))
+				})
+			})
+		})
+
+		Context("stop", func() {
+			It("stops the tunnel", func() {
+				tunnelProcess.StatusCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 1080}
+
+				err := tunnel.Execute([]string{"stop"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelProcess.StopCall.CallCount).To(Equal(1))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("Tunnel stopped."))
+			})
+
+			Context("when the tunnel fails to stop", func() {
+				It("returns an error", func() {
+					tunnelProcess.StopCall.Returns.Error = errors.New("pear")
+
+					err := tunnel.Execute([]string{"stop"}, state)
+					Expect(err).To(MatchError("Stop tunnel: pear"))
+				})
+			})
+		})
+
+		Context("status", func() {
+			It("prints the port and pid of the running tunnel", func() {
+				tunnelProcess.StatusCall.Returns.Status = ssh.TunnelStatus{Running: true, PID: 42, Port: 1080}
+
+				err := tunnel.Execute([]string{"status"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Tunnel is running on 127.0.0.1:1080 (pid 42).\n"))
+			})
+
+			It("prints when the tunnel is not running", func() {
+				err := tunnel.Execute([]string{"status"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("Tunnel is not running."))
+			})
+
+			Context("when the status cannot be read", func() {
+				It("returns an error", func() {
+					tunnelProcess.StatusCall.Returns.Error = errors.New("apricot")
+
+					err := tunnel.Execute([]string{"status"}, state)
+					Expect(err).To(MatchError("Get tunnel status: apricot"))
+				})
+			})
+		})
+
+		Context("run", func() {
+			It("serves the proxy through the jumpbox in the foreground", func() {
+				err := tunnel.Execute([]string{"run", "--port", "0"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshKeyGetter.GetCall.Receives.Deployment).To(Equal("jumpbox"))
+				Expect(tunnelServer.ServeCall.Receives.Listener.Addr().String()).To(HavePrefix("127.0.0.1:"))
+				Expect(tunnelServer.ServeCall.Receives.Jumpbox).To(Equal(storage.Jumpbox{URL: "some-jumpbox:22"}))
+				Expect(tunnelServer.ServeCall.Receives.PrivateKey).To(Equal("some-jumpbox-key"))
+				Expect(tunnelProcess.LockCall.CallCount).To(Equal(1))
+			})
+
+			It("reports the port it serves on to bbl tunnel start when it is ready", func() {
+				err := tunnel.Execute([]string{"run", "--port", "0", "--ready-fd", "3"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelProcess.ReadyCall.CallCount).To(Equal(0))
+				Expect(tunnelServer.ServeCall.Receives.Ready()).To(Succeed())
+
+				_, port, err := net.SplitHostPort(tunnelServer.ServeCall.Receives.Listener.Addr().String())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tunnelProcess.ReadyCall.Receives.FD).To(Equal(3))
+				Expect(strconv.Itoa(tunnelProcess.ReadyCall.Receives.Port)).To(Equal(port))
+			})
+
+			It("does not report readiness when it runs in the foreground", func() {
+				err := tunnel.Execute([]string{"run", "--port", "0"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tunnelServer.ServeCall.Receives.Ready()).To(Succeed())
+				Expect(tunnelProcess.ReadyCall.CallCount).To(Equal(0))
+			})
+
+			Context("when another tunnel holds the lock", func() {
+				It("returns an error", func() {
+					tunnelProcess.LockCall.Returns.Error = errors.New("a tunnel is already running for this state directory")
+
+					err := tunnel.Execute([]string{"run", "--port", "0"}, state)
+					Expect(err).To(MatchError("Lock tunnel: a tunnel is already running for this state directory"))
+					Expect(tunnelServer.ServeCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the jumpbox key cannot be read", func() {
+				It("returns an error", func() {
+					sshKeyGetter.GetCall.Returns.Error = errors.New("grape")
+
+					err := tunnel.Execute([]string{"run", "--port", "0"}, state)
+					Expect(err).To(MatchError("Get jumpbox private key: grape"))
+				})
+			})
+		})
+
+		Context("when no subcommand is given", func() {
+			It("returns an error", func() {
+				err := tunnel.Execute([]string{}, state)
+				Expect(err).To(MatchError("This command requires a subcommand: start, stop or status."))
+			})
+		})
+
+		Context("when an unknown subcommand is given", func() {
+			It("returns an error", func() {
+				err := tunnel.Execute([]string{"restart"}, state)
+				Expect(err).To(MatchError(`Unknown subcommand "restart", use start, stop or status.`))
+			})
+		})
+	})
+})
@@ -2,31 +2,49 @@ package commands
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+	"golang.org/x/net/proxy"
 )
 
 type Up struct {
 	plan                 plan
+	logger               logger
 	boshManager          boshManager
 	cloudConfigManager   cloudConfigManager
 	runtimeConfigManager runtimeConfigManager
 	stateStore           stateStore
 	terraformManager     terraformManager
+	hostKeyScanner       hostKeyScanner
+	dialerProvider       dialerProvider
 }
 
-func NewUp(plan plan, boshManager boshManager,
+type hostKeyScanner interface {
+	Scan(dialer proxy.Dialer, addr string) (string, error)
+}
+
+type dialerProvider interface {
+	Dialer(state storage.State) (proxy.Dialer, error)
+}
+
+func NewUp(plan plan, logger logger, boshManager boshManager,
 	cloudConfigManager cloudConfigManager,
 	runtimeConfigManager runtimeConfigManager,
-	stateStore stateStore, terraformManager terraformManager) Up {
+	stateStore stateStore, terraformManager terraformManager,
+	hostKeyScanner hostKeyScanner, dialerProvider dialerProvider) Up {
 	return Up{
 		plan:                 plan,
+		logger:               logger,
 		boshManager:          boshManager,
 		cloudConfigManager:   cloudConfigManager,
 		runtimeConfigManager: runtimeConfigManager,
 		stateStore:           stateStore,
 		terraformManager:     terraformManager,
+		hostKeyScanner:       hostKeyScanner,
+		dialerProvider:       dialerProvider,
 	}
 }
 
@@ -65,21 +83,11 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Parse terraform outputs: %s", err)
 	}
 
-	state, err = u.boshManager.CreateJumpbox(state, terraformOutputs)
-	switch err.(type) {
-	case bosh.ManagerCreateError:
-		bcErr := err.(bosh.ManagerCreateError)
-		if setErr := u.stateStore.Set(bcErr.State()); setErr != nil {
-			return fmt.Errorf("Save state after jumpbox create error: %s, %s", err, setErr)
+	if !state.NoJumpbox {
+		state, err = createJumpbox(u.logger, u.boshManager, u.stateStore, u.hostKeyScanner, state, terraformOutputs)
+		if err != nil {
+			return err
 		}
-		return fmt.Errorf("Create jumpbox: %s", err)
-	case error:
-		return fmt.Errorf("Create jumpbox: %s", err)
-	}
-
-	err = u.stateStore.Set(state)
-	if err != nil {
-		return fmt.Errorf("Save state after create jumpbox: %s", err)
 	}
 
 	state, err = u.boshManager.CreateDirector(state, terraformOutputs)
@@ -99,6 +107,22 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after create director: %s", err)
 	}
 
+	dialer, err := u.dialerProvider.Dialer(state)
+	if err != nil {
+		return fmt.Errorf("Connect to jumpbox: %s", err)
+	}
+
+	hostKey, err := u.hostKeyScanner.Scan(dialer, net.JoinHostPort(directorIP(state), "22"))
+	if err != nil {
+		u.logger.Println(fmt.Sprintf("Unable to record the director host key, connections will not verify it until the next bbl up: %s", err))
+	} else {
+		state.BOSH.DirectorHostKey = hostKey
+		err = u.stateStore.Set(state)
+		if err != nil {
+			return fmt.Errorf("Save state after recording the director host key: %s", err)
+		}
+	}
+
 	err = u.cloudConfigManager.Update(state)
 	if err != nil {
 		return fmt.Errorf("Update cloud config: %s", err)
@@ -115,3 +139,42 @@ func (u Up) Execute(args []string, state storage.State) error {
 func (u Up) ParseArgs(args []string, state storage.State) (PlanConfig, error) {
 	return u.plan.ParseArgs(args, state)
 }
+
+// createJumpbox runs create-env for the jumpbox and records the host key it
+// comes up with. Keys are recorded right after create-env, so later
+// connections can verify them instead of trusting the first key they see.
+// The state is saved before the scan, so a jumpbox that cannot be scanned
+// is still recorded.
+func createJumpbox(logger logger, boshManager boshManager, stateStore stateStore, hostKeyScanner hostKeyScanner,
+	state storage.State, terraformOutputs terraform.Outputs) (storage.State, error) {
+	state, err := boshManager.CreateJumpbox(state, terraformOutputs)
+	switch err.(type) {
+	case bosh.ManagerCreateError:
+		bcErr := err.(bosh.ManagerCreateError)
+		if setErr := stateStore.Set(bcErr.State()); setErr != nil {
+			return state, fmt.Errorf("Save state after jumpbox create error: %s, %s", err, setErr)
+		}
+		return state, fmt.Errorf("Create jumpbox: %s", err)
+	case error:
+		return state, fmt.Errorf("Create jumpbox: %s", err)
+	}
+
+	err = stateStore.Set(state)
+	if err != nil {
+		return state, fmt.Errorf("Save state after create jumpbox: %s", err)
+	}
+
+	hostKey, err := hostKeyScanner.Scan(proxy.Direct, state.Jumpbox.URL)
+	if err != nil {
+		logger.Println(fmt.Sprintf("Unable to record the jumpbox host key, connections will not verify it until the next bbl up: %s", err))
+		return state, nil
+	}
+
+	state.Jumpbox.HostKey = hostKey
+	err = stateStore.Set(state)
+	if err != nil {
+		return state, fmt.Errorf("Save state after recording the jumpbox host key: %s", err)
+	}
+
+	return state, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
+	"golang.org/x/net/proxy"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -23,6 +24,9 @@ var _ = Describe("Up", func() {
 		cloudConfigManager   *fakes.CloudConfigManager
 		runtimeConfigManager *fakes.RuntimeConfigManager
 		stateStore           *fakes.StateStore
+		hostKeyScanner       *fakes.HostKeyScanner
+		boshClientProvider   *fakes.BOSHClientProvider
+		logger               *fakes.Logger
 	)
 
 	BeforeEach(func() {
@@ -32,8 +36,12 @@ var _ = Describe("Up", func() {
 		cloudConfigManager = &fakes.CloudConfigManager{}
 		runtimeConfigManager = &fakes.RuntimeConfigManager{}
 		stateStore = &fakes.StateStore{}
+		hostKeyScanner = &fakes.HostKeyScanner{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		logger = &fakes.Logger{}
 
-		command = commands.NewUp(plan, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager)
+		command = commands.NewUp(plan, logger, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager,
+			hostKeyScanner, boshClientProvider)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -69,12 +77,27 @@ var _ = Describe("Up", func() {
 			terraformApplyState = storage.State{LatestTFOutput: "terraform-apply-call", IAAS: "some-iaas"}
 			terraformManager.ApplyCall.Returns.BBLState = terraformApplyState
 
-			createJumpboxState = storage.State{LatestTFOutput: "create-jumpbox-call", IAAS: "some-iaas"}
+			createJumpboxState = storage.State{
+				LatestTFOutput: "create-jumpbox-call",
+				IAAS:           "some-iaas",
+				Jumpbox:        storage.Jumpbox{URL: "some-jumpbox:22"},
+			}
 			boshManager.CreateJumpboxCall.Returns.State = createJumpboxState
 
-			createDirectorState = storage.State{LatestTFOutput: "create-director-call", IAAS: "some-iaas"}
+			createDirectorState = storage.State{
+				LatestTFOutput: "create-director-call",
+				IAAS:           "some-iaas",
+				Jumpbox:        storage.Jumpbox{URL: "some-jumpbox:22", HostKey: "ssh-rsa jumpbox-host-key"},
+				BOSH:           storage.BOSH{DirectorAddress: "https://10.0.0.6:25555"},
+			}
 			boshManager.CreateDirectorCall.Returns.State = createDirectorState
 
+			hostKeyScanner.ScanCall.Returns.HostKeys = map[string]string{
+				"some-jumpbox:22": "ssh-rsa jumpbox-host-key",
+				"10.0.0.6:22":     "ssh-rsa director-host-key",
+			}
+			boshClientProvider.DialerCall.Returns.Dialer = &fakes.Dialer{}
+
 			terraformOutputs = terraform.Outputs{
 				Map: map[string]interface{}{
 					"jumpbox_url": "some-jumpbox-url",
@@ -111,19 +134,57 @@ var _ = Describe("Up", func() {
 				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(1))
 				Expect(boshManager.CreateJumpboxCall.Receives.State).To(Equal(terraformApplyState))
 				Expect(boshManager.CreateJumpboxCall.Receives.TerraformOutputs).To(Equal(terraformOutputs))
+
+				jumpboxState := createJumpboxState
+				jumpboxState.Jumpbox.HostKey = "ssh-rsa jumpbox-host-key"
 				Expect(stateStore.SetCall.Receives[1].State).To(Equal(createJumpboxState))
+				Expect(stateStore.SetCall.Receives[2].State).To(Equal(jumpboxState))
 
 				Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(0))
 				Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(1))
-				Expect(boshManager.CreateDirectorCall.Receives.State).To(Equal(createJumpboxState))
+				Expect(boshManager.CreateDirectorCall.Receives.State).To(Equal(jumpboxState))
 				Expect(boshManager.CreateDirectorCall.Receives.TerraformOutputs).To(Equal(terraformOutputs))
-				Expect(stateStore.SetCall.Receives[2].State).To(Equal(createDirectorState))
+
+				directorState := createDirectorState
+				directorState.BOSH.DirectorHostKey = "ssh-rsa director-host-key"
+				Expect(stateStore.SetCall.Receives[3].State).To(Equal(createDirectorState))
+				Expect(stateStore.SetCall.Receives[4].State).To(Equal(directorState))
 
 				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
-				Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+				Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(directorState))
 
 				Expect(runtimeConfigManager.UpdateCall.CallCount).To(Equal(1))
-				Expect(runtimeConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+				Expect(runtimeConfigManager.UpdateCall.Receives.State).To(Equal(directorState))
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(5))
+			})
+		})
+
+		It("records the host keys of the jumpbox and director", func() {
+			err := command.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(hostKeyScanner.ScanCall.Receives.Dialers[0]).To(Equal(proxy.Direct))
+			Expect(hostKeyScanner.ScanCall.Receives.Addrs[0]).To(Equal("some-jumpbox:22"))
+
+			Expect(boshClientProvider.DialerCall.Receives.State).To(Equal(createDirectorState))
+			Expect(hostKeyScanner.ScanCall.Receives.Dialers[1]).To(Equal(&fakes.Dialer{}))
+			Expect(hostKeyScanner.ScanCall.Receives.Addrs[1]).To(Equal("10.0.0.6:22"))
+		})
+
+		Context("when the environment has no jumpbox", func() {
+			BeforeEach(func() {
+				terraformApplyState.NoJumpbox = true
+				terraformManager.ApplyCall.Returns.BBLState = terraformApplyState
+			})
+
+			It("creates the director without creating a jumpbox", func() {
+				err := command.Execute([]string{}, storage.State{NoJumpbox: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(0))
+				Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(1))
+				Expect(boshManager.CreateDirectorCall.Receives.State).To(Equal(terraformApplyState))
 
 				Expect(stateStore.SetCall.CallCount).To(Equal(3))
 			})
@@ -214,6 +275,33 @@ var _ = Describe("Up", func() {
 				})
 			})
 
+			Context("when the jumpbox host key cannot be read", func() {
+				BeforeEach(func() {
+					hostKeyScanner.ScanCall.Returns.Errors = map[string]error{"some-jumpbox:22": errors.New("guava")}
+				})
+
+				It("warns and creates the director with the saved jumpbox", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(logger.PrintlnCall.Messages).To(ContainElement(
+						"Unable to record the jumpbox host key, connections will not verify it until the next bbl up: guava"))
+					Expect(stateStore.SetCall.Receives[1].State).To(Equal(createJumpboxState))
+					Expect(boshManager.CreateDirectorCall.Receives.State).To(Equal(createJumpboxState))
+				})
+			})
+
+			Context("when saving the state fails after recording the jumpbox host key", func() {
+				BeforeEach(func() {
+					stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {Error: errors.New("kiwi")}}
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Save state after recording the jumpbox host key: kiwi"))
+				})
+			})
+
 			Context("when saving the state fails after create jumpbox", func() {
 				BeforeEach(func() {
 					stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {Error: errors.New("kiwi")}}
@@ -236,14 +324,55 @@ var _ = Describe("Up", func() {
 				})
 			})
 
-			Context("when saving the state fails after create director", func() {
+			Context("when the jumpbox cannot be reached", func() {
 				BeforeEach(func() {
-					stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {Error: errors.New("kiwi")}}
+					boshClientProvider.DialerCall.Returns.Error = errors.New("lychee")
 				})
 
 				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Connect to jumpbox: lychee"))
+				})
+			})
+
+			Context("when the director host key cannot be read", func() {
+				BeforeEach(func() {
+					hostKeyScanner.ScanCall.Returns.Errors = map[string]error{"10.0.0.6:22": errors.New("papaya")}
+				})
+
+				It("warns and keeps the saved director", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(logger.PrintlnCall.Messages).To(ContainElement(
+						"Unable to record the director host key, connections will not verify it until the next bbl up: papaya"))
+					Expect(stateStore.SetCall.CallCount).To(Equal(4))
+					Expect(stateStore.SetCall.Receives[3].State).To(Equal(createDirectorState))
+					Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+				})
+			})
+
+			Context("when saving the state fails after create director", func() {
+				BeforeEach(func() {
+					stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {}, {Error: errors.New("kiwi")}}
+				})
+
+				It("does not scan the director host key", func() {
 					err := command.Execute([]string{}, storage.State{})
 					Expect(err).To(MatchError("Save state after create director: kiwi"))
+
+					Expect(hostKeyScanner.ScanCall.Receives.Addrs).To(Equal([]string{"some-jumpbox:22"}))
+				})
+			})
+
+			Context("when saving the state fails after recording the director host key", func() {
+				BeforeEach(func() {
+					stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {}, {}, {Error: errors.New("kiwi")}}
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Save state after recording the director host key: kiwi"))
 				})
 			})
 
@@ -345,21 +474,21 @@ var _ = Describe("Up", func() {
 					err := command.Execute([]string{}, storage.State{})
 					Expect(err).To(MatchError("Create bosh director: rambutan"))
 
-					Expect(stateStore.SetCall.CallCount).To(Equal(3))
-					Expect(stateStore.SetCall.Receives[2].State).To(Equal(partialState))
+					Expect(stateStore.SetCall.CallCount).To(Equal(4))
+					Expect(stateStore.SetCall.Receives[3].State).To(Equal(partialState))
 				})
 
 				Context("when it fails to save the state", func() {
 					BeforeEach(func() {
-						stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {errors.New("lychee")}}
+						stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {}, {errors.New("lychee")}}
 					})
 
 					It("returns a compound error", func() {
 						err := command.Execute([]string{}, storage.State{})
 						Expect(err).To(MatchError("Save state after bosh director create error: rambutan, lychee"))
 
-						Expect(stateStore.SetCall.CallCount).To(Equal(3))
-						Expect(stateStore.SetCall.Receives[2].State).To(Equal(partialState))
+						Expect(stateStore.SetCall.CallCount).To(Equal(4))
+						Expect(stateStore.SetCall.Receives[3].State).To(Equal(partialState))
 					})
 				})
 			})
@@ -33,8 +33,11 @@ Basic Commands: A good place to start
 Maintenance Lifecycle Commands:
   destroy                 Tears down BOSH director infrastructure. Cleans up state directory
   rotate                  Rotates SSH key for the jumpbox user
+  jumpbox                 Manages additional jumpbox users and the jumpbox ssh CA
   plan                    Populates a state directory with the latest config without applying it
   cleanup-leftovers       Cleans up orphaned IAAS resources
+  backup-director         Backs up the BOSH director with bbr
+  restore-director        Restores the BOSH director from a bbr backup
 
 Environmental Detail Commands: Useful for automation and gaining access
   jumpbox-address         Prints BOSH jumpbox address
@@ -47,12 +50,15 @@ Environmental Detail Commands: Useful for automation and gaining access
   director-ssh-key        Prints director SSH private key
   lbs                     Prints load balancer(s) and DNS records
   outputs                 Prints the outputs from terraform
-  ssh                     Opens an SSH connection to the director or jumpbox
+  cloud-config            Prints, diffs and validates the cloud config
+  ssh                     Opens an SSH connection to the director, jumpbox or an instance
+  tunnel                  Runs a socks5 proxy to the jumpbox in the background
 
 Troubleshooting Commands:
   help                    Prints usage
   version                 Prints version
-  latest-error            Prints the output from the latest call to terraform`
+  latest-error            Prints the output from the latest call to terraform
+  doctor                  Checks local dependencies and connectivity to the environment`
 
 type Usage struct {
 	logger logger
@@ -183,6 +183,7 @@ func modifiesState(command string) bool {
 		"leftovers":         {},
 		"cleanup-leftovers": {},
 		"rotate":            {},
+		"jumpbox":           {},
 	}[command]
 	return ok
 }
@@ -0,0 +1,57 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type ArtifactStore struct {
+	IsConfiguredCall struct {
+		CallCount int
+		Returns   struct {
+			IsConfigured bool
+		}
+	}
+
+	UploadCall struct {
+		CallCount int
+		Receives  struct {
+			State     storage.State
+			Name      string
+			SourceDir string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	DownloadCall struct {
+		CallCount int
+		Receives  struct {
+			State   storage.State
+			Name    string
+			DestDir string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (a *ArtifactStore) IsConfigured() bool {
+	a.IsConfiguredCall.CallCount++
+	return a.IsConfiguredCall.Returns.IsConfigured
+}
+
+func (a *ArtifactStore) Upload(state storage.State, name, sourceDir string) error {
+	a.UploadCall.CallCount++
+	a.UploadCall.Receives.State = state
+	a.UploadCall.Receives.Name = name
+	a.UploadCall.Receives.SourceDir = sourceDir
+	return a.UploadCall.Returns.Error
+}
+
+func (a *ArtifactStore) Download(state storage.State, name, destDir string) error {
+	a.DownloadCall.CallCount++
+	a.DownloadCall.Receives.State = state
+	a.DownloadCall.Receives.Name = name
+	a.DownloadCall.Receives.DestDir = destDir
+	return a.DownloadCall.Returns.Error
+}
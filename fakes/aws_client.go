@@ -10,6 +10,7 @@ type AWSClient struct {
 			Error error
 		}
 		CallCount int
+		Fake      func(region string) ([]string, error)
 	}
 	RetrieveDNSCall struct {
 		Receives struct {
@@ -25,6 +26,9 @@ type AWSClient struct {
 func (a *AWSClient) RetrieveAZs(region string) ([]string, error) {
 	a.RetrieveAZsCall.Receives.Region = region
 	a.RetrieveAZsCall.CallCount++
+	if a.RetrieveAZsCall.Fake != nil {
+		return a.RetrieveAZsCall.Fake(region)
+	}
 	return a.RetrieveAZsCall.Returns.AZs, a.RetrieveAZsCall.Returns.Error
 }
 
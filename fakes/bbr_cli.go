@@ -0,0 +1,28 @@
+package fakes
+
+type BBRCLI struct {
+	RunCall struct {
+		CallCount int
+		Receives  []BBRRunReceive
+		Returns   struct {
+			Error error
+		}
+	}
+}
+
+type BBRRunReceive struct {
+	WorkingDirectory string
+	BOSHAllProxy     string
+	Args             []string
+}
+
+func (b *BBRCLI) Run(workingDirectory, boshAllProxy string, args []string) error {
+	b.RunCall.CallCount++
+	b.RunCall.Receives = append(b.RunCall.Receives, BBRRunReceive{
+		WorkingDirectory: workingDirectory,
+		BOSHAllProxy:     boshAllProxy,
+		Args:             args,
+	})
+
+	return b.RunCall.Returns.Error
+}
@@ -23,15 +23,6 @@ type BOSHCLI struct {
 			Error error
 		}
 	}
-	UpdateRuntimeConfigCall struct {
-		CallCount int
-		Receives  struct {
-			Filepath, Name string
-		}
-		Returns struct {
-			Error error
-		}
-	}
 	RunStub        func(stdout io.Writer, workingDirectory string, args []string) error
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
@@ -130,12 +121,3 @@ func (fake *BOSHCLI) recordInvocation(key string, args []interface{}) {
 	}
 	fake.invocations[key] = append(fake.invocations[key], args)
 }
-
-func (fake *BOSHCLI) UpdateRuntimeConfig(filepath, name string) error {
-	fake.UpdateRuntimeConfigCall.CallCount++
-
-	fake.UpdateRuntimeConfigCall.Receives.Filepath = filepath
-	fake.UpdateRuntimeConfigCall.Receives.Name = name
-
-	return fake.UpdateRuntimeConfigCall.Returns.Error
-}
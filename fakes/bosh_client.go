@@ -6,48 +6,179 @@ import (
 )
 
 type BOSHClient struct {
-	UpdateRuntimeConfigCall struct {
+	UpdateCloudConfigCall struct {
 		CallCount int
 		Receives  struct {
 			Yaml []byte
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	ConfigureHTTPClientCall struct {
+		CallCount int
+		Receives  struct {
+			Dialer proxy.Dialer
+		}
+	}
+
+	InfoCall struct {
+		CallCount int
+		Returns   struct {
+			Info  bosh.Info
+			Error error
+		}
+	}
+
+	DeploymentsCall struct {
+		CallCount int
+		Returns   struct {
+			Deployments []bosh.Deployment
+			Error       error
+		}
+	}
+
+	RunningTasksCall struct {
+		CallCount int
+		Returns   struct {
+			Tasks []bosh.Task
+			Error error
+		}
+	}
+
+	DeleteDeploymentCall struct {
+		CallCount int
+		Receives  []string
+		Returns   struct {
+			Error error
+		}
+	}
+
+	UpdateConfigCall struct {
+		CallCount int
+		Receives  []BOSHConfigReceive
+		Returns   struct {
+			Error error
+		}
+	}
+
+	ConfigsCall struct {
+		CallCount int
+		Receives  struct {
+			Type string
+			Name string
+		}
+		Returns struct {
+			Configs []bosh.Config
+			Error   error
+		}
+	}
+
+	LatestConfigCall struct {
+		CallCount int
+		Receives  struct {
+			Type string
 			Name string
 		}
 		Returns struct {
+			Config bosh.Config
+			Error  error
+		}
+	}
+
+	DiffConfigCall struct {
+		CallCount int
+		Receives  BOSHConfigReceive
+		Returns   struct {
+			Diff  []bosh.DiffLine
 			Error error
 		}
 	}
 
-	UpdateCloudConfigCall struct {
+	StemcellsCall struct {
+		CallCount int
+		Returns   struct {
+			Stemcells []bosh.Stemcell
+			Error     error
+		}
+	}
+
+	UploadStemcellCall struct {
 		CallCount int
 		Receives  struct {
-			Yaml []byte
+			Location string
+			SHA1     string
 		}
 		Returns struct {
 			Error error
 		}
 	}
 
-	ConfigureHTTPClientCall struct {
+	TaskCall struct {
 		CallCount int
 		Receives  struct {
-			Dialer proxy.Dialer
+			ID int
+		}
+		Returns struct {
+			Task  bosh.Task
+			Error error
 		}
 	}
 
-	InfoCall struct {
+	TaskOutputCall struct {
 		CallCount int
-		Returns   struct {
-			Info  bosh.Info
+		Receives  struct {
+			ID         int
+			OutputType string
+		}
+		Returns struct {
+			Output string
+			Error  error
+		}
+	}
+
+	WaitForTaskCall struct {
+		CallCount int
+		Receives  struct {
+			ID int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	SetupSSHCall struct {
+		CallCount int
+		Receives  struct {
+			Deployment string
+			Target     bosh.SSHTarget
+			User       string
+			PublicKey  string
+		}
+		Returns struct {
+			Hosts []bosh.SSHHost
+			Error error
+		}
+	}
+
+	CleanupSSHCall struct {
+		CallCount int
+		Receives  struct {
+			Deployment string
+			Target     bosh.SSHTarget
+			User       string
+		}
+		Returns struct {
 			Error error
 		}
 	}
 }
 
-func (c *BOSHClient) UpdateRuntimeConfig(yaml []byte, name string) error {
-	c.UpdateRuntimeConfigCall.CallCount++
-	c.UpdateRuntimeConfigCall.Receives.Yaml = yaml
-	c.UpdateRuntimeConfigCall.Receives.Name = name
-	return c.UpdateRuntimeConfigCall.Returns.Error
+type BOSHConfigReceive struct {
+	Type    string
+	Name    string
+	Content []byte
 }
 
 func (c *BOSHClient) UpdateCloudConfig(yaml []byte) error {
@@ -65,3 +196,101 @@ func (c *BOSHClient) Info() (bosh.Info, error) {
 	c.InfoCall.CallCount++
 	return c.InfoCall.Returns.Info, c.InfoCall.Returns.Error
 }
+
+func (c *BOSHClient) Deployments() ([]bosh.Deployment, error) {
+	c.DeploymentsCall.CallCount++
+	return c.DeploymentsCall.Returns.Deployments, c.DeploymentsCall.Returns.Error
+}
+
+func (c *BOSHClient) RunningTasks() ([]bosh.Task, error) {
+	c.RunningTasksCall.CallCount++
+	return c.RunningTasksCall.Returns.Tasks, c.RunningTasksCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteDeployment(name string) error {
+	c.DeleteDeploymentCall.CallCount++
+	c.DeleteDeploymentCall.Receives = append(c.DeleteDeploymentCall.Receives, name)
+	return c.DeleteDeploymentCall.Returns.Error
+}
+
+func (c *BOSHClient) UpdateConfig(configType, name string, content []byte) error {
+	c.UpdateConfigCall.CallCount++
+	c.UpdateConfigCall.Receives = append(c.UpdateConfigCall.Receives, BOSHConfigReceive{
+		Type:    configType,
+		Name:    name,
+		Content: content,
+	})
+	return c.UpdateConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) Configs(configType, name string) ([]bosh.Config, error) {
+	c.ConfigsCall.CallCount++
+	c.ConfigsCall.Receives.Type = configType
+	c.ConfigsCall.Receives.Name = name
+	return c.ConfigsCall.Returns.Configs, c.ConfigsCall.Returns.Error
+}
+
+func (c *BOSHClient) LatestConfig(configType, name string) (bosh.Config, error) {
+	c.LatestConfigCall.CallCount++
+	c.LatestConfigCall.Receives.Type = configType
+	c.LatestConfigCall.Receives.Name = name
+	return c.LatestConfigCall.Returns.Config, c.LatestConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) DiffConfig(configType, name string, content []byte) ([]bosh.DiffLine, error) {
+	c.DiffConfigCall.CallCount++
+	c.DiffConfigCall.Receives = BOSHConfigReceive{
+		Type:    configType,
+		Name:    name,
+		Content: content,
+	}
+	return c.DiffConfigCall.Returns.Diff, c.DiffConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) Stemcells() ([]bosh.Stemcell, error) {
+	c.StemcellsCall.CallCount++
+	return c.StemcellsCall.Returns.Stemcells, c.StemcellsCall.Returns.Error
+}
+
+func (c *BOSHClient) UploadStemcell(location, sha1 string) error {
+	c.UploadStemcellCall.CallCount++
+	c.UploadStemcellCall.Receives.Location = location
+	c.UploadStemcellCall.Receives.SHA1 = sha1
+	return c.UploadStemcellCall.Returns.Error
+}
+
+func (c *BOSHClient) Task(id int) (bosh.Task, error) {
+	c.TaskCall.CallCount++
+	c.TaskCall.Receives.ID = id
+	return c.TaskCall.Returns.Task, c.TaskCall.Returns.Error
+}
+
+func (c *BOSHClient) TaskOutput(id int, outputType string) (string, error) {
+	c.TaskOutputCall.CallCount++
+	c.TaskOutputCall.Receives.ID = id
+	c.TaskOutputCall.Receives.OutputType = outputType
+	return c.TaskOutputCall.Returns.Output, c.TaskOutputCall.Returns.Error
+}
+
+func (c *BOSHClient) WaitForTask(id int) error {
+	c.WaitForTaskCall.CallCount++
+	c.WaitForTaskCall.Receives.ID = id
+	return c.WaitForTaskCall.Returns.Error
+}
+
+func (c *BOSHClient) SetupSSH(deployment string, target bosh.SSHTarget, user, publicKey string) ([]bosh.SSHHost, error) {
+	c.SetupSSHCall.CallCount++
+	c.SetupSSHCall.Receives.Deployment = deployment
+	c.SetupSSHCall.Receives.Target = target
+	c.SetupSSHCall.Receives.User = user
+	c.SetupSSHCall.Receives.PublicKey = publicKey
+	return c.SetupSSHCall.Returns.Hosts, c.SetupSSHCall.Returns.Error
+}
+
+func (c *BOSHClient) CleanupSSH(deployment string, target bosh.SSHTarget, user string) error {
+	c.CleanupSSHCall.CallCount++
+	c.CleanupSSHCall.Receives.Deployment = deployment
+	c.CleanupSSHCall.Receives.Target = target
+	c.CleanupSSHCall.Receives.User = user
+	return c.CleanupSSHCall.Returns.Error
+}
@@ -1,64 +1,60 @@
 package fakes
 
 import (
-	"io"
-
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
 )
 
 type BOSHClientProvider struct {
-	ClientCall struct {
+	DialerCall struct {
 		CallCount int
-
-		Receives struct {
-			Jumpbox          storage.Jumpbox
-			DirectorAddress  string
-			DirectorUsername string
-			DirectorPassword string
-			DirectorCACert   string
+		Receives  struct {
+			State storage.State
 		}
 		Returns struct {
-			Client bosh.ConfigUpdater
+			Dialer proxy.Dialer
 			Error  error
 		}
 	}
-	BoshCLICall struct {
+	EndpointCheckerCall struct {
+		CallCount int
+		Receives  struct {
+			Dialer  proxy.Dialer
+			CACerts []byte
+		}
+		Returns struct {
+			EndpointChecker bosh.EndpointChecker
+		}
+	}
+	ClientCall struct {
 		CallCount int
 
 		Receives struct {
-			Jumpbox          storage.Jumpbox
-			Stderr           io.Writer
-			DirectorAddress  string
-			DirectorUsername string
-			DirectorPassword string
-			DirectorCACert   string
+			State storage.State
 		}
 		Returns struct {
-			BoshCLI bosh.RuntimeConfigUpdater
-			Error   error
+			Client bosh.DirectorClient
+			Error  error
 		}
 	}
 }
 
-func (b *BOSHClientProvider) Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, directorCACert string) (bosh.ConfigUpdater, error) {
-	b.ClientCall.CallCount++
-	b.ClientCall.Receives.Jumpbox = jumpbox
-	b.ClientCall.Receives.DirectorAddress = directorAddress
-	b.ClientCall.Receives.DirectorUsername = directorUsername
-	b.ClientCall.Receives.DirectorPassword = directorPassword
-	b.ClientCall.Receives.DirectorCACert = directorCACert
-	return b.ClientCall.Returns.Client, b.ClientCall.Returns.Error
+func (b *BOSHClientProvider) Dialer(state storage.State) (proxy.Dialer, error) {
+	b.DialerCall.CallCount++
+	b.DialerCall.Receives.State = state
+	return b.DialerCall.Returns.Dialer, b.DialerCall.Returns.Error
 }
 
-func (b *BOSHClientProvider) BoshCLI(jumpbox storage.Jumpbox, stderr io.Writer, directorAddress, directorUsername, directorPassword, directorCACert string) (bosh.RuntimeConfigUpdater, error) {
-	b.BoshCLICall.CallCount++
-	b.BoshCLICall.Receives.Jumpbox = jumpbox
-	b.BoshCLICall.Receives.Stderr = stderr
-	b.BoshCLICall.Receives.DirectorAddress = directorAddress
-	b.BoshCLICall.Receives.DirectorUsername = directorUsername
-	b.BoshCLICall.Receives.DirectorPassword = directorPassword
-	b.BoshCLICall.Receives.DirectorCACert = directorCACert
-	return b.BoshCLICall.Returns.BoshCLI, b.BoshCLICall.Returns.Error
+func (b *BOSHClientProvider) EndpointChecker(dialer proxy.Dialer, caCerts []byte) bosh.EndpointChecker {
+	b.EndpointCheckerCall.CallCount++
+	b.EndpointCheckerCall.Receives.Dialer = dialer
+	b.EndpointCheckerCall.Receives.CACerts = caCerts
+	return b.EndpointCheckerCall.Returns.EndpointChecker
+}
 
+func (b *BOSHClientProvider) Client(state storage.State) (bosh.DirectorClient, error) {
+	b.ClientCall.CallCount++
+	b.ClientCall.Receives.State = state
+	return b.ClientCall.Returns.Client, b.ClientCall.Returns.Error
 }
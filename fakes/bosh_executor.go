@@ -34,7 +34,7 @@ type BOSHExecutor struct {
 		Receives  struct {
 			DirInput      bosh.DirInput
 			DeploymentDir string
-			Iaas          string
+			State         storage.State
 		}
 		Returns struct {
 			Error error
@@ -46,7 +46,7 @@ type BOSHExecutor struct {
 		Receives  struct {
 			DirInput      bosh.DirInput
 			DeploymentDir string
-			Iaas          string
+			State         storage.State
 		}
 		Returns struct {
 			Error error
@@ -104,20 +104,20 @@ func (e *BOSHExecutor) DeleteEnv(input bosh.DirInput, state storage.State) error
 	return e.DeleteEnvCall.Returns.Error
 }
 
-func (e *BOSHExecutor) PlanJumpbox(input bosh.DirInput, deploymentDir, iaas string) error {
+func (e *BOSHExecutor) PlanJumpbox(input bosh.DirInput, deploymentDir string, state storage.State) error {
 	e.PlanJumpboxCall.CallCount++
 	e.PlanJumpboxCall.Receives.DirInput = input
 	e.PlanJumpboxCall.Receives.DeploymentDir = deploymentDir
-	e.PlanJumpboxCall.Receives.Iaas = iaas
+	e.PlanJumpboxCall.Receives.State = state
 
 	return e.PlanJumpboxCall.Returns.Error
 }
 
-func (e *BOSHExecutor) PlanDirector(input bosh.DirInput, deploymentDir, iaas string) error {
+func (e *BOSHExecutor) PlanDirector(input bosh.DirInput, deploymentDir string, state storage.State) error {
 	e.PlanDirectorCall.CallCount++
 	e.PlanDirectorCall.Receives.DirInput = input
 	e.PlanDirectorCall.Receives.DeploymentDir = deploymentDir
-	e.PlanDirectorCall.Receives.Iaas = iaas
+	e.PlanDirectorCall.Receives.State = state
 
 	return e.PlanDirectorCall.Returns.Error
 }
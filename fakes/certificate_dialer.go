@@ -0,0 +1,29 @@
+package fakes
+
+import proxy "github.com/cloudfoundry/socks5-proxy"
+
+type CertificateDialer struct {
+	DialerCall struct {
+		CallCount int
+		Receives  struct {
+			Username    string
+			PrivateKey  string
+			Certificate string
+			ServerURL   string
+		}
+		Returns struct {
+			Dialer proxy.DialFunc
+			Error  error
+		}
+	}
+}
+
+func (c *CertificateDialer) Dialer(username, privateKey, certificate, serverURL string) (proxy.DialFunc, error) {
+	c.DialerCall.CallCount++
+	c.DialerCall.Receives.Username = username
+	c.DialerCall.Receives.PrivateKey = privateKey
+	c.DialerCall.Receives.Certificate = certificate
+	c.DialerCall.Receives.ServerURL = serverURL
+
+	return c.DialerCall.Returns.Dialer, c.DialerCall.Returns.Error
+}
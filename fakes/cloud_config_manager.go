@@ -39,6 +39,16 @@ type CloudConfigManager struct {
 			Error       error
 		}
 	}
+	ValidateCall struct {
+		CallCount int
+		Receives  struct {
+			CloudConfig string
+		}
+		Returns struct {
+			Problems []string
+			Error    error
+		}
+	}
 	IsPresentCloudConfigCall struct {
 		CallCount int
 		Returns   struct {
@@ -76,6 +86,12 @@ func (c *CloudConfigManager) Interpolate() (string, error) {
 	return c.InterpolateCall.Returns.CloudConfig, c.InterpolateCall.Returns.Error
 }
 
+func (c *CloudConfigManager) Validate(cloudConfig string) ([]string, error) {
+	c.ValidateCall.CallCount++
+	c.ValidateCall.Receives.CloudConfig = cloudConfig
+	return c.ValidateCall.Returns.Problems, c.ValidateCall.Returns.Error
+}
+
 func (c *CloudConfigManager) IsPresentCloudConfig() bool {
 	c.IsPresentCloudConfigCall.CallCount++
 	return c.IsPresentCloudConfigCall.Returns.IsPresent
@@ -21,6 +21,16 @@ type CloudConfigOpsGenerator struct {
 			Error    error
 		}
 	}
+	GenerateCPIConfigCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			CPIConfig string
+			Error     error
+		}
+	}
 }
 
 func (c *CloudConfigOpsGenerator) Generate(state storage.State) (string, error) {
@@ -32,3 +42,9 @@ func (c *CloudConfigOpsGenerator) GenerateVars(state storage.State) (string, err
 	c.GenerateVarsCall.Receives.State = state
 	return c.GenerateVarsCall.Returns.VarsYAML, c.GenerateVarsCall.Returns.Error
 }
+
+func (c *CloudConfigOpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	c.GenerateCPIConfigCall.CallCount++
+	c.GenerateCPIConfigCall.Receives.State = state
+	return c.GenerateCPIConfigCall.Returns.CPIConfig, c.GenerateCPIConfigCall.Returns.Error
+}
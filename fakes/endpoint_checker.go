@@ -0,0 +1,35 @@
+package fakes
+
+type EndpointChecker struct {
+	GetCall struct {
+		CallCount int
+		Receives  []string
+		Returns   map[string]error
+	}
+
+	UAATokenCall struct {
+		CallCount int
+		Receives  struct {
+			UAAAddress   string
+			ClientID     string
+			ClientSecret string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (e *EndpointChecker) Get(address string) error {
+	e.GetCall.CallCount++
+	e.GetCall.Receives = append(e.GetCall.Receives, address)
+	return e.GetCall.Returns[address]
+}
+
+func (e *EndpointChecker) UAAToken(uaaAddress, clientID, clientSecret string) error {
+	e.UAATokenCall.CallCount++
+	e.UAATokenCall.Receives.UAAAddress = uaaAddress
+	e.UAATokenCall.Receives.ClientID = clientID
+	e.UAATokenCall.Receives.ClientSecret = clientSecret
+	return e.UAATokenCall.Returns.Error
+}
@@ -120,6 +120,7 @@ type FileIO struct {
 
 	ReadDirCall struct {
 		CallCount int
+		Fake      func(string) ([]os.FileInfo, error)
 		Receives  struct {
 			Dirname string
 		}
@@ -271,6 +272,9 @@ func (f *FileIO) RemoveAll(path string) error {
 func (f *FileIO) ReadDir(dirname string) ([]os.FileInfo, error) {
 	f.ReadDirCall.CallCount++
 	f.ReadDirCall.Receives.Dirname = dirname
+	if f.ReadDirCall.Fake != nil {
+		return f.ReadDirCall.Fake(dirname)
+	}
 	return f.ReadDirCall.Returns.FileInfos, f.ReadDirCall.Returns.Error
 }
 
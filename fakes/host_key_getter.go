@@ -6,6 +6,7 @@ type HostKeyGetter struct {
 	GetCall struct {
 		CallCount int
 		Receives  struct {
+			Username   string
 			PrivateKey string
 			ServerURL  string
 		}
@@ -14,12 +15,26 @@ type HostKeyGetter struct {
 			Error   error
 		}
 	}
+	PinCall struct {
+		CallCount int
+		Receives  struct {
+			ServerURL string
+			HostKey   string
+		}
+	}
 }
 
-func (h *HostKeyGetter) Get(privateKey, serverURL string) (ssh.PublicKey, error) {
+func (h *HostKeyGetter) Get(username, privateKey, serverURL string) (ssh.PublicKey, error) {
 	h.GetCall.CallCount++
+	h.GetCall.Receives.Username = username
 	h.GetCall.Receives.PrivateKey = privateKey
 	h.GetCall.Receives.ServerURL = serverURL
 
 	return h.GetCall.Returns.HostKey, h.GetCall.Returns.Error
 }
+
+func (h *HostKeyGetter) Pin(serverURL, hostKey string) {
+	h.PinCall.CallCount++
+	h.PinCall.Receives.ServerURL = serverURL
+	h.PinCall.Receives.HostKey = hostKey
+}
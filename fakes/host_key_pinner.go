@@ -0,0 +1,17 @@
+package fakes
+
+type HostKeyPinner struct {
+	PinCall struct {
+		CallCount int
+		Receives  struct {
+			ServerURL string
+			HostKey   string
+		}
+	}
+}
+
+func (h *HostKeyPinner) Pin(serverURL, hostKey string) {
+	h.PinCall.CallCount++
+	h.PinCall.Receives.ServerURL = serverURL
+	h.PinCall.Receives.HostKey = hostKey
+}
@@ -0,0 +1,24 @@
+package fakes
+
+import "golang.org/x/net/proxy"
+
+type HostKeyScanner struct {
+	ScanCall struct {
+		CallCount int
+		Receives  struct {
+			Dialers []proxy.Dialer
+			Addrs   []string
+		}
+		Returns struct {
+			HostKeys map[string]string
+			Errors   map[string]error
+		}
+	}
+}
+
+func (h *HostKeyScanner) Scan(dialer proxy.Dialer, addr string) (string, error) {
+	h.ScanCall.CallCount++
+	h.ScanCall.Receives.Dialers = append(h.ScanCall.Receives.Dialers, dialer)
+	h.ScanCall.Receives.Addrs = append(h.ScanCall.Receives.Addrs, addr)
+	return h.ScanCall.Returns.HostKeys[addr], h.ScanCall.Returns.Errors[addr]
+}
@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type JumpboxProxy struct {
+	ProxyAddrCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Addr  string
+			Error error
+		}
+	}
+}
+
+func (j *JumpboxProxy) ProxyAddr(state storage.State) (string, error) {
+	j.ProxyAddrCall.CallCount++
+	j.ProxyAddrCall.Receives.State = state
+
+	return j.ProxyAddrCall.Returns.Addr, j.ProxyAddrCall.Returns.Error
+}
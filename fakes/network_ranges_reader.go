@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type NetworkRangesReader struct {
+	ReadCall struct {
+		CallCount int
+		Receives  struct {
+			Path string
+		}
+		Returns struct {
+			NetworkRanges []storage.NetworkRanges
+			Error         error
+		}
+	}
+}
+
+func (n *NetworkRangesReader) Read(path string) ([]storage.NetworkRanges, error) {
+	n.ReadCall.CallCount++
+	n.ReadCall.Receives.Path = path
+	return n.ReadCall.Returns.NetworkRanges, n.ReadCall.Returns.Error
+}
@@ -3,6 +3,7 @@ package fakes
 type PathFinder struct {
 	CommandExistsCall struct {
 		CallCount int
+		Fake      func(string) bool
 		Receives  struct {
 			Command string
 		}
@@ -10,10 +11,32 @@ type PathFinder struct {
 			Exists bool
 		}
 	}
+
+	CommandVersionCall struct {
+		CallCount int
+		Receives  struct {
+			Command     string
+			VersionArgs []string
+		}
+		Returns struct {
+			Version string
+			Error   error
+		}
+	}
 }
 
 func (p *PathFinder) CommandExists(command string) bool {
 	p.CommandExistsCall.CallCount++
 	p.CommandExistsCall.Receives.Command = command
+	if p.CommandExistsCall.Fake != nil {
+		return p.CommandExistsCall.Fake(command)
+	}
 	return p.CommandExistsCall.Returns.Exists
 }
+
+func (p *PathFinder) CommandVersion(command string, versionArgs ...string) (string, error) {
+	p.CommandVersionCall.CallCount++
+	p.CommandVersionCall.Receives.Command = command
+	p.CommandVersionCall.Receives.VersionArgs = versionArgs
+	return p.CommandVersionCall.Returns.Version, p.CommandVersionCall.Returns.Error
+}
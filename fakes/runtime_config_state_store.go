@@ -8,6 +8,20 @@ type DirProvider struct {
 			Error error
 		}
 	}
+	GetRuntimeConfigDirCall struct {
+		CallCount int
+		Returns   struct {
+			Dir   string
+			Error error
+		}
+	}
+	GetCPIConfigDirCall struct {
+		CallCount int
+		Returns   struct {
+			Dir   string
+			Error error
+		}
+	}
 }
 
 func (d *DirProvider) GetDirectorDeploymentDir() (string, error) {
@@ -15,3 +29,15 @@ func (d *DirProvider) GetDirectorDeploymentDir() (string, error) {
 
 	return d.GetDirectorDeploymentDirCall.Returns.Dir, d.GetDirectorDeploymentDirCall.Returns.Error
 }
+
+func (d *DirProvider) GetRuntimeConfigDir() (string, error) {
+	d.GetRuntimeConfigDirCall.CallCount++
+
+	return d.GetRuntimeConfigDirCall.Returns.Dir, d.GetRuntimeConfigDirCall.Returns.Error
+}
+
+func (d *DirProvider) GetCPIConfigDir() (string, error) {
+	d.GetCPIConfigDirCall.CallCount++
+
+	return d.GetCPIConfigDirCall.Returns.Dir, d.GetCPIConfigDirCall.Returns.Error
+}
@@ -0,0 +1,18 @@
+package fakes
+
+type SSHCertificateGetter struct {
+	GetCall struct {
+		CallCount int
+		Returns   struct {
+			PrivateKey  string
+			Certificate string
+			Error       error
+		}
+	}
+}
+
+func (s *SSHCertificateGetter) Get() (string, string, error) {
+	s.GetCall.CallCount++
+
+	return s.GetCall.Returns.PrivateKey, s.GetCall.Returns.Certificate, s.GetCall.Returns.Error
+}
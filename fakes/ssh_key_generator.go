@@ -0,0 +1,60 @@
+package fakes
+
+import "time"
+
+type SSHKeyGenerator struct {
+	GenerateCall struct {
+		CallCount int
+		Returns   struct {
+			PrivateKey string
+			PublicKey  string
+			Error      error
+		}
+	}
+	GenerateKeyCall struct {
+		CallCount int
+		Receives  struct {
+			KeyType string
+		}
+		Returns struct {
+			PrivateKey string
+			PublicKey  string
+			Error      error
+		}
+	}
+	GenerateCertificateCall struct {
+		CallCount int
+		Receives  struct {
+			CAPrivateKey string
+			Principal    string
+			Validity     time.Duration
+		}
+		Returns struct {
+			PrivateKey  string
+			Certificate string
+			Error       error
+		}
+	}
+}
+
+func (s *SSHKeyGenerator) Generate() (string, string, error) {
+	s.GenerateCall.CallCount++
+
+	return s.GenerateCall.Returns.PrivateKey, s.GenerateCall.Returns.PublicKey, s.GenerateCall.Returns.Error
+}
+
+func (s *SSHKeyGenerator) GenerateKey(keyType string) (string, string, error) {
+	s.GenerateKeyCall.CallCount++
+	s.GenerateKeyCall.Receives.KeyType = keyType
+
+	return s.GenerateKeyCall.Returns.PrivateKey, s.GenerateKeyCall.Returns.PublicKey, s.GenerateKeyCall.Returns.Error
+}
+
+func (s *SSHKeyGenerator) GenerateCertificate(caPrivateKey, principal string, validity time.Duration) (string, string, error) {
+	s.GenerateCertificateCall.CallCount++
+	s.GenerateCertificateCall.Receives.CAPrivateKey = caPrivateKey
+	s.GenerateCertificateCall.Receives.Principal = principal
+	s.GenerateCertificateCall.Receives.Validity = validity
+
+	return s.GenerateCertificateCall.Returns.PrivateKey, s.GenerateCertificateCall.Returns.Certificate, s.GenerateCertificateCall.Returns.Error
+}
@@ -28,6 +28,22 @@ type StateStore struct {
 		}
 	}
 
+	GetRuntimeConfigDirCall struct {
+		CallCount int
+		Returns   struct {
+			Directory string
+			Error     error
+		}
+	}
+
+	GetCPIConfigDirCall struct {
+		CallCount int
+		Returns   struct {
+			Directory string
+			Error     error
+		}
+	}
+
 	GetStateDirCall struct {
 		CallCount int
 		Returns   struct {
@@ -102,6 +118,18 @@ func (s *StateStore) GetCloudConfigDir() (string, error) {
 	return s.GetCloudConfigDirCall.Returns.Directory, s.GetCloudConfigDirCall.Returns.Error
 }
 
+func (s *StateStore) GetRuntimeConfigDir() (string, error) {
+	s.GetRuntimeConfigDirCall.CallCount++
+
+	return s.GetRuntimeConfigDirCall.Returns.Directory, s.GetRuntimeConfigDirCall.Returns.Error
+}
+
+func (s *StateStore) GetCPIConfigDir() (string, error) {
+	s.GetCPIConfigDirCall.CallCount++
+
+	return s.GetCPIConfigDirCall.Returns.Directory, s.GetCPIConfigDirCall.Returns.Error
+}
+
 func (s *StateStore) GetStateDir() string {
 	s.GetStateDirCall.CallCount++
 
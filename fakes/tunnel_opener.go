@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type TunnelOpener struct {
+	OpenCall struct {
+		CallCount int
+		Receives  struct {
+			State  storage.State
+			Target string
+		}
+		Returns struct {
+			Tunnel ssh.Tunnel
+			Error  error
+		}
+	}
+}
+
+func (t *TunnelOpener) Open(state storage.State, target string) (ssh.Tunnel, error) {
+	t.OpenCall.CallCount++
+	t.OpenCall.Receives.State = state
+	t.OpenCall.Receives.Target = target
+
+	return t.OpenCall.Returns.Tunnel, t.OpenCall.Returns.Error
+}
+
+type Tunnel struct {
+	AddrCall struct {
+		CallCount int
+		Returns   struct {
+			Addr string
+		}
+	}
+	CloseCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
+}
+
+func (t *Tunnel) Addr() string {
+	t.AddrCall.CallCount++
+
+	return t.AddrCall.Returns.Addr
+}
+
+func (t *Tunnel) Close() error {
+	t.CloseCall.CallCount++
+
+	return t.CloseCall.Returns.Error
+}
@@ -0,0 +1,83 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/ssh"
+
+type TunnelProcess struct {
+	StatusCall struct {
+		CallCount int
+		Returns   struct {
+			Status ssh.TunnelStatus
+			Error  error
+		}
+	}
+	StartCall struct {
+		CallCount int
+		Receives  struct {
+			Port int
+		}
+		Returns struct {
+			Status ssh.TunnelStatus
+			Error  error
+		}
+	}
+	StopCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
+	LockCall struct {
+		CallCount int
+		Returns   struct {
+			Release func()
+			Error   error
+		}
+	}
+	ReadyCall struct {
+		CallCount int
+		Receives  struct {
+			FD   int
+			Port int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (t *TunnelProcess) Status() (ssh.TunnelStatus, error) {
+	t.StatusCall.CallCount++
+
+	return t.StatusCall.Returns.Status, t.StatusCall.Returns.Error
+}
+
+func (t *TunnelProcess) Start(port int) (ssh.TunnelStatus, error) {
+	t.StartCall.CallCount++
+	t.StartCall.Receives.Port = port
+
+	return t.StartCall.Returns.Status, t.StartCall.Returns.Error
+}
+
+func (t *TunnelProcess) Stop() error {
+	t.StopCall.CallCount++
+
+	return t.StopCall.Returns.Error
+}
+
+func (t *TunnelProcess) Lock() (func(), error) {
+	t.LockCall.CallCount++
+
+	release := t.LockCall.Returns.Release
+	if release == nil {
+		release = func() {}
+	}
+	return release, t.LockCall.Returns.Error
+}
+
+func (t *TunnelProcess) Ready(fd, port int) error {
+	t.ReadyCall.CallCount++
+	t.ReadyCall.Receives.FD = fd
+	t.ReadyCall.Receives.Port = port
+
+	return t.ReadyCall.Returns.Error
+}
@@ -0,0 +1,32 @@
+package fakes
+
+import (
+	"net"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type TunnelServer struct {
+	ServeCall struct {
+		CallCount int
+		Receives  struct {
+			Listener   net.Listener
+			Jumpbox    storage.Jumpbox
+			PrivateKey string
+			Ready      func() error
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (t *TunnelServer) Serve(listener net.Listener, jumpbox storage.Jumpbox, privateKey string, ready func() error) error {
+	t.ServeCall.CallCount++
+	t.ServeCall.Receives.Listener = listener
+	t.ServeCall.Receives.Jumpbox = jumpbox
+	t.ServeCall.Receives.PrivateKey = privateKey
+	t.ServeCall.Receives.Ready = ready
+
+	return t.ServeCall.Returns.Error
+}
@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type VMTypesReader struct {
+	ReadCall struct {
+		CallCount int
+		Receives  struct {
+			Path string
+		}
+		Returns struct {
+			VMTypes []storage.VMType
+			Error   error
+		}
+	}
+}
+
+func (v *VMTypesReader) Read(path string) ([]storage.VMType, error) {
+	v.ReadCall.CallCount++
+	v.ReadCall.Receives.Path = path
+	return v.ReadCall.Returns.VMTypes, v.ReadCall.Returns.Error
+}
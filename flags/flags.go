@@ -3,6 +3,7 @@ package flags
 import (
 	"flag"
 	"io/ioutil"
+	"strings"
 )
 
 type Flags struct {
@@ -27,6 +28,12 @@ func (f Flags) Bool(v *bool, name string) {
 	f.set.BoolVar(v, name, false, "")
 }
 
+// StringSlice defines a flag that can be passed more than once. Every value
+// is appended to v.
+func (f Flags) StringSlice(v *[]string, name string) {
+	f.set.Var((*stringSlice)(v), name, "")
+}
+
 func (f Flags) Parse(args []string) error {
 	return f.set.Parse(args)
 }
@@ -34,3 +41,14 @@ func (f Flags) Parse(args []string) error {
 func (f Flags) Args() []string {
 	return f.set.Args()
 }
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -12,12 +12,14 @@ var _ = Describe("Flags", func() {
 		f         flags.Flags
 		stringVal string
 		boolVal   bool
+		sliceVal  []string
 	)
 
 	BeforeEach(func() {
 		f = flags.New("test")
 		f.String(&stringVal, "string", "")
 		f.Bool(&boolVal, "bool")
+		f.StringSlice(&sliceVal, "slice")
 	})
 
 	Describe("Parse", func() {
@@ -32,6 +34,12 @@ var _ = Describe("Flags", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(boolVal).To(BeTrue())
 		})
+
+		It("can parse flags that are passed more than once", func() {
+			err := f.Parse([]string{"--slice", "a", "--slice", "b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sliceVal).To(Equal([]string{"a", "b"}))
+		})
 	})
 
 	Describe("Args", func() {
@@ -1,9 +1,6 @@
 package gcp
 
 import (
-	"fmt"
-	"strings"
-
 	compute "google.golang.org/api/compute/v1"
 )
 
@@ -25,10 +22,6 @@ func (c Client) ProjectID() string {
 	return c.projectID
 }
 
-func (c Client) listInstances() (*compute.InstanceList, error) {
-	return c.computeClient.ListInstances(c.projectID, c.zone)
-}
-
 func (c Client) GetZones(region string) ([]string, error) {
 	return c.computeClient.GetZones(region, c.projectID)
 }
@@ -57,63 +50,3 @@ func (c Client) CheckExists(networkName string) (bool, error) {
 	}
 	return false, nil
 }
-
-func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
-	instanceList, err := c.listInstances()
-	if err != nil {
-		return err
-	}
-
-	var runningInstances []*compute.Instance
-	for _, instance := range instanceList.Items {
-		isInNetwork := c.isInNetwork(networkName, instance.NetworkInterfaces)
-		isBoshDirector := c.isBoshDirector(instance.Metadata)
-
-		if isInNetwork && !isBoshDirector {
-			runningInstances = append(runningInstances, instance)
-		}
-	}
-
-	if len(runningInstances) == 0 {
-		return nil
-	}
-
-	var errorMessages []string
-	for _, instance := range runningInstances {
-		var hasDeployment bool
-		for _, item := range instance.Metadata.Items {
-			if item.Key == "deployment" {
-				errorMessages = append(errorMessages, fmt.Sprintf("%s (deployment: %s)", instance.Name, *item.Value))
-				hasDeployment = true
-				break
-			}
-		}
-
-		if !hasDeployment {
-			errorMessages = append(errorMessages, fmt.Sprintf("%s (not managed by bosh)", instance.Name))
-		}
-	}
-
-	return fmt.Errorf("bbl environment is not safe to delete; vms still exist in network:\n%s",
-		strings.Join(errorMessages, "\n"))
-}
-
-func (c Client) isInNetwork(networkName string, networkInterfaces []*compute.NetworkInterface) bool {
-	for _, networkInterface := range networkInterfaces {
-		if networkInterface.Network == networkName {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (c Client) isBoshDirector(metadata *compute.Metadata) bool {
-	for _, item := range metadata.Items {
-		if item.Key == "director" && item.Value != nil && *item.Value == "bosh-init" {
-			return true
-		}
-	}
-
-	return false
-}
@@ -1,6 +1,9 @@
 package helpers
 
-import "os/exec"
+import (
+	"os/exec"
+	"strings"
+)
 
 type PathFinder struct{}
 
@@ -12,3 +15,13 @@ func (p PathFinder) CommandExists(command string) bool {
 	_, err := exec.LookPath(command)
 	return err == nil
 }
+
+// CommandVersion returns the first line the command prints when invoked with versionArgs.
+func (p PathFinder) CommandVersion(command string, versionArgs ...string) (string, error) {
+	output, err := exec.Command(command, versionArgs...).CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0]), nil
+}
@@ -41,4 +41,19 @@ var _ = Describe("PathFinder", func() {
 			})
 		})
 	})
+
+	Describe("CommandVersion", func() {
+		It("returns the first line of the version output", func() {
+			version, err := pathFinder.CommandVersion("go", "version")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(HavePrefix("go version go"))
+		})
+
+		Context("when the command cannot be run", func() {
+			It("returns an error", func() {
+				_, err := pathFinder.CommandVersion("some-missing-command", "--version")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -0,0 +1,383 @@
+// Package patch applies go-patch ops files to YAML documents, as bosh
+// interpolate does, without running the bosh CLI.
+package patch
+
+import (
+	"fmt"
+	"reflect"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Op is a single replace, remove or test operation of an ops file. A test
+// op checks that the path holds Value, or with Absent that it holds nothing.
+type Op struct {
+	Type   string      `yaml:"type"`
+	Path   string      `yaml:"path"`
+	Value  interface{} `yaml:"value,omitempty"`
+	Absent bool        `yaml:"absent,omitempty"`
+}
+
+// ParseOps parses an ops file and checks the type and path of every op.
+func ParseOps(contents []byte) ([]Op, error) {
+	var ops []Op
+	err := yaml.Unmarshal(contents, &ops)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		switch op.Type {
+		case "replace", "remove":
+		case "test":
+			if op.Absent && op.Value != nil {
+				return nil, fmt.Errorf("op %d: cannot test for both a value and absent", i+1)
+			}
+		default:
+			return nil, fmt.Errorf("op %d: unknown type %q, use replace, remove or test", i+1, op.Type)
+		}
+
+		_, err := parsePath(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("op %d: %s", i+1, err)
+		}
+	}
+
+	return ops, nil
+}
+
+// Apply applies ops to doc in order. Errors name the op that failed.
+func Apply(doc interface{}, ops []Op) (interface{}, error) {
+	for i, op := range ops {
+		var err error
+		doc, err = op.apply(doc)
+		if err != nil {
+			return nil, fmt.Errorf("op %d (%s %s): %s", i+1, op.Type, op.Path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+func (o Op) apply(doc interface{}) (interface{}, error) {
+	tokens, err := parsePath(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Type {
+	case "replace":
+		return replace(doc, tokens, 0, deepCopy(o.Value))
+	case "remove":
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("cannot remove the whole document")
+		}
+		return remove(doc, tokens, 0)
+	case "test":
+		return doc, o.test(doc, tokens)
+	}
+
+	return nil, fmt.Errorf("unknown type %q, use replace, remove or test", o.Type)
+}
+
+func (o Op) test(doc interface{}, tokens []token) error {
+	value, found, err := find(doc, tokens, 0)
+	if err != nil {
+		return err
+	}
+
+	if o.Absent {
+		if found {
+			return fmt.Errorf("expected to find nothing at %s, found %s", formatPath(tokens), describe(value))
+		}
+		return nil
+	}
+
+	if !found {
+		return fmt.Errorf("expected to find a value at %s, found nothing", formatPath(tokens))
+	}
+	if !reflect.DeepEqual(value, o.Value) {
+		return fmt.Errorf("expected the value at %s to match, found %s", formatPath(tokens), describe(value))
+	}
+	return nil
+}
+
+func replace(node interface{}, tokens []token, i int, value interface{}) (interface{}, error) {
+	if i == len(tokens) {
+		return value, nil
+	}
+
+	t := tokens[i]
+	last := i == len(tokens)-1
+
+	if t.kind == keyToken {
+		m, ok := node.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map at %s, found %s", formatPath(tokens[:i]), describe(node))
+		}
+
+		child, found := m[t.key]
+		if !found {
+			if !t.optional {
+				return nil, fmt.Errorf("expected to find map key %q at %s", t.key, formatPath(tokens[:i+1]))
+			}
+			if !last {
+				child = newContainer(tokens[i+1])
+			}
+		}
+
+		child, err := replace(child, tokens, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		m[t.key] = child
+		return m, nil
+	}
+
+	a, ok := node.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array at %s, found %s", formatPath(tokens[:i]), describe(node))
+	}
+
+	if t.kind == afterLastToken {
+		if !last {
+			return nil, fmt.Errorf("expected - to be the last segment of the path")
+		}
+		return append(a, value), nil
+	}
+
+	index, found, err := findIndex(a, tokens, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		item := value
+		if !last {
+			item, err = replace(map[interface{}]interface{}{t.key: t.value}, tokens, i+1, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(a, item), nil
+	}
+
+	switch t.modifier {
+	case "before", "after":
+		if !last {
+			return nil, fmt.Errorf("expected :%s to be used on the last segment of the path", t.modifier)
+		}
+		if t.modifier == "after" {
+			index++
+		}
+		a = append(a, nil)
+		copy(a[index+1:], a[index:])
+		a[index] = value
+		return a, nil
+	}
+
+	a[index], err = replace(a[index], tokens, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// find returns the node that tokens refer to. Missing map keys, indexes and
+// matches mean there is nothing at the path, which is not an error.
+func find(node interface{}, tokens []token, i int) (interface{}, bool, error) {
+	if i == len(tokens) {
+		return node, true, nil
+	}
+
+	t := tokens[i]
+
+	if t.kind == keyToken {
+		m, ok := node.(map[interface{}]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("expected a map at %s, found %s", formatPath(tokens[:i]), describe(node))
+		}
+
+		child, found := m[t.key]
+		if !found {
+			return nil, false, nil
+		}
+		return find(child, tokens, i+1)
+	}
+
+	a, ok := node.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("expected an array at %s, found %s", formatPath(tokens[:i]), describe(node))
+	}
+
+	if t.kind == afterLastToken {
+		return nil, false, nil
+	}
+	if t.modifier == "before" || t.modifier == "after" {
+		return nil, false, fmt.Errorf("cannot test with :%s", t.modifier)
+	}
+	if t.kind == indexToken && (t.index >= len(a) || t.index < -len(a)) {
+		return nil, false, nil
+	}
+
+	// A match that finds nothing is absent, as if the segment were optional.
+	lookup := append([]token{}, tokens...)
+	lookup[i].optional = true
+
+	index, found, err := findIndex(a, lookup, i)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return find(a[index], tokens, i+1)
+}
+
+func remove(node interface{}, tokens []token, i int) (interface{}, error) {
+	t := tokens[i]
+	last := i == len(tokens)-1
+
+	if t.kind == keyToken {
+		m, ok := node.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map at %s, found %s", formatPath(tokens[:i]), describe(node))
+		}
+
+		child, found := m[t.key]
+		if !found {
+			if t.optional {
+				return m, nil
+			}
+			return nil, fmt.Errorf("expected to find map key %q at %s", t.key, formatPath(tokens[:i+1]))
+		}
+
+		if last {
+			delete(m, t.key)
+			return m, nil
+		}
+
+		child, err := remove(child, tokens, i+1)
+		if err != nil {
+			return nil, err
+		}
+		m[t.key] = child
+		return m, nil
+	}
+
+	a, ok := node.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array at %s, found %s", formatPath(tokens[:i]), describe(node))
+	}
+
+	if t.kind == afterLastToken {
+		return nil, fmt.Errorf("cannot remove -, it is after the last array item")
+	}
+	if t.modifier == "before" || t.modifier == "after" {
+		return nil, fmt.Errorf("cannot remove with :%s", t.modifier)
+	}
+
+	index, found, err := findIndex(a, tokens, i)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return a, nil
+	}
+
+	if last {
+		return append(a[:index:index], a[index+1:]...), nil
+	}
+
+	a[index], err = remove(a[index], tokens, i+1)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// findIndex returns the array index that tokens[i] refers to, after applying
+// :prev and :next. Optional matches that find nothing are not an error.
+func findIndex(a []interface{}, tokens []token, i int) (int, bool, error) {
+	t := tokens[i]
+	path := formatPath(tokens[:i+1])
+
+	var index int
+	switch t.kind {
+	case indexToken:
+		index = t.index
+		if index < 0 {
+			index += len(a)
+		}
+		if index < 0 || index >= len(a) {
+			return 0, false, fmt.Errorf("index %d is out of bounds at %s, the array has %d items", t.index, path, len(a))
+		}
+	case matchToken:
+		matches := []int{}
+		for j, item := range a {
+			m, ok := item.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := m[t.key]; ok && fmt.Sprintf("%v", value) == t.value {
+				matches = append(matches, j)
+			}
+		}
+		if len(matches) == 0 && t.optional {
+			return 0, false, nil
+		}
+		if len(matches) != 1 {
+			return 0, false, fmt.Errorf("expected to find exactly one array item matching %s=%s at %s, found %d",
+				t.key, t.value, path, len(matches))
+		}
+		index = matches[0]
+	}
+
+	switch t.modifier {
+	case "prev":
+		index--
+	case "next":
+		index++
+	}
+	if index < 0 || index >= len(a) {
+		return 0, false, fmt.Errorf("there is no array item at %s, the array has %d items", path, len(a))
+	}
+
+	return index, true, nil
+}
+
+// newContainer returns what an optional path creates for a missing key: an
+// array when the next segment refers to array items, otherwise a map.
+func newContainer(next token) interface{} {
+	if next.kind == keyToken {
+		return map[interface{}]interface{}{}
+	}
+	return []interface{}{}
+}
+
+func describe(node interface{}) string {
+	switch node.(type) {
+	case nil:
+		return "nothing"
+	case map[interface{}]interface{}:
+		return "a map"
+	case []interface{}:
+		return "an array"
+	}
+	return fmt.Sprintf("%q", fmt.Sprintf("%v", node))
+}
+
+func deepCopy(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		m := map[interface{}]interface{}{}
+		for key, value := range n {
+			m[key] = deepCopy(value)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(n))
+		for i, value := range n {
+			a[i] = deepCopy(value)
+		}
+		return a
+	}
+	return node
+}
@@ -0,0 +1,207 @@
+package patch_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/patch"
+
+	yaml "gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const document = `
+azs:
+- name: z1
+- name: z2
+vm_types:
+- name: default
+  cloud_properties:
+    machine_type: n1-standard-1
+tags: [a, b, c]
+`
+
+func apply(ops string) (string, error) {
+	var doc interface{}
+	Expect(yaml.Unmarshal([]byte(document), &doc)).To(Succeed())
+
+	parsed, err := patch.ParseOps([]byte(ops))
+	Expect(err).NotTo(HaveOccurred())
+
+	result, err := patch.Apply(doc, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	contents, err := yaml.Marshal(result)
+	Expect(err).NotTo(HaveOccurred())
+	return string(contents), nil
+}
+
+var _ = Describe("Ops", func() {
+	Describe("ParseOps", func() {
+		It("parses the ops", func() {
+			ops, err := patch.ParseOps([]byte("- type: replace\n  path: /azs/0/name\n  value: z3\n- type: remove\n  path: /tags\n- type: test\n  path: /compilation\n  absent: true\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ops).To(Equal([]patch.Op{
+				{Type: "replace", Path: "/azs/0/name", Value: "z3"},
+				{Type: "remove", Path: "/tags"},
+				{Type: "test", Path: "/compilation", Absent: true},
+			}))
+		})
+
+		DescribeTable("returns an error naming the op",
+			func(ops, message string) {
+				_, err := patch.ParseOps([]byte(ops))
+				Expect(err).To(MatchError(message))
+			},
+			Entry("an unknown type", "- type: move\n  path: /azs\n", `op 1: unknown type "move", use replace, remove or test`),
+			Entry("a test for a value and absent", "- type: test\n  path: /azs\n  value: []\n  absent: true\n", "op 1: cannot test for both a value and absent"),
+			Entry("a relative path", "- type: remove\n  path: /azs\n- type: remove\n  path: azs\n", `op 2: path "azs" must start with /`),
+			Entry("an unknown modifier", "- type: remove\n  path: /azs/0:first\n", `op 1: path "/azs/0:first" has unknown modifier "first"`),
+			Entry("a modifier on a map key", "- type: remove\n  path: /azs:next\n", `op 1: path "/azs:next" can only use :next on array items`),
+		)
+	})
+
+	Describe("Apply", func() {
+		DescribeTable("replace",
+			func(ops, expected string) {
+				result, err := apply(ops)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(MatchYAML(expected))
+			},
+			Entry("the whole document", "- type: replace\n  path: /\n  value: {a: b}\n", "a: b\n"),
+			Entry("a map key", "- type: replace\n  path: /vm_types/0/cloud_properties/machine_type\n  value: n1-standard-2\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-2}}]
+tags: [a, b, c]
+`),
+			Entry("a missing optional key and its parents", "- type: replace\n  path: /compilation?/workers\n  value: 5\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+compilation: {workers: 5}
+`),
+			Entry("a matched item", "- type: replace\n  path: /azs/name=z2/cloud_properties?/zone\n  value: us-east1-c\n", `
+azs: [{name: z1}, {name: z2, cloud_properties: {zone: us-east1-c}}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`),
+			Entry("an optional match that is missing", "- type: replace\n  path: /vm_types/name=large?/cloud_properties/cpu\n  value: 4\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}, {name: large, cloud_properties: {cpu: 4}}]
+tags: [a, b, c]
+`),
+			Entry("an optional match at the end of the path", "- type: replace\n  path: /azs/name=z1?\n  value: {name: z1, cpi: aws}\n", `
+azs: [{name: z1, cpi: aws}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`),
+			Entry("a negative index", "- type: replace\n  path: /tags/-1\n  value: z\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, z]
+`),
+			Entry("after the last item", "- type: replace\n  path: /tags/-\n  value: d\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c, d]
+`),
+			Entry("an item before another", "- type: replace\n  path: /azs/name=z2:before\n  value: {name: z1b}\n", `
+azs: [{name: z1}, {name: z1b}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`),
+			Entry("an item after another", "- type: replace\n  path: /tags/0:after\n  value: a2\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, a2, b, c]
+`),
+			Entry("the next item", "- type: replace\n  path: /azs/name=z1:next/name\n  value: z3\n", `
+azs: [{name: z1}, {name: z3}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`),
+			Entry("an escaped key", "- type: replace\n  path: /vm_types/0/cloud_properties?/a~1b~0c\n  value: d\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1, a/b~c: d}}]
+tags: [a, b, c]
+`),
+		)
+
+		DescribeTable("remove",
+			func(ops, expected string) {
+				result, err := apply(ops)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(MatchYAML(expected))
+			},
+			Entry("a map key", "- type: remove\n  path: /vm_types/0/cloud_properties\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default}]
+tags: [a, b, c]
+`),
+			Entry("a matched item", "- type: remove\n  path: /azs/name=z1\n", `
+azs: [{name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`),
+			Entry("an index", "- type: remove\n  path: /tags/1\n", `
+azs: [{name: z1}, {name: z2}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, c]
+`),
+			Entry("a missing optional item", "- type: remove\n  path: /azs/name=z9?\n", document),
+		)
+
+		DescribeTable("test",
+			func(ops string) {
+				result, err := apply(ops)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(MatchYAML(document))
+			},
+			Entry("a scalar", "- type: test\n  path: /vm_types/name=default/cloud_properties/machine_type\n  value: n1-standard-1\n"),
+			Entry("a map", "- type: test\n  path: /azs/1\n  value: {name: z2}\n"),
+			Entry("an array", "- type: test\n  path: /tags\n  value: [a, b, c]\n"),
+			Entry("an absent key", "- type: test\n  path: /compilation/workers\n  absent: true\n"),
+			Entry("an absent match", "- type: test\n  path: /azs/name=z3\n  absent: true\n"),
+			Entry("an absent index", "- type: test\n  path: /tags/3\n  absent: true\n"),
+		)
+
+		It("applies the ops after a test that passes", func() {
+			result, err := apply("- type: test\n  path: /azs/name=z3\n  absent: true\n- type: replace\n  path: /azs/-\n  value: {name: z3}\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(MatchYAML(`
+azs: [{name: z1}, {name: z2}, {name: z3}]
+vm_types: [{name: default, cloud_properties: {machine_type: n1-standard-1}}]
+tags: [a, b, c]
+`))
+		})
+
+		DescribeTable("returns an error naming the op and the path that failed",
+			func(ops, message string) {
+				_, err := apply(ops)
+				Expect(err).To(MatchError(message))
+			},
+			Entry("a missing key", "- type: replace\n  path: /tags/0\n  value: x\n- type: replace\n  path: /compilation/workers\n  value: 5\n",
+				`op 2 (replace /compilation/workers): expected to find map key "compilation" at /compilation`),
+			Entry("a missing match", "- type: remove\n  path: /azs/name=z9/cloud_properties\n",
+				"op 1 (remove /azs/name=z9/cloud_properties): expected to find exactly one array item matching name=z9 at /azs/name=z9, found 0"),
+			Entry("an index out of bounds", "- type: replace\n  path: /tags/3\n  value: d\n",
+				"op 1 (replace /tags/3): index 3 is out of bounds at /tags/3, the array has 3 items"),
+			Entry("a key on an array", "- type: replace\n  path: /azs/name\n  value: z1\n",
+				"op 1 (replace /azs/name): expected a map at /azs, found an array"),
+			Entry("an index on a string", "- type: replace\n  path: /tags/0/0\n  value: z1\n",
+				`op 1 (replace /tags/0/0): expected an array at /tags/0, found "a"`),
+			Entry("segments after -", "- type: replace\n  path: /tags/-/name\n  value: z1\n",
+				"op 1 (replace /tags/-/name): expected - to be the last segment of the path"),
+			Entry("removing the document", "- type: remove\n  path: /\n",
+				"op 1 (remove /): cannot remove the whole document"),
+			Entry("a test for a different value", "- type: test\n  path: /tags/0\n  value: b\n",
+				`op 1 (test /tags/0): expected the value at /tags/0 to match, found "a"`),
+			Entry("a test for a missing value", "- type: test\n  path: /compilation/workers\n  value: 5\n",
+				"op 1 (test /compilation/workers): expected to find a value at /compilation/workers, found nothing"),
+			Entry("a test for an absent value that is present", "- type: test\n  path: /azs/name=z1\n  absent: true\n",
+				"op 1 (test /azs/name=z1): expected to find nothing at /azs/name=z1, found a map"),
+		)
+	})
+})
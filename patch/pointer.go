@@ -0,0 +1,97 @@
+package patch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	keyToken tokenKind = iota
+	indexToken
+	afterLastToken
+	matchToken
+)
+
+// token is one segment of a path, e.g. "vm_types", "0", "-" or "name=z1?".
+type token struct {
+	kind     tokenKind
+	key      string
+	value    string
+	index    int
+	optional bool
+	modifier string
+	segment  string
+}
+
+// parsePath splits a go-patch path into tokens. Segments are separated by
+// "/", in which "~1" stands for "/" and "~0" for "~". A "?" suffix makes the
+// segment optional, and every segment after it too.
+func parsePath(path string) ([]token, error) {
+	if path == "" || path[0] != '/' {
+		return nil, fmt.Errorf("path %q must start with /", path)
+	}
+	if path == "/" {
+		return []token{}, nil
+	}
+
+	tokens := []token{}
+	optional := false
+	for _, segment := range strings.Split(path[1:], "/") {
+		segment = strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1)
+
+		t := token{segment: segment}
+
+		parts := strings.Split(segment, ":")
+		base := parts[0]
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("path %q has more than one modifier in %q", path, segment)
+		}
+		if len(parts) == 2 {
+			switch parts[1] {
+			case "prev", "next", "before", "after":
+				t.modifier = parts[1]
+			default:
+				return nil, fmt.Errorf("path %q has unknown modifier %q", path, parts[1])
+			}
+		}
+
+		if strings.HasSuffix(base, "?") {
+			optional = true
+			base = strings.TrimSuffix(base, "?")
+		}
+		t.optional = optional
+
+		if base == "-" {
+			t.kind = afterLastToken
+		} else if index, err := strconv.Atoi(base); err == nil {
+			t.kind = indexToken
+			t.index = index
+		} else if i := strings.Index(base, "="); i > 0 {
+			t.kind = matchToken
+			t.key = base[:i]
+			t.value = base[i+1:]
+		} else {
+			t.kind = keyToken
+			t.key = base
+		}
+
+		if t.modifier != "" && (t.kind == keyToken || t.kind == afterLastToken) {
+			return nil, fmt.Errorf("path %q can only use :%s on array items", path, t.modifier)
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+func formatPath(tokens []token) string {
+	segments := []string{}
+	for _, t := range tokens {
+		segments = append(segments, strings.Replace(strings.Replace(t.segment, "~", "~0", -1), "/", "~1", -1))
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,98 @@
+package patch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var variablePattern = regexp.MustCompile(`\(\((!?[-/\.\w\pL]+)\)\)`)
+
+// Interpolate replaces ((variables)) in map keys and values with their value
+// in vars. A value that is only a variable takes the type of the variable's
+// value; a variable within a longer string is replaced by its text. Dotted
+// names, e.g. ((cert.ca)), look up keys within a value. Variables without a
+// value are left as they are, like bosh interpolate does.
+func Interpolate(node interface{}, vars map[interface{}]interface{}) (interface{}, error) {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		m := map[interface{}]interface{}{}
+		for key, value := range n {
+			key, err := Interpolate(key, vars)
+			if err != nil {
+				return nil, err
+			}
+			value, err := Interpolate(value, vars)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(n))
+		for i, value := range n {
+			value, err := Interpolate(value, vars)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = value
+		}
+		return a, nil
+	case string:
+		return interpolateString(n, vars)
+	}
+
+	return node, nil
+}
+
+func interpolateString(s string, vars map[interface{}]interface{}) (interface{}, error) {
+	matches := variablePattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(s) {
+		value, found := lookup(s[matches[0][2]:matches[0][3]], vars)
+		if !found {
+			return s, nil
+		}
+		return deepCopy(value), nil
+	}
+
+	var err error
+	result := variablePattern.ReplaceAllStringFunc(s, func(variable string) string {
+		name := variablePattern.FindStringSubmatch(variable)[1]
+		value, found := lookup(name, vars)
+		if !found {
+			return variable
+		}
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			if err == nil {
+				err = fmt.Errorf("variable %q in %q is not a string, number or boolean", name, s)
+			}
+			return variable
+		}
+		return fmt.Sprintf("%v", value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func lookup(name string, vars map[interface{}]interface{}) (interface{}, bool) {
+	keys := strings.Split(strings.TrimPrefix(name, "!"), ".")
+
+	var value interface{} = vars
+	for _, key := range keys {
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
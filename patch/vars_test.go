@@ -0,0 +1,53 @@
+package patch_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/patch"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolate", func() {
+	var vars map[interface{}]interface{}
+
+	BeforeEach(func() {
+		vars = map[interface{}]interface{}{
+			"zone":    "us-east1-b",
+			"workers": 5,
+			"subnets": []interface{}{"subnet-1", "subnet-2"},
+			"cert":    map[interface{}]interface{}{"ca": "some-ca"},
+		}
+	})
+
+	It("replaces variables, keeping the type of values that are only a variable", func() {
+		result, err := patch.Interpolate(map[interface{}]interface{}{
+			"zone":     "((zone))",
+			"workers":  "((workers))",
+			"subnets":  "((subnets))",
+			"ca":       "((cert.ca))",
+			"label":    "((zone))-((workers))",
+			"missing":  "((missing))",
+			"secret":   "((!zone))",
+			"list":     []interface{}{"((zone))", 1},
+			"((zone))": true,
+		}, vars)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result).To(Equal(map[interface{}]interface{}{
+			"zone":       "us-east1-b",
+			"workers":    5,
+			"subnets":    []interface{}{"subnet-1", "subnet-2"},
+			"ca":         "some-ca",
+			"label":      "us-east1-b-5",
+			"missing":    "((missing))",
+			"secret":     "us-east1-b",
+			"list":       []interface{}{"us-east1-b", 1},
+			"us-east1-b": true,
+		}))
+	})
+
+	It("returns an error when a variable within a string is not a scalar", func() {
+		_, err := patch.Interpolate([]interface{}{"subnets: ((subnets))"}, vars)
+		Expect(err).To(MatchError(`variable "subnets" in "subnets: ((subnets))" is not a string, number or boolean`))
+	})
+})
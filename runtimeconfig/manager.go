@@ -1,66 +1,205 @@
 package runtimeconfig
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
+const opsFileSuffix = "-ops"
+
 type Manager struct {
 	logger             logger
-	boshClientProvider boshClientProvider
+	command            command
 	dirProvider        dirProvider
+	boshClientProvider boshClientProvider
+	fs                 fs
 }
 
 type logger interface {
 	Step(string, ...interface{})
 }
 
+type command interface {
+	Run(stdout io.Writer, workingDirectory string, args []string) error
+}
+
 type boshClientProvider interface {
-	BoshCLI(jumpbox storage.Jumpbox, stderr io.Writer, directorAddress, directorUsername, directorPassword, directorCACert string) (bosh.RuntimeConfigUpdater, error)
+	Client(state storage.State) (bosh.DirectorClient, error)
 }
 
 type dirProvider interface {
 	GetDirectorDeploymentDir() (string, error)
+	GetRuntimeConfigDir() (string, error)
+	GetCPIConfigDir() (string, error)
+}
+
+type fs interface {
+	fileio.FileReader
+	fileio.DirReader
 }
 
-func NewManager(logger logger, dirProvider dirProvider, boshClientProvider boshClientProvider) Manager {
+type namedConfig struct {
+	name     string
+	path     string
+	opsFiles []string
+}
+
+func NewManager(logger logger, cmd command, dirProvider dirProvider, boshClientProvider boshClientProvider, fs fs) Manager {
 	return Manager{
 		logger:             logger,
-		boshClientProvider: boshClientProvider,
+		command:            cmd,
 		dirProvider:        dirProvider,
+		boshClientProvider: boshClientProvider,
+		fs:                 fs,
 	}
 }
 
+// Update applies the bundled dns runtime config followed by every config in
+// the runtime-config and cpi-config directories of the state dir. A config
+// file <name>.yml is applied as the config <name>, and <name>-ops.yml is
+// applied to it as an ops file. A runtime-config/dns.yml replaces the
+// bundled dns runtime config.
 func (m Manager) Update(state storage.State) error {
-	boshCLI, err := m.boshClientProvider.BoshCLI(state.Jumpbox,
-		os.Stderr,
-		state.BOSH.DirectorAddress,
-		state.BOSH.DirectorUsername,
-		state.BOSH.DirectorPassword,
-		state.BOSH.DirectorSSLCA,
-	)
+	boshClient, err := m.boshClientProvider.Client(state)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("Create bosh client: %s", err)
 	}
 
-	dir, err := m.dirProvider.GetDirectorDeploymentDir()
+	deploymentDir, err := m.dirProvider.GetDirectorDeploymentDir()
 	if err != nil {
 		return fmt.Errorf("could not find bosh-deployment directory: %s", err)
 	}
 
-	filename := "dns.yml"
-	m.logger.Step("applying runtime config")
-	filepath := filepath.Join(dir, "runtime-configs", filename)
+	runtimeConfigDir, err := m.dirProvider.GetRuntimeConfigDir()
+	if err != nil {
+		return fmt.Errorf("could not find runtime-config directory: %s", err)
+	}
 
-	err = boshCLI.UpdateRuntimeConfig(filepath, "dns")
+	cpiConfigDir, err := m.dirProvider.GetCPIConfigDir()
 	if err != nil {
-		return fmt.Errorf("failed to update runtime-config: %s", err)
+		return fmt.Errorf("could not find cpi-config directory: %s", err)
+	}
+
+	bundled := map[string]string{
+		"dns": filepath.Join(deploymentDir, "runtime-configs", "dns.yml"),
+	}
+
+	runtimeConfigs, err := m.configs(runtimeConfigDir, bundled)
+	if err != nil {
+		return fmt.Errorf("failed to read runtime-config directory: %s", err)
+	}
+
+	cpiConfigs, err := m.configs(cpiConfigDir, map[string]string{})
+	if err != nil {
+		return fmt.Errorf("failed to read cpi-config directory: %s", err)
+	}
+
+	for _, config := range runtimeConfigs {
+		m.logger.Step("applying runtime config %s", config.name)
+		err = m.apply(boshClient, "runtime", config)
+		if err != nil {
+			return fmt.Errorf("failed to update runtime-config %s: %s", config.name, err)
+		}
+	}
+
+	for _, config := range cpiConfigs {
+		m.logger.Step("applying cpi config %s", config.name)
+		err = m.apply(boshClient, "cpi", config)
+		if err != nil {
+			return fmt.Errorf("failed to update cpi-config %s: %s", config.name, err)
+		}
 	}
 
 	return nil
 }
+
+func (m Manager) configs(dir string, bundled map[string]string) ([]namedConfig, error) {
+	files, err := m.fs.ReadDir(dir)
+	if err != nil {
+		return []namedConfig{}, err
+	}
+
+	paths := map[string]string{}
+	for name, path := range bundled {
+		paths[name] = path
+	}
+
+	opsFiles := map[string][]string{}
+	for _, file := range files {
+		name, ok := configName(file.Name())
+		if file.IsDir() || !ok {
+			continue
+		}
+
+		if strings.HasSuffix(name, opsFileSuffix) {
+			target := strings.TrimSuffix(name, opsFileSuffix)
+			opsFiles[target] = append(opsFiles[target], filepath.Join(dir, file.Name()))
+			continue
+		}
+
+		paths[name] = filepath.Join(dir, file.Name())
+	}
+
+	for name, ops := range opsFiles {
+		if _, ok := paths[name]; !ok {
+			return []namedConfig{}, fmt.Errorf("%s has no matching config %s.yml", filepath.Base(ops[0]), name)
+		}
+	}
+
+	configs := []namedConfig{}
+	for name, path := range paths {
+		configs = append(configs, namedConfig{
+			name:     name,
+			path:     path,
+			opsFiles: opsFiles[name],
+		})
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].name < configs[j].name
+	})
+
+	return configs, nil
+}
+
+func (m Manager) apply(boshClient bosh.DirectorClient, configType string, config namedConfig) error {
+	content, err := m.interpolate(config)
+	if err != nil {
+		return err
+	}
+
+	return boshClient.UpdateConfig(configType, config.name, content)
+}
+
+func (m Manager) interpolate(config namedConfig) ([]byte, error) {
+	if len(config.opsFiles) == 0 {
+		return m.fs.ReadFile(config.path)
+	}
+
+	args := []string{"interpolate", config.path}
+	for _, opsFile := range config.opsFiles {
+		args = append(args, "-o", opsFile)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	err := m.command.Run(buf, filepath.Dir(config.path), args)
+	if err != nil {
+		return nil, fmt.Errorf("interpolate: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func configName(filename string) (string, bool) {
+	if filepath.Ext(filename) != ".yml" {
+		return "", false
+	}
+	return strings.TrimSuffix(filename, ".yml"), true
+}
@@ -2,6 +2,10 @@ package runtimeconfig_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
@@ -14,18 +18,24 @@ var _ = Describe("Manager", func() {
 	var (
 		logger             *fakes.Logger
 		boshCLI            *fakes.BOSHCLI
+		boshClient         *fakes.BOSHClient
 		boshClientProvider *fakes.BOSHClientProvider
 		manager            runtimeconfig.Manager
 		incomingState      storage.State
 
-		fileIO      *fakes.FileIO
-		dirProvider *fakes.DirProvider
+		fileIO         *fakes.FileIO
+		dirProvider    *fakes.DirProvider
+		runtimeConfigs []os.FileInfo
+		cpiConfigs     []os.FileInfo
+		interpolations [][]string
 	)
+
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
 		dirProvider = &fakes.DirProvider{}
 		fileIO = &fakes.FileIO{}
 		boshCLI = &fakes.BOSHCLI{}
+		boshClient = &fakes.BOSHClient{}
 		boshClientProvider = &fakes.BOSHClientProvider{}
 		incomingState = storage.State{
 			IAAS: "gcp",
@@ -33,50 +43,156 @@ var _ = Describe("Manager", func() {
 				DirectorAddress:  "some-director-address",
 				DirectorUsername: "some-director-username",
 				DirectorPassword: "some-director-password",
+				DirectorSSLCA:    "some-director-ca",
 			},
 		}
-		boshClientProvider.BoshCLICall.Returns.BoshCLI = boshCLI
-		manager = runtimeconfig.NewManager(logger, dirProvider, boshClientProvider)
+
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+		dirProvider.GetDirectorDeploymentDirCall.Returns.Dir = "some-dir"
+		dirProvider.GetRuntimeConfigDirCall.Returns.Dir = "runtime-config"
+		dirProvider.GetCPIConfigDirCall.Returns.Dir = "cpi-config"
+
+		runtimeConfigs = []os.FileInfo{}
+		cpiConfigs = []os.FileInfo{}
+		fileIO.ReadDirCall.Fake = func(dir string) ([]os.FileInfo, error) {
+			if dir == "cpi-config" {
+				return cpiConfigs, nil
+			}
+			return runtimeConfigs, nil
+		}
+		fileIO.ReadFileCall.Fake = func(path string) ([]byte, error) {
+			return []byte(fmt.Sprintf("contents of %s", path)), nil
+		}
+
+		interpolations = [][]string{}
+		boshCLI.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+			interpolations = append(interpolations, args)
+			stdout.Write([]byte(fmt.Sprintf("interpolated %s", strings.Join(args[1:], " "))))
+			return nil
+		}
+
+		manager = runtimeconfig.NewManager(logger, boshCLI, dirProvider, boshClientProvider, fileIO)
 	})
+
 	Describe("Update", func() {
 		It("logs steps taken", func() {
 			err := manager.Update(incomingState)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(logger.StepCall.Messages).To(Equal([]string{
-				"applying runtime config",
+				"applying runtime config dns",
 			}))
 		})
 
-		It("updates the bosh director with a runtime config provided a valid bbl state", func() {
-			dirProvider.GetDirectorDeploymentDirCall.Returns.Dir = "some-dir"
-
-			err := manager.Update(incomingState) // TODO: name config - after filename?
+		It("updates the bosh director with the bundled dns runtime config", func() {
+			err := manager.Update(incomingState)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(boshCLI.UpdateRuntimeConfigCall.Receives.Filepath).To(Equal("some-dir/runtime-configs/dns.yml"))
-			Expect(boshCLI.UpdateRuntimeConfigCall.Receives.Name).To(Equal("dns"))
+			Expect(boshClientProvider.ClientCall.Receives.State).To(Equal(incomingState))
+
+			Expect(boshClient.UpdateConfigCall.Receives).To(Equal([]fakes.BOSHConfigReceive{
+				{Type: "runtime", Name: "dns", Content: []byte("contents of some-dir/runtime-configs/dns.yml")},
+			}))
+			Expect(interpolations).To(BeEmpty())
+		})
+
+		Context("when the state dir contains runtime and cpi configs", func() {
+			BeforeEach(func() {
+				runtimeConfigs = []os.FileInfo{
+					fakes.FileInfo{FileName: "syslog.yml"},
+					fakes.FileInfo{FileName: "dns.yml"},
+					fakes.FileInfo{FileName: "syslog-ops.yml"},
+					fakes.FileInfo{FileName: "README.md"},
+					fakes.DirFileInfo{FileInfo: fakes.FileInfo{FileName: "nested.yml"}},
+				}
+				cpiConfigs = []os.FileInfo{
+					fakes.FileInfo{FileName: "cpis.yml"},
+				}
+			})
+
+			It("applies each config by name, interpolating ops files", func() {
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(interpolations).To(Equal([][]string{
+					{"interpolate", "runtime-config/syslog.yml", "-o", "runtime-config/syslog-ops.yml"},
+				}))
+
+				Expect(boshClient.UpdateConfigCall.Receives).To(Equal([]fakes.BOSHConfigReceive{
+					{Type: "runtime", Name: "dns", Content: []byte("contents of runtime-config/dns.yml")},
+					{Type: "runtime", Name: "syslog", Content: []byte("interpolated runtime-config/syslog.yml -o runtime-config/syslog-ops.yml")},
+					{Type: "cpi", Name: "cpis", Content: []byte("contents of cpi-config/cpis.yml")},
+				}))
+
+				Expect(logger.StepCall.Messages).To(Equal([]string{
+					"applying runtime config dns",
+					"applying runtime config syslog",
+					"applying cpi config cpis",
+				}))
+			})
 		})
 
 		Context("failure cases", func() {
-			Context("config director deployment dir does not exist", func() {
-				It("returns an error", func() {
-					dirProvider.GetDirectorDeploymentDirCall.Returns.Dir = ""
-					dirProvider.GetDirectorDeploymentDirCall.Returns.Error = errors.New("lime")
-
-					err := manager.Update(storage.State{})
-					Expect(err).To(MatchError("could not find bosh-deployment directory: lime"))
-				})
+			It("returns an error when the bosh client cannot be created", func() {
+				boshClientProvider.ClientCall.Returns.Error = errors.New("kiwi")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("Create bosh client: kiwi"))
+			})
+
+			It("returns an error when the director deployment dir does not exist", func() {
+				dirProvider.GetDirectorDeploymentDirCall.Returns.Error = errors.New("lime")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("could not find bosh-deployment directory: lime"))
+			})
+
+			It("returns an error when the runtime-config dir does not exist", func() {
+				dirProvider.GetRuntimeConfigDirCall.Returns.Error = errors.New("lemon")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("could not find runtime-config directory: lemon"))
+			})
+
+			It("returns an error when the cpi-config dir does not exist", func() {
+				dirProvider.GetCPIConfigDirCall.Returns.Error = errors.New("grapefruit")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("could not find cpi-config directory: grapefruit"))
+			})
+
+			It("returns an error when a config dir cannot be read", func() {
+				fileIO.ReadDirCall.Fake = nil
+				fileIO.ReadDirCall.Returns.Error = errors.New("pomelo")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("failed to read runtime-config directory: pomelo"))
+			})
+
+			It("returns an error when an ops file has no matching config", func() {
+				cpiConfigs = []os.FileInfo{fakes.FileInfo{FileName: "vsphere-ops.yml"}}
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("failed to read cpi-config directory: vsphere-ops.yml has no matching config vsphere.yml"))
+			})
+
+			It("returns an error when interpolation fails", func() {
+				runtimeConfigs = []os.FileInfo{
+					fakes.FileInfo{FileName: "syslog.yml"},
+					fakes.FileInfo{FileName: "syslog-ops.yml"},
+				}
+				boshCLI.RunStub = func(io.Writer, string, []string) error {
+					return errors.New("tangerine")
+				}
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("failed to update runtime-config syslog: interpolate: tangerine"))
 			})
 
-			Context("when bosh cli fails to update the runtime config", func() {
-				BeforeEach(func() {
-					boshCLI.UpdateRuntimeConfigCall.Returns.Error = errors.New("mandarin")
-				})
+			It("returns an error when the director rejects the config", func() {
+				boshClient.UpdateConfigCall.Returns.Error = errors.New("mandarin")
 
-				It("returns an error", func() {
-					err := manager.Update(storage.State{})
-					Expect(err).To(MatchError("failed to update runtime-config: mandarin"))
-				})
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("failed to update runtime-config dns: mandarin"))
 			})
 		})
 	})
@@ -0,0 +1,78 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Certificates are valid from a little before they are issued, so clocks
+// that are slightly behind the jumpbox's still accept them.
+const certificateClockSkew = 5 * time.Minute
+
+// GenerateCertificate returns a new ed25519 private key and a user
+// certificate for it in authorized_keys format. The certificate is signed by
+// caPrivateKey and lets principal log in until validity has passed.
+func (k KeyGenerator) GenerateCertificate(caPrivateKey, principal string, validity time.Duration) (string, string, error) {
+	ca, err := gossh.ParsePrivateKey([]byte(caPrivateKey))
+	if err != nil {
+		return "", "", fmt.Errorf("parse ssh CA: %s", err)
+	}
+
+	privateKey, publicKey, err := k.GenerateKey(KeyTypeEd25519)
+	if err != nil {
+		return "", "", err // not tested
+	}
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", "", fmt.Errorf("parse public key: %s", err) // not tested
+	}
+
+	now := time.Now()
+	certificate := &gossh.Certificate{
+		Key:             key,
+		CertType:        gossh.UserCert,
+		KeyId:           fmt.Sprintf("bbl-%s-%d", principal, now.Unix()),
+		ValidPrincipals: []string{principal},
+		ValidAfter:      uint64(now.Add(-certificateClockSkew).Unix()),
+		ValidBefore:     uint64(now.Add(validity).Unix()),
+		Permissions: gossh.Permissions{
+			Extensions: map[string]string{
+				"permit-port-forwarding": "",
+				"permit-pty":             "",
+			},
+		},
+	}
+
+	err = certificate.SignCert(rand.Reader, ca)
+	if err != nil {
+		return "", "", fmt.Errorf("sign certificate: %s", err) // not tested
+	}
+
+	return privateKey, string(gossh.MarshalAuthorizedKey(certificate)), nil
+}
+
+// NewCertSigner returns a signer that authenticates with the private key and
+// presents its certificate.
+func NewCertSigner(privateKey, certificate string) (gossh.Signer, error) {
+	signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %s", err)
+	}
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %s", err)
+	}
+
+	cert, ok := key.(*gossh.Certificate)
+	if !ok {
+		return nil, errors.New("parse certificate: not a certificate")
+	}
+
+	return gossh.NewCertSigner(cert, signer)
+}
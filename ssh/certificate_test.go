@@ -0,0 +1,92 @@
+package ssh_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Certificates", func() {
+	var (
+		caPrivateKey string
+		caPublicKey  gossh.PublicKey
+	)
+
+	BeforeEach(func() {
+		var err error
+		var publicKey string
+		caPrivateKey, publicKey, err = ssh.KeyGenerator{}.GenerateKey(ssh.KeyTypeEd25519)
+		Expect(err).NotTo(HaveOccurred())
+
+		caPublicKey, _, _, _, err = gossh.ParseAuthorizedKey([]byte(publicKey))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("GenerateCertificate", func() {
+		It("generates a key and a short-lived user certificate for it signed by the CA", func() {
+			privateKey, certificate, err := ssh.KeyGenerator{}.GenerateCertificate(caPrivateKey, "jumpbox", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(certificate))
+			Expect(err).NotTo(HaveOccurred())
+			cert, ok := key.(*gossh.Certificate)
+			Expect(ok).To(BeTrue())
+
+			signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert.Key.Marshal()).To(Equal(signer.PublicKey().Marshal()))
+
+			Expect(cert.CertType).To(Equal(uint32(gossh.UserCert)))
+			Expect(cert.ValidPrincipals).To(Equal([]string{"jumpbox"}))
+			Expect(cert.Permissions.Extensions).To(HaveKey("permit-port-forwarding"))
+			Expect(time.Unix(int64(cert.ValidBefore), 0)).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+			checker := &gossh.CertChecker{
+				IsUserAuthority: func(auth gossh.PublicKey) bool {
+					return string(auth.Marshal()) == string(caPublicKey.Marshal())
+				},
+			}
+			Expect(checker.CheckCert("jumpbox", cert)).To(Succeed())
+			Expect(checker.CheckCert("root", cert)).NotTo(Succeed())
+		})
+
+		Context("when the CA cannot be parsed", func() {
+			It("returns an error", func() {
+				_, _, err := ssh.KeyGenerator{}.GenerateCertificate("%%%", "jumpbox", time.Hour)
+				Expect(err).To(MatchError(ContainSubstring("parse ssh CA")))
+			})
+		})
+	})
+
+	Describe("NewCertSigner", func() {
+		It("returns a signer that presents the certificate", func() {
+			privateKey, certificate, err := ssh.KeyGenerator{}.GenerateCertificate(caPrivateKey, "jumpbox", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			signer, err := ssh.NewCertSigner(privateKey, certificate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.PublicKey().Type()).To(Equal(gossh.CertAlgoED25519v01))
+		})
+
+		Context("when the certificate is a plain public key", func() {
+			It("returns an error", func() {
+				privateKey, publicKey, err := ssh.KeyGenerator{}.GenerateKey(ssh.KeyTypeEd25519)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = ssh.NewCertSigner(privateKey, publicKey)
+				Expect(err).To(MatchError("parse certificate: not a certificate"))
+			})
+		})
+
+		Context("when the private key cannot be parsed", func() {
+			It("returns an error", func() {
+				_, err := ssh.NewCertSigner("%%%", "")
+				Expect(err).To(MatchError(ContainSubstring("parse private key")))
+			})
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	proxy "github.com/cloudfoundry/socks5-proxy"
+	gossh "golang.org/x/crypto/ssh"
+	netproxy "golang.org/x/net/proxy"
+)
+
+const hostKeyScanTimeout = 30 * time.Second
+
+var errHostKeyScanned = errors.New("host key scanned")
+
+type hostKeyGetter interface {
+	Get(username, privateKey, serverURL string) (gossh.PublicKey, error)
+}
+
+// HostKeys checks the host keys presented to the socks5 proxy against the
+// keys recorded in the bbl state. Servers without a recorded key are trusted
+// on first use, as they were before keys were recorded.
+type HostKeys struct {
+	hostKeyGetter hostKeyGetter
+	mutex         *sync.Mutex
+	pinned        map[string]string
+}
+
+func NewHostKeys(hostKeyGetter hostKeyGetter) HostKeys {
+	return HostKeys{
+		hostKeyGetter: hostKeyGetter,
+		mutex:         &sync.Mutex{},
+		pinned:        map[string]string{},
+	}
+}
+
+// Pin records the host key, in authorized_keys format, that serverURL must
+// present. An empty key removes the pin.
+func (h HostKeys) Pin(serverURL, hostKey string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if hostKey == "" {
+		delete(h.pinned, serverURL)
+		return
+	}
+	h.pinned[serverURL] = hostKey
+}
+
+func (h HostKeys) Get(username, privateKey, serverURL string) (gossh.PublicKey, error) {
+	key, err := h.hostKeyGetter.Get(username, privateKey, serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.verify(serverURL, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Dialer connects to the jumpbox with a user certificate, which the socks5
+// proxy's own dialer cannot present. The jumpbox must present its pinned
+// host key, if it has one.
+func (h HostKeys) Dialer(username, privateKey, certificate, serverURL string) (proxy.DialFunc, error) {
+	signer, err := NewCertSigner(privateKey, certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig := proxy.NewSSHClientConfig(username, func(hostname string, remote net.Addr, key gossh.PublicKey) error {
+		return h.verify(serverURL, key)
+	}, gossh.PublicKeys(signer))
+
+	conn, err := gossh.Dial("tcp", serverURL, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ssh dial: %s", err)
+	}
+
+	return conn.Dial, nil
+}
+
+func (h HostKeys) verify(serverURL string, key gossh.PublicKey) error {
+	h.mutex.Lock()
+	pinned, ok := h.pinned[serverURL]
+	h.mutex.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	pinnedKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(pinned))
+	if err != nil {
+		return fmt.Errorf("parse host key for %s: %s", serverURL, err)
+	}
+
+	if !bytes.Equal(key.Marshal(), pinnedKey.Marshal()) {
+		return fmt.Errorf("host key for %s does not match the bbl state: expected %s, got %s",
+			serverURL, gossh.FingerprintSHA256(pinnedKey), gossh.FingerprintSHA256(key))
+	}
+
+	return nil
+}
+
+// Scan returns the host key of the ssh server at addr in authorized_keys
+// format. The handshake is abandoned before authenticating, so no
+// credentials are needed.
+func (HostKeys) Scan(dialer netproxy.Dialer, addr string) (string, error) {
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("dial %s: %s", addr, err)
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(hostKeyScanTimeout))
+
+	var hostKey gossh.PublicKey
+	_, _, _, err = gossh.NewClientConn(conn, addr, &gossh.ClientConfig{
+		User: "jumpbox",
+		HostKeyCallback: func(hostname string, remote net.Addr, key gossh.PublicKey) error {
+			hostKey = key
+			return errHostKeyScanned
+		},
+	})
+	if hostKey == nil {
+		return "", fmt.Errorf("ssh handshake with %s: %s", addr, err)
+	}
+
+	return strings.TrimSpace(string(gossh.MarshalAuthorizedKey(hostKey))), nil
+}
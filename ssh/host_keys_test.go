@@ -0,0 +1,234 @@
+package ssh_test
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type hostKeyGetter struct {
+	key      gossh.PublicKey
+	err      error
+	received []string
+}
+
+func (h *hostKeyGetter) Get(username, privateKey, serverURL string) (gossh.PublicKey, error) {
+	h.received = []string{username, privateKey, serverURL}
+	return h.key, h.err
+}
+
+func newHostKey() (gossh.Signer, string) {
+	privateKey, publicKey, err := ssh.KeyGenerator{}.Generate()
+	Expect(err).NotTo(HaveOccurred())
+
+	signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+	Expect(err).NotTo(HaveOccurred())
+
+	return signer, strings.TrimSpace(publicKey)
+}
+
+var _ = Describe("HostKeys", func() {
+	var (
+		signer    gossh.Signer
+		publicKey string
+		getter    *hostKeyGetter
+		hostKeys  ssh.HostKeys
+	)
+
+	BeforeEach(func() {
+		signer, publicKey = newHostKey()
+		getter = &hostKeyGetter{key: signer.PublicKey()}
+		hostKeys = ssh.NewHostKeys(getter)
+	})
+
+	Describe("Get", func() {
+		It("trusts the key of a server without a pinned key", func() {
+			key, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(key).To(Equal(signer.PublicKey()))
+			Expect(getter.received).To(Equal([]string{"jumpbox", "some-private-key", "some-jumpbox:22"}))
+		})
+
+		It("returns the key of a server that matches its pinned key", func() {
+			hostKeys.Pin("some-jumpbox:22", publicKey)
+
+			key, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(signer.PublicKey()))
+		})
+
+		It("trusts the server again when the pin is removed", func() {
+			_, otherKey := newHostKey()
+			hostKeys.Pin("some-jumpbox:22", otherKey)
+			hostKeys.Pin("some-jumpbox:22", "")
+
+			_, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the server presents a different key", func() {
+			It("returns an error", func() {
+				otherSigner, otherKey := newHostKey()
+				hostKeys.Pin("some-jumpbox:22", otherKey)
+
+				_, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+				Expect(err).To(MatchError(ContainSubstring("host key for some-jumpbox:22 does not match the bbl state")))
+				Expect(err.Error()).To(ContainSubstring(gossh.FingerprintSHA256(otherSigner.PublicKey())))
+				Expect(err.Error()).To(ContainSubstring(gossh.FingerprintSHA256(signer.PublicKey())))
+			})
+		})
+
+		Context("when the pinned key cannot be parsed", func() {
+			It("returns an error", func() {
+				hostKeys.Pin("some-jumpbox:22", "%%%")
+
+				_, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+				Expect(err).To(MatchError(ContainSubstring("parse host key for some-jumpbox:22")))
+			})
+		})
+
+		Context("when the host key cannot be read", func() {
+			It("returns an error", func() {
+				getter.err = errors.New("connection refused")
+
+				_, err := hostKeys.Get("jumpbox", "some-private-key", "some-jumpbox:22")
+				Expect(err).To(MatchError("connection refused"))
+			})
+		})
+	})
+
+	Describe("Dialer", func() {
+		var (
+			listener     net.Listener
+			caPrivateKey string
+		)
+
+		BeforeEach(func() {
+			var caPublicKey string
+			var err error
+			caPrivateKey, caPublicKey, err = ssh.KeyGenerator{}.GenerateKey(ssh.KeyTypeEd25519)
+			Expect(err).NotTo(HaveOccurred())
+			ca, _, _, _, err := gossh.ParseAuthorizedKey([]byte(caPublicKey))
+			Expect(err).NotTo(HaveOccurred())
+
+			checker := &gossh.CertChecker{
+				IsUserAuthority: func(auth gossh.PublicKey) bool {
+					return string(auth.Marshal()) == string(ca.Marshal())
+				},
+			}
+			config := &gossh.ServerConfig{PublicKeyCallback: checker.Authenticate}
+			config.AddHostKey(signer)
+
+			listener, err = net.Listen("tcp4", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						_, chans, reqs, err := gossh.NewServerConn(conn, config)
+						if err != nil {
+							return
+						}
+						go gossh.DiscardRequests(reqs)
+						for newChannel := range chans {
+							newChannel.Reject(gossh.Prohibited, "no channels")
+						}
+					}()
+				}
+			}()
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("logs in with a certificate signed by a CA the server trusts", func() {
+			privateKey, certificate, err := ssh.KeyGenerator{}.GenerateCertificate(caPrivateKey, "jumpbox", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			hostKeys.Pin(listener.Addr().String(), publicKey)
+
+			dial, err := hostKeys.Dialer("jumpbox", privateKey, certificate, listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dial).NotTo(BeNil())
+		})
+
+		Context("when the certificate is not signed by the trusted CA", func() {
+			It("returns an error", func() {
+				otherCA, _, err := ssh.KeyGenerator{}.GenerateKey(ssh.KeyTypeEd25519)
+				Expect(err).NotTo(HaveOccurred())
+				privateKey, certificate, err := ssh.KeyGenerator{}.GenerateCertificate(otherCA, "jumpbox", time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = hostKeys.Dialer("jumpbox", privateKey, certificate, listener.Addr().String())
+				Expect(err).To(MatchError(ContainSubstring("ssh dial")))
+			})
+		})
+
+		Context("when the server presents a different host key", func() {
+			It("returns an error", func() {
+				privateKey, certificate, err := ssh.KeyGenerator{}.GenerateCertificate(caPrivateKey, "jumpbox", time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				_, otherKey := newHostKey()
+				hostKeys.Pin(listener.Addr().String(), otherKey)
+
+				_, err = hostKeys.Dialer("jumpbox", privateKey, certificate, listener.Addr().String())
+				Expect(err).To(MatchError(ContainSubstring("does not match the bbl state")))
+			})
+		})
+	})
+
+	Describe("Scan", func() {
+		var listener net.Listener
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp4", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			config := &gossh.ServerConfig{NoClientAuth: true}
+			config.AddHostKey(signer)
+
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					go gossh.NewServerConn(conn, config)
+				}
+			}()
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("returns the host key of the server", func() {
+			key, err := hostKeys.Scan(&net.Dialer{}, listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(publicKey))
+		})
+
+		Context("when the server cannot be reached", func() {
+			It("returns an error", func() {
+				addr := listener.Addr().String()
+				listener.Close()
+
+				_, err := hostKeys.Scan(&net.Dialer{}, addr)
+				Expect(err).To(MatchError(ContainSubstring("dial " + addr)))
+			})
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	KeyTypeRSA     = "rsa"
+	KeyTypeEd25519 = "ed25519"
+)
+
+type KeyGenerator struct{}
+
+// Generate returns a new PEM encoded RSA private key and its public key in
+// authorized_keys format.
+func (k KeyGenerator) Generate() (string, string, error) {
+	return k.GenerateKey(KeyTypeRSA)
+}
+
+// GenerateKey is Generate for the given key type. Ed25519 private keys are
+// encoded in the OpenSSH format, which is the only one ssh reads them in.
+func (KeyGenerator) GenerateKey(keyType string) (string, string, error) {
+	switch keyType {
+	case KeyTypeRSA:
+		return generateRSAKey()
+	case KeyTypeEd25519:
+		return generateEd25519Key()
+	}
+
+	return "", "", fmt.Errorf("unsupported key type %q", keyType)
+}
+
+func generateRSAKey() (string, string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", fmt.Errorf("generate rsa key: %s", err) // not tested
+	}
+
+	publicKey, err := gossh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("create public key: %s", err) // not tested
+	}
+
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	return string(privateKey), string(gossh.MarshalAuthorizedKey(publicKey)), nil
+}
+
+func generateEd25519Key() (string, string, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate ed25519 key: %s", err) // not tested
+	}
+
+	publicKey, err := gossh.NewPublicKey(public)
+	if err != nil {
+		return "", "", fmt.Errorf("create public key: %s", err) // not tested
+	}
+
+	checkBytes := make([]byte, 4)
+	_, err = rand.Read(checkBytes)
+	if err != nil {
+		return "", "", fmt.Errorf("generate check bytes: %s", err) // not tested
+	}
+	check := binary.BigEndian.Uint32(checkBytes)
+
+	// See PROTOCOL.key in openssh-portable for the layout.
+	privateBlock := gossh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		Keytype string
+		Pub     []byte
+		Priv    []byte
+		Comment string
+	}{check, check, gossh.KeyAlgoED25519, []byte(public), []byte(private), ""})
+	for i := 1; len(privateBlock)%8 != 0; i++ {
+		privateBlock = append(privateBlock, byte(i))
+	}
+
+	body := gossh.Marshal(struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{"none", "none", "", 1, publicKey.Marshal(), privateBlock})
+
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte("openssh-key-v1\x00"), body...),
+	})
+
+	return string(privateKey), string(gossh.MarshalAuthorizedKey(publicKey)), nil
+}
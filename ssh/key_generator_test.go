@@ -0,0 +1,54 @@
+package ssh_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyGenerator", func() {
+	It("generates a matching private and public key", func() {
+		privateKey, publicKey, err := ssh.KeyGenerator{}.Generate()
+		Expect(err).NotTo(HaveOccurred())
+
+		signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+		Expect(err).NotTo(HaveOccurred())
+
+		parsedPublicKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(publicKey))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsedPublicKey.Marshal()).To(Equal(signer.PublicKey().Marshal()))
+		Expect(parsedPublicKey.Type()).To(Equal("ssh-rsa"))
+	})
+
+	Describe("GenerateKey", func() {
+		DescribeTable("generates a matching private and public key of the given type",
+			func(keyType, algorithm, pemType string) {
+				privateKey, publicKey, err := ssh.KeyGenerator{}.GenerateKey(keyType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.HasPrefix(privateKey, "-----BEGIN "+pemType+"-----")).To(BeTrue())
+
+				signer, err := gossh.ParsePrivateKey([]byte(privateKey))
+				Expect(err).NotTo(HaveOccurred())
+
+				parsedPublicKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(publicKey))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parsedPublicKey.Marshal()).To(Equal(signer.PublicKey().Marshal()))
+				Expect(parsedPublicKey.Type()).To(Equal(algorithm))
+			},
+			Entry("rsa", "rsa", "ssh-rsa", "RSA PRIVATE KEY"),
+			Entry("ed25519", "ed25519", "ssh-ed25519", "OPENSSH PRIVATE KEY"),
+		)
+
+		Context("when the key type is not supported", func() {
+			It("returns an error", func() {
+				_, _, err := ssh.KeyGenerator{}.GenerateKey("dsa")
+				Expect(err).To(MatchError(`unsupported key type "dsa"`))
+			})
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
+)
+
+type dialerProvider interface {
+	Dialer(state storage.State) (proxy.Dialer, error)
+}
+
+// Tunnel forwards connections made to a local address on to a target behind
+// the jumpbox.
+type Tunnel interface {
+	Addr() string
+	Close() error
+}
+
+type TunnelOpener struct {
+	dialerProvider dialerProvider
+	logger         *log.Logger
+}
+
+type tunnel struct {
+	listener net.Listener
+	dialer   proxy.Dialer
+	target   string
+	logger   *log.Logger
+}
+
+func NewTunnelOpener(dialerProvider dialerProvider, logger *log.Logger) TunnelOpener {
+	return TunnelOpener{
+		dialerProvider: dialerProvider,
+		logger:         logger,
+	}
+}
+
+// Open returns a tunnel to target once the jumpbox proxy is ready. Both
+// ends run in-process, so no ssh -D or netcat is needed.
+func (o TunnelOpener) Open(state storage.State, target string) (Tunnel, error) {
+	dialer, err := o.dialerProvider.Dialer(state)
+	if err != nil {
+		return nil, fmt.Errorf("create dialer: %s", err)
+	}
+
+	// Hosts without an IPv4 loopback, such as IPv6-only containers, listen
+	// on the IPv6 loopback instead.
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		listener, err = net.Listen("tcp6", "[::1]:0")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listen: %s", err)
+	}
+
+	t := &tunnel{
+		listener: listener,
+		dialer:   dialer,
+		target:   target,
+		logger:   o.logger,
+	}
+	go t.serve()
+
+	return t, nil
+}
+
+func (t *tunnel) Addr() string {
+	return t.listener.Addr().String()
+}
+
+func (t *tunnel) Close() error {
+	return t.listener.Close()
+}
+
+func (t *tunnel) serve() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		go t.forward(conn)
+	}
+}
+
+func (t *tunnel) forward(local net.Conn) {
+	defer local.Close()
+
+	// ssh only sees the local connection close, so say why.
+	remote, err := t.dialer.Dial("tcp", t.target)
+	if err != nil {
+		t.logger.Printf("bbl: connect to %s through the jumpbox: %s", t.target, err)
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
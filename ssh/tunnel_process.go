@@ -0,0 +1,262 @@
+package ssh
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+)
+
+const (
+	tunnelFile         = "tunnel.json"
+	tunnelLockFile     = "tunnel.lock"
+	tunnelLogFile      = "tunnel.log"
+	tunnelReadyTimeout = 30 * time.Second
+
+	// tunnelReadyFD is the descriptor of the pipe that bbl tunnel start
+	// passes to bbl tunnel run, the first one after stdin, stdout and stderr.
+	tunnelReadyFD = 3
+)
+
+type stateDirGetter interface {
+	GetStateDir() string
+}
+
+type tunnelFs interface {
+	fileio.FileReader
+	fileio.FileWriter
+	fileio.Remover
+}
+
+type TunnelStatus struct {
+	Running bool `json:"-"`
+	PID     int  `json:"pid"`
+	Port    int  `json:"port"`
+}
+
+// TunnelProcess starts and stops bbl tunnel run in the background, and
+// records its pid and port in the state directory. The running process holds
+// a lock in the state directory, so a pid that the system has since reused
+// for another process is never reported as running or signalled.
+type TunnelProcess struct {
+	stateStore stateDirGetter
+	fs         tunnelFs
+}
+
+func NewTunnelProcess(stateStore stateDirGetter, fs tunnelFs) TunnelProcess {
+	return TunnelProcess{
+		stateStore: stateStore,
+		fs:         fs,
+	}
+}
+
+func (t TunnelProcess) Status() (TunnelStatus, error) {
+	contents, err := t.fs.ReadFile(t.path())
+	if os.IsNotExist(err) {
+		return TunnelStatus{}, nil
+	}
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("read tunnel file: %s", err)
+	}
+
+	var status TunnelStatus
+	err = json.Unmarshal(contents, &status)
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("parse tunnel file: %s", err)
+	}
+
+	status.Running, err = t.locked()
+	if err != nil {
+		return TunnelStatus{}, err
+	}
+
+	return status, nil
+}
+
+// Start runs bbl tunnel run for the state directory as a detached process,
+// and returns once it reports that its proxy is connected to the jumpbox.
+// A port of 0 lets the process pick a free port.
+func (t TunnelProcess) Start(port int) (TunnelStatus, error) {
+	bbl, err := os.Executable()
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("find bbl executable: %s", err) // not tested
+	}
+
+	stateDir, err := filepath.Abs(t.stateStore.GetStateDir())
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("find state dir: %s", err) // not tested
+	}
+
+	logPath := filepath.Join(stateDir, tunnelLogFile)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("open tunnel log: %s", err) // not tested
+	}
+	defer logFile.Close()
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("create ready pipe: %s", err) // not tested
+	}
+	defer readyReader.Close()
+
+	cmd := exec.Command(bbl, "--state-dir", stateDir, "tunnel", "run",
+		"--port", strconv.Itoa(port), "--ready-fd", strconv.Itoa(tunnelReadyFD))
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.ExtraFiles = []*os.File{readyWriter}
+
+	err = cmd.Start()
+	readyWriter.Close()
+	if err != nil {
+		return TunnelStatus{}, fmt.Errorf("start tunnel: %s", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	port, err = waitForReady(readyReader, exited)
+	if err != nil {
+		cmd.Process.Kill()
+		return TunnelStatus{}, fmt.Errorf("%s, see %s", err, logPath)
+	}
+
+	status := TunnelStatus{Running: true, PID: cmd.Process.Pid, Port: port}
+
+	contents, err := json.Marshal(status)
+	if err != nil {
+		return TunnelStatus{}, err // not tested
+	}
+
+	err = t.fs.WriteFile(t.path(), contents, 0644)
+	if err != nil {
+		cmd.Process.Kill()
+		return TunnelStatus{}, fmt.Errorf("write tunnel file: %s", err)
+	}
+
+	return status, nil
+}
+
+func (t TunnelProcess) Stop() error {
+	status, err := t.Status()
+	if err != nil {
+		return err
+	}
+
+	if status.Running {
+		err = syscall.Kill(status.PID, syscall.SIGTERM)
+		if err != nil {
+			return fmt.Errorf("stop tunnel: %s", err) // not tested
+		}
+	}
+
+	err = t.fs.Remove(t.path())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove tunnel file: %s", err)
+	}
+
+	return nil
+}
+
+// Lock marks the tunnel of the state directory as running until release is
+// called or the process exits. Only one tunnel can hold it at a time.
+func (t TunnelProcess) Lock() (func(), error) {
+	file, err := os.OpenFile(t.lockPath(), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open tunnel lock: %s", err)
+	}
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		file.Close()
+		return nil, errors.New("a tunnel is already running for this state directory")
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("lock tunnel: %s", err) // not tested
+	}
+
+	return func() { file.Close() }, nil
+}
+
+// Ready tells the bbl tunnel start that passed fd to this process that the
+// proxy serves on port.
+func (TunnelProcess) Ready(fd, port int) error {
+	file := os.NewFile(uintptr(fd), "tunnel-ready")
+	defer file.Close()
+
+	_, err := fmt.Fprintf(file, "ready %d\n", port)
+	return err
+}
+
+func (t TunnelProcess) locked() (bool, error) {
+	file, err := os.Open(t.lockPath())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("open tunnel lock: %s", err) // not tested
+	}
+	defer file.Close()
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("check tunnel lock: %s", err) // not tested
+	}
+
+	return false, nil
+}
+
+func (t TunnelProcess) lockPath() string {
+	return filepath.Join(t.stateStore.GetStateDir(), tunnelLockFile)
+}
+
+func (t TunnelProcess) path() string {
+	return filepath.Join(t.stateStore.GetStateDir(), tunnelFile)
+}
+
+// waitForReady returns the port that bbl tunnel run reports on ready. The
+// pipe closes without a report when the process exits first.
+func waitForReady(ready io.Reader, exited chan error) (int, error) {
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(ready).ReadString('\n')
+		lines <- line
+	}()
+
+	select {
+	case line := <-lines:
+		var port int
+		_, err := fmt.Sscanf(line, "ready %d\n", &port)
+		if err == nil {
+			return port, nil
+		}
+
+		select {
+		case err = <-exited:
+			if err == nil {
+				err = errors.New("exited")
+			}
+		case <-time.After(tunnelReadyTimeout):
+			err = errors.New("closed the ready pipe")
+		}
+		return 0, fmt.Errorf("tunnel stopped before it was ready: %s", err)
+	case <-time.After(tunnelReadyTimeout):
+		return 0, fmt.Errorf("tunnel was not ready after %s", tunnelReadyTimeout)
+	}
+}
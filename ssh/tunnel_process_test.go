@@ -0,0 +1,160 @@
+package ssh_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type stateDir string
+
+func (s stateDir) GetStateDir() string {
+	return string(s)
+}
+
+var _ = Describe("TunnelProcess", func() {
+	var (
+		dir           string
+		tunnelProcess ssh.TunnelProcess
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		tunnelProcess = ssh.NewTunnelProcess(stateDir(dir), &afero.Afero{Fs: afero.NewOsFs()})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Status", func() {
+		It("is not running without a tunnel file", func() {
+			status, err := tunnelProcess.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Running).To(BeFalse())
+		})
+
+		It("reads the pid and port from the state dir", func() {
+			release, err := tunnelProcess.Lock()
+			Expect(err).NotTo(HaveOccurred())
+			defer release()
+
+			tunnelFile := fmt.Sprintf(`{"pid": %d, "port": 1080}`, os.Getpid())
+			err = ioutil.WriteFile(filepath.Join(dir, "tunnel.json"), []byte(tunnelFile), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := tunnelProcess.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ssh.TunnelStatus{Running: true, PID: os.Getpid(), Port: 1080}))
+		})
+
+		It("is not running when no tunnel holds the lock, even if the pid is in use", func() {
+			tunnelFile := fmt.Sprintf(`{"pid": %d, "port": 1080}`, os.Getpid())
+			err := ioutil.WriteFile(filepath.Join(dir, "tunnel.json"), []byte(tunnelFile), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			release, err := tunnelProcess.Lock()
+			Expect(err).NotTo(HaveOccurred())
+			release()
+
+			status, err := tunnelProcess.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Running).To(BeFalse())
+		})
+
+		Context("when the tunnel file is not valid json", func() {
+			It("returns an error", func() {
+				err := ioutil.WriteFile(filepath.Join(dir, "tunnel.json"), []byte("%%%"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = tunnelProcess.Status()
+				Expect(err).To(MatchError(ContainSubstring("parse tunnel file")))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		It("stops the tunnel process and removes the tunnel file", func() {
+			release, err := tunnelProcess.Lock()
+			Expect(err).NotTo(HaveOccurred())
+			defer release()
+
+			cmd := exec.Command("sleep", "60")
+			Expect(cmd.Start()).To(Succeed())
+
+			tunnelFile := fmt.Sprintf(`{"pid": %d, "port": 1080}`, cmd.Process.Pid)
+			err = ioutil.WriteFile(filepath.Join(dir, "tunnel.json"), []byte(tunnelFile), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = tunnelProcess.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cmd.Wait()).To(MatchError("signal: terminated"))
+			Expect(filepath.Join(dir, "tunnel.json")).NotTo(BeAnExistingFile())
+		})
+
+		It("does not signal a process that reused the pid of a stopped tunnel", func() {
+			cmd := exec.Command("sleep", "60")
+			Expect(cmd.Start()).To(Succeed())
+			defer cmd.Process.Kill()
+
+			tunnelFile := fmt.Sprintf(`{"pid": %d, "port": 1080}`, cmd.Process.Pid)
+			err := ioutil.WriteFile(filepath.Join(dir, "tunnel.json"), []byte(tunnelFile), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = tunnelProcess.Stop()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(syscall.Kill(cmd.Process.Pid, 0)).To(Succeed())
+			Expect(filepath.Join(dir, "tunnel.json")).NotTo(BeAnExistingFile())
+		})
+
+		It("does nothing when the tunnel is not running", func() {
+			err := tunnelProcess.Stop()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Lock", func() {
+		It("allows one tunnel per state dir", func() {
+			release, err := tunnelProcess.Lock()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tunnelProcess.Lock()
+			Expect(err).To(MatchError("a tunnel is already running for this state directory"))
+
+			release()
+
+			release, err = tunnelProcess.Lock()
+			Expect(err).NotTo(HaveOccurred())
+			release()
+		})
+	})
+
+	Describe("Ready", func() {
+		It("writes the port to the ready pipe and closes it", func() {
+			reader, writer, err := os.Pipe()
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			err = tunnelProcess.Ready(int(writer.Fd()), 41080)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("ready 41080\n"))
+		})
+	})
+})
@@ -0,0 +1,145 @@
+package ssh
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	socks5 "github.com/cloudfoundry/go-socks5"
+	proxy "github.com/cloudfoundry/socks5-proxy"
+	"golang.org/x/net/context"
+)
+
+type jumpboxDialer interface {
+	Dialer(username, key, url string) (proxy.DialFunc, error)
+}
+
+type hostKeyPinner interface {
+	Pin(serverURL, hostKey string)
+}
+
+type certificateGetter interface {
+	Get() (privateKey string, certificate string, err error)
+}
+
+type certificateDialer interface {
+	Dialer(username, privateKey, certificate, serverURL string) (proxy.DialFunc, error)
+}
+
+// TunnelServer runs a long-lived socks5 proxy that tunnels through the
+// jumpbox, for bbl tunnel.
+type TunnelServer struct {
+	jumpboxDialer     jumpboxDialer
+	hostKeys          hostKeyPinner
+	certificateGetter certificateGetter
+	certificateDialer certificateDialer
+	logger            *log.Logger
+}
+
+func NewTunnelServer(jumpboxDialer jumpboxDialer, hostKeys hostKeyPinner, certificateGetter certificateGetter,
+	certificateDialer certificateDialer, logger *log.Logger) TunnelServer {
+	return TunnelServer{
+		jumpboxDialer:     jumpboxDialer,
+		hostKeys:          hostKeys,
+		certificateGetter: certificateGetter,
+		certificateDialer: certificateDialer,
+		logger:            logger,
+	}
+}
+
+// Serve accepts socks5 connections on listener until it is closed, calling
+// ready once it is connected to the jumpbox. When the ssh connection to the
+// jumpbox drops, it is reopened on the next dial, and the jumpbox must still
+// present the host key recorded in its state. When the jumpbox trusts an ssh
+// CA, each connection logs in with a freshly signed certificate instead of
+// privateKey.
+func (t TunnelServer) Serve(listener net.Listener, jumpbox storage.Jumpbox, privateKey string, ready func() error) error {
+	t.hostKeys.Pin(jumpbox.URL, jumpbox.HostKey)
+
+	dialer := &reconnectingDialer{
+		connect: func() (proxy.DialFunc, error) {
+			certificateKey, certificate, err := t.certificateGetter.Get()
+			if err != nil {
+				return nil, fmt.Errorf("get ssh certificate: %s", err)
+			}
+
+			if certificate != "" {
+				return t.certificateDialer.Dialer("jumpbox", certificateKey, certificate, jumpbox.URL)
+			}
+
+			return t.jumpboxDialer.Dialer("jumpbox", privateKey, jumpbox.URL)
+		},
+	}
+
+	_, err := dialer.reconnect(0)
+	if err != nil {
+		return fmt.Errorf("connect to jumpbox: %s", err)
+	}
+
+	server, err := socks5.New(&socks5.Config{
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.Dial(network, addr)
+		},
+		Logger: t.logger,
+	})
+	if err != nil {
+		return fmt.Errorf("new socks5 server: %s", err) // not tested
+	}
+
+	err = ready()
+	if err != nil {
+		return fmt.Errorf("report ready: %s", err)
+	}
+
+	return server.Serve(listener)
+}
+
+type reconnectingDialer struct {
+	connect    func() (proxy.DialFunc, error)
+	mutex      sync.Mutex
+	dial       proxy.DialFunc
+	generation int
+}
+
+func (r *reconnectingDialer) Dial(network, addr string) (net.Conn, error) {
+	r.mutex.Lock()
+	dial, generation := r.dial, r.generation
+	r.mutex.Unlock()
+
+	if dial != nil {
+		conn, err := dial(network, addr)
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	dial, err := r.reconnect(generation)
+	if err != nil {
+		return nil, fmt.Errorf("reconnect to jumpbox: %s", err)
+	}
+
+	return dial(network, addr)
+}
+
+// reconnect opens a new ssh connection unless another dial already replaced
+// the one that failed.
+func (r *reconnectingDialer) reconnect(failedGeneration int) (proxy.DialFunc, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.dial != nil && r.generation != failedGeneration {
+		return r.dial, nil
+	}
+
+	dial, err := r.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	r.dial = dial
+	r.generation++
+
+	return dial, nil
+}
@@ -0,0 +1,183 @@
+package ssh_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	socks5proxy "github.com/cloudfoundry/socks5-proxy"
+	"golang.org/x/net/proxy"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type jumpboxDialer struct {
+	dials    []socks5proxy.DialFunc
+	received []string
+	err      error
+}
+
+func (j *jumpboxDialer) Dialer(username, key, url string) (socks5proxy.DialFunc, error) {
+	j.received = append(j.received, username, key, url)
+	if j.err != nil {
+		return nil, j.err
+	}
+
+	dial := j.dials[0]
+	j.dials = j.dials[1:]
+	return dial, nil
+}
+
+var _ = Describe("TunnelServer", func() {
+	var (
+		dialer            *jumpboxDialer
+		hostKeys          *fakes.HostKeyPinner
+		certificateGetter *fakes.SSHCertificateGetter
+		certificateDialer *fakes.CertificateDialer
+		jumpbox           storage.Jumpbox
+		listener          net.Listener
+		echoServer        net.Listener
+		served            chan error
+		ready             func() error
+		readyCalls        int
+	)
+
+	BeforeEach(func() {
+		var err error
+		echoServer, err = net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		go func() {
+			for {
+				conn, err := echoServer.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					io.Copy(conn, conn)
+				}()
+			}
+		}()
+
+		listener, err = net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		dropped := func(network, addr string) (net.Conn, error) {
+			return nil, errors.New("ssh: connection lost")
+		}
+		dialer = &jumpboxDialer{
+			dials: []socks5proxy.DialFunc{dropped, net.Dial},
+		}
+		hostKeys = &fakes.HostKeyPinner{}
+		certificateGetter = &fakes.SSHCertificateGetter{}
+		certificateDialer = &fakes.CertificateDialer{}
+		jumpbox = storage.Jumpbox{URL: "some-jumpbox:22", HostKey: "ssh-rsa some-host-key"}
+		served = make(chan error, 1)
+
+		readyCalls = 0
+		ready = func() error {
+			readyCalls++
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		listener.Close()
+		echoServer.Close()
+	})
+
+	It("proxies connections through the jumpbox and reconnects when the connection drops", func() {
+		go func() {
+			served <- ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", func() error {
+				readyCalls++
+				return nil
+			})
+		}()
+
+		client, err := proxy.SOCKS5("tcp", listener.Addr().String(), nil, proxy.Direct)
+		Expect(err).NotTo(HaveOccurred())
+
+		var conn net.Conn
+		Eventually(func() error {
+			conn, err = client.Dial("tcp", echoServer.Addr().String())
+			return err
+		}).Should(Succeed())
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("some-message\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("some-message\n"))
+		Expect(readyCalls).To(Equal(1))
+
+		Expect(dialer.received).To(Equal([]string{
+			"jumpbox", "some-private-key", "some-jumpbox:22",
+			"jumpbox", "some-private-key", "some-jumpbox:22",
+		}))
+	})
+
+	It("pins the host key of the jumpbox", func() {
+		dialer.err = errors.New("i/o timeout")
+
+		ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", ready)
+
+		Expect(hostKeys.PinCall.Receives.ServerURL).To(Equal("some-jumpbox:22"))
+		Expect(hostKeys.PinCall.Receives.HostKey).To(Equal("ssh-rsa some-host-key"))
+	})
+
+	Context("when the jumpbox trusts an ssh CA", func() {
+		BeforeEach(func() {
+			certificateGetter.GetCall.Returns.PrivateKey = "some-certificate-key"
+			certificateGetter.GetCall.Returns.Certificate = "some-certificate"
+			certificateDialer.DialerCall.Returns.Error = errors.New("i/o timeout")
+		})
+
+		It("logs in with a signed certificate instead of the private key", func() {
+			ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", ready)
+
+			Expect(certificateGetter.GetCall.CallCount).To(Equal(1))
+			Expect(certificateDialer.DialerCall.CallCount).To(Equal(1))
+			Expect(certificateDialer.DialerCall.Receives.Username).To(Equal("jumpbox"))
+			Expect(certificateDialer.DialerCall.Receives.PrivateKey).To(Equal("some-certificate-key"))
+			Expect(certificateDialer.DialerCall.Receives.Certificate).To(Equal("some-certificate"))
+			Expect(certificateDialer.DialerCall.Receives.ServerURL).To(Equal("some-jumpbox:22"))
+			Expect(dialer.received).To(BeEmpty())
+		})
+
+		Context("when the certificate cannot be signed", func() {
+			It("returns an error", func() {
+				certificateGetter.GetCall.Returns.Error = errors.New("bad ca")
+
+				err := ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", ready)
+				Expect(err).To(MatchError("connect to jumpbox: get ssh certificate: bad ca"))
+			})
+		})
+	})
+
+	Context("when the jumpbox cannot be reached", func() {
+		It("returns an error", func() {
+			dialer.err = errors.New("i/o timeout")
+
+			err := ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", ready)
+			Expect(err).To(MatchError("connect to jumpbox: i/o timeout"))
+			Expect(readyCalls).To(Equal(0))
+		})
+	})
+
+	Context("when readiness cannot be reported", func() {
+		It("returns an error instead of serving", func() {
+			err := ssh.NewTunnelServer(dialer, hostKeys, certificateGetter, certificateDialer, nil).Serve(listener, jumpbox, "some-private-key", func() error {
+				return errors.New("broken pipe")
+			})
+			Expect(err).To(MatchError("report ready: broken pipe"))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package ssh_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"net"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("TunnelOpener", func() {
+	var (
+		clientProvider *fakes.BOSHClientProvider
+		tunnelOpener   ssh.TunnelOpener
+		echoServer     net.Listener
+		logs           *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		var err error
+		echoServer, err = net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		go func() {
+			for {
+				conn, err := echoServer.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					io.Copy(conn, conn)
+				}()
+			}
+		}()
+
+		clientProvider = &fakes.BOSHClientProvider{}
+		clientProvider.DialerCall.Returns.Dialer = proxy.Direct
+
+		logs = gbytes.NewBuffer()
+		tunnelOpener = ssh.NewTunnelOpener(clientProvider, log.New(logs, "", 0))
+	})
+
+	AfterEach(func() {
+		echoServer.Close()
+	})
+
+	It("forwards connections to the target through the jumpbox dialer", func() {
+		tunnel, err := tunnelOpener.Open(storage.State{Jumpbox: storage.Jumpbox{URL: "some-jumpbox"}}, echoServer.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		defer tunnel.Close()
+
+		Expect(clientProvider.DialerCall.Receives.State).To(Equal(storage.State{Jumpbox: storage.Jumpbox{URL: "some-jumpbox"}}))
+
+		conn, err := net.Dial("tcp", tunnel.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("some-message\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("some-message\n"))
+	})
+
+	It("stops accepting connections when closed", func() {
+		tunnel, err := tunnelOpener.Open(storage.State{}, echoServer.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tunnel.Close()).To(Succeed())
+
+		_, err = net.Dial("tcp", tunnel.Addr())
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when the jumpbox cannot reach the target", func() {
+		It("logs why and closes the local connection", func() {
+			dialer := &fakes.Dialer{}
+			dialer.DialCall.Returns.Error = errors.New("connection refused")
+			clientProvider.DialerCall.Returns.Dialer = dialer
+
+			tunnel, err := tunnelOpener.Open(storage.State{}, "10.0.0.6:22")
+			Expect(err).NotTo(HaveOccurred())
+			defer tunnel.Close()
+
+			conn, err := net.Dial("tcp", tunnel.Addr())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = bufio.NewReader(conn).ReadString('\n')
+			Expect(err).To(Equal(io.EOF))
+
+			Eventually(logs).Should(gbytes.Say("bbl: connect to 10.0.0.6:22 through the jumpbox: connection refused"))
+		})
+	})
+
+	Context("when the dialer cannot be created", func() {
+		It("returns an error", func() {
+			clientProvider.DialerCall.Returns.Error = errors.New("kiwi")
+
+			_, err := tunnelOpener.Open(storage.State{}, "some-target")
+			Expect(err).To(MatchError("create dialer: kiwi"))
+		})
+	})
+})
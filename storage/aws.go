@@ -1,7 +1,19 @@
 package storage
 
+import "strings"
+
 type AWS struct {
-	AccessKeyID     string `json:"-"`
-	SecretAccessKey string `json:"-"`
-	Region          string `json:"region,omitempty"`
+	AccessKeyID     string   `json:"-"`
+	SecretAccessKey string   `json:"-"`
+	Region          string   `json:"region,omitempty"`
+	ExtraRegions    []string `json:"extraRegions,omitempty"`
+
+	// ExtraRegionCIDRs holds the range of the VPC of each extra region.
+	ExtraRegionCIDRs map[string]string `json:"extraRegionCIDRs,omitempty"`
+}
+
+// ExtraRegionKey returns the prefix of the terraform resources, variables
+// and outputs of an extra region, e.g. extra_us_west_2 for us-west-2.
+func ExtraRegionKey(region string) string {
+	return "extra_" + strings.Replace(region, "-", "_", -1)
 }
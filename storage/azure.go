@@ -1,5 +1,7 @@
 package storage
 
+import "strings"
+
 type Azure struct {
 	ClientID       string `json:"-"`
 	ClientSecret   string `json:"-"`
@@ -7,3 +9,42 @@ type Azure struct {
 	SubscriptionID string `json:"-"`
 	TenantID       string `json:"-"`
 }
+
+// availabilityZoneRegions are the Azure regions that have availability zones.
+var availabilityZoneRegions = map[string]bool{
+	"australiaeast":      true,
+	"brazilsouth":        true,
+	"canadacentral":      true,
+	"centralindia":       true,
+	"centralus":          true,
+	"eastasia":           true,
+	"eastus":             true,
+	"eastus2":            true,
+	"francecentral":      true,
+	"germanywestcentral": true,
+	"japaneast":          true,
+	"koreacentral":       true,
+	"northeurope":        true,
+	"norwayeast":         true,
+	"southafricanorth":   true,
+	"southcentralus":     true,
+	"southeastasia":      true,
+	"swedencentral":      true,
+	"switzerlandnorth":   true,
+	"uaenorth":           true,
+	"uksouth":            true,
+	"westeurope":         true,
+	"westus2":            true,
+	"westus3":            true,
+}
+
+// AvailabilityZones returns the Azure availability zones that back z1, z2
+// and z3, or nothing when the region has no availability zones. Regions
+// may be given as names, e.g. "westus2", or display names, e.g. "West US 2".
+func (a Azure) AvailabilityZones() []string {
+	region := strings.ToLower(strings.Replace(a.Region, " ", "", -1))
+	if !availabilityZoneRegions[region] {
+		return nil
+	}
+	return []string{"1", "2", "3"}
+}
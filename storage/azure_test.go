@@ -0,0 +1,21 @@
+package storage_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Azure", func() {
+	DescribeTable("AvailabilityZones",
+		func(region string, zones []string) {
+			Expect(storage.Azure{Region: region}.AvailabilityZones()).To(Equal(zones))
+		},
+		Entry("a region with zones", "westus2", []string{"1", "2", "3"}),
+		Entry("a display name of a region with zones", "West Europe", []string{"1", "2", "3"}),
+		Entry("a region without zones", "westus", nil),
+		Entry("no region", "", nil),
+	)
+})
@@ -10,6 +10,7 @@ type BOSH struct {
 	DirectorSSLCA          string                 `json:"directorSSLCA"`
 	DirectorSSLCertificate string                 `json:"directorSSLCertificate"`
 	DirectorSSLPrivateKey  string                 `json:"directorSSLPrivateKey"`
+	DirectorHostKey        string                 `json:"directorHostKey,omitempty"`
 	Variables              string                 `json:"variables,omitempty"`
 	State                  map[string]interface{} `json:"state,omitempty"`
 	Manifest               string                 `json:"manifest,omitempty"`
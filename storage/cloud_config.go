@@ -0,0 +1,37 @@
+package storage
+
+type CloudConfig struct {
+	VMTypes       []VMType        `json:"vmTypes,omitempty"`
+	NetworkRanges []NetworkRanges `json:"networkRanges,omitempty"`
+
+	// CompilationVMsPreemptible runs compilation vms on AWS spot or GCP
+	// preemptible instances.
+	CompilationVMsPreemptible bool `json:"compilationVMsPreemptible,omitempty"`
+
+	// SpotBidPrice is the maximum hourly price of the AWS spot vm extension
+	// in dollars. The cloud config bids $1.00 when it is not set.
+	SpotBidPrice float64 `json:"spotBidPrice,omitempty"`
+}
+
+// VMType is a vm_type from the catalog passed to bbl plan --vm-types-file.
+// CloudProperties are keyed by IaaS. On an IaaS without cloud properties the
+// vm type is resolved from CPU, RAM and EphemeralDiskSize, which are in MB.
+type VMType struct {
+	Name              string                            `json:"name"`
+	CPU               int                               `json:"cpu,omitempty"`
+	RAM               int                               `json:"ram,omitempty"`
+	EphemeralDiskSize int                               `json:"ephemeralDiskSize,omitempty"`
+	CloudProperties   map[string]map[string]interface{} `json:"cloudProperties,omitempty"`
+}
+
+// NetworkRanges are the reserved and static ips of a cloud config network
+// from bbl plan --network-ranges-file. Sizes count addresses after the
+// gateway of each subnet for reserved ips, and before its last address for
+// static ips. Ranges are ips or first-last ip ranges in one of the subnets.
+type NetworkRanges struct {
+	Network      string   `json:"network"`
+	ReservedSize int      `json:"reservedSize,omitempty"`
+	Reserved     []string `json:"reserved,omitempty"`
+	StaticSize   int      `json:"staticSize,omitempty"`
+	Static       []string `json:"static,omitempty"`
+}
@@ -0,0 +1,29 @@
+package storage
+
+const (
+	DirectorInternal = "internal"
+	DirectorExternal = "external"
+)
+
+type Director struct {
+	Blobstore string `json:"blobstore,omitempty"`
+	Database  string `json:"database,omitempty"`
+	VMType    string `json:"vmType,omitempty"`
+	DiskSize  int    `json:"diskSize,omitempty"`
+
+	// DiskEncryptionKey is a customer-managed key that encrypts the disks
+	// of the director and of the vms it creates.
+	DiskEncryptionKey string `json:"diskEncryptionKey,omitempty"`
+
+	// DiskEncryptionKeyVaultID is the resource id of the Azure Key Vault of
+	// DiskEncryptionKey, which the key id does not name.
+	DiskEncryptionKeyVaultID string `json:"diskEncryptionKeyVaultID,omitempty"`
+}
+
+func (d Director) ExternalBlobstore() bool {
+	return d.Blobstore == DirectorExternal
+}
+
+func (d Director) ExternalDatabase() bool {
+	return d.Database == DirectorExternal
+}
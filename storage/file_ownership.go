@@ -41,6 +41,8 @@ var bblManagedDirsWhichMayContainUserFiles = []string{
 	"vars",
 	"terraform",
 	"cloud-config",
+	"runtime-config",
+	"cpi-config",
 }
 
 // relPath must be from same dir as patterns above
@@ -57,6 +59,8 @@ var userManaged = []string{
 	"vars/*.tfvars",
 	"terraform/*.tf",
 	"cloud-config/*.yml",
+	"runtime-config/*.yml",
+	"cpi-config/*.yml",
 }
 
 func isUserManaged(relPath string) bool {
@@ -4,6 +4,7 @@ import "reflect"
 
 type Jumpbox struct {
 	URL       string                 `json:"url"`
+	HostKey   string                 `json:"hostKey,omitempty"`
 	Variables string                 `json:"variables,omitempty"`
 	Manifest  string                 `json:"manifest,omitempty"`
 	State     map[string]interface{} `json:"state,omitempty"`
@@ -12,3 +13,17 @@ type Jumpbox struct {
 func (j Jumpbox) IsEmpty() bool {
 	return reflect.DeepEqual(j, Jumpbox{})
 }
+
+type JumpboxConfig struct {
+	VMType        string        `json:"vmType,omitempty"`
+	DiskSize      int           `json:"diskSize,omitempty"`
+	SSHKeyType    string        `json:"sshKeyType,omitempty"`
+	AuthorizedKey string        `json:"authorizedKey,omitempty"`
+	SSHCA         bool          `json:"sshCA,omitempty"`
+	Users         []JumpboxUser `json:"users,omitempty"`
+}
+
+type JumpboxUser struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey"`
+}
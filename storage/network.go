@@ -0,0 +1,12 @@
+package storage
+
+// Network holds the networking options of the environment.
+type Network struct {
+	// DualStack adds IPv6 to the networks of the environment, next to IPv4.
+	DualStack bool `json:"dualStack,omitempty"`
+
+	// DNSServers and NTPServers replace the public resolvers and time
+	// servers of the director, the jumpbox and the cloud config networks.
+	DNSServers []string `json:"dnsServers,omitempty"`
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
@@ -20,7 +20,7 @@ var _ = Describe("patch detector", func() {
 		err := storage.NewPatchDetector("fixtures/patched", logger).Find()
 		Expect(err).NotTo(HaveOccurred())
 		Expect(logger.PrintlnCall.Messages).To(HaveLen(2))
-		Expect(logger.PrintfCall.Messages).To(HaveLen(7))
+		Expect(logger.PrintfCall.Messages).To(HaveLen(9))
 		Expect(logger.PrintlnCall.Messages).To(ContainElement(ContainSubstring("you've supplied the following files to bbl:")))
 		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("create-director-override.sh")))
 		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("create-jumpbox-override.sh")))
@@ -29,6 +29,8 @@ var _ = Describe("patch detector", func() {
 		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("terraform/patched-terraform.tf")))
 		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("vars/patched-vars.tfvars")))
 		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("cloud-config/patch-cloud-config.yml")))
+		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("runtime-config/some-addon.yml")))
+		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("cpi-config/some-cpis.yml")))
 		Expect(logger.PrintlnCall.Messages).NotTo(ContainElement(ContainSubstring("UNRELATED_FILE.md")))
 		Expect(logger.PrintlnCall.Messages).NotTo(ContainElement(ContainSubstring("fixtures")))
 		Expect(logger.PrintlnCall.Messages).To(ContainElement(ContainSubstring("they will be used by \"bbl up\".")))
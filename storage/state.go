@@ -1,21 +1,26 @@
 package storage
 
 type State struct {
-	Version        int       `json:"version"`
-	BBLVersion     string    `json:"bblVersion"`
-	IAAS           string    `json:"iaas"`
-	ID             string    `json:"id"`
-	EnvID          string    `json:"envID"`
-	NoDirector     bool      `json:"noDirector"`
-	AWS            AWS       `json:"aws,omitempty"`
-	Azure          Azure     `json:"azure,omitempty"`
-	GCP            GCP       `json:"gcp,omitempty"`
-	VSphere        VSphere   `json:"vsphere,omitempty"`
-	OpenStack      OpenStack `json:"openstack,omitempty"`
-	Jumpbox        Jumpbox   `json:"jumpbox,omitempty"`
-	BOSH           BOSH      `json:"bosh,omitempty"`
-	TFState        string    `json:"tfState"`
-	LB             LB        `json:"lb"`
-	LatestTFOutput string    `json:"latestTFOutput"`
-	StorageBucket  string    `json:"storageBucket,omitempty"`
+	Version        int           `json:"version"`
+	BBLVersion     string        `json:"bblVersion"`
+	IAAS           string        `json:"iaas"`
+	ID             string        `json:"id"`
+	EnvID          string        `json:"envID"`
+	NoDirector     bool          `json:"noDirector"`
+	NoJumpbox      bool          `json:"noJumpbox,omitempty"`
+	AWS            AWS           `json:"aws,omitempty"`
+	Azure          Azure         `json:"azure,omitempty"`
+	GCP            GCP           `json:"gcp,omitempty"`
+	VSphere        VSphere       `json:"vsphere,omitempty"`
+	OpenStack      OpenStack     `json:"openstack,omitempty"`
+	Jumpbox        Jumpbox       `json:"jumpbox,omitempty"`
+	JumpboxConfig  JumpboxConfig `json:"jumpboxConfig,omitempty"`
+	BOSH           BOSH          `json:"bosh,omitempty"`
+	Director       Director      `json:"director,omitempty"`
+	CloudConfig    CloudConfig   `json:"cloudConfig,omitempty"`
+	Network        Network       `json:"network,omitempty"`
+	TFState        string        `json:"tfState"`
+	LB             LB            `json:"lb"`
+	LatestTFOutput string        `json:"latestTFOutput"`
+	StorageBucket  string        `json:"storageBucket,omitempty"`
 }
@@ -111,6 +111,9 @@ var _ = Describe("Store", func() {
 							"key": "value",
 						},
 					},
+					JumpboxConfig: storage.JumpboxConfig{
+						VMType: "t2.small",
+					},
 					BOSH: storage.BOSH{
 						DirectorName:           "some-director-name",
 						DirectorUsername:       "some-director-username",
@@ -125,6 +128,11 @@ var _ = Describe("Store", func() {
 						Variables: "some-vars",
 						Manifest:  "name: bosh",
 					},
+					Director: storage.Director{
+						Blobstore: "external",
+						VMType:    "m4.2xlarge",
+						DiskSize:  100,
+					},
 					EnvID:   "some-env-id",
 					TFState: "some-tf-state",
 				})
@@ -167,6 +175,9 @@ var _ = Describe("Store", func() {
 						"key": "value"
 					}
 				},
+				"jumpboxConfig": {
+					"vmType": "t2.small"
+				},
 				"bosh":{
 					"directorName": "some-director-name",
 					"directorUsername": "some-director-username",
@@ -181,6 +192,13 @@ var _ = Describe("Store", func() {
 						"key": "value"
 					}
 				},
+				"director": {
+					"blobstore": "external",
+					"vmType": "m4.2xlarge",
+					"diskSize": 100
+				},
+				"cloudConfig": {},
+				"network": {},
 				"tfState": "some-tf-state",
 				"latestTFOutput": ""
 		    	}`))
@@ -273,6 +291,8 @@ var _ = Describe("Store", func() {
 			}
 		},
 		Entry("cloud-config", "cloud-config", func() (string, error) { return store.GetCloudConfigDir() }),
+		Entry("runtime-config", "runtime-config", func() (string, error) { return store.GetRuntimeConfigDir() }),
+		Entry("cpi-config", "cpi-config", func() (string, error) { return store.GetCPIConfigDir() }),
 		Entry("state", "", func() (string, error) { return store.GetStateDir(), nil }),
 		Entry("vars", "vars", func() (string, error) { return store.GetVarsDir() }),
 		Entry("terraform", "terraform", func() (string, error) { return store.GetTerraformDir() }),
@@ -290,6 +310,8 @@ var _ = Describe("Store", func() {
 			Expect(fileIO.MkdirAllCall.Receives.Dir).To(Equal(expectedDir))
 		},
 		Entry("cloud-config", "cloud-config", func() (string, error) { return store.GetCloudConfigDir() }),
+		Entry("runtime-config", "runtime-config", func() (string, error) { return store.GetRuntimeConfigDir() }),
+		Entry("cpi-config", "cpi-config", func() (string, error) { return store.GetCPIConfigDir() }),
 		Entry("vars", "vars", func() (string, error) { return store.GetVarsDir() }),
 		Entry("terraform", "terraform", func() (string, error) { return store.GetTerraformDir() }),
 		Entry("bosh-deployment", "bosh-deployment", func() (string, error) { return store.GetDirectorDeploymentDir() }),
@@ -44,6 +44,23 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"availability_zones": azs,
 	}
 
+	if state.Network.DualStack {
+		inputs["dual_stack"] = true
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		inputs["disk_encryption_key"] = state.Director.DiskEncryptionKey
+	}
+
+	for _, region := range state.AWS.ExtraRegions {
+		extraAZs, err := i.awsClient.RetrieveAZs(region)
+		if err != nil {
+			return map[string]interface{}{}, err
+		}
+		inputs[storage.ExtraRegionKey(region)+"_availability_zones"] = extraAZs
+		inputs[storage.ExtraRegionKey(region)+"_vpc_cidr"] = state.AWS.ExtraRegionCIDRs[region]
+	}
+
 	if state.LB.Type == "cf" {
 		inputs["ssl_certificate"] = state.LB.Cert
 		inputs["ssl_certificate_private_key"] = state.LB.Key
@@ -67,6 +67,48 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 
+		It("adds the availability zones and vpc range of each extra region", func() {
+			awsClient.RetrieveAZsCall.Fake = func(region string) ([]string, error) {
+				return []string{region + "a", region + "b"}, nil
+			}
+
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID: "some-env-id",
+				AWS: storage.AWS{
+					Region:           "us-east-1",
+					ExtraRegions:     []string{"us-west-2"},
+					ExtraRegionCIDRs: map[string]string{"us-west-2": "10.3.0.0/16"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["availability_zones"]).To(Equal([]string{"us-east-1a", "us-east-1b"}))
+			Expect(inputs["extra_us_west_2_availability_zones"]).To(Equal([]string{"us-west-2a", "us-west-2b"}))
+			Expect(inputs["extra_us_west_2_vpc_cidr"]).To(Equal("10.3.0.0/16"))
+		})
+
+		It("turns on dual stack when the state asks for it", func() {
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID:   "some-env-id",
+				AWS:     storage.AWS{Region: "some-region"},
+				Network: storage.Network{DualStack: true},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["dual_stack"]).To(BeTrue())
+		})
+
+		It("passes the disk encryption key when the state has one", func() {
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID:    "some-env-id",
+				AWS:      storage.AWS{Region: "some-region"},
+				Director: storage.Director{DiskEncryptionKey: "some-key-arn"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["disk_encryption_key"]).To(Equal("some-key-arn"))
+		})
+
 		Context("when a cf lb exists", func() {
 			var state storage.State
 
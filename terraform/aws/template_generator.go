@@ -9,15 +9,25 @@ import (
 type TemplateGenerator struct{}
 
 type templates struct {
-	base           string
-	iam            string
-	lbSubnet       string
-	cfLB           string
-	cfDNS          string
-	concourseLB    string
-	sslCertificate string
-	isoSeg         string
-	vpc            string
+	base              string
+	jumpbox           string
+	jumpboxDNS        string
+	directorAccess    string
+	iam               string
+	lbSubnet          string
+	cfLB              string
+	cfDNS             string
+	concourseLB       string
+	sslCertificate    string
+	isoSeg            string
+	vpc               string
+	directorBlobstore string
+	directorDatabase  string
+	dualStack         string
+	diskEncryption    string
+
+	extraRegion        string
+	extraRegionPeering string
 }
 
 func NewTemplateGenerator() TemplateGenerator {
@@ -28,6 +38,12 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 	template := strings.Join([]string{tmpls.base, tmpls.iam, tmpls.vpc}, "\n")
 
+	if state.NoJumpbox {
+		template = strings.Join([]string{template, tmpls.directorAccess}, "\n")
+	} else {
+		template = strings.Join([]string{template, tmpls.jumpbox}, "\n")
+	}
+
 	switch state.LB.Type {
 	case "concourse":
 		template = strings.Join([]string{template, tmpls.lbSubnet, tmpls.concourseLB}, "\n")
@@ -36,6 +52,47 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 
 		if state.LB.Domain != "" {
 			template = strings.Join([]string{template, tmpls.cfDNS}, "\n")
+
+			if !state.NoJumpbox {
+				template = strings.Join([]string{template, tmpls.jumpboxDNS}, "\n")
+			}
+		}
+	}
+
+	if state.Director.ExternalBlobstore() {
+		template = strings.Join([]string{template, tmpls.directorBlobstore}, "\n")
+	}
+
+	if state.Director.ExternalDatabase() {
+		template = strings.Join([]string{template, tmpls.directorDatabase}, "\n")
+	}
+
+	if state.Network.DualStack {
+		template = strings.Join([]string{template, tmpls.dualStack}, "\n")
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		template = strings.Join([]string{template, tmpls.diskEncryption}, "\n")
+	}
+
+	// Every extra region gets its own provider and VPC, peered with the VPC
+	// of the director and with the other extra regions.
+	extraRegions := state.AWS.ExtraRegions
+	for _, region := range extraRegions {
+		template = strings.Join([]string{template, strings.NewReplacer(
+			"EXTRA_REGION_KEY", storage.ExtraRegionKey(region),
+			"EXTRA_REGION_NAME", region,
+		).Replace(tmpls.extraRegion)}, "\n")
+	}
+
+	for i, region := range extraRegions {
+		for _, peer := range extraRegions[i+1:] {
+			template = strings.Join([]string{template, strings.NewReplacer(
+				"EXTRA_REGION_KEY", storage.ExtraRegionKey(region),
+				"EXTRA_REGION_NAME", region,
+				"PEER_REGION_KEY", storage.ExtraRegionKey(peer),
+				"PEER_REGION_NAME", peer,
+			).Replace(tmpls.extraRegionPeering)}, "\n")
 		}
 	}
 
@@ -45,6 +102,9 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 func readTemplates() templates {
 	tmpls := templates{}
 	tmpls.base = string(MustAsset("templates/base.tf"))
+	tmpls.jumpbox = string(MustAsset("templates/jumpbox.tf"))
+	tmpls.jumpboxDNS = string(MustAsset("templates/jumpbox_dns.tf"))
+	tmpls.directorAccess = string(MustAsset("templates/director_access.tf"))
 	tmpls.iam = string(MustAsset("templates/iam.tf"))
 	tmpls.lbSubnet = string(MustAsset("templates/lb_subnet.tf"))
 	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
@@ -53,6 +113,12 @@ func readTemplates() templates {
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
 	tmpls.isoSeg = string(MustAsset("templates/iso_segments.tf"))
 	tmpls.vpc = string(MustAsset("templates/vpc.tf"))
+	tmpls.directorBlobstore = string(MustAsset("templates/director_blobstore.tf"))
+	tmpls.directorDatabase = string(MustAsset("templates/director_database.tf"))
+	tmpls.dualStack = string(MustAsset("templates/dual_stack.tf"))
+	tmpls.diskEncryption = string(MustAsset("templates/disk_encryption.tf"))
+	tmpls.extraRegion = string(MustAsset("templates/extra_region.tf"))
+	tmpls.extraRegionPeering = string(MustAsset("templates/extra_region_peering.tf"))
 
 	return tmpls
 }
@@ -27,7 +27,7 @@ var _ = Describe("TemplateGenerator", func() {
 	Describe("Generate", func() {
 		Context("when no lb type is provided", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("base", "iam", "vpc")
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox")
 			})
 			It("uses the base template", func() {
 				template := templateGenerator.Generate(storage.State{})
@@ -37,7 +37,7 @@ var _ = Describe("TemplateGenerator", func() {
 
 		Context("when a concourse lb type is provided", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("base", "iam", "vpc", "lb_subnet", "concourse_lb")
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "lb_subnet", "concourse_lb")
 				lb = storage.LB{
 					Type: "concourse",
 				}
@@ -50,7 +50,7 @@ var _ = Describe("TemplateGenerator", func() {
 
 		Context("when a CF lb type is provided with no system domain", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("base", "iam", "vpc", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments")
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments")
 				lb = storage.LB{
 					Type: "cf",
 				}
@@ -63,7 +63,7 @@ var _ = Describe("TemplateGenerator", func() {
 
 		Context("when a CF lb type is provided with a system domain", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("base", "iam", "vpc", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments", "cf_dns")
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments", "cf_dns", "jumpbox_dns")
 				lb = storage.LB{
 					Type:   "cf",
 					Domain: "some-domain",
@@ -74,6 +74,73 @@ var _ = Describe("TemplateGenerator", func() {
 				checkTemplate(template, expectedTemplate)
 			})
 		})
+
+		Context("when the director uses an external blobstore and database", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "director_blobstore", "director_database")
+			})
+			It("adds the s3 bucket and rds instance to the base template", func() {
+				template := templateGenerator.Generate(storage.State{
+					Director: storage.Director{Blobstore: "external", Database: "external"},
+				})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when there is no jumpbox", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "director_access", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments", "cf_dns")
+				lb = storage.LB{
+					Type:   "cf",
+					Domain: "some-domain",
+				}
+			})
+			It("opens the director to the access cidrs and omits the jumpbox resources", func() {
+				template := templateGenerator.Generate(storage.State{NoJumpbox: true, LB: lb})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when dual stack is on", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "dual_stack")
+			})
+			It("adds the ipv6 routes, security group rules and outputs", func() {
+				template := templateGenerator.Generate(storage.State{Network: storage.Network{DualStack: true}})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when a disk encryption key is provided", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "jumpbox", "disk_encryption")
+			})
+			It("lets the bosh role use the key", func() {
+				template := templateGenerator.Generate(storage.State{Director: storage.Director{DiskEncryptionKey: "some-key-arn"}})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when extra regions are provided", func() {
+			It("adds a peered vpc for each extra region", func() {
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						Region:       "us-east-1",
+						ExtraRegions: []string{"us-west-2", "eu-west-1"},
+					},
+				})
+
+				Expect(template).To(HavePrefix(expectTemplate("base", "iam", "vpc", "jumpbox")))
+				Expect(template).NotTo(ContainSubstring("EXTRA_REGION"))
+				Expect(template).NotTo(ContainSubstring("PEER_REGION"))
+
+				Expect(template).To(ContainSubstring(`alias      = "extra_us_west_2"`))
+				Expect(template).To(ContainSubstring(`region     = "us-west-2"`))
+				Expect(template).To(ContainSubstring(`alias      = "extra_eu_west_1"`))
+				Expect(template).To(ContainSubstring(`resource "aws_vpc_peering_connection" "extra_us_west_2_to_extra_eu_west_1"`))
+				Expect(template).NotTo(ContainSubstring(`resource "aws_vpc_peering_connection" "extra_eu_west_1_to_extra_us_west_2"`))
+			})
+		})
 	})
 })
 
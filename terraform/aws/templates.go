@@ -4,8 +4,17 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/director_access.tf
+// templates/director_blobstore.tf
+// templates/director_database.tf
+// templates/disk_encryption.tf
+// templates/dual_stack.tf
+// templates/extra_region.tf
+// templates/extra_region_peering.tf
 // templates/iam.tf
 // templates/iso_segments.tf
+// templates/jumpbox.tf
+// templates/jumpbox_dns.tf
 // templates/lb_subnet.tf
 // templates/ssl_certificate.tf
 // templates/vpc.tf
@@ -76,7 +85,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x5b\x5f\x6f\xe3\xb8\x11\x7f\x3e\x7f\x0a\xc1\xd8\x87\xdd\x36\xf6\x5a\x8e\xff\xe5\x80\x2d\x70\xed\x15\xe8\xf5\xe1\x5a\xf4\xee\xad\x58\x08\x14\x45\xcb\x6c\x24\x51\x20\x29\x67\xb3\x81\xbf\x7b\xc1\x7f\x92\x28\x89\x96\x94\x8d\xd7\xc9\xdd\x3d\x6c\x22\xce\x0c\x67\x7e\x33\x9c\x19\x4a\x93\x23\xa0\x18\x84\x09\xf2\xa6\x19\xe0\x01\x48\x71\x90\x82\x7c\xea\x3d\x4d\x3c\x8f\x3f\xe6\xc8\xfb\xe4\x4d\xc5\x83\xc9\xc4\xf3\x22\xb4\x07\x45\xc2\xbd\x4f\x72\xd5\xf3\x40\x3e\xcb\x08\xe5\x07\x04\x18\x9f\xf9\x82\x12\xa4\x78\xe6\x2f\xa2\x3d\xdc\x6d\xb7\xd3\x36\xcd\xb2\xa4\x01\x7e\x08\x57\xdb\x55\x49\xc3\x48\xc1\x0f\x33\x5f\xfc\x66\x68\xb6\x2b\xe8\xef\x36\x7e\x68\xd3\xd8\x7b\xdd\x6e\xc0\x7e\xb9\x58\xaf\x3b\x68\xaa\xbd\xd0\x9d\xbf\xf3\xb7\x91\xa2\x81\x60\x06\x51\xc6\x29\x48\xe4\x6e\x86\x66\x19\xdd\x6e\xc0\x76\xa3\x68\x50\xd1\x45\x73\x87\x42\xe4\xef\xf6\x7e\x49\xf3\x80\xa4\x2a\x75\x9d\x6f\xc1\x6e\x75\xb7\x5f\x43\x9b\x66\x69\xd1\x2c\x7d\x7f\xb9\x58\xad\xb4\xce\x05\x9b\x69\x93\xea\x34\xd1\x0a\xae\xd1\x1e\x2e\x6d\x1a\x5b\xce\x7e\xb9\x0d\xd7\xe0\x6e\x5b\xd2\xc4\xe4\x58\xea\xa4\x69\xe0\xed\xdd\xc6\x5f\x80\x4a\x4e\x87\xce\xe1\x6e\xbb\x5f\xdf\x46\x3b\x9b\xc6\xde\x6b\x17\xee\x21\xda\xed\xa5\x9c\xd3\xe4\x34\x99\x54\x51\x03\x20\x44\x8c\x05\xf7\xe8\xd1\x0e\x1a\xc6\x29\xce\xe2\xa9\x4d\xcc\x10\xa4\x88\x0f\x24\xa6\x28\xc6\x24\x1b\x40\x18\x12\x76\x08\x70\x16\x92\x22\x8b\x02\x88\x23\xaa\x78\xaa\x70\x9d\x2e\xe6\xf2\xff\x8f\x8b\x06\x27\x38\x02\x9c\x80\x10\x27\x98\x3f\x06\x5f\x49\x86\x98\xbd\x5d\x82\x19\x6f\xb0\xa0\xec\x18\xe0\x68\x88\xad\x07\x42\x79\x30\x98\xfc\x98\xc3\x9a\xee\x92\x54\xe1\x6f\xa8\x2d\x83\x7c\x63\x91\xbf\x91\x72\x28\x62\xa4\xa0\x50\x98\xf4\xc0\x02\x84\xf3\xa9\x37\xfd\x5f\x91\xe6\x21\xf9\xa2\x7e\x53\x80\xe4\x28\x8b\x58\x40\x32\xef\x93\xf7\x5f\x49\x89\x33\x8e\x68\x86\x78\x10\x03\x8e\x1e\xc0\xe3\x1c\xc7\xd3\xcf\x13\xcf\x3b\xe6\xd0\xf3\x4c\x08\x70\x5a\x20\x7b\x13\x9e\xb0\x20\xa7\xf8\x08\x38\x52\xce\x54\x3e\x38\xa6\x1a\x3f\x90\xc4\x84\x62\x7e\x48\x85\xae\xff\xf9\xed\x27\xa1\x3d\x65\x20\x08\x31\x67\x42\xe2\x6a\x71\xb7\x69\xab\x7d\x8f\x1e\x83\x1c\x60\xda\x12\x27\x16\x32\x90\x22\x05\xc8\xbb\xa7\x23\xa0\x73\x05\xec\x29\x28\x29\x27\x9e\x97\x17\x61\x82\xa1\x90\xa3\xe8\x1a\x6a\xce\x0d\xed\xbc\x22\x0c\x48\x8e\x32\xc6\x0e\xa7\x0e\x18\x19\x82\x05\x15\x91\x11\x53\x52\x08\x44\x45\x86\x6c\x3e\x14\xfa\x69\xdd\xcc\x89\xb1\x14\x9c\x65\x80\xcf\x0c\xd3\x4c\x31\x49\x5f\x30\x48\x71\xce\xb1\x74\xc6\xf4\xd7\x9f\x7e\x9f\x2a\xd8\x03\x1c\xd5\x04\x25\x04\x82\x64\xae\x1e\x9f\x64\x12\xe6\x20\x66\x3a\xff\xfe\x2a\xb6\x1d\xb8\xdf\x49\xf0\x26\x78\x8f\xe0\x23\x4c\x90\x16\x80\xe3\x8c\x50\x14\xc0\x03\xc8\x62\xc4\x64\x50\x08\x53\x64\x04\x9c\xfa\xf0\x08\x68\x91\x20\x0d\x0a\x27\x55\x24\xa9\xc7\x62\x83\x06\x3d\x8e\x94\xb2\x6d\x51\xf3\x36\xb0\xf3\xd2\x5e\x7d\x12\x34\x24\x28\xa6\x88\x49\x67\xef\x29\x49\x83\x9c\x50\x2e\x17\x16\x82\x94\x98\xdf\xcd\x93\x9c\x12\x4e\x20\x49\x34\xf3\x4c\x26\x6f\x71\xca\x82\x30\x21\xf0\x5e\x99\x5c\x25\x87\xcf\x63\x6c\xc6\x30\xcd\x2f\x6c\x2c\xce\x4a\x6b\x1b\x96\x88\xcd\xdb\x20\xcc\xfc\x16\x0a\xf2\xd1\x0b\x59\xcc\xe1\x45\x0d\xb6\xfe\x73\x5b\xdf\x24\xe3\xb0\x85\x44\x83\xa4\x19\x1b\x8d\xe5\xcd\x7a\x7d\xbb\x16\x06\x49\x10\x9a\xf6\x9f\xb1\x4b\x85\x3c\x48\x3a\x8d\x1b\x81\x6b\x11\xbd\x46\x5c\x8b\xe8\x6d\xe0\x8a\x33\xc6\x41\x06\x35\x98\x0a\x43\x93\xf4\x71\xde\xb4\xea\xdd\x93\x38\x0c\x07\xc2\xf8\x7b\xb9\x73\x11\x66\x88\xab\xc2\xa0\x7f\xae\x0e\xcb\x8d\xb7\xfd\x70\x12\x18\x98\x2d\x02\x1b\x56\x11\x7c\xcb\x79\x8a\x22\x5c\xa4\x82\x4c\x09\x28\x13\xb8\xb5\xab\x63\x33\x69\x52\x09\x51\x84\x18\x0f\xe0\x01\xc1\x7b\xc3\xb9\x07\x09\x43\xa2\xa0\xa6\xd8\xe1\x4d\x51\x23\xc8\x7d\x91\xbf\x17\x35\xa0\xd6\xc2\xdf\x78\xe2\x81\xea\xa1\x94\x15\xa2\x8a\xb4\x9c\xa0\x12\xc2\x98\xf0\xfa\xdc\x55\x85\x3a\xcb\x90\xea\x28\xff\x9e\x1d\x7f\xf9\xb9\xb5\x3e\xed\x2e\x31\xaa\x73\x11\x3b\x3f\xa7\x6b\x31\x7e\xaa\x83\x6e\x9e\x09\x73\x0c\xdc\x9d\xdd\x4d\x4e\xc9\x11\x47\x88\x4a\x45\x74\x1b\x53\xf6\xb6\x95\xfe\xd5\x33\xe5\xb9\xb2\xa3\xad\x48\xaa\x67\x92\x44\xf9\xa0\xf2\x57\xe5\x17\x75\x56\x8f\x88\x32\xdd\x06\xfc\xe5\x93\xe7\xcf\xfd\xed\x7c\xd1\x11\xe7\xba\xfb\x6b\x77\x25\xae\x85\xa7\xaa\xa1\xe8\xea\x25\xfa\xdb\x1d\xc7\x39\x1c\xd0\xf3\x18\xce\xfe\xc6\xe7\x17\x4d\xf9\x52\xdd\xcf\x99\x9d\x2f\xd7\x02\x39\x80\x92\xcb\xa2\x6a\x8e\x4d\xec\x67\x13\x60\x57\x72\xef\xcb\xea\xe7\xca\xa4\x2b\x8f\xd7\x12\x38\x4a\xf6\xcd\xfd\xda\x77\x82\x67\xc2\x23\xca\xcc\x2b\x80\xc7\x59\xed\xae\x0c\x8f\x6c\xf4\x5e\x01\x3e\x5d\x0d\xa7\x59\x6c\xb5\x9d\xd6\x42\xbd\xf9\x34\x0b\xcf\x6a\x41\xcf\xe2\x04\x92\x84\x3c\x94\x85\xe1\x7b\x20\x86\xce\x03\xa6\xee\x1a\x63\xe2\x69\xf1\xdd\xc0\x62\xec\xe0\x42\xa8\x32\xe0\x65\x80\x1a\x18\x61\x15\xd9\xef\x7f\xfb\x77\x4f\xdb\xb9\x5c\x9e\xef\x3b\xe5\xfa\xe8\xa6\x53\xbf\x2d\x19\xd4\xbc\x9b\x17\x14\xa3\xeb\xa2\xe0\xea\xaf\x89\x7f\xfd\xd7\x6f\xff\xf0\x7e\xc6\x14\x41\x4e\xe8\x4b\x15\x46\xc7\xd6\xa3\x8a\xe2\x8d\x68\x36\x4a\x55\xc7\xd5\xc8\x0e\xc0\xca\xfa\x78\x2e\x20\x5d\xfe\xea\x90\x77\xa9\xfa\xe8\x08\x38\xbd\xd0\x7d\x64\x15\xf8\xad\x37\x93\xa7\xe1\x27\xf8\x2c\x60\x72\x11\xc4\x28\x73\xa6\xba\x9e\x83\x3c\x0a\xbe\xd1\x87\xb8\xff\x4e\xbe\xd9\x6d\x76\x3d\xd7\x47\x45\x71\xd1\x83\xdc\x8b\x75\x01\xc0\x1b\x05\x78\xb7\x5a\xdd\x9e\x07\x58\x53\x5c\x17\x60\x48\x51\x74\x28\xc2\xb7\x0a\xf2\x6e\xb5\xea\x01\x59\x51\x5c\x17\x64\x91\x31\x22\x5d\x4f\x02\x90\xe3\x37\x8a\xf6\x72\xbd\x5e\xaf\x7b\x6a\xbf\x26\xb9\x3a\xde\x6f\x14\xe2\xd7\xf1\x4a\x6f\x6c\x92\x76\x5f\x21\xaf\x0a\xf7\x5b\x79\x83\x3a\x12\xee\x6f\xbb\x6a\x8d\xed\xdb\x5e\xe7\x35\xab\xfa\xbc\x3a\xa0\xeb\xd7\x94\xfd\x8d\xff\x3f\xb5\xc8\x17\x6a\xf9\xdd\xfb\x7e\xb7\xae\xdf\x7c\x83\x7e\x46\x83\x6f\x65\xe4\x3f\x4a\x53\x6f\xf0\xa0\xe3\xdf\x7a\x5d\x18\x8f\xdb\xdb\xdd\x9d\x03\x11\xbd\x74\x69\x4c\xce\x5e\x67\xae\x84\x8a\xf3\x9a\x52\x2e\x5d\x1a\x15\xd3\xb7\xbd\x32\x60\xdc\xbd\x58\xb5\x76\x69\x68\x74\x69\xb8\x00\x30\xaf\xb3\xe8\x58\x95\xb9\x5d\xe2\xbf\xb1\xf5\xbc\xfc\xab\xbd\xeb\xb5\x9f\xce\xa6\xe3\x05\x10\x7f\x7e\xf7\x79\x79\xc4\xaf\xd7\x81\x8e\x40\x5c\x7e\x09\x2f\x1b\x4e\xfd\xdb\x93\xdd\x03\x75\xb5\x40\xf5\x13\x55\x7d\xda\x57\x02\xe4\xd7\x70\x33\x52\x77\xe3\xed\x6e\xbc\xc5\x87\x51\x2f\x4a\x95\x1a\xdd\xad\x0e\x25\x05\x47\x01\x07\x61\x15\x1b\xd6\xa3\xb1\x1f\x5e\x25\xb3\x53\x52\x84\x18\xc7\x19\x10\xbd\x57\x60\x1b\x5c\x9b\x6a\xf4\x3c\xfd\x29\xbc\x39\x7d\x50\xfb\x0e\xde\xfa\x66\x6e\x02\xad\xb6\x65\x9d\xbd\x64\xad\xad\xcf\x9b\x3a\x3a\x9c\x5a\x17\x09\x18\x23\x10\x4b\x03\xa6\xde\x54\xad\xd4\x7c\x6d\x12\xb8\x3d\x3c\x31\x60\x68\xa2\xa1\xf6\xb7\xa8\x5b\xc6\x60\x75\x24\xeb\xba\x41\x52\x64\xbc\x0d\x6b\x82\xb2\x98\x1f\x64\xa8\xb5\x07\x49\xab\x99\x0b\xdb\x23\xbd\x91\x5c\xa7\x73\x06\xf4\xea\x46\x29\x35\xc7\x59\x84\xbe\xfc\xd9\x57\xbb\xb5\xb4\x50\x52\x50\x82\x52\x94\x71\x87\xa2\x96\xa4\xa1\x87\xa4\xfa\xcc\x2e\xb5\x7b\xf7\x54\x93\x71\x1a\x73\xc3\xa8\x0c\x17\xf7\x8c\x96\x76\xae\xdb\x86\x7d\x04\x4b\xaf\xbd\xc8\x31\x74\x4b\x1b\x78\x14\xcb\x71\xa1\x0e\xcf\xbb\x46\x52\xc6\x9c\xc1\x2e\x05\x9f\x79\x0e\x07\xc5\xfb\xd0\x60\xef\x3a\xc2\x26\xf6\x6a\x47\xb9\xb9\xe7\xfc\x4f\x73\x1c\xb5\xa2\x70\xd8\xf9\x1e\x01\x45\x33\x01\x0a\x4f\xc7\x43\xa2\x44\x3e\x51\xc7\xa1\x7c\x89\xda\xb8\xef\xcb\x9a\xd1\x9a\x6f\x2a\x95\x13\xb1\xd2\xf2\xe8\xd9\xd1\x33\x9b\x3f\x7e\x68\x38\xa3\x9c\x61\x53\xda\x5a\x1b\xdd\x78\x3a\x1b\x98\x36\xb9\x5c\xc5\xf9\x20\xf6\xb5\x62\x2f\x6d\xad\xf3\x0f\x60\xdf\x7c\xe8\x42\xff\x3e\xd5\x7f\x23\x30\x2d\x7f\x12\x80\xa2\x4c\xba\xf7\x1e\x3d\x06\x94\x70\xa0\x5f\x84\x98\xe9\x09\x52\xf0\xbc\xe0\xd5\x84\x93\x99\x03\xd7\x5e\x03\x49\x81\x2a\x3c\xcd\xf4\x78\x35\xe5\x6d\xc8\x95\x3e\x46\x98\x35\xb7\x5e\xc9\x29\xb1\x75\x0f\x8d\x57\x0f\x83\x1c\xa5\x7a\xfc\x2b\x63\x98\xe3\x23\xea\xd0\x1a\x7d\x29\x71\xeb\x54\x18\xe1\xf2\x32\x22\x7f\xd6\x43\xe9\x38\xb7\xf5\x35\x24\x05\x4d\x46\x8a\xf9\x71\xb9\xb4\x24\x55\x9f\x00\xa2\xa8\xba\x39\x95\xe2\x0e\x9c\xe7\xec\xc7\x8f\x1f\xfb\xc5\x8a\xfb\x9d\x25\xd9\x1a\xd8\xeb\xd0\x4f\xaf\xbb\x4c\x3c\x3b\x68\xd6\x10\x37\xf2\x4d\xa7\xd9\xc2\xf9\xbd\xfe\xbc\xf8\x73\x6d\x6c\xd3\x41\xe3\xa5\x37\x3f\x3a\x34\x25\x3a\x66\xfe\x1a\x8e\x73\x8c\x6f\xd6\x85\x7f\xee\x0c\x83\x6f\x12\xef\x42\xc6\xda\xaa\xac\x08\xdd\x68\x38\x5a\xbb\x9a\x00\xf0\x75\x28\x67\xab\x28\xd9\x82\x54\x42\x6f\x09\x6b\x67\x7b\xc3\x50\xff\xeb\xa3\x1a\x83\x35\xbc\x59\x23\xd7\x59\x2d\x00\xb4\xcd\x53\xcb\x7f\x73\xf3\x2f\xa0\x99\xe3\x0c\x80\xaf\x41\x09\x5b\x90\x82\x3c\xc7\x59\xdc\x12\x39\xf9\xc1\xf3\xbe\xe2\x3c\x05\xf9\x7b\x1b\x92\x8e\xe2\xda\x81\xcc\x8d\xd7\xcb\x25\xf0\xf8\x30\xf9\xa1\x57\x49\x59\xb3\xae\xa7\x66\xbd\x64\xb6\xd4\xb5\xca\xb5\xc3\xf7\x16\x8d\xc3\xda\xea\xef\xb2\x5a\xec\x16\x8d\x83\x3d\x7e\xe8\x63\x8e\x1f\x1c\x09\xa0\x56\x7b\x1d\x32\x3a\xaa\xbc\x03\x84\x01\xc2\xba\x6a\xbe\x94\xf6\xff\x00\x00\x00\xff\xff\x39\xf1\x7e\xd7\x26\x3a\x00\x00")
+var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe5\x5a\x5b\x6f\xdb\x36\x14\x7e\x5e\x7e\x05\x61\xf4\xa1\xd9\x6c\xcf\x72\x7c\xcb\x80\x6e\xe8\xd0\x01\x2b\x86\x75\x43\xbb\xb7\x22\x10\x68\x8a\x96\xb9\xc8\x92\x20\x52\x4e\x93\xc0\xff\x7d\xbc\x4a\xa2\x44\xd9\x52\x93\xb4\x29\x96\x3e\x34\x21\xcf\xf5\xe3\xe1\x39\x47\x24\xf7\x30\x23\x70\x1d\x61\x30\x88\x21\xf3\xe1\x8e\xf8\x3b\x98\x0e\xc0\xfd\x19\x00\xec\x36\xc5\xe0\x15\x18\x88\x81\x33\xfe\x77\x80\x37\x30\x8f\x18\x1f\x12\xb3\x00\xc0\x74\x14\x27\x19\xdb\x62\x48\xd9\xc8\x13\x94\x9c\x7d\xe4\x4d\x82\x0d\x5a\x2d\x97\x83\x26\xcd\xb4\xa0\x81\xde\x1a\xcd\x96\xb3\x82\x86\x26\x39\xdb\x72\x19\xe2\x47\xd3\x2c\x67\xc8\x5b\x2d\xbc\xb5\x4d\x63\xeb\xba\x58\xc0\xcd\x74\x32\x9f\x3b\x68\x4a\x5d\xf8\xd2\x5b\x79\xcb\x40\xd1\x20\x38\x42\x38\x66\x19\x8c\xa4\x36\x43\x33\x0d\xb8\xa8\xe5\x42\xd1\xe0\xdc\x45\x73\x89\xd7\xd8\x5b\x6d\xbc\x82\xe6\x06\x4b\x53\xaa\x36\x5f\xc0\xd5\xec\x72\x33\x47\x36\xcd\xd4\xa2\x99\x7a\xde\x74\x32\x9b\x69\x9b\x73\x3a\xd2\x2e\x55\x69\x82\x19\x9a\xe3\x0d\x9a\xda\x34\xb6\x9c\xcd\x74\xb9\x9e\xc3\xcb\x65\x41\x13\x26\xfb\xc2\x26\x4d\x83\x2e\x2e\x17\xde\x04\x96\x72\x1c\x36\xaf\x57\xcb\xcd\xfc\x22\x58\xd9\x34\xb6\xae\xd5\x7a\x83\xf0\x6a\x23\xe5\x1c\xce\x0e\x67\x67\xfb\x22\x6a\x20\x42\x98\x52\xff\x1a\xdf\xda\x41\x43\x59\x46\xe2\x70\x60\x13\x53\x8c\x32\xcc\x3a\x12\x67\x38\x24\x49\xdc\x81\x70\x9d\xd0\xad\x4f\xe2\x75\x92\xc7\x81\x8f\x48\x90\x29\x9e\x32\x5c\x07\x93\xb1\xfc\xf7\xe3\xa4\xc6\x09\xf7\x90\x44\x70\x4d\x22\xc2\x6e\xfd\xbb\x24\xc6\xd4\x56\x17\x11\xca\x6a\x2c\x38\xde\xfb\x24\xe8\xe2\xeb\x96\xc7\xbd\xdf\x99\x7c\x9f\xa2\x8a\xed\x92\x54\xe1\x6f\xa8\x2d\x87\x3c\xe3\x91\xb7\xa8\xc9\x09\x72\x18\xf9\x94\x41\x74\x6d\xa3\xa0\xa4\x6d\x60\x44\xb1\x1c\xa5\x28\x23\x29\xe3\x00\x0b\x71\xaf\x29\x25\x61\x0c\xde\xfe\xbd\x5f\x00\x61\x04\x58\x47\x09\xba\xa6\x80\x25\x80\x6f\x27\xc0\x6d\x03\x30\x0e\x00\xcd\xd7\x31\x66\x74\x08\x62\xfc\x89\x89\x49\xce\x30\xab\xeb\x27\xf4\x9a\x7b\x8d\xb2\x5b\x29\xbd\x5c\x6b\xdb\x90\xc1\xc0\x61\xc5\xfb\x77\x20\xd9\x00\x08\x50\x4e\x59\xb2\xc3\xd9\x68\x07\x63\x18\xe2\x00\xfc\xf1\xe7\x07\xc0\x05\x71\x63\x20\x03\x5a\x38\x95\xa6\x09\x75\x54\x70\x89\x20\x00\xfb\x1d\x95\xe6\x64\x98\x27\x82\x0c\x71\x73\x58\x44\xfd\x34\x23\x7b\xc8\xb0\x32\x45\x45\x8b\x20\x94\x56\xc1\x28\x4c\x32\xc2\xb6\x3b\x61\xc0\xfb\x0f\xaf\x85\x59\x19\x85\xfe\x9a\x70\x05\x7c\x6c\x36\xb9\x5c\xd8\x12\xe1\x8d\x8c\x76\x3f\x85\x24\x6b\x88\x13\x13\x31\xdc\x61\xe5\xe3\x8b\x7b\x8e\xcb\x58\x85\xc0\xc1\x2f\x28\x39\x5d\x9a\xaf\x23\x82\x84\x1c\x45\x57\x33\x73\x6c\x68\xc7\x25\xa1\x9f\xa4\x38\xa6\x74\x7b\x18\x34\xed\xe1\xfb\x2a\xcf\x44\x0c\x87\x59\x92\xf3\xec\x2d\x73\x79\x7d\x50\xd8\xa7\x6d\x33\x8b\x60\x19\x38\xe2\x4c\x23\xc3\x34\x52\x4c\xcd\x45\x7a\xf7\xfa\x1f\x31\x2a\xc2\x95\x04\x15\x41\x3c\x60\x60\x34\x56\xc3\x07\x59\x2e\x18\x0c\xa9\xae\x14\xef\x84\xda\x8e\xfa\x0e\x82\x37\x22\x1b\x8c\x6e\x11\x0f\x28\x25\x80\x47\x67\x92\x61\x1f\x6d\x61\x1c\x62\xca\x45\x7d\x1c\x08\x57\x06\x57\x26\x21\x1d\xc3\xc3\xcf\xf2\x08\x6b\x50\x58\xc2\x33\x05\xc3\x19\x8f\x63\x3d\x2c\x14\xd4\xe8\xb9\x63\xd2\xd8\xa6\xa8\x71\x13\xd8\x71\xe1\xaf\xde\xb3\x1a\x12\x1c\x72\x9b\xe4\x62\x6f\xb2\x64\xe7\xa7\x3c\x1b\xc8\x89\x89\x20\x4d\xcc\xdf\x66\x24\xcd\x12\x96\xa0\x24\xd2\xcc\x23\x59\x66\xc4\x56\xf4\xf5\x56\x14\x2e\x97\x69\xec\xaa\x8f\xcf\x04\xed\xd2\x27\x76\x96\x67\x28\xe3\x6d\xcd\x13\xa1\xbc\x09\xc2\xc8\x6b\xa0\x20\x87\x1e\xc9\x63\x86\x9e\xd4\x61\xeb\xa7\xdd\xfb\x3a\x19\xb7\xaa\x8e\x44\x8d\xa4\x1e\x1b\xb5\xe9\xc5\x7c\x7e\x31\x17\x0e\x49\x10\xfc\xee\x7e\xa9\x90\x17\x85\xc1\xe1\x5c\x0f\x5c\xf3\xe0\x39\xe2\xca\xad\xfa\x26\x70\x25\x31\xaf\xcb\x31\xd2\x60\x2a\x0c\x4d\xd2\x27\x69\xdd\xab\x17\xf7\x62\x33\x6c\x13\xca\x5e\x4a\xcd\xb2\xf6\xaa\xc2\xa0\x7f\x2f\x37\xcb\x10\x2c\xcf\x0f\x02\x03\xa3\xc2\xb7\x61\x15\xc1\x37\x1d\xef\x70\x40\xf2\x9d\x20\x53\x02\x8a\x04\x6e\x69\x6d\x51\x26\x5d\x2a\x20\xe2\x45\x81\xf1\x6c\x8c\xd1\x35\xa8\x75\x16\xbc\x5d\x6c\x59\x4d\x51\x23\x92\xeb\x3c\x7d\x29\x6a\x40\xe5\x63\x63\x08\xc4\x80\xea\xf6\x94\x17\xa2\x8a\x34\x16\x41\x25\x84\x3e\xe1\x75\xe5\xaa\x42\xce\x32\xa4\x7a\xdf\xdf\xe2\xfd\xdb\x37\x8d\xf9\x81\xbb\xc4\x60\x62\xea\xac\xfc\x4d\x75\x38\xbc\x42\x07\xd4\x97\x85\xf2\xa3\x5e\x72\x5d\x6b\x42\xbe\xc8\x37\xf0\x76\x4c\x42\x59\xb3\xcc\x3a\x55\x41\x37\x63\xc2\x1d\x03\xb7\xe8\xbc\x0a\x04\x59\x96\x63\x61\x08\xdf\x0b\x7b\x12\xe0\x4c\x1a\xa2\xdb\x98\xa2\x0b\x2f\xed\x2f\xc7\xd4\xca\x15\xbd\x77\x49\x52\x8e\x49\x12\xb5\x06\x76\x73\xa0\xc6\xd4\x5e\xdd\xe3\x8c\xea\x36\xe0\xe7\x57\xc0\x1b\x7b\xcb\xf1\xc4\x11\xe7\xba\xd3\x6b\x76\x25\x6d\x13\xf7\x65\x43\xe1\xea\x25\x4e\xb7\x3b\x2d\xfb\xb0\x43\xcf\x63\x38\x4f\x37\x3e\x6f\x35\xe5\x63\x75\x3f\x47\x34\x3f\x5d\x0b\xd4\x02\x94\x9c\x16\x55\xb3\x6f\x62\x3f\x9a\x00\x5d\xc9\xfd\x54\x56\x3f\x56\x26\xdb\xf2\x78\x25\x81\xe3\x68\x53\xd7\x67\x76\xcd\x83\xe1\x11\x65\xe6\x19\xc0\xd3\x5a\xed\xbe\x32\x3c\xb2\xd1\x7b\x06\xf8\xb8\x1a\x4e\x50\xed\x31\x5d\x08\x35\x9a\x4f\x33\xf1\x59\x2d\xe8\x51\x9c\x60\x14\x25\x37\x45\x61\xf8\x12\x88\xe1\xe3\x80\xa9\x6f\x8d\x3e\xf1\x34\x79\x0c\xb0\xcc\xb7\x73\xef\x94\x2d\xb8\x4e\xa7\xeb\x5f\xff\xfa\xf0\x3b\x78\x43\x32\x8c\x58\x92\x3d\x56\xce\x6e\x51\xdd\x2b\x5f\x0f\x45\x1d\x2c\x4c\xed\x97\xbe\x1d\x80\x15\xa9\xdb\xa7\x74\xdb\x37\x9a\x1c\xf2\x9e\x2a\x75\x4f\xa7\x2d\xc1\x24\x27\xdc\xd1\xa4\xc0\x6f\x1c\xef\x1d\xba\xef\xc4\x63\x80\xb5\x81\xd5\x68\x86\x1f\x80\xd9\xff\xec\x1b\xb1\x15\xee\x23\xb5\xf3\xab\xc2\xfd\xad\x7c\x3a\xf6\x84\xfb\x61\x35\xa6\x6f\x56\x78\x9e\xf5\xc5\x02\xa9\x89\xf6\x03\xb3\x40\xef\x3a\xfc\x0d\x65\x82\xd6\xf5\x7f\x04\xc4\x3f\x3f\x11\x3c\x3d\xe2\x5f\x2f\x19\xf4\x40\x5c\x9e\xc6\x14\x7b\x5f\xff\x75\x6f\xf7\x37\xae\xf6\xa6\xba\xa3\xca\xe3\x25\x25\x40\x9e\xc8\x98\x0b\xa8\x21\x58\x0d\xc1\xe4\x5c\x75\x44\x24\xdd\x2f\xfc\x0a\xa3\xf3\x58\x47\x70\x97\xd7\x4e\xe0\x17\x50\x91\x5c\xb1\xc2\x88\xd2\x0a\xc0\x4f\x60\x30\x90\x86\x41\x79\xfb\xa4\x08\x60\x10\x88\xa5\xf2\x93\xd8\x47\x19\x86\xa6\x9b\xab\x2b\xe9\xd5\xaf\x29\x90\xdc\x7d\x16\x87\x9a\xf1\x3c\x20\xee\xae\x0c\xa8\xd6\x50\xdf\xa3\x09\xc9\xdc\x2a\x49\x1c\x9b\x91\x58\x7a\xe5\xdb\xcb\x51\xb9\xa1\x04\x40\x1f\x16\xd5\xcf\xe7\x2a\x27\x45\x8d\x53\x25\xb3\x0d\x2a\x2a\xab\xec\x05\x6b\x65\x7e\x5c\xb7\x71\x7c\xc4\x25\x2d\x92\x2f\x55\x82\x88\x74\x80\x3b\xa9\x66\x2a\x91\xa8\x4f\xa3\x6a\xc7\x8b\x1d\x8e\x15\x6b\x66\x3f\xc4\xdc\x62\x87\x94\x09\xa3\x6a\x1b\xe2\x6d\x24\x73\x1c\x4d\xe2\x38\x64\x5b\xb9\x11\x9a\x97\xc2\xe5\xa9\xa4\xbd\x22\x27\xf7\x59\xfd\x34\xd7\xb9\xdd\x66\x43\x65\x14\xcf\x70\x01\xfe\xf4\x83\xa7\xb4\x35\xac\x50\x52\x70\x84\x77\x38\x66\x2d\x86\x5a\x92\x9e\x7a\x0b\xdb\x46\x7f\x89\xed\x5c\x1e\x99\x49\xcb\x38\xa6\xa5\x09\x87\x3e\x1f\x62\x25\x1c\xe2\x73\xac\x81\x63\xdb\x47\x99\x9d\x2c\x8a\xf8\x7a\x94\x84\xd1\x2e\xad\x63\xd2\x28\x8e\xfe\x1d\x31\xda\x76\xbc\xdc\x27\x5b\xb8\x0c\xfc\xcc\x8c\xd1\x69\x67\x76\xdd\x96\xae\x64\x63\x76\x49\x25\xe9\xd4\x75\x8e\xbf\xe7\xc6\x37\xf6\x4b\xb7\x4c\xd4\x03\x8a\x7a\xaa\x16\x2b\x1d\x76\x89\x12\x39\xa2\xb6\x43\xa0\x4f\x31\xfc\xda\xb1\x88\xac\x6e\x8d\xbb\x8a\xc2\x38\xf9\x90\xa3\xc3\xcd\x4e\x19\x53\x36\x7f\x78\xd3\x76\x1f\xa5\xac\xb5\x14\x0d\x81\xce\x5b\xff\xe6\xbb\x74\x9d\x7c\x2a\xd7\x58\xdc\x6c\x75\x60\x9f\x2b\xf6\xc2\xd7\x2a\x7f\x07\xf6\xc5\xb9\x0b\xfd\xeb\x9d\x7e\x99\x34\x28\x7e\x13\x80\xe2\x58\x2e\xaf\x78\x54\xc1\xdb\x41\x93\x95\xcc\x49\x28\x5f\xd3\x34\x67\xe5\x6d\x85\x79\xd3\xa1\x57\x0d\x46\x39\x2e\xf1\x34\x2f\x41\xca\x17\x1b\x86\x5c\xd9\x63\x84\x59\x6f\x50\x4a\x39\x05\xb6\xed\x0f\x40\xca\x41\x3f\xc5\x3b\x7d\x95\x13\x53\xc2\xc8\x1e\x3b\xac\xb6\x6e\xa4\x6a\xc6\xf2\xf1\xb1\x9e\x37\x0f\x4b\x48\x6a\xdb\x79\xf4\x26\xa5\x26\xae\xe7\x17\xad\x51\xd1\x7a\xea\x77\x5c\xfc\xb1\x1e\xb9\x58\x32\x13\x3d\x2d\x77\x50\xd4\x56\xf4\xb1\x9f\xa6\xab\xaa\xaa\x22\xed\xb8\x6d\x6f\xe9\x74\x2a\x02\xe0\x5d\x57\xce\x46\xe6\xb3\x05\xa9\xac\xd1\x10\xd6\x4c\x29\x86\xa1\xfa\xb0\xae\xc2\x60\xdd\xf6\x55\xc8\xf5\xd6\xf1\x61\xe6\xe6\x71\xbc\xf8\x02\xaf\xc4\x0b\x2f\xde\x46\x54\x36\xe1\xd8\xfc\xcf\xe5\xf0\x66\xa1\x85\xb3\x25\x1a\xe1\x9d\x5f\x00\x2e\xee\x8c\x53\xf1\x24\xae\x6e\xcc\xd9\x77\x00\xdc\x91\x94\x4f\xbf\xb4\xc1\x74\xe4\x7e\x07\xa6\x43\x70\x92\x4b\x20\x79\x7e\xf6\xdd\x49\x23\x65\x4a\xfd\x7a\x66\x56\x33\x7a\xc3\x5c\xab\x9a\xb4\x44\x8d\x45\xd3\xe2\x6d\xf9\x58\xb1\xc1\x6e\xd1\xb4\xb0\x87\x37\xa7\x98\xc3\x1b\x9b\xd5\x54\x96\x6a\x69\x68\x91\xe1\x28\x42\x6d\x89\xe2\xb4\x30\x57\x49\x92\xd2\xfe\x03\xad\x39\xd0\x32\x3b\x2d\x00\x00")
 
 func templatesBaseTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -91,12 +100,12 @@ func templatesBaseTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/base.tf", size: 14886, mode: os.FileMode(480), modTime: time.Unix(1532366037, 0)}
+	info := bindataFileInfo{name: "templates/base.tf", size: 11579, mode: os.FileMode(480), modTime: time.Unix(1792397503, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x95\xc1\x6b\xdb\x30\x14\xc6\xef\xfe\x2b\x1e\xa2\x87\xa4\xa4\x26\xa5\xec\x52\x28\xa3\x8c\x1d\xd7\xcb\x76\x1b\xc3\x28\xd2\x4b\xa2\xa2\x48\x42\xef\xd9\x6d\x57\xfc\xbf\x0f\x59\x6e\xea\xb6\xc9\x66\x0f\x92\x53\x90\x9e\xbe\xf7\xfb\xe4\xef\xd9\x8d\x8c\x46\xae\x2c\x82\xa0\x27\x62\xdc\x55\xda\xef\xa4\x71\x02\x9e\x0b\x00\x7e\x0a\x08\x37\x20\x88\xa3\x71\x1b\x51\xb4\x45\xf1\x5a\x1f\x64\x44\xc7\xd5\x6f\xef\x70\x50\xdd\xff\x06\x87\x00\x34\xae\x65\x6d\xf9\x65\x23\x2f\x91\x8a\x26\xb0\xf1\x2e\x2d\xfd\xd8\x22\x38\xb9\x43\xf0\x6b\xe0\x2d\x42\xd6\x86\xa4\x0d\x6b\x1f\xf3\x5a\xf4\x8d\xd1\xa8\x21\x83\x42\x06\x05\xb3\x06\xc3\x80\x8f\x86\x98\xca\x0e\x51\x4b\x96\x20\xe4\x03\x55\xd1\xd7\x8c\x9f\xae\x7a\xc6\x9e\x38\xc3\x2a\x5f\x3b\x4e\xad\xcf\x9e\x1b\x19\xcb\x81\x19\xb8\x49\x8c\xf0\x19\x96\x70\x0d\x97\xad\x28\x0a\xc8\x6c\x87\x8a\xdb\xae\xa3\xaf\x39\xd4\x0c\x02\x5d\x53\x69\x47\xdd\x4e\x95\xce\x54\x84\xb1\xc1\x48\xb9\x67\x23\x6d\x9d\x64\x7e\x8a\xb3\x67\x0a\xd6\xf0\x4c\x2c\xc4\x02\xac\x57\xd2\x96\xc3\xf2\x79\x2b\x7e\x25\xdd\x6e\x87\xba\xb3\x9d\xa4\xd1\xfb\xdb\x3d\x8e\x8d\x16\x77\xe8\x78\xa6\xbc\x53\x92\x67\xef\xef\xa1\x1c\x42\x96\xe7\x65\x2f\xbc\x00\x6b\x88\x67\x42\xcc\xe7\x0b\x58\xce\xe1\xfa\xbd\x4e\xba\xd5\xf2\x83\x58\x06\x38\x2a\xd3\x8a\xfe\xf2\x5e\x9c\xfd\x1d\xfd\xde\x1b\x97\xef\x64\x6d\x25\x33\xba\xb1\x26\x86\x2d\x7a\x84\x3d\xc7\x7c\x9e\xdc\x1c\xd7\x3e\x6c\x6c\x5a\x83\x1c\x83\x88\xe4\xeb\xa8\xf0\x60\xf8\x86\x82\xa3\x23\x78\x09\xd7\xb0\x3c\x14\xc1\x37\xc3\x9a\x0b\x58\x6e\x72\x54\x00\xee\xde\xd4\xa6\xce\x46\xb7\x17\x5b\x4f\x8c\xfa\xa2\x03\x28\x00\xda\xe3\xc8\x11\x95\x8f\x5a\x80\xd0\x8e\xfe\x87\xf5\x25\xac\x5d\x7d\x8e\x77\xbf\xb4\x0f\xc4\x9b\x18\xbf\x77\xf3\xfa\x2e\xb9\x01\x71\xf7\xbd\x5b\x60\xdb\x47\xff\x6a\xb9\x4c\x3d\x32\x23\xf5\xe3\xf4\x71\x86\xfa\x11\xfa\x87\xc1\x07\x63\xb5\x92\x51\x57\x7b\xa7\xe3\xd9\xcf\xcb\x11\xf4\x5f\xee\x6e\xbf\x7d\x1d\x61\x20\xd1\xa1\x5d\x95\x6a\x9d\x21\x63\x65\x57\x65\xca\x4b\x6a\x38\xce\x0b\xd1\x76\xaa\x05\xa2\xed\x89\x4c\x10\x6d\xa7\x3b\x58\xf9\xe9\x16\xd2\x99\x31\x1e\x6e\xc7\xf2\x9b\x50\xde\xd7\xbb\xb0\xf2\x8f\xdd\xff\x50\xaf\xac\x51\x95\x09\xe3\x2c\xb0\x0a\x53\x1d\xb0\x0a\x27\x7a\x08\xac\xc2\xf4\x87\x60\xc8\x1f\x9c\x79\x43\xde\xca\xf4\xc1\xae\x08\x37\xe9\xcb\x40\x53\x87\xfd\x3c\x69\x5c\x10\x6e\x4e\x61\xd7\x90\x3f\x3a\x39\x7f\x02\x00\x00\xff\xff\xd2\x92\xe9\xe6\xe5\x08\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x95\xc1\x8a\xdb\x30\x10\x86\xef\x7e\x8a\x41\xf4\x90\x84\xc4\x64\x59\x7a\x29\x2c\xa5\x94\x1e\x9b\x4b\x7b\x2b\xc5\x68\xad\x71\xa2\xa2\x48\x46\x92\xbd\xdd\x2e\x7e\xf7\x8e\x2c\xc7\x75\xb2\x4e\x6b\x17\xe2\x53\x32\x9a\xf9\xe7\xfb\x87\x91\x5d\x73\x2b\xf9\xa3\x42\x60\xee\xd9\x79\x3c\x66\xc2\x1c\xb9\xd4\x0c\x5e\x12\x00\xff\x5c\x22\x3c\xd0\x91\xb7\x52\xef\x59\xd2\x24\x49\xdd\xe7\x97\xdc\xa2\xf6\xd9\x2f\xa3\x71\x90\xdd\x3d\x83\x22\x00\x81\x05\xaf\x94\x3f\x1d\xc4\x90\xcb\xad\x2c\xbd\x34\x3a\x84\xbe\x1e\x10\x34\x3f\x22\x98\x02\x3c\xfd\x8e\xda\x10\xb4\xa1\x30\x36\xc6\xac\xa9\xa5\x40\x01\x11\x14\x22\x28\xc8\x02\xa4\x07\xfc\x29\x9d\x77\x69\x8b\x28\xb8\xe7\xc0\xf8\x93\xcb\xac\xa9\x3c\xbe\xbd\xef\x18\x3b\xe2\x08\x9b\x9b\x8a\x1a\x50\xeb\x37\x2f\x64\x29\x1d\x98\x81\x87\xc0\x08\xef\x61\x0b\xef\xe0\xae\x61\x09\x65\xb7\x6c\x63\xc9\x4d\xdb\x91\xda\x94\x95\x07\x86\xba\xce\x84\x76\xed\x49\x16\x6a\x32\x87\xb6\x46\xeb\x62\xcf\x9a\xab\x2a\xc8\x7c\x23\x1d\x57\x2a\xe9\x17\x6c\xcd\xd6\xa0\x4c\xce\x55\x3a\x4c\x5f\x36\xec\x7b\xd0\x6d\x4f\x5c\x5b\xdb\x4a\x4a\xd1\x4f\xf7\x3a\x36\x2a\x3c\x52\x70\x91\x1b\x9d\x73\xbf\xb8\x9c\x43\x3a\x84\x4c\x57\x69\x27\x4c\x18\x34\xc1\x05\x63\xcb\xe5\x1a\xb6\x4b\xb2\x7e\xa1\x13\xa6\x9a\xbe\x12\x8b\x00\x57\x65\x1a\xd6\x0d\xef\xe4\xec\xef\xe8\x3f\x8c\xd4\x71\x26\x85\xe2\xde\xa3\x9e\x6a\x62\xd8\xa2\x43\xe8\x39\xe8\x21\x37\xd7\xb5\xc7\x8d\xcd\x6b\x10\xd7\xc0\xa2\x33\x95\xcd\x71\x74\xf9\x86\x82\x93\x57\xf0\x8e\xc8\xb7\x63\x2b\x78\x76\x59\x63\x82\xe7\xfb\xb8\x2a\x00\xbb\xb3\xdc\xd0\x59\x8a\x66\x73\x30\x54\x23\x36\x2d\x00\xa5\x35\xd7\x91\x2d\xe6\xc6\x0a\x82\x26\xe0\xff\x61\x3d\x2d\x6b\x9b\x1f\xd7\xbb\x0b\xf5\x0b\x71\xb6\xc6\x97\x6e\xfe\xbc\x4b\x28\x65\xf7\xa5\x0d\x78\xd5\xad\xfe\xfd\x76\x1b\x7a\x44\x46\xd7\x5d\xa7\xd7\x77\xa8\xbb\x42\xff\x30\xf8\x24\x95\xc8\xb9\x15\x59\xef\x74\x3a\xfb\x2a\x9d\x40\xff\x71\xf7\xe1\xf3\xa7\x09\x06\x02\x1d\xaa\xc7\x34\x2f\x22\xa4\xcd\xe8\x4f\xd8\x97\xd0\x70\x9a\x17\xe7\x0e\x73\x2d\x50\xc9\x8d\x4c\x90\xf2\x7c\x07\x3e\x2f\xe7\x3a\xa0\x92\x1b\x39\x20\xe5\xf9\x0e\xa4\x33\xa3\x17\x86\xe2\xf4\xd6\xa1\xaf\x1d\xad\xe7\x3e\xbc\x56\xdd\xdc\x9b\xb2\x0a\x1a\x1b\xaa\xbe\x85\x5d\x92\xbe\xba\x76\xbf\x01\xdf\xe4\x22\xfa\x22\x08\x00\x00")
 
 func templatesCf_dnsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -111,7 +120,7 @@ func templatesCf_dnsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2277, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2082, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -156,6 +165,146 @@ func templatesConcourse_lbTf() (*asset, error) {
 	return a, nil
 }
 
+var _templatesDirector_accessTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x91\x41\x6e\x83\x30\x10\x45\xf7\x9c\xc2\xb2\xb2\xac\x68\xa1\x29\xa5\x8b\x9e\x24\x8a\x2c\x63\x3b\xc4\xaa\x9b\xb1\xc6\x76\xaa\x28\xe2\xee\xb5\xa1\x6d\x48\x52\x36\x59\x44\x0c\x2b\xff\x27\x0f\x9f\xc7\x9e\xa3\xe6\x8d\x51\x84\x4a\x8d\x4a\x78\x40\xc6\x85\x50\xce\x31\xa1\x25\x3a\x4a\x8e\x19\x21\xfe\x60\x15\x89\xf3\x4e\xa8\xd1\xce\xd3\x18\x49\xb5\xe1\xc1\xf8\x18\xad\x68\xf1\x94\xf7\xcf\x63\x4d\x1f\x08\x2d\x5e\xcb\xbc\xa8\xfa\x73\x51\xf6\xc1\x5b\x0a\xea\x21\xa9\xe8\x3a\xeb\xb2\x0c\x95\x83\x80\x22\xbe\x96\x7f\x39\xe6\x94\x08\xa8\xfd\x81\xb5\x08\xc1\x32\x0c\x46\xd1\xeb\x42\x0d\xb8\x2d\xe3\xad\xda\xf9\xa1\xd5\xc5\x2d\x2d\x53\xbf\xc5\xf1\x7a\x61\xde\xdf\xbc\xc8\xb4\xec\xe8\xe8\xd3\x4e\x13\x97\xe8\x5d\x1b\x0b\xba\xc4\x2d\x82\x07\x01\xe6\x8c\x7b\x61\x13\xdb\x20\x7c\x32\x0b\xe8\x47\xac\xaa\xab\x3a\xad\x85\x73\x30\x42\xc9\x2b\x6b\x0c\x88\x0f\xf7\x87\x56\xb1\xf7\x9e\x63\xfe\xef\x3f\xe8\x6e\x57\x16\x38\x9f\xb1\xab\x7a\xb9\x7c\x9e\x70\xf5\x83\xee\xe9\x4a\xa0\x92\xdb\xd0\xcc\xd9\x57\xb4\x32\xe5\x6b\x40\xf7\xf4\x75\x3a\x5b\x3d\x63\x69\xe5\x4b\x9c\x09\x6b\xbf\xec\x46\x6d\xdf\x92\x93\x59\x4d\x3c\x05\x00\x00")
+
+func templatesDirector_accessTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_accessTf,
+		"templates/director_access.tf",
+	)
+}
+
+func templatesDirector_accessTf() (*asset, error) {
+	bytes, err := templatesDirector_accessTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_access.tf", size: 1340, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDirector_blobstoreTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x54\xcb\x6e\xdb\x30\x10\xbc\xeb\x2b\x08\x22\xa7\xc0\x72\x63\x07\x6d\x81\x20\x3d\xb8\xa8\xd3\x4b\xd0\x14\x0d\x90\x4b\x10\x10\x14\xbd\xb2\xd9\xd0\xa2\xc0\x87\x52\x21\xd0\xbf\x77\xa9\xa7\x8d\x2a\xce\x0b\xd5\x41\xa0\xb8\x3b\x3b\xb3\xe4\xac\x0c\x58\xed\x8d\x00\x42\xf9\x83\x65\xf6\x94\x25\x5e\xdc\x83\xa3\x84\xae\xa4\x01\xe1\xb4\x61\x89\xd2\x89\xc5\x05\x50\xf2\x18\x11\xd2\x24\xb0\xdc\x40\x2a\xff\x90\x2f\x84\x1e\x3d\x16\xdc\x4c\xed\x46\x1b\xc7\x20\x2b\x98\x5c\x55\x71\xa2\xed\x26\xee\x81\x31\x45\x5c\xaa\x91\x86\xad\xc0\x3a\xa3\x4b\xc4\x39\xe3\x21\xc2\x7d\x0b\xa6\x00\xc3\xac\x5c\x01\xc2\x85\x29\x73\x27\x75\xc6\x84\xce\x52\xb9\xf6\x86\x87\xaf\x9a\x98\x10\xe3\x15\xb4\x4b\x42\x78\x9e\xab\x92\x3d\x81\x4e\x4a\x64\x4a\xb9\x57\xae\xcf\x47\x26\x0b\x8c\xab\xb5\x36\xd2\x6d\xb6\x41\xf9\x62\x79\x3d\xff\xf8\x89\xb6\x09\x55\xd4\xbd\xab\xa0\xcb\xf1\xb5\x6d\xc1\x3f\xf8\x16\x86\x4e\xc7\x7b\xa4\x35\x0e\x91\x66\xef\x44\x25\xdf\x32\x8f\x22\x0f\x1c\x68\xf6\xa2\xea\xa3\x95\xb9\x10\x60\x2d\xbb\x87\xf2\x40\xfd\x40\xdf\xd4\xdf\x15\x34\xfd\x37\x7d\x1a\x94\x54\xf4\xe9\x26\x58\xae\x95\x14\xe5\xbb\x7b\x79\x83\x26\xc4\x34\xe4\x88\x3a\x3f\x5f\x5e\x5d\x44\x81\x8e\xde\x80\xb1\x78\xdf\xf4\x8c\xd0\xf9\xc9\x6c\x1e\xcf\x4e\xe2\xd9\x67\x3a\x09\xa1\x6b\xc7\x1d\x6c\x21\x73\x18\xbc\xad\xaf\xb1\x73\x02\x5d\x08\xd7\x80\x6e\x7b\x6f\x50\x7b\x7a\x76\x29\xad\xfb\xda\xd8\x7f\xb2\x17\xf8\x0e\xed\xfe\xa5\x16\xb5\x1f\x3b\xcb\xdc\x75\x79\x74\x99\xa6\xa8\x3c\xe8\x58\x28\xa5\x1f\xfa\x02\xf4\x57\x7b\x92\x21\xd4\x74\xdb\x0f\xd9\x58\xbb\xdc\x64\x55\x53\xbd\x9a\xbc\x48\x34\x6a\xbb\x4a\x7e\x07\xee\x7d\xcd\x3f\xfd\xf8\xfe\x37\x50\xe0\xa0\x0d\xfd\xd7\x36\x3e\x1c\xd3\x7e\xa2\xee\xd0\x54\xe1\xd2\xd0\x5a\xda\xbb\xdc\xbb\x1d\x0b\x0d\xb0\xb6\x20\x0b\x77\xde\xf8\xa9\xe0\xca\xc3\x60\x94\x83\x9c\xe8\x34\xfa\x1c\xc1\x30\x30\x68\xcc\x51\x8a\xfd\xb1\x7a\x2b\x8f\x05\x61\xb0\x91\xdd\xf9\x1c\xb8\xc2\xf3\x0a\xbe\xa6\x56\xed\x0a\x0b\x99\x95\x4e\x16\xd0\xfd\x3f\xab\xe8\x2f\x0e\xdf\xe9\xfa\xc1\x05\x00\x00")
+
+func templatesDirector_blobstoreTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_blobstoreTf,
+		"templates/director_blobstore.tf",
+	)
+}
+
+func templatesDirector_blobstoreTf() (*asset, error) {
+	bytes, err := templatesDirector_blobstoreTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_blobstore.tf", size: 1473, mode: os.FileMode(480), modTime: time.Unix(1792390871, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDirector_databaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x56\x4d\x8f\xd3\x30\x10\xbd\xf7\x57\x58\x61\x0f\xb0\xa2\x2e\x2c\x1f\x12\x48\x2b\x24\xc4\x81\x13\x48\xc0\x0d\x2d\x96\x6b\x4f\x53\x6b\x5d\xdb\xb2\x9d\x42\x41\xfd\xef\x8c\xed\x24\x9b\x36\xd9\xdd\x2e\xe4\x16\xe7\xcd\x1b\xcf\xcc\x9b\x99\x78\x08\xb6\xf1\x02\x48\xe5\xb9\x91\x76\xc3\x42\xf4\xca\xd4\x15\xa9\xa4\xf2\x20\xa2\xf5\x4c\xf2\xc8\x97\x3c\x00\x73\x3c\x84\x9f\xd6\xcb\x8a\xfc\x99\x11\xa2\xc1\xd4\x71\x4d\xc8\x25\x79\x71\x81\xaf\xc1\x81\x50\x5c\xe3\xeb\x8a\xeb\x00\xb3\xfd\x6c\xe6\x7b\x6e\xfe\x33\x30\xb9\x64\xa1\x59\x1a\x88\xac\xf6\xb6\x71\x53\x1e\x0a\xb1\xe1\x1b\x20\xe5\xb9\x24\xd5\xd9\x9f\x2d\xf7\x34\xac\xad\x8f\x0c\xcc\x96\x29\xb9\x9f\x2f\x6d\x58\xcf\xe5\xb2\x4a\x6e\x0b\xa5\x92\x01\xc1\xdf\x11\x9d\x5c\x95\x43\xaa\x4c\x04\x6f\xb8\x6e\xdf\x03\x3d\xa7\x68\x5d\x5d\xcd\xd0\x2e\xf2\x3a\x64\x6f\x84\x7c\x4a\xfe\x7a\x4f\x13\x3e\xf6\xe3\x60\x02\x88\xc6\xab\xb8\x7b\x40\x2c\x77\xba\x90\x10\x84\x57\x2e\x2a\x6b\x12\xee\xfd\xe7\xaf\x1f\x49\x47\x49\x7a\x4a\x04\x6e\x9d\x40\xdb\x01\xa1\xb6\x82\x6b\x5a\x8e\xf7\xd5\xc3\x43\x4b\x95\x54\x2b\x10\x3b\xa1\xa1\xb5\x52\xb5\xb1\x1e\x98\x58\x73\x53\x43\xc9\x6b\x8a\x03\x13\x77\x42\x2e\x98\x6f\x34\x4c\xcb\xc7\x86\x58\xa3\x69\xc9\xcc\x91\x55\x17\x53\x1b\xd5\x98\x97\x8e\x08\x73\x31\x53\xbc\x3b\xd7\xe7\xf8\xe0\x41\x26\x94\x32\x7a\x0c\x09\xe6\xbc\x8d\x56\x58\x3d\x05\x8b\xc2\x25\xc8\xca\x63\x03\x38\x54\xda\x18\xf2\xea\x65\x56\x79\xb4\x93\xdf\x07\x88\x92\x19\x36\x0e\xef\xb6\xb8\x52\x35\x8e\xcf\x72\x64\x53\x1d\xe4\xb8\xc7\x4a\xa0\xac\x4f\x11\x1e\x73\x1e\x56\xea\xd7\x3d\x5d\x34\xcf\x91\xf3\x8d\xd2\xbb\x3e\x1f\x5d\xa9\xde\xd0\xd7\x59\x52\xbd\xdb\x56\x21\x45\xd6\x08\xf4\x32\xd0\x95\xcd\x01\x07\x5d\xe5\x6f\x5b\xae\x9b\x2c\xba\xe7\x7d\xef\x64\x8d\x16\x4d\xa2\x01\x8b\xbe\x09\x31\xe0\x9d\x5b\x6d\x7a\x70\x9a\x0b\x78\x9c\xae\xe8\xa1\xc6\x1e\x78\x4a\xaa\xc5\x8f\x26\xcc\x6b\xbb\x9d\xd3\xf3\xb3\x45\x85\x07\xd5\x13\x72\x89\xf0\x8a\xbc\xc3\x7a\xf5\x0c\xd4\x5d\x2b\x5a\xa2\x2b\xa6\x7b\x9a\xee\xc4\x37\xfc\xb7\x35\x98\x34\x2a\xec\xa6\x22\x6f\x47\x26\x63\x50\xc9\xf8\x23\xf2\x6d\x0d\x37\xad\xb7\x05\xaf\x56\x0a\x9b\x20\xa6\xd3\x36\xc3\x84\xd7\x5c\x99\x10\xf3\xa1\x00\x1f\x11\x22\x78\xc4\xf3\x26\x62\x86\x55\x44\x03\x24\x8a\x6b\x1e\xc9\x97\x0f\x5f\x49\x13\x90\x40\x99\x0c\x2f\x97\xa4\xb3\xc4\x45\xaa\x75\x8c\x93\x35\x64\x82\x97\x32\x36\x3e\x4d\xd4\x8c\x0b\x6f\x17\x8b\xae\xdd\x0f\xb3\xb8\x5f\x1c\x24\xe0\xf0\x6d\xbe\x6c\x8c\xd4\x18\x34\x6c\xa6\x25\x95\x22\xe1\x46\xc0\xed\x5a\x52\x12\x4c\x8a\x11\x55\xd7\x2a\xea\xde\xe1\x9c\x4a\x8f\xfb\x41\x19\x98\x6a\xb7\x7e\x12\xf4\x28\x86\x89\x0e\x69\xf8\x0d\x50\x59\x7c\xe8\xbd\xbd\x1f\x13\x1a\xf7\xcf\x10\x20\x97\x34\x5e\xd0\x0d\x48\xd5\x6c\x12\x92\xeb\x94\x9d\x08\x92\xa5\xb4\xf0\x1a\x3a\xe4\xc5\xb3\xd4\x98\xe5\x8c\x1d\x8d\x0b\xe4\xa9\xdd\x45\x35\x00\x80\x11\x7e\xe7\x90\xa6\x03\x60\xa6\xe1\x68\x96\x1f\x12\xa4\x90\x13\x03\x16\xda\x4f\xa0\x06\x88\x6e\x85\x8e\x11\xd8\x06\xc3\x05\x4c\x6f\x5f\xbf\x58\xd9\xd0\xe8\x98\x67\xdf\xd1\x52\x65\xad\xf7\x7e\xd6\x1c\x7d\x9f\x18\xa2\xc9\x62\xdf\x6d\x96\xd1\xd4\x3a\xd8\xaa\xa7\xcc\xe3\xab\xe1\xb4\xb8\xfd\x52\x47\x90\xbb\xee\x15\xae\x95\x63\x2b\x95\xf7\xb8\xe1\x0e\xc5\x16\x07\x65\x41\x45\xdb\x26\xba\x26\x0e\xd4\xcb\xe0\x57\xbb\xf8\xd1\xd3\x1a\xb5\x56\x64\xdc\x0f\xa6\xfe\x16\x9d\xb4\x26\xdc\x73\x29\xd3\xe2\x28\x43\xe1\x6e\x0f\x69\x21\xfc\x83\x87\x64\x76\x0a\x7d\x12\xd5\x3f\xd0\x77\x5a\x3c\x29\x82\x83\x1f\xbb\xe2\xe6\xbf\x64\x19\xc0\x04\x1c\x83\x5b\x38\xb9\x4c\x5c\x72\x6c\xb9\xe3\x40\x6f\x06\xc5\xbd\x04\xf9\x0f\xe5\xe1\x69\xba\x2b\x45\x68\xd9\xcd\xe1\x01\x67\x32\xa5\x69\x20\xd3\xa9\xb1\x8d\xeb\x5c\xee\x32\xe1\x5f\x8a\x55\x99\xf8\x5a\x0b\x00\x00")
+
+func templatesDirector_databaseTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_databaseTf,
+		"templates/director_database.tf",
+	)
+}
+
+func templatesDirector_databaseTf() (*asset, error) {
+	bytes, err := templatesDirector_databaseTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_database.tf", size: 2906, mode: os.FileMode(480), modTime: time.Unix(1792400290, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDisk_encryptionTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x52\x41\x6b\xc2\x30\x18\xbd\xe7\x57\x84\xb0\xd3\x98\x9d\xf5\x32\x10\x77\x90\xd9\x79\xd8\x61\xc3\xc1\x2e\x32\x42\x4c\x3f\x31\x34\x4d\x24\x89\x4a\x91\xfc\xf7\x25\x6d\x56\x50\xcb\x60\xbd\xa4\x7d\xef\x7d\xdf\xf7\x9a\xf7\x19\xb0\xfa\x60\x38\x60\xc2\x4e\x96\x0a\x56\xd3\xbd\x96\x82\x37\x04\x93\x8d\xb6\x3b\x5a\x0a\x5b\x51\x50\xdc\x34\x7b\x27\xb4\x22\xf8\x8c\x30\x56\xac\x06\xfc\x8c\xc9\xdd\xf9\xc8\x4c\x06\xea\x48\x45\xe9\xe9\x90\xfe\xb7\x5b\x28\xda\x33\xb7\x8b\x45\x8f\x04\x85\x2f\xae\x0f\xca\x75\x3d\x72\x3c\xc2\x52\x73\x26\xb3\x30\xfe\xc3\xe8\xad\x90\x10\x8e\xa3\x28\xa1\xf4\xad\xb8\x6b\x12\xd4\xb3\x59\xf1\xfe\x8a\xa2\x05\xf2\x05\xc6\x46\x43\x53\x4c\x26\xe3\x7c\x32\xca\xc7\xa3\xfc\x89\x3c\x44\xea\xd3\x31\x07\x35\x28\x17\xc8\x75\x00\x70\x6b\x3a\x3e\xa4\xd8\x6e\x81\x47\x9c\xcc\xa5\xd4\xa7\x56\xdf\x12\x73\xee\xba\x6e\xeb\x84\x04\xac\xaa\xed\xb4\xe8\x7e\xa5\x17\x26\x78\x01\x83\xf0\x0a\x92\xfe\xfe\x9a\x59\x82\x02\x13\x6c\x2d\x98\x63\x6f\xd0\xdc\xf0\x2f\x06\x02\xbb\x34\x4c\x0d\xcc\xb2\xdc\x88\x0d\x84\x32\x92\x98\xef\xde\xf7\x2a\xe5\x77\xe9\xbc\x0b\xe6\x3a\x8b\x0a\x1a\xdf\x77\x68\x4f\x8f\xe2\x9b\x47\xf1\x56\x3d\x42\xe6\x66\x19\x8c\x96\x90\x32\xa4\xcc\x39\xc6\x77\xed\xbd\xfe\xb5\x1c\xb1\x24\xf9\x18\x5e\x91\x28\x20\x7d\xaa\x94\x19\xd5\x09\x2f\x17\x30\x1b\x9a\x90\x05\xb1\xff\xe7\xfe\x78\xf4\x03\xbb\x00\xe5\xdc\xe4\x02\x00\x00")
+
+func templatesDisk_encryptionTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDisk_encryptionTf,
+		"templates/disk_encryption.tf",
+	)
+}
+
+func templatesDisk_encryptionTf() (*asset, error) {
+	bytes, err := templatesDisk_encryptionTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/disk_encryption.tf", size: 740, mode: os.FileMode(480), modTime: time.Unix(1792397503, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDual_stackTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x54\xcb\x4e\xc3\x30\x10\x3c\x93\xaf\xb0\x2c\x0e\x80\xa8\x69\x0f\x20\x54\x89\x2f\x41\xc8\x72\x92\x25\x58\xb8\xb1\x65\x3b\xad\xda\x2a\xff\x8e\x1f\x4d\xc8\xa3\x29\x12\x14\x44\x6e\xf1\xec\xce\xee\xcc\x7a\xad\xc1\xc8\x4a\x67\x80\x30\xdb\x18\x0a\x85\x06\x63\xa8\x2c\xc5\x96\xf2\xd2\x82\x2e\xc1\xd2\x82\x59\xd8\xb0\x2d\x46\xb8\x07\x17\x18\xed\x13\x84\xd6\x2a\xa3\x3c\x47\x4f\x08\x5f\xee\x85\xcc\x98\x20\xf1\xa4\xc6\x49\x9d\x24\xba\x47\xaf\x65\x65\xc1\xf1\xa4\xd2\xbc\xc5\x1f\x6a\x59\x2a\x80\x72\xb5\x7e\x88\x74\x39\x18\xcb\x4b\x66\xb9\x2c\xc3\x29\xcd\x78\xae\x69\xea\x98\xdf\x7d\x8d\xe5\xf2\x6e\x8e\x5d\xd8\xa1\x27\x5f\x79\xf4\x85\x56\x7c\xb9\xa1\x02\xc2\x0b\x12\x1a\x43\xa8\x57\x3c\x9f\xc8\xef\x04\x91\x61\xcb\xe4\xb4\xc2\x58\x9a\x89\x9f\xa9\xec\xfa\x3d\x54\xdc\x76\x79\x6a\x66\xa4\x3f\xb1\x6f\xab\x3f\x26\x67\xc2\x01\x03\x59\xa5\xb9\x75\x0d\xbb\x58\x45\x75\x25\x7a\x7e\x1c\x81\x29\xcf\x56\xad\x33\x03\xbc\xb9\x59\x63\x66\x32\x41\xd9\x68\xb4\x5b\x05\x23\x65\xbc\x0c\x7e\x78\x5c\x69\x69\x65\x26\x45\x0f\xbf\x7f\xf4\xd0\xab\x96\x2b\xaa\xa4\xb6\x1d\x68\xb6\xf0\x9c\xb2\x7f\xdc\x02\x83\x19\x1a\x0f\x3c\xc7\x31\xbe\x9c\xc5\x22\x26\x84\xdc\x7c\x8e\x97\xff\x91\x5f\x70\xda\xae\xd9\x62\xca\xae\xf9\x84\x5b\xf3\x33\x99\x15\xd6\xf1\x97\x8c\x3a\xc2\xfd\xff\x4c\x72\x7b\xa8\x2a\x8b\x70\x78\x6c\x9b\xd8\xc3\x8b\xcc\x44\x05\xa3\x07\xb9\x89\x89\x3b\xdb\xa4\xb7\x97\x82\xed\xa8\xa9\xd2\xc6\xb2\x58\x79\xc5\x94\x72\x1b\x33\x62\x4d\x2e\x10\xda\x71\xe5\xe0\xab\xc6\xc1\x90\xda\xb9\x62\xe1\xdf\x90\x1b\xc2\xd6\x8c\x0b\x96\x72\xe1\xbd\xdc\xc9\x12\x6a\x7c\x8b\xbe\xcc\x1a\xa8\xaf\xf1\x75\x72\x11\x1a\xff\x00\x33\xa4\x46\xea\xaf\x06\x00\x00")
+
+func templatesDual_stackTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDual_stackTf,
+		"templates/dual_stack.tf",
+	)
+}
+
+func templatesDual_stackTf() (*asset, error) {
+	bytes, err := templatesDual_stackTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dual_stack.tf", size: 1711, mode: os.FileMode(480), modTime: time.Unix(1792396091, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesExtra_regionTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x59\x5b\x6b\xe3\x38\x14\x7e\x9e\xfc\x0a\x63\xf6\x61\xba\x9b\x78\x53\x18\x98\x7d\xe9\xc2\x3c\x94\x65\x58\xb6\x03\xc3\x3c\xec\x50\x06\xa1\xd8\xa7\x89\xa8\x23\x19\x49\x4e\x37\x1d\xf2\xdf\x57\x17\xdf\x2d\x3b\x72\xd2\x96\x36\x85\x96\x5a\x47\x47\xdf\xf9\xce\x4d\xc7\xd9\x61\x4e\xf0\x2a\x85\x20\xbc\xfe\xf7\xdb\xd7\x4f\xe8\xeb\xf5\x5f\x9f\xbf\xdc\xa0\xbf\xaf\xbf\x23\xbc\xc3\x24\xc5\x2b\x92\x12\xb9\x47\x8f\x8c\x82\x08\x83\x9f\xb3\x20\x90\xfb\x0c\x82\xab\x20\x4c\x89\x90\xe1\xec\x30\x9b\xed\x86\x75\xec\xb2\x18\xc5\x24\xe1\xed\x9d\x42\x72\x42\xd7\x66\x6f\xc6\xd9\x8e\x24\xc0\x83\x10\x3f\x14\xfa\x71\x4a\xb0\x08\xcc\xe7\xaa\xaf\x32\xd4\x12\x71\x0c\x42\xa0\x7b\xd8\x6b\x89\x5f\x7e\x2a\x00\x51\xfd\xec\xa0\x45\x04\xc4\x1c\x64\x5b\xa4\x7e\x66\x44\x38\xac\x09\xa3\xce\x73\x6e\x3e\xfd\x73\x1d\xce\x94\xcc\x0e\xb8\xd0\x42\x4a\xe0\xcf\xab\xe0\x32\xba\xfc\x18\x2d\x0d\x6e\x0e\x82\xe5\x3c\x06\x83\x5b\x9b\x19\x3a\xa0\x1a\x73\x2a\x0b\x9b\x9f\x2b\xb3\x2f\x72\x19\xa7\xe9\x42\xab\x94\xc5\xf7\x6d\x79\x6b\xc3\x20\xc3\xc6\x24\x42\x85\xc4\x34\x06\x24\x81\xaa\xbf\xfb\x72\x6f\x02\x77\x38\x4f\xa5\x16\x51\x0b\xca\x55\x28\xa1\x02\x6d\x98\x90\x14\x6f\x41\x28\x11\xc9\x73\xd0\x06\x4b\xbc\x16\x06\x76\x10\xdc\xa8\xa5\xfa\x60\xa0\x3b\x44\x92\xc3\xa2\xc7\xd3\x42\x1b\xaf\x36\x1c\xfa\xb4\x10\x2a\x81\x53\x45\xf9\x1a\x4b\x78\xc0\xfb\xe3\x1c\x8d\xf0\xa2\x0d\x25\x49\xc9\x45\xc1\x7a\x4f\x32\x52\x18\x1d\x0e\x12\xf9\x4a\xe1\x70\x9c\x8f\x28\x96\xd5\x6a\xc7\x5d\x3e\x60\xbc\xe0\xb4\x9c\x6a\xe4\xf5\xff\xf6\xd4\xf7\xa3\x5e\x9d\x07\x7f\xcc\x83\xe5\xc5\x21\x3c\xd1\x37\xca\xba\x45\x61\x9d\xdb\x45\x9c\xe5\x52\x85\x8b\x8e\x89\x21\x76\x5a\x22\x2f\xe2\x2d\x73\xe2\x74\x38\x81\x6f\x8a\x25\x20\x24\x51\xba\x54\x66\xa3\xb6\x67\x96\x91\xf9\xf9\x7d\xa9\xc5\x8a\xa8\x2d\x1d\xdd\xca\x45\x57\x78\x0f\x7a\xbf\x01\xb9\xa9\xac\x52\xd4\x58\x8f\x8e\xd9\x3c\x46\x59\x71\x04\x16\x82\xc5\xc4\x98\x37\x31\xe2\x8f\xf0\x66\x77\x55\x26\x54\xf8\xed\xf3\x68\xe4\xa4\x01\x26\x9e\x92\x01\x20\xd9\x90\xb5\x66\xc9\x3f\xb9\x13\xc8\x80\x26\x02\x99\xba\x7f\x1b\x7a\xb9\x3a\xfc\x61\x83\xbe\xf6\xad\xa9\xa8\x3d\x94\x1a\x8e\x77\x35\x3c\xe6\x0f\x9c\xaa\xb8\xb5\x61\xdc\x24\x53\x99\x1b\x0d\xf1\x50\x3a\xa2\xed\xca\xc9\x9e\x9c\x50\x60\x2d\x75\x38\x2d\x44\xc4\x50\xd2\x8e\xb5\x44\x96\x53\xd9\xcf\xc1\x14\xe8\x5a\x6e\xdc\x05\xb4\x7f\x79\xb9\x38\x74\x0a\x77\x37\x0b\x3d\xeb\x77\x73\x9b\x7f\x19\xff\x30\xb7\x56\x44\x84\x26\xf0\xdf\x6f\x97\x16\x4d\x0f\xa5\x55\x0b\x29\x6c\x81\x4a\x5f\xcb\x5a\xaa\x4f\xef\x15\xa5\xa3\x8a\x86\xa1\xac\xab\xb5\x1e\x6c\xfb\x50\xbf\x52\x72\x07\xf1\x3e\x56\xb7\x3d\xab\x9d\xac\x29\xe3\x80\xe2\x0d\xa6\x6b\x73\x93\xb8\x0d\x6b\xb2\xc2\xb9\x72\x6a\x17\xaf\x49\x95\xe9\xad\xa8\x8a\xa3\x57\xd4\x8f\x3c\x30\x3d\x75\x53\x6a\x14\x10\x67\x2f\x69\xac\x3f\x4f\x3f\x72\xd9\x7c\x7e\x53\xf2\xaa\x12\xfe\x25\xe2\xe4\xfa\xe0\x6a\x71\x65\x3a\x8e\x15\xc8\x2e\xfe\xe8\x57\xc5\x49\x2f\x31\x4f\x6c\x80\x13\x28\xd7\x71\x9e\x01\xe8\xf9\x0a\xc5\x8c\x52\x88\x07\x18\xb7\x04\x37\xcb\xa1\xe5\x4c\x35\x94\x34\xb2\x8f\x0d\x60\xad\x0c\x15\x62\x5e\xa5\xd2\x6c\x28\x66\x2b\xf7\x5c\xa5\xea\x5e\x2e\x19\xd2\x13\x5b\x26\x95\xcc\x1d\x4e\xc5\xa9\xc3\x47\x61\xeb\xc0\xed\xd6\xcd\x46\x71\x32\xf0\x69\x33\xdb\xb1\x10\x1c\x38\xac\xc3\x9b\x43\x64\x90\xca\x26\x4d\x5d\x18\x67\x0c\x6c\xe3\x9c\x0d\x16\xbb\x3b\xce\xb6\x68\xc5\xc4\xa6\x75\x7b\x1c\xa8\x25\x43\xe5\xc4\xec\xef\x85\xf1\x60\xf5\xf3\x1f\x7d\x9f\x9a\xfd\x89\xbc\xb8\xeb\xd7\x44\x72\x86\xf3\xfc\x4d\x13\xa4\x82\xd8\xbc\x20\x39\x39\xb3\x26\xd2\x38\xa1\x7e\x7a\xf1\xfa\xb2\x34\x0a\x88\x73\xae\xbb\xd2\x5a\xa1\xcd\xc6\x7b\x65\x47\xb4\x4b\xf0\x08\xa5\xfa\xbd\x4f\xef\xe5\x92\xcf\xdd\xb0\x38\x72\x61\x8f\xb4\x04\xc6\x9c\x64\xb2\xa8\xf7\x9f\x0b\xc9\xd0\xd5\x5d\xc6\x7b\xc7\xb9\xd7\xd6\x1e\xb4\xc3\x31\x76\x11\xcf\x8f\x5c\x36\x9d\xf2\x93\x07\x98\x8e\x96\x66\xb0\xb4\x97\x22\x5f\x24\x65\xf8\x9a\x57\xaa\xdd\x3c\x52\x81\xa7\xac\x16\xa1\x85\x29\x59\xcc\xd2\xd6\xfa\xe2\x52\x2f\x99\xba\x95\x31\x2e\x1b\x4b\x4b\xad\x92\xb5\x9f\x96\xcf\xeb\xec\x10\xd5\xf3\xdb\x5e\x8a\xcc\x3d\xaa\xd1\x8f\x67\xf1\x0b\xd2\xe3\xf0\x43\x35\xaa\xbf\x7e\x37\xc1\xcb\x79\xa9\x1e\x21\x4e\xe7\x5e\x9d\x38\x5a\x79\xfc\xd9\x7b\x1b\x31\xfd\x2c\x01\xac\x80\xd8\x2b\xd4\x59\x04\x3a\x54\xbc\x69\xf2\xee\x61\x8f\x32\x4c\x5c\x17\x72\x4b\xd7\x6e\x2b\xa6\xbc\xc1\xd3\xfa\x8a\x0e\xd7\xed\x20\xb5\x3e\xad\x2d\x5f\xa5\x24\xae\xbf\x25\x92\xa9\x40\x19\x27\x3b\x35\x3f\xeb\x87\x51\x29\x1b\xd5\x82\x88\x65\x40\x85\xd8\xd8\xd6\xad\xee\x13\x59\x2e\x1d\xa8\x8b\x6f\x5c\x50\x89\xa4\x18\xb7\x70\x9a\x43\xed\xd5\xd2\xec\x68\xd0\xe8\xa8\xdc\x7f\xe4\xb8\xd1\xc4\xec\x9c\x7a\x56\x29\x1b\x03\x81\x1f\x51\x35\x3e\xa3\x2d\xce\x32\x3d\x64\x74\x11\xcc\xde\x05\xc1\x23\xc9\xd4\xf2\xfb\x23\xef\x1c\x1d\x23\x75\x6f\x6a\x2f\xfa\xcd\xe4\xc9\xfc\x10\x5e\xcc\xde\x79\x9b\x63\x02\xfd\x35\x1b\x54\x67\x62\x6d\xd8\xff\x66\x9d\x14\x15\xda\x1d\x00\x00")
+
+func templatesExtra_regionTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesExtra_regionTf,
+		"templates/extra_region.tf",
+	)
+}
+
+func templatesExtra_regionTf() (*asset, error) {
+	bytes, err := templatesExtra_regionTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/extra_region.tf", size: 7642, mode: os.FileMode(480), modTime: time.Unix(1792401691, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesExtra_region_peeringTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x93\xd1\x4e\xc3\x20\x14\x86\xef\xfb\x14\xa4\xf1\xb6\x8d\x3e\x80\x17\xbb\x68\x8c\x31\x4e\xb3\x78\xa1\x31\x86\x30\x7a\xd6\x10\x3b\x68\x28\xad\x59\x96\xbe\xbb\xd0\xb1\xad\xd0\x6e\xd6\x69\xa6\x5c\xc2\xcf\x39\xff\xf9\x7e\x90\x50\x8a\x4a\x52\x40\x21\xf9\x28\x71\x5d\x50\x5c\x00\x48\xc6\x33\x4c\x05\xe7\x40\x15\x13\x3c\x44\x61\xf2\xfc\x34\x9b\xe0\x59\x72\x73\xfb\x30\xc5\x77\xc9\x0b\x56\x02\x3f\x26\xc9\xac\xb3\x15\xa2\x75\x80\x50\x21\x45\xcd\x52\x90\x48\xaf\xeb\xb6\x68\xec\xdf\x0d\xb5\xcc\x34\x62\x29\x42\x56\x76\xb1\xb6\xdd\x7b\xe2\x98\xa5\x8d\xb9\x60\x5c\x61\x7b\xcb\xb9\xe0\xb9\x70\xf4\x12\x32\x6d\xdf\xe8\xbb\xaa\xe9\xe4\x3e\x31\x12\x52\xe9\x21\x08\xa5\x50\x28\x2d\x59\x90\xbc\x84\x40\x6f\x2b\x92\x95\xed\x28\x08\x4d\xc9\x12\x36\xdd\x6a\x22\x63\xe0\xb5\xee\xde\x44\x8e\x45\x53\x2c\xf2\xab\x47\x96\xa1\xe9\xd2\x04\x4d\x10\xc8\x11\x94\xad\x15\x90\xa7\xe2\x76\x97\x85\xef\xdf\xb2\xec\x07\xda\x7b\x60\x07\x24\xf1\x08\x5b\x5b\xfe\x5d\xb8\xbe\x31\x25\xab\x33\x91\x96\xa2\x52\xf0\xab\x3c\x87\x1e\x73\xdb\x05\x2b\x32\xcf\x61\xf7\xa8\xbd\xa7\xdd\x91\xf4\x29\x32\xae\x53\xe7\x24\x77\x54\x96\x63\x0a\xa5\x62\x9c\xb4\x09\x51\x96\x4a\x3c\xcf\x05\x7d\xdf\xd5\x36\xb4\xbc\x41\xda\xf0\x8c\xb4\x39\x53\xd8\x07\xa9\xfb\xc6\x74\x81\x1e\xbe\x93\x5f\xf1\x37\xa1\xfb\x5e\x7e\xc8\xbc\x07\xe7\xcf\xa1\x97\x40\x2b\xc9\xd4\x0a\x67\x7a\xa2\x02\xcb\x2a\x1f\x19\xc1\x1e\x85\x5b\xe2\x50\x34\x47\x3e\x82\xe7\xa1\x3b\xaf\x7b\x14\x8f\x35\xb1\x8d\x44\xad\x0a\xe8\xbd\x0c\xcd\x4e\x33\x28\xc3\x8d\x4d\x25\xa8\xc8\x9d\xf3\xe8\xca\x1c\x2d\xa4\x58\xe2\x42\x48\xd5\x39\xba\x34\x25\x85\xbb\xbb\xdd\xdf\x27\x5e\xee\xf6\x5f\xbf\xfc\x6a\x6f\x63\x13\x19\x91\xf0\x89\x89\x0c\x7c\x92\xf1\x81\x8c\xb4\xf0\xcf\xf2\x38\xf2\x0d\x4d\x20\x9f\x8b\x74\x58\x60\xdd\x08\x00\x00")
+
+func templatesExtra_region_peeringTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesExtra_region_peeringTf,
+		"templates/extra_region_peering.tf",
+	)
+}
+
+func templatesExtra_region_peeringTf() (*asset, error) {
+	bytes, err := templatesExtra_region_peeringTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/extra_region_peering.tf", size: 2269, mode: os.FileMode(480), modTime: time.Unix(1792395542, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesIamTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x51\x6f\xe3\x36\x0c\x7e\xae\x7f\x05\x61\xec\x61\x2b\x9a\xac\xed\xcb\x80\xe0\x8a\x43\xd1\x66\xc5\xb6\x1b\x56\x24\xc5\x3d\xac\x28\x0c\x46\xa6\x1d\x6d\xb2\xe4\x49\x72\xba\xac\xc8\x7f\x1f\x24\xd9\x4e\xd2\xd8\x4e\xbb\xe1\xee\xa5\x40\xfd\x7d\x24\x3f\x92\x0e\x49\xaf\x50\x73\x5c\x08\x82\x78\xa1\xcc\x32\xe1\x58\x24\x5c\x1a\x8b\x92\x51\x52\x6a\x95\x71\x41\x31\xbc\x44\x00\x29\x65\x58\x09\x0b\x57\x10\xc7\xd1\x26\x8a\x84\x62\x28\x8c\x87\x38\x16\xf7\x81\x7a\xaf\xd5\x8a\xa7\x94\x3a\xd6\x37\x2f\x2b\xd4\xe3\x5e\xaf\x70\xe5\x3c\xc1\x47\x38\x87\x09\x5c\xc0\xc6\x3b\x4d\xd1\x22\xc4\xf8\x6c\x7a\x84\x78\x91\x41\x8f\xc4\x82\xde\x10\x66\x13\x47\x11\x00\x53\x95\xb4\x81\xed\x75\x8f\x0f\x25\x07\x01\x9a\x8c\xaa\x34\xa3\xad\x08\xad\x06\x03\x93\x5c\x25\x3c\xdd\x24\x5e\x80\xe7\x46\x00\x25\xda\xa5\xa3\x7c\xff\x3a\xf8\x05\x8c\x60\x40\x40\x04\x20\x78\x46\x6c\xcd\x04\xf9\x58\x00\x4c\x13\x5a\x4a\x16\x94\x29\x4d\x49\x4a\xc6\x6a\xb5\x86\x2b\xb0\xba\xa2\x08\x60\xe3\x6c\xd0\x98\xaa\x20\x1f\x3d\x29\x95\xe0\xcc\x11\x3e\x7c\x98\xfe\xf6\x63\xe4\x9c\xc4\x9f\x49\x1b\xae\x64\x3c\x81\xf8\xf2\xfc\xe2\x72\x74\x71\x3e\xba\xf8\x21\x3e\x73\xd0\xdc\xa2\xa5\x82\xa4\x8d\x27\xf0\xe8\x03\x86\xb0\x00\xf1\x35\xb3\xb5\x91\xb1\x66\x72\xed\x63\xcc\x5c\x82\x67\x0d\xe3\x5e\x73\xc9\x78\x89\x22\x9e\xb4\x66\xce\x27\xe9\x15\x67\xe4\x2c\x89\x5d\x8e\xb1\xc0\x7f\x94\xc4\x67\x33\x66\xaa\x88\x6b\xda\xa6\x75\x32\xcd\x32\x62\x2e\x7c\x7c\x2d\x84\x7a\xde\x7a\x9f\xf3\xd4\x3d\x0d\x16\x9b\x08\xe0\x29\xda\x44\x2e\xa7\xce\x36\x85\xbc\xdf\xda\xa8\x9a\xfd\xff\x5a\xf5\x05\x4a\xfd\xb8\xad\x22\xb1\x4b\x57\x74\xc5\x38\x5a\xba\x4e\x53\x4d\xc6\xb4\xc5\x69\x70\x6b\x91\x2d\x3f\x2b\x51\x15\xf4\x1a\xbb\x51\xe5\xfa\xa7\x02\xf3\x43\xc0\xbf\x51\xdd\x46\xb7\x24\xc8\xd2\x5c\x62\x69\x96\xca\x76\xa3\x7d\x96\x86\x69\xbe\x68\x94\xd2\x81\xd6\x96\xb0\x42\x2e\x70\xc1\x05\xb7\xeb\xdf\x95\xec\x27\x7a\xf1\xfd\x68\xfd\x3b\xef\x25\xcc\x28\xe7\x4a\xf6\xc2\x73\x62\x95\xe6\x76\x7d\xa7\x55\x55\xf6\xb3\xea\x4a\xf4\x13\xaa\x85\xa4\x7e\x38\xd4\xaa\x03\x1e\xe8\x9b\x6f\x4f\x5f\x0b\x02\xfa\x80\xf9\x81\xcf\x5f\x55\xca\xb3\x75\x53\x96\x6b\x6b\x35\x5f\x54\xf6\xc0\xfd\xac\x92\xbd\xa5\x7b\x20\x5d\x70\x89\xb6\xbf\xb8\xae\xa8\xc6\x92\xee\x7c\xb1\x6e\x49\x0f\xc1\x37\xce\xa3\x98\x97\xca\x36\xee\x67\xf4\x57\x45\x66\xa0\xb8\x6f\xe0\xd6\xcf\x77\xa9\x07\x9c\x50\xb4\x99\xea\x28\x47\xfb\xb6\x38\xf0\xc1\x2d\xc2\x8e\x08\xa5\x40\x56\x9b\x47\x27\x00\x4f\x67\xee\x6f\xc7\xe0\x72\x4f\x67\xf5\x64\x72\xcf\x4f\xeb\xd9\x75\x16\x9d\xbc\x78\x70\xe7\x77\x7e\xe2\xfd\x73\x2c\x26\xf7\x68\x8c\x9f\xab\xef\xf5\x7d\x32\xe0\x98\x04\x1a\xcb\x99\x50\x98\x2e\x50\xa0\x64\x5c\xe6\x93\xd3\xff\x14\xa2\x29\xc6\x76\xc2\xc3\xd0\xdc\xae\xe1\x8e\x91\xd6\x62\x7f\x16\x66\x32\xa3\xa9\x64\x7a\x5d\xda\xd3\x57\x96\x2d\xe3\x8e\x24\x69\xb4\x74\x8b\x16\x7f\xa1\x75\x2f\x2f\x74\xf7\x4e\xa3\xb4\x7d\x94\xa6\xcb\xde\xcd\x1e\xe5\xe9\x95\xec\x9d\xfc\x3b\x84\xbf\x36\x6e\xff\x3b\xba\x9e\x76\x76\x73\x82\x7e\x6a\xfb\x4d\xb0\xbb\xae\x1c\xa5\x76\x77\xe4\xba\xa8\xdd\x68\x19\x88\xfb\x2b\xd0\x9f\x42\x63\xd4\xf2\xe0\xf2\x39\xb2\xd1\x3a\x75\xbf\xe3\x04\xab\xb5\x8e\x3c\xde\xe4\xb3\x27\xd0\x3d\x09\xf2\x9c\xe5\x7b\xf5\x75\x1c\x47\x3c\x97\xee\x2a\x62\x4b\x94\x39\x19\xb8\x82\xc7\xd8\x79\x8e\x9f\xfc\x65\x74\x90\x50\x26\xd4\x73\x22\x54\xee\x92\x58\x88\x90\x83\x50\x79\x92\xbb\x1d\x90\x6c\xb3\x71\x5c\x26\x54\x95\x3e\xa3\x65\xcb\xa4\xa5\x8c\x17\x0b\xd1\x48\xf7\x57\x6f\x68\xab\x6b\x04\x74\x64\xda\x84\x33\x75\x37\x00\x56\x25\x4b\x78\xda\xbe\x3f\x3b\xf7\x68\x40\x3c\xc9\x6a\xcc\x32\xce\x12\xbb\x2e\x29\x90\x66\xd3\x9f\xa7\x37\x0f\x1d\x1d\xea\x12\xb9\x9b\x9c\xd3\x9a\x94\x9a\x32\xfe\xf7\xb6\x4f\x66\xa9\xb4\x4d\x9a\x6e\x09\x95\x8f\x82\xdd\xe0\xf9\xdb\xe6\x32\xd4\x79\x47\x72\x0e\xcd\x28\xbc\xaa\x5f\xec\x34\x6d\x4e\xc3\xe3\x47\xe4\xf1\x13\x75\x55\xb2\xad\xf0\x63\xc7\x6a\xef\x4d\xfc\xb6\x23\x75\xa7\x0c\xef\xaf\xe9\xf6\x66\xed\xf9\x65\x6d\xdf\x37\xfe\x55\x2e\x54\x17\xaa\x9e\xbe\x9f\x54\xee\x0f\xa9\xdd\xdd\xb9\x0f\xcf\xad\x26\x2c\x0e\xf0\xfb\xca\x7e\x52\xf9\x74\x45\x72\x7f\xb5\x7b\xb0\x19\xdb\x8d\xf7\x41\x46\x08\x60\x9a\x9e\x3d\x1d\x7f\x37\xba\x56\xf5\x7e\x07\x55\x65\xcb\xca\xfa\x35\xdd\xf3\x55\xbc\x42\x51\xd1\xf0\x87\x25\x7c\x84\x3f\x14\x97\xdf\xc6\xf1\x19\xb8\xef\xdb\x71\xdf\x6c\x0d\xa3\xf1\xd4\x4f\x98\xef\x60\xb2\xb5\x7a\x93\x81\x9f\xe0\xff\x06\x00\x00\xff\xff\x6f\x9b\x07\x6e\xce\x0f\x00\x00")
 
 func templatesIamTfBytes() ([]byte, error) {
@@ -196,6 +345,46 @@ func templatesIso_segmentsTf() (*asset, error) {
 	return a, nil
 }
 
+var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x97\x4b\x8f\xda\x30\x10\xc7\xef\x7c\x0a\x2b\xea\xb1\x04\x0a\xdb\x15\x5d\x89\x53\x6f\x3d\x54\x3d\xf4\x86\x2a\xcb\xb1\x87\xe0\x36\xc4\x96\x1f\xec\xa2\x55\xbe\x7b\x9d\xd8\x81\x05\xf2\x80\xaa\x88\x25\x9c\xc8\x4c\xfe\x33\xfe\xf9\x31\x63\x05\x5a\x58\x45\x01\x45\xe4\x59\x63\xe0\x32\x42\xd1\x6f\xbb\x96\x89\x78\xf1\xff\x5e\x07\x08\x31\x90\x90\x33\x8d\x45\x8e\xe6\x68\x51\x79\xf2\xdc\x80\xca\xc1\xe0\x94\x18\x78\x26\xdb\x98\xa7\xd1\x2f\xe7\xba\x91\x14\x85\x67\x8e\x8c\xb2\x30\x28\x06\x03\x75\x10\x44\x03\xb5\x8a\x9b\x2d\x4e\x95\xb0\x12\x2b\x9b\x81\x0b\xea\x05\x49\xd6\x64\xc6\x5a\xaf\x7c\x26\x47\x46\xce\xf6\xc1\xa2\x0f\xaf\xa7\xea\x71\x8b\x6c\xcc\x59\x11\x39\x3d\xb3\x95\x80\x9a\x9e\x79\x99\x51\xea\x12\xd7\xa5\x9b\x54\xc2\x08\x2a\xb2\x26\xb7\x9f\x5f\x7f\x94\x2e\x4b\x25\xd6\x58\x0a\x65\x4e\x5d\x26\x93\x32\x92\x68\xb4\xee\xec\x9e\x10\x3e\x1d\x60\xdb\xc8\xc2\x2c\xf9\x91\x9c\x09\x39\x11\x7a\xd5\x08\xd8\x50\x89\x2b\x23\x49\x21\x37\xff\xc8\xba\x41\xfd\x3f\x72\x76\x29\xf6\x70\x7e\x9c\x3d\xce\xba\x49\x07\x8f\xdb\xb2\xb6\x84\xdc\x29\xe0\xd9\xc3\xc3\xb4\x1b\x70\xf0\xb8\x2d\x60\xaa\x80\xad\x6c\x72\xaf\x90\x1d\xc3\x1e\xc8\xde\xe3\xf6\x27\x06\xe3\x0a\xa8\x11\x0a\x13\xc9\xef\x94\xf6\xe4\xb3\x7b\x7a\x8e\xe7\xe0\x72\x55\xde\xfb\xb2\xeb\x41\xe6\x64\x0d\x07\xec\x36\x44\xc5\x90\x6f\x5c\xbc\x62\x18\x3c\x87\xb5\xc4\xd0\x4b\x54\x95\x5a\x53\xc5\xa5\xe1\x55\xa9\x8e\xbe\x05\x49\x5f\x98\x77\xd3\x51\x09\x66\x82\x92\x2c\xf6\xaf\x5d\x7e\x25\x03\x92\xea\x2a\x38\x42\xdf\xcb\xf0\x17\xc4\x2d\xca\xef\x33\xbe\x04\xba\xa5\x19\x04\x11\x9e\xe6\x42\xb9\xed\xb8\x22\x79\x0a\xba\x6a\x1d\xca\x61\x45\x1f\x51\xf4\x26\xcf\xaa\x6d\x28\xce\x5d\x8f\x75\x6f\xd2\xd1\x11\x9c\x35\x1d\x4d\xcb\xab\x6f\x5d\x75\x2d\xa8\xd6\x2a\x1f\x0c\x94\x33\x85\x13\xc7\xfc\x8f\xde\x19\x16\x01\x6f\xb5\x07\x78\x9e\x08\x9b\x33\x5c\x3a\x16\x8e\xc9\x85\x3c\x14\x93\xef\x8c\xc7\x74\x3a\xfb\xd2\x42\x24\x98\xae\xcd\xa4\xb3\x97\xb9\x11\x95\xd6\x1e\x65\x67\xba\x36\x95\xfa\xd0\x7e\x6f\xdb\xa7\xf5\x20\xde\xdb\xae\x8d\x06\x7c\xf6\x57\x00\x03\xdd\x5c\x86\x9f\xda\xb0\x8c\x5b\x90\x8c\x5b\x71\x8c\xe3\xea\x37\x1a\xfb\xf1\x0b\x6b\xa4\x35\x2e\x83\x97\x70\x11\xaa\x2f\x75\x1b\x92\x59\xd8\x0f\xca\x5d\xf6\xe2\x37\x17\xbf\x58\xda\x24\xe3\xae\x36\x48\x5f\xbb\x6a\x99\xda\xc5\xaa\xec\x42\x99\xa7\xc9\xe4\x40\x69\xdf\x3c\x30\xb6\xc7\xbe\x93\x5b\x19\x23\xf5\xd3\x68\xd4\x2f\x5b\x2e\x8e\xc6\x1c\x8f\x2b\x6c\x43\xba\x3d\x15\xfb\x58\x11\x33\x58\x12\x9b\x99\xa3\xef\x8e\x72\x5f\xf4\x8b\x97\x53\xf3\x17\x4b\x64\x58\xbc\x7b\x0f\x00\x00")
+
+func templatesJumpboxTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJumpboxTf,
+		"templates/jumpbox.tf",
+	)
+}
+
+func templatesJumpboxTf() (*asset, error) {
+	bytes, err := templatesJumpboxTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 3963, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJumpbox_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x35\x8e\xcd\x0a\xc2\x30\x10\x84\xef\x79\x8a\x65\xe9\x39\x14\x8a\xc7\x1e\x7c\x0e\x91\x90\x26\x0b\x46\x92\x6e\xc8\x8f\x5a\x4b\xdf\xdd\xd8\xea\x9e\x76\xbe\x9d\x65\x26\x51\xe6\x9a\x0c\x01\xea\x67\x56\x89\x6b\xa1\xd3\xa0\x12\x19\x4e\x16\x01\x27\xce\x37\x84\x55\x00\xbc\x79\x26\xe5\x2c\x8c\x80\xdd\xea\xd9\x68\x2f\x7f\x68\xc3\x76\x9e\x75\x20\x68\x33\x1e\x3f\xb2\x5b\x1f\x3a\xc9\xbc\xe4\x42\x41\x59\x0e\xda\xcd\xbb\xaf\x2c\xf1\xef\x3b\xef\xba\x78\x38\xf4\xd0\xf7\xa2\x81\x23\x3a\x37\x70\x69\x41\xdf\x52\xe4\xa2\xbc\xd7\x10\x27\x7e\xed\x7b\xac\x93\x77\x46\xb9\xb8\xe1\x55\x6c\xe2\x03\xda\x7a\x25\xfa\xc2\x00\x00\x00")
+
+func templatesJumpbox_dnsTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJumpbox_dnsTf,
+		"templates/jumpbox_dns.tf",
+	)
+}
+
+func templatesJumpbox_dnsTf() (*asset, error) {
+	bytes, err := templatesJumpbox_dnsTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/jumpbox_dns.tf", size: 194, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesLb_subnetTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x4d\x6e\xfb\x20\x10\xc5\xf7\x3e\xc5\x08\x65\xf1\xff\x48\x68\xd4\x55\x37\xb9\x42\x2f\x50\x45\x08\xe3\xa9\x83\x4a\x20\x32\x63\xa7\xa9\xe5\xbb\x57\x80\x15\xdb\xb5\xd3\xa6\xc9\xc6\x02\xe6\xf7\xde\x63\x86\x0a\xbd\xab\x2b\x85\xc0\xe4\xd9\x0b\x5f\xe7\x16\x89\x01\x33\x79\xff\xed\x19\xb4\x19\x80\x72\xb5\x25\x18\xff\x76\xc0\x56\xad\x41\x5b\xd2\xe1\x4f\x23\x2b\x2e\x1b\xa9\x8d\xcc\xb5\xd1\x74\x11\x1f\xce\xa2\xff\xdb\xb1\x0c\xa0\x39\x29\xa1\x8b\x79\xa5\x53\xd2\xf0\xb4\x19\xcf\x29\x5d\x54\x22\x37\x4e\xbd\x4d\xce\x85\xe5\xe4\x24\xaa\x84\x82\xb0\xb4\x86\xa7\x75\x32\xc5\xb5\x2d\xf0\xfd\xff\x63\x52\x9b\xb9\x48\x14\x34\x78\x44\x4b\x37\x8c\x4e\x48\x81\x93\x01\x90\x2c\x7d\x4c\x0e\xf0\x2c\x8f\x3d\x26\x94\xa3\x6d\x82\xe5\x8d\xc9\x37\xc9\xd7\xaa\x1d\x55\x47\x13\x5d\x00\x18\xfd\x8a\xea\xa2\x0c\xf6\x14\x5d\x5a\x57\xa1\x50\x07\x69\x4b\xf4\xb0\x83\x17\x36\x44\x66\x6b\x60\x33\x5f\x6c\x1f\x59\x5d\x96\x4d\x9b\x54\xb9\x9a\x50\x90\xcc\x0d\xa6\x4e\x4d\x16\xda\xe1\xce\x97\x2e\x7a\x99\x76\x83\x53\xa0\x27\x6d\x25\x69\x67\xc5\xa8\x3f\x3b\x60\x5b\x1e\xff\x0f\xdb\x90\xb7\x94\x84\x67\x79\xf9\xd2\xe6\x24\x1f\x24\xb4\x25\xac\x2c\x92\xe8\x0f\x72\x5d\xf2\xbe\xeb\x23\xc9\x71\xf9\xb5\x74\xb4\xcf\xa7\x0e\xf9\x37\x71\x7a\xa0\xf4\xde\x29\x1d\xed\x33\x60\x69\xe7\x87\xc1\xbe\x77\xaa\x13\xe3\x6a\x79\x32\x64\xc3\x43\xe2\x83\x1a\xff\xc7\x75\x31\x1b\xb4\xd9\x05\xfc\x26\xb8\xab\xe9\x54\xd3\xe8\xad\x0a\x5d\xf4\xa9\x1a\x69\x6a\x8c\x33\x96\x68\xcb\x76\x3a\xb6\x5f\xe6\xcc\x53\xdf\x8f\x9d\xd5\xde\x54\x89\x4f\xfb\x7e\xf0\x30\x80\x89\xf8\x19\x00\x00\xff\xff\x0b\x56\xd0\x1c\xba\x04\x00\x00")
 
 func templatesLb_subnetTfBytes() ([]byte, error) {
@@ -236,7 +425,7 @@ func templatesSsl_certificateTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesVpcTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xd1\x6a\xe3\x30\x10\x45\xdf\xf5\x15\x17\xb1\x0f\xc9\xb2\x6b\xb2\xaf\x81\x6c\xff\xa0\xfd\x04\xa1\x48\x53\x67\x5a\x65\x64\x24\xd9\x6d\x08\xfe\xf7\x22\xd9\x69\x21\xf4\xa1\x03\x36\x66\xe6\x0e\xf7\x8c\xef\x64\x13\xdb\x63\x20\x68\x7a\xe7\x5c\x58\x7a\x33\x0d\xce\xb0\xd7\xb8\x2a\xa0\x5c\x06\xc2\x5a\x07\xe8\x5c\x12\x4b\xaf\x15\xe0\xe9\xd9\x8e\xa1\xdc\x06\x4b\x2b\xbb\xc4\x43\xe1\x28\xb5\xf5\xd4\xbe\x6c\x08\x17\x8c\x99\x60\x05\x37\x07\x4c\x83\xd3\x6a\x56\x2a\x44\x67\x43\x6e\x46\xd5\xd4\xc5\x51\x4a\x5d\xfd\x75\x0d\x24\x7d\x39\x6d\x26\x9b\xba\x3b\xae\x2d\xfe\x63\x87\x07\xec\xb0\xc7\xbf\x59\xaf\xab\xec\x57\x90\x9f\xac\x7e\x33\xc2\x1e\x2f\x91\x65\xa3\xa1\xff\xc0\xbe\xe5\xda\xee\xea\xf3\xbb\x63\xbf\x9d\x1b\x6d\xa2\x1c\xc7\xe4\x08\x7a\x15\x68\xe8\xf6\xae\xfc\x0b\xfb\x5d\x2d\x3c\xf5\xc8\xee\xf3\xbe\x86\xec\xd8\x27\x73\x0c\xd1\xbd\xde\xab\x2b\x5b\xd3\xb2\x4f\x4d\xca\x92\x8b\x15\x47\xa6\x90\x58\x71\x97\x9b\x74\x0d\xa0\x4a\x48\x6a\x82\xc6\x4b\x36\xa7\x98\x8b\xd8\x33\x65\x1c\x50\xd2\x48\xaa\x66\x68\xfb\xe5\x1f\x03\x8f\xf6\x4c\x5f\x3e\x24\x93\x61\x3f\xff\x6d\x71\x00\xb3\x9a\xd5\x47\x00\x00\x00\xff\xff\xe1\xdc\x0f\xba\x0f\x02\x00\x00")
+var _templatesVpcTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x5d\x8f\xd3\x30\x10\x7c\xef\xaf\x58\x19\x74\x6a\x51\x2f\xf4\x24\xe8\x43\xa5\x03\xc1\x1b\x2f\xc0\x3f\x88\xb6\xf6\x36\x31\xe7\xb3\x23\xdb\x09\x54\xa7\xfe\x77\xd6\x76\x42\xa3\x50\x09\x22\xe5\x43\xf6\xec\xce\xec\x64\x3c\xa0\xd7\x78\x34\x04\x82\x7e\xe9\x10\xb5\x6d\xea\xa1\x93\xb5\x56\x02\x5e\x56\x00\xf1\xdc\x11\x8c\xd7\x23\x88\x10\x3d\x23\x04\x6f\x28\x3a\x61\x6f\xe2\xb4\x51\x96\x82\xf4\xba\x8b\xda\xd9\xb4\xf4\x2d\x7f\xa1\x31\x67\xe8\x03\x01\x5a\x98\x18\x80\x19\xc4\xea\xb2\x5a\x19\x27\xd1\x84\x4c\x94\x48\xa5\xeb\x6d\x4c\xa5\xaf\x5f\x0c\xd9\x26\xb6\xeb\x01\x7d\xb5\xd0\xb5\x81\x0f\xb0\x83\x8f\x7c\x1f\xe0\xe1\x22\xc6\x52\xad\x46\x21\xff\x53\x7a\x63\x8b\x9b\xfd\x70\xda\xae\x05\x88\x2d\xe0\xcf\x90\x96\xab\x74\xbf\xa9\xb8\x8e\x69\x98\xe7\x15\x7c\x76\xb1\x85\xa0\x79\x50\x70\x27\x40\x90\xce\x2a\x5d\xa6\x04\xf4\x04\x34\xa0\xe9\x31\x92\xda\x42\x70\x10\x5b\x82\xd0\x1f\x2d\xc5\x02\xb7\xf0\xe5\xfb\xf0\xee\xde\x59\x73\xce\xdd\xb8\x7d\xae\x92\xe8\x07\x52\xe0\xfa\x58\xba\x76\x06\x25\xb5\xce\x28\xf2\x70\x64\x8b\x9e\xb8\x13\x46\xd0\x01\x2c\x0d\xbc\x86\x21\xe8\xc6\x92\xaa\xe0\x93\xcd\x8d\xe6\xbe\xc2\x73\x1f\x22\xa0\xf1\x84\xea\x0c\x2d\x0e\x34\x12\xef\xc7\x5e\x27\xe7\x41\xf5\x2c\x38\x44\x94\x4f\xd5\xe4\x5f\x37\xec\x6b\xa9\x95\x2f\x1e\x26\x87\x12\xa8\xce\x20\xb6\xec\x6a\x0e\xcf\x2c\x31\xae\x17\x1e\x4d\xe5\x75\x26\xd9\x82\xc2\x88\xd5\x84\x99\xf4\xfd\x0d\xdc\x6c\xd8\x78\x71\x52\xbb\xdd\xe1\xf0\xf6\xfd\x5e\x5c\x72\x2c\x52\x31\x88\xb1\x5a\x5c\x93\x59\x22\x39\x4b\xc9\x42\xe6\xdd\x1d\xfc\xfb\xe7\x3f\x30\xe3\x2e\xe7\x66\x96\x99\x1b\xf8\x22\xc5\x53\x70\xbd\x97\x34\x97\x93\x9f\x57\x25\x8b\xab\x64\x30\x05\xbb\xfa\x93\xe9\x4c\x77\x9d\x7a\x89\x4e\xec\x19\xcb\x88\xa2\xcc\xf2\x40\x56\x52\x1d\xc9\xf2\xfb\x3c\x41\xc7\x43\x97\x20\xbc\xc1\xa7\xb6\x56\x36\xd4\xad\x0b\xd1\xe2\x33\x87\xf2\x11\xa2\xef\x29\x65\xb5\x64\xa4\x6e\xc8\x92\x4f\x81\xac\x17\xc6\xdf\xf2\xaf\xa4\x3c\x62\x53\x4e\x24\xc0\x57\x6e\x3a\xf3\xc7\x0e\xc9\x96\xfb\x7c\x78\x01\x2e\x6c\xcf\x6f\xde\x87\xe4\x5f\x3d\x04\x00\x00")
 
 func templatesVpcTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -251,7 +440,7 @@ func templatesVpcTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vpc.tf", size: 527, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/vpc.tf", size: 1085, mode: os.FileMode(480), modTime: time.Unix(1792401811, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -312,8 +501,17 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/director_access.tf": templatesDirector_accessTf,
+	"templates/director_blobstore.tf": templatesDirector_blobstoreTf,
+	"templates/director_database.tf": templatesDirector_databaseTf,
+	"templates/disk_encryption.tf": templatesDisk_encryptionTf,
+	"templates/dual_stack.tf": templatesDual_stackTf,
+	"templates/extra_region.tf": templatesExtra_regionTf,
+	"templates/extra_region_peering.tf": templatesExtra_region_peeringTf,
 	"templates/iam.tf": templatesIamTf,
 	"templates/iso_segments.tf": templatesIso_segmentsTf,
+	"templates/jumpbox.tf": templatesJumpboxTf,
+	"templates/jumpbox_dns.tf": templatesJumpbox_dnsTf,
 	"templates/lb_subnet.tf": templatesLb_subnetTf,
 	"templates/ssl_certificate.tf": templatesSsl_certificateTf,
 	"templates/vpc.tf": templatesVpcTf,
@@ -364,8 +562,17 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"director_access.tf": &bintree{templatesDirector_accessTf, map[string]*bintree{}},
+		"director_blobstore.tf": &bintree{templatesDirector_blobstoreTf, map[string]*bintree{}},
+		"director_database.tf": &bintree{templatesDirector_databaseTf, map[string]*bintree{}},
+		"disk_encryption.tf": &bintree{templatesDisk_encryptionTf, map[string]*bintree{}},
+		"dual_stack.tf": &bintree{templatesDual_stackTf, map[string]*bintree{}},
+		"extra_region.tf": &bintree{templatesExtra_regionTf, map[string]*bintree{}},
+		"extra_region_peering.tf": &bintree{templatesExtra_region_peeringTf, map[string]*bintree{}},
 		"iam.tf": &bintree{templatesIamTf, map[string]*bintree{}},
 		"iso_segments.tf": &bintree{templatesIso_segmentsTf, map[string]*bintree{}},
+		"jumpbox.tf": &bintree{templatesJumpboxTf, map[string]*bintree{}},
+		"jumpbox_dns.tf": &bintree{templatesJumpbox_dnsTf, map[string]*bintree{}},
 		"lb_subnet.tf": &bintree{templatesLb_subnetTf, map[string]*bintree{}},
 		"ssl_certificate.tf": &bintree{templatesSsl_certificateTf, map[string]*bintree{}},
 		"vpc.tf": &bintree{templatesVpcTf, map[string]*bintree{}},
@@ -52,9 +52,14 @@ variable "vpc_cidr" {
   default = "10.0.0.0/16"
 }
 
-resource "aws_eip" "jumpbox_eip" {
-  depends_on = ["aws_internet_gateway.ig"]
-  vpc        = true
+variable "dual_stack" {
+  default     = false
+  description = "Assign IPv6 cidr blocks to the vpc and subnets, next to IPv4"
+}
+
+variable "disk_encryption_key" {
+  default     = ""
+  description = "ARN of a customer-managed KMS key that encrypts the disks of bosh vms"
 }
 
 resource "tls_private_key" "bosh_vms" {
@@ -203,15 +208,6 @@ resource "aws_security_group_rule" "internal_security_group_rule_allow_internet"
   cidr_blocks       = ["0.0.0.0/0"]
 }
 
-resource "aws_security_group_rule" "internal_security_group_rule_ssh" {
-  security_group_id        = "${aws_security_group.internal_security_group.id}"
-  type                     = "ingress"
-  protocol                 = "TCP"
-  from_port                = 22
-  to_port                  = 22
-  source_security_group_id = "${aws_security_group.jumpbox.id}"
-}
-
 resource "aws_security_group" "bosh_security_group" {
   name        = "${var.env_id}-bosh-security-group"
   description = "BOSH Director"
@@ -235,42 +231,6 @@ resource "aws_security_group_rule" "bosh_security_group_rule_tcp_ssh" {
   cidr_blocks       = ["${var.bosh_inbound_cidr}"]
 }
 
-resource "aws_security_group_rule" "bosh_security_group_rule_tcp_bosh_agent" {
-  security_group_id        = "${aws_security_group.bosh_security_group.id}"
-  type                     = "ingress"
-  protocol                 = "tcp"
-  from_port                = 6868
-  to_port                  = 6868
-  source_security_group_id = "${aws_security_group.jumpbox.id}"
-}
-
-resource "aws_security_group_rule" "bosh_security_group_rule_uaa" {
-  security_group_id        = "${aws_security_group.bosh_security_group.id}"
-  type                     = "ingress"
-  protocol                 = "tcp"
-  from_port                = 8443
-  to_port                  = 8443
-  source_security_group_id = "${aws_security_group.jumpbox.id}"
-}
-
-resource "aws_security_group_rule" "bosh_security_group_rule_credhub" {
-  security_group_id        = "${aws_security_group.bosh_security_group.id}"
-  type                     = "ingress"
-  protocol                 = "tcp"
-  from_port                = 8844
-  to_port                  = 8844
-  source_security_group_id = "${aws_security_group.jumpbox.id}"
-}
-
-resource "aws_security_group_rule" "bosh_security_group_rule_tcp_director_api" {
-  security_group_id        = "${aws_security_group.bosh_security_group.id}"
-  type                     = "ingress"
-  protocol                 = "tcp"
-  from_port                = 25555
-  to_port                  = 25555
-  source_security_group_id = "${aws_security_group.jumpbox.id}"
-}
-
 resource "aws_security_group_rule" "bosh_security_group_rule_tcp" {
   security_group_id        = "${aws_security_group.bosh_security_group.id}"
   type                     = "ingress"
@@ -298,65 +258,6 @@ resource "aws_security_group_rule" "bosh_security_group_rule_allow_internet" {
   cidr_blocks       = ["0.0.0.0/0"]
 }
 
-resource "aws_security_group" "jumpbox" {
-  name        = "${var.env_id}-jumpbox-security-group"
-  description = "Jumpbox"
-  vpc_id      = "${local.vpc_id}"
-
-  tags {
-    Name = "${var.env_id}-jumpbox-security-group"
-  }
-
-  lifecycle {
-    ignore_changes = ["name", "description"]
-  }
-}
-
-resource "aws_security_group_rule" "jumpbox_ssh" {
-  security_group_id = "${aws_security_group.jumpbox.id}"
-  type              = "ingress"
-  protocol          = "tcp"
-  from_port         = 22
-  to_port           = 22
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
-}
-
-resource "aws_security_group_rule" "jumpbox_rdp" {
-  security_group_id = "${aws_security_group.jumpbox.id}"
-  type              = "ingress"
-  protocol          = "tcp"
-  from_port         = 3389
-  to_port           = 3389
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
-}
-
-resource "aws_security_group_rule" "jumpbox_agent" {
-  security_group_id = "${aws_security_group.jumpbox.id}"
-  type              = "ingress"
-  protocol          = "tcp"
-  from_port         = 6868
-  to_port           = 6868
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
-}
-
-resource "aws_security_group_rule" "jumpbox_director" {
-  security_group_id = "${aws_security_group.jumpbox.id}"
-  type              = "ingress"
-  protocol          = "tcp"
-  from_port         = 25555
-  to_port           = 25555
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
-}
-
-resource "aws_security_group_rule" "jumpbox_egress" {
-  security_group_id = "${aws_security_group.jumpbox.id}"
-  type              = "egress"
-  protocol          = "-1"
-  from_port         = 0
-  to_port           = 0
-  cidr_blocks       = ["0.0.0.0/0"]
-}
-
 resource "aws_security_group_rule" "bosh_internal_security_rule_tcp" {
   security_group_id        = "${aws_security_group.internal_security_group.id}"
   type                     = "ingress"
@@ -379,6 +280,9 @@ resource "aws_subnet" "bosh_subnet" {
   vpc_id     = "${local.vpc_id}"
   cidr_block = "${cidrsubnet(var.vpc_cidr, 8, 0)}"
 
+  ipv6_cidr_block                 = "${var.dual_stack ? cidrsubnet(local.vpc_ipv6_cidr, 8, 0) : ""}"
+  assign_ipv6_address_on_creation = "${var.dual_stack}"
+
   tags {
     Name = "${var.env_id}-bosh-subnet"
   }
@@ -405,6 +309,9 @@ resource "aws_subnet" "internal_subnets" {
   cidr_block        = "${cidrsubnet(var.vpc_cidr, 4, count.index+1)}"
   availability_zone = "${element(var.availability_zones, count.index)}"
 
+  ipv6_cidr_block                 = "${var.dual_stack ? cidrsubnet(local.vpc_ipv6_cidr, 8, count.index+1) : ""}"
+  assign_ipv6_address_on_creation = "${var.dual_stack}"
+
   tags {
     Name = "${var.env_id}-internal-subnet${count.index}"
   }
@@ -455,18 +362,6 @@ output "private_key" {
   sensitive = true
 }
 
-output "external_ip" {
-  value = "${aws_eip.jumpbox_eip.public_ip}"
-}
-
-output "jumpbox_url" {
-  value = "${aws_eip.jumpbox_eip.public_ip}:22"
-}
-
-output "director_address" {
-  value = "https://${aws_eip.jumpbox_eip.public_ip}:25555"
-}
-
 output "nat_eip" {
   value = "${aws_eip.nat_eip.public_ip}"
 }
@@ -479,14 +374,6 @@ output "bosh_security_group" {
   value = "${aws_security_group.bosh_security_group.id}"
 }
 
-output "jumpbox_security_group" {
-  value = "${aws_security_group.jumpbox.id}"
-}
-
-output "jumpbox__default_security_groups" {
-  value = ["${aws_security_group.jumpbox.id}"]
-}
-
 output "director__default_security_groups" {
   value = ["${aws_security_group.bosh_security_group.id}"]
 }
@@ -508,7 +395,7 @@ output "region" {
 }
 
 output "kms_key_arn" {
-  value = "${aws_kms_key.kms_key.arn}"
+  value = "${var.disk_encryption_key == "" ? aws_kms_key.kms_key.arn : var.disk_encryption_key}"
 }
 
 output "internal_az_subnet_id_mapping" {
@@ -62,15 +62,6 @@ resource "aws_route53_record" "ssh" {
   records = ["${aws_elb.cf_ssh_lb.dns_name}"]
 }
 
-resource "aws_route53_record" "bosh" {
-  zone_id = "${local.zone_id}"
-  name    = "bosh.${var.system_domain}"
-  type    = "A"
-  ttl     = 300
-
-  records = ["${aws_eip.jumpbox_eip.public_ip}"]
-}
-
 resource "aws_route53_record" "tcp" {
   zone_id = "${local.zone_id}"
   name    = "tcp.${var.system_domain}"
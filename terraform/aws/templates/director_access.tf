@@ -0,0 +1,40 @@
+variable "director_access_cidrs" {
+  type    = "list"
+  default = ["10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"]
+}
+
+resource "aws_security_group_rule" "director_access_bosh_agent" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 6868
+  to_port           = 6868
+  cidr_blocks       = ["${var.director_access_cidrs}"]
+}
+
+resource "aws_security_group_rule" "director_access_uaa" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 8443
+  to_port           = 8443
+  cidr_blocks       = ["${var.director_access_cidrs}"]
+}
+
+resource "aws_security_group_rule" "director_access_credhub" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 8844
+  to_port           = 8844
+  cidr_blocks       = ["${var.director_access_cidrs}"]
+}
+
+resource "aws_security_group_rule" "director_access_director_api" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "tcp"
+  from_port         = 25555
+  to_port           = 25555
+  cidr_blocks       = ["${var.director_access_cidrs}"]
+}
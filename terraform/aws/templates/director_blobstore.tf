@@ -0,0 +1,67 @@
+resource "aws_s3_bucket" "director_blobstore" {
+  bucket_prefix = "${var.short_env_id}-bosh-blobstore-"
+  force_destroy = true
+
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = "AES256"
+      }
+    }
+  }
+
+  tags {
+    Name = "${var.env_id}-bosh-blobstore"
+  }
+}
+
+resource "aws_iam_user" "director_blobstore" {
+  name = "${var.env_id}-bosh-blobstore"
+}
+
+resource "aws_iam_access_key" "director_blobstore" {
+  user = "${aws_iam_user.director_blobstore.name}"
+}
+
+resource "aws_iam_user_policy" "director_blobstore" {
+  name = "${var.env_id}-bosh-blobstore"
+  user = "${aws_iam_user.director_blobstore.name}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": [
+        "s3:ListBucket",
+        "s3:GetBucketLocation"
+      ],
+      "Effect": "Allow",
+      "Resource": "${aws_s3_bucket.director_blobstore.arn}"
+    },
+    {
+      "Action": [
+        "s3:GetObject",
+        "s3:PutObject",
+        "s3:DeleteObject"
+      ],
+      "Effect": "Allow",
+      "Resource": "${aws_s3_bucket.director_blobstore.arn}/*"
+    }
+  ]
+}
+EOF
+}
+
+output "director__blobstore_bucket_name" {
+  value = "${aws_s3_bucket.director_blobstore.id}"
+}
+
+output "director__blobstore_access_key_id" {
+  value = "${aws_iam_access_key.director_blobstore.id}"
+}
+
+output "director__blobstore_secret_access_key" {
+  value     = "${aws_iam_access_key.director_blobstore.secret}"
+  sensitive = true
+}
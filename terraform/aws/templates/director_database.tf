@@ -0,0 +1,102 @@
+resource "random_string" "director_database_password" {
+  length  = 32
+  special = false
+}
+
+resource "aws_db_subnet_group" "director_database" {
+  name       = "${var.short_env_id}-bosh-db"
+  subnet_ids = ["${aws_subnet.internal_subnets.*.id}"]
+
+  tags {
+    Name = "${var.env_id}-bosh-db"
+  }
+}
+
+resource "aws_security_group" "director_database" {
+  name        = "${var.env_id}-bosh-db"
+  description = "BOSH director database"
+  vpc_id      = "${local.vpc_id}"
+
+  tags {
+    Name = "${var.env_id}-bosh-db"
+  }
+
+  lifecycle {
+    ignore_changes = ["name"]
+  }
+}
+
+resource "aws_security_group_rule" "director_database_postgres" {
+  security_group_id        = "${aws_security_group.director_database.id}"
+  type                     = "ingress"
+  protocol                 = "tcp"
+  from_port                = 5432
+  to_port                  = 5432
+  source_security_group_id = "${aws_security_group.bosh_security_group.id}"
+}
+
+resource "aws_db_parameter_group" "director_database" {
+  name_prefix = "${var.short_env_id}-bosh-db-"
+  family      = "postgres9.6"
+
+  parameter {
+    name  = "rds.force_ssl"
+    value = "1"
+  }
+}
+
+locals {
+  rds_truststore = "${replace(var.region, "/^us-gov-.*$/", "") == "" ? "truststore.pki.${var.region}.rds.amazonaws.com" : "truststore.pki.rds.amazonaws.com"}"
+}
+
+# The director verifies the database against the certificate authorities
+# that RDS uses in the region.
+data "http" "director_database_ca" {
+  url = "https://${local.rds_truststore}/${var.region}/${var.region}-bundle.pem"
+}
+
+resource "aws_db_instance" "director_database" {
+  identifier_prefix      = "${var.short_env_id}-bosh-"
+  engine                 = "postgres"
+  engine_version         = "9.6"
+  instance_class         = "db.t2.medium"
+  allocated_storage      = 20
+  storage_type           = "gp2"
+  storage_encrypted      = true
+  name                   = "bosh"
+  username               = "bosh"
+  password               = "${random_string.director_database_password.result}"
+  db_subnet_group_name   = "${aws_db_subnet_group.director_database.name}"
+  vpc_security_group_ids = ["${aws_security_group.director_database.id}"]
+  parameter_group_name   = "${aws_db_parameter_group.director_database.name}"
+  skip_final_snapshot    = true
+}
+
+output "director__external_db_host" {
+  value = "${aws_db_instance.director_database.address}"
+}
+
+output "director__external_db_port" {
+  value = "${aws_db_instance.director_database.port}"
+}
+
+output "director__external_db_user" {
+  value = "${aws_db_instance.director_database.username}"
+}
+
+output "director__external_db_password" {
+  value     = "${random_string.director_database_password.result}"
+  sensitive = true
+}
+
+output "director__external_db_adapter" {
+  value = "postgres"
+}
+
+output "director__external_db_name" {
+  value = "${aws_db_instance.director_database.name}"
+}
+
+output "director__db_ca" {
+  value = "${data.http.director_database_ca.body}"
+}
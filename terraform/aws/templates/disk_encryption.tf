@@ -0,0 +1,35 @@
+resource "aws_iam_policy" "bosh_disk_encryption" {
+  name = "${var.env_id}_bosh_disk_encryption_policy"
+  path = "/"
+
+  count = "${1 - local.iamProfileProvided}"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "kms:Encrypt",
+        "kms:Decrypt",
+        "kms:ReEncrypt*",
+        "kms:GenerateDataKey*",
+        "kms:CreateGrant",
+        "kms:DescribeKey"
+      ],
+      "Resource": [
+        "${var.disk_encryption_key}"
+      ]
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy_attachment" "bosh_disk_encryption" {
+  role       = "${var.env_id}_bosh_role"
+  policy_arn = "${aws_iam_policy.bosh_disk_encryption.arn}"
+
+  count = "${1 - local.iamProfileProvided}"
+}
@@ -0,0 +1,52 @@
+resource "aws_egress_only_internet_gateway" "egress_only_ig" {
+  vpc_id = "${local.vpc_id}"
+}
+
+resource "aws_route" "bosh_route_table_ipv6" {
+  destination_ipv6_cidr_block = "::/0"
+  gateway_id                  = "${aws_internet_gateway.ig.id}"
+  route_table_id              = "${aws_route_table.bosh_route_table.id}"
+}
+
+resource "aws_route" "internal_route_table_ipv6" {
+  destination_ipv6_cidr_block = "::/0"
+  egress_only_gateway_id      = "${aws_egress_only_internet_gateway.egress_only_ig.id}"
+  route_table_id              = "${aws_route_table.internal_route_table.id}"
+}
+
+resource "aws_security_group_rule" "internal_security_group_rule_icmpv6" {
+  security_group_id = "${aws_security_group.internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "58"
+  from_port         = -1
+  to_port           = -1
+  ipv6_cidr_blocks  = ["::/0"]
+}
+
+resource "aws_security_group_rule" "internal_security_group_rule_allow_internet_ipv6" {
+  security_group_id = "${aws_security_group.internal_security_group.id}"
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  ipv6_cidr_blocks  = ["::/0"]
+}
+
+resource "aws_security_group_rule" "bosh_security_group_rule_allow_internet_ipv6" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  ipv6_cidr_blocks  = ["::/0"]
+}
+
+output "vpc_ipv6_cidr" {
+  value = "${local.vpc_ipv6_cidr}"
+}
+
+output "internal_az_subnet_ipv6_cidr_mapping" {
+  value = "${
+	  zipmap("${aws_subnet.internal_subnets.*.availability_zone}", "${aws_subnet.internal_subnets.*.ipv6_cidr_block}")
+	}"
+}
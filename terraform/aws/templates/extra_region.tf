@@ -0,0 +1,222 @@
+variable "EXTRA_REGION_KEY_availability_zones" {
+  type = "list"
+}
+
+variable "EXTRA_REGION_KEY_vpc_cidr" {
+  type = "string"
+}
+
+provider "aws" {
+  alias      = "EXTRA_REGION_KEY"
+  access_key = "${var.access_key}"
+  secret_key = "${var.secret_key}"
+  region     = "EXTRA_REGION_NAME"
+
+  version = ">= 1.17.0"
+}
+
+resource "aws_vpc" "EXTRA_REGION_KEY" {
+  provider             = "aws.EXTRA_REGION_KEY"
+  cidr_block           = "${var.EXTRA_REGION_KEY_vpc_cidr}"
+  instance_tenancy     = "default"
+  enable_dns_hostnames = true
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-vpc"
+  }
+}
+
+resource "aws_internet_gateway" "EXTRA_REGION_KEY" {
+  provider = "aws.EXTRA_REGION_KEY"
+  vpc_id   = "${aws_vpc.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_subnet" "EXTRA_REGION_KEY_nat_subnet" {
+  provider   = "aws.EXTRA_REGION_KEY"
+  vpc_id     = "${aws_vpc.EXTRA_REGION_KEY.id}"
+  cidr_block = "${cidrsubnet(var.EXTRA_REGION_KEY_vpc_cidr, 8, 0)}"
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-nat-subnet"
+  }
+}
+
+resource "aws_route_table" "EXTRA_REGION_KEY_nat_route_table" {
+  provider = "aws.EXTRA_REGION_KEY"
+  vpc_id   = "${aws_vpc.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_nat_route_table" {
+  provider               = "aws.EXTRA_REGION_KEY"
+  destination_cidr_block = "0.0.0.0/0"
+  gateway_id             = "${aws_internet_gateway.EXTRA_REGION_KEY.id}"
+  route_table_id         = "${aws_route_table.EXTRA_REGION_KEY_nat_route_table.id}"
+}
+
+resource "aws_route_table_association" "EXTRA_REGION_KEY_nat_subnet" {
+  provider       = "aws.EXTRA_REGION_KEY"
+  subnet_id      = "${aws_subnet.EXTRA_REGION_KEY_nat_subnet.id}"
+  route_table_id = "${aws_route_table.EXTRA_REGION_KEY_nat_route_table.id}"
+}
+
+resource "aws_eip" "EXTRA_REGION_KEY_nat_eip" {
+  provider   = "aws.EXTRA_REGION_KEY"
+  depends_on = ["aws_internet_gateway.EXTRA_REGION_KEY"]
+  vpc        = true
+}
+
+resource "aws_nat_gateway" "EXTRA_REGION_KEY" {
+  provider      = "aws.EXTRA_REGION_KEY"
+  allocation_id = "${aws_eip.EXTRA_REGION_KEY_nat_eip.id}"
+  subnet_id     = "${aws_subnet.EXTRA_REGION_KEY_nat_subnet.id}"
+}
+
+resource "aws_subnet" "EXTRA_REGION_KEY_internal_subnets" {
+  provider          = "aws.EXTRA_REGION_KEY"
+  count             = "${length(var.EXTRA_REGION_KEY_availability_zones)}"
+  vpc_id            = "${aws_vpc.EXTRA_REGION_KEY.id}"
+  cidr_block        = "${cidrsubnet(var.EXTRA_REGION_KEY_vpc_cidr, 4, count.index+1)}"
+  availability_zone = "${element(var.EXTRA_REGION_KEY_availability_zones, count.index)}"
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-internal-subnet${count.index}"
+  }
+
+  lifecycle {
+    ignore_changes = ["cidr_block", "availability_zone"]
+  }
+}
+
+resource "aws_route_table" "EXTRA_REGION_KEY_internal_route_table" {
+  provider = "aws.EXTRA_REGION_KEY"
+  vpc_id   = "${aws_vpc.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_internal_route_table" {
+  provider               = "aws.EXTRA_REGION_KEY"
+  destination_cidr_block = "0.0.0.0/0"
+  nat_gateway_id         = "${aws_nat_gateway.EXTRA_REGION_KEY.id}"
+  route_table_id         = "${aws_route_table.EXTRA_REGION_KEY_internal_route_table.id}"
+}
+
+resource "aws_route_table_association" "EXTRA_REGION_KEY_internal_subnets" {
+  provider       = "aws.EXTRA_REGION_KEY"
+  count          = "${length(var.EXTRA_REGION_KEY_availability_zones)}"
+  subnet_id      = "${element(aws_subnet.EXTRA_REGION_KEY_internal_subnets.*.id, count.index)}"
+  route_table_id = "${aws_route_table.EXTRA_REGION_KEY_internal_route_table.id}"
+}
+
+resource "aws_vpc_peering_connection" "EXTRA_REGION_KEY" {
+  vpc_id      = "${local.vpc_id}"
+  peer_vpc_id = "${aws_vpc.EXTRA_REGION_KEY.id}"
+  peer_region = "EXTRA_REGION_NAME"
+  auto_accept = false
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-peering"
+  }
+}
+
+resource "aws_vpc_peering_connection_accepter" "EXTRA_REGION_KEY" {
+  provider                  = "aws.EXTRA_REGION_KEY"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY.id}"
+  auto_accept               = true
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-peering"
+  }
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_from_bosh_subnet" {
+  route_table_id            = "${aws_route_table.bosh_route_table.id}"
+  destination_cidr_block    = "${var.EXTRA_REGION_KEY_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_from_internal_subnets" {
+  route_table_id            = "${aws_route_table.internal_route_table.id}"
+  destination_cidr_block    = "${var.EXTRA_REGION_KEY_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_to_vpc" {
+  provider                  = "aws.EXTRA_REGION_KEY"
+  route_table_id            = "${aws_route_table.EXTRA_REGION_KEY_internal_route_table.id}"
+  destination_cidr_block    = "${var.vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY.id}"
+}
+
+resource "aws_security_group" "EXTRA_REGION_KEY_internal_security_group" {
+  provider    = "aws.EXTRA_REGION_KEY"
+  name        = "${var.env_id}-EXTRA_REGION_NAME-internal-security-group"
+  description = "Internal"
+  vpc_id      = "${aws_vpc.EXTRA_REGION_KEY.id}"
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-internal-security-group"
+  }
+}
+
+resource "aws_security_group_rule" "EXTRA_REGION_KEY_internal_security_group_rule" {
+  provider          = "aws.EXTRA_REGION_KEY"
+  security_group_id = "${aws_security_group.EXTRA_REGION_KEY_internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.vpc_cidr}", "${var.EXTRA_REGION_KEY_vpc_cidr}"]
+}
+
+resource "aws_security_group_rule" "EXTRA_REGION_KEY_internal_security_group_rule_allow_internet" {
+  provider          = "aws.EXTRA_REGION_KEY"
+  security_group_id = "${aws_security_group.EXTRA_REGION_KEY_internal_security_group.id}"
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "EXTRA_REGION_KEY_to_internal_security_group" {
+  security_group_id = "${aws_security_group.internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.EXTRA_REGION_KEY_vpc_cidr}"]
+}
+
+resource "aws_security_group_rule" "EXTRA_REGION_KEY_to_bosh_security_group" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.EXTRA_REGION_KEY_vpc_cidr}"]
+}
+
+resource "aws_key_pair" "EXTRA_REGION_KEY_bosh_vms" {
+  provider   = "aws.EXTRA_REGION_KEY"
+  key_name   = "${var.env_id}_bosh_vms"
+  public_key = "${tls_private_key.bosh_vms.public_key_openssh}"
+}
+
+output "EXTRA_REGION_KEY_default_key_name" {
+  value = "${aws_key_pair.EXTRA_REGION_KEY_bosh_vms.key_name}"
+}
+
+output "EXTRA_REGION_KEY_internal_security_group" {
+  value = "${aws_security_group.EXTRA_REGION_KEY_internal_security_group.id}"
+}
+
+output "EXTRA_REGION_KEY_az_subnet_id_mapping" {
+  value = "${
+	  zipmap("${aws_subnet.EXTRA_REGION_KEY_internal_subnets.*.availability_zone}", "${aws_subnet.EXTRA_REGION_KEY_internal_subnets.*.id}")
+	}"
+}
+
+output "EXTRA_REGION_KEY_az_subnet_cidr_mapping" {
+  value = "${
+	  zipmap("${aws_subnet.EXTRA_REGION_KEY_internal_subnets.*.availability_zone}", "${aws_subnet.EXTRA_REGION_KEY_internal_subnets.*.cidr_block}")
+	}"
+}
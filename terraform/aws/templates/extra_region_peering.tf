@@ -0,0 +1,55 @@
+resource "aws_vpc_peering_connection" "EXTRA_REGION_KEY_to_PEER_REGION_KEY" {
+  provider    = "aws.EXTRA_REGION_KEY"
+  vpc_id      = "${aws_vpc.EXTRA_REGION_KEY.id}"
+  peer_vpc_id = "${aws_vpc.PEER_REGION_KEY.id}"
+  peer_region = "PEER_REGION_NAME"
+  auto_accept = false
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-PEER_REGION_NAME-peering"
+  }
+}
+
+resource "aws_vpc_peering_connection_accepter" "EXTRA_REGION_KEY_to_PEER_REGION_KEY" {
+  provider                  = "aws.PEER_REGION_KEY"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY_to_PEER_REGION_KEY.id}"
+  auto_accept               = true
+
+  tags {
+    Name = "${var.env_id}-EXTRA_REGION_NAME-PEER_REGION_NAME-peering"
+  }
+}
+
+resource "aws_route" "EXTRA_REGION_KEY_to_PEER_REGION_KEY" {
+  provider                  = "aws.EXTRA_REGION_KEY"
+  route_table_id            = "${aws_route_table.EXTRA_REGION_KEY_internal_route_table.id}"
+  destination_cidr_block    = "${var.PEER_REGION_KEY_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY_to_PEER_REGION_KEY.id}"
+}
+
+resource "aws_route" "PEER_REGION_KEY_to_EXTRA_REGION_KEY" {
+  provider                  = "aws.PEER_REGION_KEY"
+  route_table_id            = "${aws_route_table.PEER_REGION_KEY_internal_route_table.id}"
+  destination_cidr_block    = "${var.EXTRA_REGION_KEY_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.EXTRA_REGION_KEY_to_PEER_REGION_KEY.id}"
+}
+
+resource "aws_security_group_rule" "PEER_REGION_KEY_to_EXTRA_REGION_KEY_internal_security_group" {
+  provider          = "aws.EXTRA_REGION_KEY"
+  security_group_id = "${aws_security_group.EXTRA_REGION_KEY_internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.PEER_REGION_KEY_vpc_cidr}"]
+}
+
+resource "aws_security_group_rule" "EXTRA_REGION_KEY_to_PEER_REGION_KEY_internal_security_group" {
+  provider          = "aws.PEER_REGION_KEY"
+  security_group_id = "${aws_security_group.PEER_REGION_KEY_internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.EXTRA_REGION_KEY_vpc_cidr}"]
+}
@@ -0,0 +1,8 @@
+resource "aws_route53_record" "bosh" {
+  zone_id = "${local.zone_id}"
+  name    = "bosh.${var.system_domain}"
+  type    = "A"
+  ttl     = 300
+
+  records = ["${aws_eip.jumpbox_eip.public_ip}"]
+}
@@ -7,6 +7,16 @@ variable "existing_vpc_id" {
 locals {
   vpc_count = "${length(var.existing_vpc_id) > 0 ? 0 : 1}"
   vpc_id    = "${length(var.existing_vpc_id) > 0 ? var.existing_vpc_id : join(" ", aws_vpc.vpc.*.id)}"
+
+  # Both sides of a conditional are evaluated, so the subnets of an IPv4-only
+  # vpc are carved out of a placeholder block that is never assigned. An
+  # existing vpc must already have an IPv6 block for dual stack.
+  vpc_ipv6_cidr = "${var.dual_stack ? join(" ", concat(aws_vpc.vpc.*.ipv6_cidr_block, data.aws_vpc.existing.*.ipv6_cidr_block)) : "fd00::/56"}"
+}
+
+data "aws_vpc" "existing" {
+  count = "${var.dual_stack && length(var.existing_vpc_id) > 0 ? 1 : 0}"
+  id    = "${var.existing_vpc_id}"
 }
 
 resource "aws_vpc" "vpc" {
@@ -15,6 +25,8 @@ resource "aws_vpc" "vpc" {
   instance_tenancy     = "default"
   enable_dns_hostnames = true
 
+  assign_generated_ipv6_cidr_block = "${var.dual_stack}"
+
   tags {
     Name = "${var.env_id}-vpc"
   }
@@ -24,6 +24,15 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"region":        state.Azure.Region,
 	}
 
+	if zones := state.Azure.AvailabilityZones(); len(zones) > 0 {
+		input["zones"] = zones
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		input["disk_encryption_key"] = state.Director.DiskEncryptionKey
+		input["disk_encryption_key_vault_id"] = state.Director.DiskEncryptionKeyVaultID
+	}
+
 	if state.LB.Cert != "" && state.LB.Key != "" {
 		input["pfx_cert_base64"] = state.LB.Cert
 		input["pfx_password"] = state.LB.Key
@@ -56,6 +56,26 @@ var _ = Describe("InputGenerator", func() {
 			})
 		})
 
+		Context("given a region with availability zones", func() {
+			It("returns the zones of the load balancers", func() {
+				state.Azure.Region = "westeurope"
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveKeyWithValue("zones", []string{"1", "2", "3"}))
+			})
+		})
+
+		Context("given a disk encryption key", func() {
+			It("returns the key and its vault", func() {
+				state.Director.DiskEncryptionKey = "https://some-vault.vault.azure.net/keys/some-key/0123abcd"
+				state.Director.DiskEncryptionKeyVaultID = "/subscriptions/some-subscription/resourceGroups/some-group/providers/Microsoft.KeyVault/vaults/some-vault"
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveKeyWithValue("disk_encryption_key", "https://some-vault.vault.azure.net/keys/some-key/0123abcd"))
+				Expect(inputs).To(HaveKeyWithValue("disk_encryption_key_vault_id", "/subscriptions/some-subscription/resourceGroups/some-group/providers/Microsoft.KeyVault/vaults/some-vault"))
+			})
+		})
+
 		Context("given a partial LB state", func() {
 			It("does not generate input for the LB", func() {
 				state.LB.Cert = "Cert content"
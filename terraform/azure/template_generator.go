@@ -17,6 +17,7 @@ type templates struct {
 	cfLB                 string
 	cfDNS                string
 	concourseLB          string
+	diskEncryption       string
 }
 
 type TemplateGenerator struct{}
@@ -41,6 +42,10 @@ func (t TemplateGenerator) Generate(state storage.State) string {
 		template = strings.Join([]string{template, tmpls.concourseLB}, "\n")
 	}
 
+	if state.Director.DiskEncryptionKey != "" {
+		template = strings.Join([]string{template, tmpls.diskEncryption}, "\n")
+	}
+
 	return template
 }
 
@@ -56,6 +61,7 @@ func readTemplates() templates {
 	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
 	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
+	tmpls.diskEncryption = string(MustAsset("templates/disk_encryption.tf"))
 
 	return tmpls
 }
@@ -60,6 +60,17 @@ var _ = Describe("TemplateGenerator", func() {
 				checkTemplate(template, expectedTemplate)
 			})
 		})
+
+		Context("when a disk encryption key is provided", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "output", "tls", "disk_encryption")
+			})
+
+			It("adds the disk encryption set to the base template", func() {
+				template := templateGenerator.Generate(storage.State{Director: storage.Director{DiskEncryptionKey: "some-key"}})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
 	})
 })
 
@@ -3,6 +3,7 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/disk_encryption.tf
 // templates/network.tf
 // templates/network_security_group.tf
 // templates/output.tf
@@ -97,7 +98,7 @@ func templatesCf_dnsTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xcd\x6e\xe3\x36\x10\xbe\xeb\x29\x08\xa1\x87\xb6\x58\x39\x4e\x62\x14\xb9\xe8\xd0\xa2\x87\xf6\xbc\xbd\x13\x94\x34\xb2\x89\xd0\x24\xcb\x1f\x67\xdd\x85\xdf\xbd\x20\x29\xc9\x16\x4d\xd9\x72\x8a\xdd\xcd\xa2\xc9\x2d\x9e\x1f\x0e\xbf\xf9\x66\x38\xa3\x1d\x51\x94\x54\x0c\x50\xae\xf7\xda\xc0\x16\x37\x62\x4b\x28\xcf\xd1\xe7\x43\x96\x1d\x85\xb2\xfd\x84\x6b\x50\x06\x57\x44\xc3\x2f\xab\x94\x58\x12\xad\x5f\x84\x6a\x82\x4c\x81\x16\x56\xd5\x80\x72\xf2\x8f\x55\xa0\xb6\x58\xdb\x8a\x83\xc9\x51\x5e\xb7\x85\x76\x07\x64\x08\x71\xb2\x05\x14\xff\x95\x28\xff\xe1\xf3\x8e\xa8\x05\xf0\x1d\xa6\xcd\xa1\x08\x06\x19\x42\xa4\x69\x14\x68\x8d\xa5\x82\x96\x7e\x3a\x55\xaf\x69\xa3\xc2\x01\x3f\x3a\x4b\x0e\xe6\x45\xa8\x67\xec\x7e\xfe\x80\x9e\x3e\xa0\xfb\x9f\x0e\xce\x41\x1f\x15\x5e\x2b\x61\x25\x0e\xc7\x7b\x07\x7d\x94\x63\x8d\x45\x25\xf4\x66\xe1\xd4\xbc\xf9\x8e\x2a\x63\x09\xc3\xbd\x7b\x6f\x3f\x32\x8f\x34\x46\xf6\x49\x54\x7a\x57\x1a\x6a\xab\xa8\xd9\x87\x73\x3d\x4a\xd3\x10\x25\x10\x72\xe1\x31\x51\x13\x43\x05\x4f\xaa\x2a\x58\x53\xc1\x27\x51\x98\x0b\x42\x86\x90\x21\x6b\xed\x43\x43\x08\xf8\x8e\x2a\xc1\xb7\xc0\xcd\x59\x50\xee\xa4\xc3\xcc\x4b\x2b\xcb\x20\x30\x63\x63\x8c\xbc\xc0\x8d\x0b\x14\xf1\x96\x19\x42\x52\x51\xe1\x9c\xa6\xed\x1e\x96\xf7\x19\x42\x0d\x55\x50\xc7\x50\x1d\x7d\xff\xc9\x2b\x61\x79\xe3\x29\x57\xd7\xa0\xf5\x64\x14\xbf\x32\x26\x5e\xc2\xa9\xc2\x88\x5a\xb0\x09\xbd\xbf\x6a\x1f\x5b\x07\xab\x14\xca\x60\x45\xf8\x1a\xc6\x5a\x3f\x3b\x9d\x06\xb4\xa1\xdc\x27\xf2\x4c\xb1\x44\xf9\xd3\xf2\xc4\xd1\x54\x41\x9c\x39\x8a\x15\x7b\x9d\x64\x41\x9c\x82\x3c\xab\x2e\xd2\x24\x4e\x10\x2b\xad\xb8\xa8\xdb\xdb\x6a\x64\x4c\x17\xfd\x7a\xbe\xe8\x39\x84\x79\xf8\xbe\x09\xb3\x5a\x3d\xbe\x33\xe6\xc8\x18\x26\xd6\xaf\xe3\x8b\x33\x9c\xc1\x96\xc7\xef\x9d\x2d\xff\x43\xba\x48\x5b\x31\x5a\x63\x7a\xed\xdd\xbd\xcc\x8f\xaa\xa0\x72\xea\x19\x3e\xb7\xbc\xf2\x1e\xbf\x02\xa4\xe1\x16\x43\x32\x08\x1b\x62\x29\x51\xde\xec\x39\xd9\xd2\x7a\x02\x03\x22\x25\xa3\x41\x19\xaf\x89\x81\x17\xb2\xbf\x75\x0a\x21\x52\x16\xbd\xe9\x7f\x1c\x33\xe6\x0e\x33\x8e\xa9\xcf\xb6\x9b\x46\x86\x20\x4b\x94\x7f\x34\x84\x37\x44\x35\xf8\xe3\x96\x30\x96\x7b\xb9\xa1\xa0\x62\x79\x90\xd4\x44\x92\xda\x15\x75\x89\x1e\xfc\xd8\x12\x4a\xae\x82\xd8\xf3\x38\x98\x0d\x10\x66\x36\x85\xd7\x0c\x8e\x52\x75\x5a\xa2\xfc\x8f\x6e\x36\x41\x48\x12\xb3\x49\x78\xba\x0b\xd2\x8d\xd0\x26\x21\x25\x92\x2e\xc2\xc5\x47\x43\xfa\x21\x18\x51\x6e\x40\xed\x48\x74\xe6\xe3\xb2\xbb\xf3\x16\x84\x35\x28\x29\xb4\x3c\xdc\x60\x8f\xcd\x46\x81\xde\x08\xd6\x38\x61\x8f\x40\x97\x4b\xc7\xa8\x5a\xf0\x96\xae\xad\x0a\x29\x89\x41\x49\x95\x43\x67\x5c\x50\x59\x8c\x8c\x43\xcc\x61\x56\xc7\xb4\x99\x31\x3e\xd3\xe6\x70\x17\xf4\xf5\xdd\x51\x35\xfc\xb2\xf0\xbb\xc1\x91\x35\x3e\xee\x56\x09\x6e\x80\x37\xbe\xbf\x9d\x06\x5b\xa2\xbc\x97\x39\xd1\x30\x01\x20\xe4\x35\x4b\xb4\x5a\x3d\xbe\xc6\xc9\xc8\xc7\xd3\xf2\x56\x17\x4c\xac\xe3\x30\x12\x71\x5c\xcd\xc2\xa5\xd2\xac\xdb\xa2\x77\x34\x91\x91\xf3\xee\x11\x27\x67\xd0\x70\xed\x74\x18\xf0\x33\x84\x2a\x52\x3f\xbb\x08\x87\x37\x40\x08\x16\x5d\xf7\x2c\x9a\xce\xa6\xe8\x6c\x0a\x67\x73\xe6\xd0\x61\x8b\x35\x18\x43\xf9\xb0\x72\xa4\xfb\xf2\xcc\x25\xac\xa8\xa0\xd8\x18\x6d\xba\xaa\x17\xe2\x99\x82\x5f\x69\x1b\x4c\xda\x96\xf2\xd0\x02\xf2\xdf\xa9\x76\x7b\x6d\x73\x92\x94\xc4\x89\x4f\xcb\xc9\x92\x8f\x8a\x5e\xc1\xdf\x16\xb4\xc1\xe3\x62\x2c\xd1\xfd\xe0\xa1\x02\x1c\x5f\x2c\xd1\x5f\x3c\x38\x5a\x33\xbf\x8a\xd3\xd6\xb5\xeb\xb3\x0e\x55\xa2\x5c\x6b\x56\x38\x8d\x70\x78\x43\x0c\x19\x93\x22\x5a\xe6\x0f\x7d\x67\x0a\xfb\xfb\x58\xaf\xff\xf5\x98\x6d\x9f\x14\x46\xb5\x01\x0e\xea\x62\x52\x6e\xce\x8e\x73\x5d\x30\x6d\x3a\x4a\x4e\x52\x1f\x4f\xd2\xea\x0a\xc9\x47\x15\x79\x86\xf8\x85\xd2\xbe\x30\x7e\x8d\x93\xfd\x35\x20\xd2\x6f\x0c\x23\x7d\x0b\x48\x9d\x72\xc4\xe2\xf8\x9c\x88\xc5\x5f\x1a\x55\xd7\x81\xdf\x10\xa8\x27\x0f\xc2\x17\xc6\xb4\xef\x4c\x4a\x58\xd7\x64\xfd\x9e\x74\x1d\xda\x9b\xea\xd9\xaf\x5e\xa1\x0d\x5a\x06\xd8\xec\x65\xba\x7f\xff\x46\xb4\x1b\x4e\xdd\x7f\xa3\x4c\x5f\xd8\x19\xe6\xb7\x92\xd4\x13\x75\xfa\xf9\x6f\xe6\xeb\x34\xf1\x34\xdd\xf4\x21\xf0\xf4\x0d\xfa\x4a\x39\xd0\xdf\x30\x09\xfa\x3d\x0b\x7d\x83\xf9\x36\x49\x88\x7a\xdb\x9b\xcd\xc1\x21\xcb\x84\x35\xd2\x1a\xb7\x75\xba\x75\xb4\x5f\x43\xbd\xcf\xb0\x86\xee\x08\xb3\x91\xfb\xc4\xde\x3a\x5e\xf7\x4f\x9c\xc6\x1f\xd9\x27\x5c\xce\xf8\x88\xf0\x6f\x00\x00\x00\xff\xff\x51\x97\x99\x8a\x37\x19\x00\x00")
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x59\x4b\x8f\xdb\x36\x10\xbe\xfb\x57\x10\x6e\x0e\x4d\xb1\xf2\x7a\x1f\x28\x16\x05\x36\x41\x8a\x1e\xda\x73\x7a\x6a\x50\x10\x94\x44\x59\xc4\xd2\xa4\x42\x52\xde\x38\x81\xff\x7b\x87\xa4\x24\xeb\x41\xc9\xf2\x06\x49\x36\x45\xf6\xb0\xf6\x8a\x33\xc3\xd1\x37\x1f\xe7\xc1\xdd\x11\xc5\x48\xcc\x29\x5a\xea\xbd\x36\x74\x8b\x53\xb9\x25\x4c\x2c\xd1\xa7\xc3\x62\xb1\x6b\x16\x8b\xec\x03\x4e\xa8\x32\x38\x26\x9a\xfe\x7a\x1b\x5a\x2e\x88\xd6\x8f\x52\xa5\x7e\x4d\x51\x2d\x4b\x95\xc0\x1a\xf9\x58\x2a\xaa\xb6\x58\x97\xb1\xa0\x66\x89\x96\x49\x16\x69\xbb\xc1\x02\x21\x41\xb6\x14\xf5\x7f\xee\xd1\xf2\xc5\x27\xb0\xbd\xa2\x62\x87\x59\x7a\x88\xbc\x02\x88\x93\x34\x05\xbb\x1a\x17\x8a\x66\xec\x43\x5b\x3c\x61\xa9\xf2\x1b\xfc\x6c\x35\xe1\x13\x5c\x79\xc0\xf6\xf1\x05\xba\xbb\x40\x57\x2f\x0f\xd6\x40\xed\x15\xde\x28\x59\x16\xd8\x6f\xef\x0c\xd4\x5e\x76\x25\x56\xb1\xd4\xf9\xca\x8a\x39\xf5\x1d\x53\xa6\x24\x1c\xd7\xe6\x9d\x7e\x47\xbd\x27\xd1\xd1\x0f\xa2\x52\x9b\xd2\x34\x29\x15\x33\x7b\xbf\xaf\x43\x69\x1c\xa2\x00\x42\xd6\x3d\x2e\x13\x62\x98\x14\x41\x51\x45\x37\xb0\x34\x8a\xc2\x5c\x10\x40\xdd\x90\x8d\x76\xae\x21\x04\xfb\x33\x25\xc5\x96\x0a\x33\x70\xca\xee\x74\x98\xf9\xd2\xaa\xe4\xd4\x33\x23\x37\xa6\x98\xe0\xc6\x04\x45\x9c\x26\xe8\x15\x8a\x49\x6b\x34\xac\x77\xbd\xbe\x02\x99\x94\x29\x9a\xf4\xa1\x3a\xda\xfe\x4b\xc4\xb2\x14\xa9\xa3\x5c\x92\x00\xe3\x46\xbd\x78\xc3\xb9\x7c\xf4\xbb\x4a\x23\x13\xc9\x47\xe4\xfe\x4e\x9c\x6f\x15\xac\x85\x84\x93\xa4\x88\xd8\xd0\xae\xd4\x2f\x56\x26\xa5\xda\x30\xe1\x02\x39\x10\x04\x99\xbb\x75\xcb\xd0\xd8\x81\x18\x18\xea\x0b\xd6\x32\xc1\x03\xd1\x06\x79\xd6\xb9\x08\x93\x38\x40\xac\xb0\xe0\x2a\xc9\xce\x3b\x23\x5d\xba\xe8\xa7\xf3\x45\xcf\x21\xcc\xf5\xf7\x4d\x98\xdb\xdb\x9b\x1f\x8c\x39\x32\x86\xcb\xcd\xd3\xf8\x62\x15\x67\xb0\xe5\xe6\x7b\x67\xcb\xff\x9e\x2e\x3f\xa1\x7f\xa4\xa0\x91\xa2\x29\x04\x81\x40\xe5\x22\x45\xc1\x59\x55\x3a\x37\xc4\xd0\x47\xb2\xd7\xb0\x25\x4d\x91\xc9\x29\xda\x5d\x23\xfd\x50\x22\x22\x52\x44\x90\x36\x20\x96\xd8\x0f\x50\x55\x29\xd8\x62\xc5\x05\x80\x65\x25\xf7\x88\x28\x8a\xa4\xe0\x7b\x54\x6a\x50\x66\x02\xf9\xa2\xab\xd1\x23\x33\x39\x22\x3b\xc2\x38\x89\x19\xb7\xec\xf9\x08\x2e\xe8\xd5\xc2\x96\x6c\xee\xab\x69\x92\x61\xfb\x10\x1f\xfd\x72\x6f\xc6\xa9\xd8\x98\xdc\x75\x35\x4e\xe7\x25\x7a\x85\xd6\xe8\x35\xba\x42\xbf\xa1\xf5\x18\xff\x8b\x32\x86\x37\xc2\xec\x54\x23\x31\x4d\xf8\x38\x62\xc5\x58\x5f\x71\x76\x83\xf1\x84\xa8\x37\x6f\xd1\xb0\x8b\xf0\xc6\x17\x0f\x8e\x85\x6f\x35\x44\xee\x35\xb4\xb3\x2e\x52\x4b\x40\x69\x99\xee\xc1\x24\x7c\x77\x46\x6d\x2c\xa7\x61\x98\x30\xfa\xb6\x8a\xbb\x33\xfb\x3b\xd1\xde\x68\x30\x04\x2d\x52\xe1\x8a\x54\xe7\x76\x75\x60\x22\xaa\x55\x3f\xb3\x6d\x9b\xdf\x1c\x3a\x92\x0d\x3c\x7b\x57\x09\xba\xd5\xc3\xf2\xdf\x45\x85\xa4\x6f\x03\x9b\xb7\x99\x8b\x1f\xde\x5d\x3b\x08\x9b\xbf\xdf\x6e\x21\xb4\x3e\x40\xd0\x60\x32\xaa\x3e\xcb\x5c\x6d\x28\x21\x05\x49\xec\x69\x83\xc4\xec\xba\x51\x9f\x49\x63\xda\xf7\xbb\x8b\x49\x4e\x09\x37\x79\xe4\x24\xbd\xa1\x50\xfa\x05\xc1\x3f\xab\x96\x13\x04\x08\x1c\xf0\xa1\xa5\x4b\xbf\x9a\x4b\x6d\x02\xab\xa4\x60\x2b\x0f\x6b\x67\xf6\xaa\x9c\x67\xc2\x50\xb5\x23\xbd\x3d\x6f\xd6\x15\x44\x5b\x2a\x4b\x83\x82\x8b\xa5\xf0\x6f\xb0\xc7\x26\x07\x4e\xe4\x92\xa7\x76\xb1\x46\xa0\xa2\x94\x3d\x57\x89\x14\x19\xdb\x94\xca\x33\xa3\x0f\x4a\x28\x29\x54\xca\x90\x19\xa2\x8e\xb2\xf7\xd9\x8f\x60\x20\x3b\x63\x2a\x02\x83\x97\x5e\x5e\x5f\x1e\x45\xfd\x93\x95\x1b\xf9\x8e\xe4\x75\x7e\x67\x30\x69\x18\x2a\x52\x57\xb6\xda\xce\xc2\x5e\xf5\x9a\x5d\x6a\x1a\x3b\x08\x8b\x95\xbc\x47\x50\xd3\x9e\x62\xa4\x63\xe3\x6e\x7d\xae\x09\x68\x18\xfa\x6e\x04\xfc\x38\x19\x85\x13\x59\xba\x36\x34\x12\x91\x61\x0e\xed\x07\xa7\x91\xb0\x55\xb2\x99\xdb\xe0\x57\x4c\x92\x07\xeb\x61\x53\xda\x25\x1c\x80\xee\xeb\x0e\xbc\xa9\x74\xa2\x4a\x27\xb2\x3a\x03\x83\x16\x5b\xa8\xd0\x06\x9a\x87\x66\x92\x0c\x57\xa7\x99\xb3\x75\x14\x53\x68\xe7\xb5\xa9\x4e\xbd\x94\x0f\x8c\xba\x9b\x0a\x70\x3e\xcb\x98\xf0\x29\x60\xf9\x07\xd3\xf6\xba\x22\x6d\x05\x25\xb0\xe3\xdd\x7a\xf4\xc8\xf7\x0e\xbd\xa2\xef\x4b\x68\x81\x70\xf7\x30\xde\xa3\xab\xc6\x42\x4c\x71\xff\xc5\x02\xf9\xc5\x81\xa3\x35\x77\x37\x2c\x2c\xb3\x55\x83\x06\x32\x2b\x48\x44\x56\xc2\x6f\x9e\x12\x43\xba\xa4\xe8\xdd\xd1\x1c\xea\xcc\xe4\xaf\x65\xba\x72\xf5\xd3\x63\xb4\x5d\x50\x38\x83\x3c\x24\x20\xfb\x4e\x05\xe5\xec\xe8\x58\xd3\x11\xd7\xa6\xa2\xe4\x28\xf5\xf1\x28\xad\x4e\x90\xbc\x73\x22\x07\x88\x4f\x1c\xed\x89\xae\xba\x1b\xec\xaf\x01\x91\x7e\x66\x18\xe9\x73\x40\xaa\x84\x7b\x2c\xee\xef\xd3\x63\xf1\x97\x46\xd5\x66\xe0\x67\x04\x6a\xab\x20\x7c\x61\x4c\xeb\xcc\x04\x7d\xa0\x4d\xb2\x6e\xfc\x3d\x0d\xed\x59\xe7\xd9\x4d\xd4\x3e\x0d\xc2\x37\x6c\xf6\x45\x38\x7f\xfb\x2e\xd9\xb7\x41\xed\x48\x4f\x8c\x82\xf3\x53\x49\xa8\x44\xb5\x6f\x75\x67\x56\xa7\x91\xd2\x74\xd6\xfd\x6e\xbb\x06\x7d\xa5\x18\xe8\x6f\x18\x04\xfd\x23\x0a\x75\x82\xf9\x36\x41\xe8\xe5\xb6\x67\x1b\x03\x88\x02\x40\x5f\x40\x7b\x04\xc3\xaf\x9d\x8a\xeb\x69\xd8\xd9\xf4\xd3\x30\x4c\x39\x65\xcf\x7c\x60\x7c\xee\xde\xe2\xb4\x8c\xf6\xff\x77\x32\x62\x72\xc6\xdd\xd0\x7f\xd5\xb7\x0f\x16\x0e\x1b\x00\x00")
 
 func templatesCf_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -112,12 +113,12 @@ func templatesCf_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 6455, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 6926, mode: os.FileMode(480), modTime: time.Unix(1792398799, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xcd\x8e\xdb\x2a\x18\xdd\xfb\x29\x10\xba\xab\x2b\x25\x8a\x3a\x51\xeb\x2e\xb2\xa8\xba\xea\xae\x52\xbb\xb7\x30\x30\x19\x34\x0c\xa0\x0f\xc8\xb4\x1d\xe5\xdd\x2b\xec\xd8\xe3\xc4\xe0\x38\x9e\x4a\x8d\xd5\x64\x7d\x38\xe6\x3b\x3f\x36\x01\xb8\xd5\x1e\x28\x47\x98\xfc\xf2\xc0\xe1\xa9\x30\xbe\x94\x82\x16\xc2\x60\x84\xa9\x56\x54\x7b\xb0\x1c\xa3\x97\x0c\x21\x45\x9e\x38\x4a\xfd\x36\x08\xff\xf7\xb2\x23\xb0\xe4\x6a\x57\x08\xb6\x5f\xb4\x8b\x17\xb2\xc4\x19\x42\x52\x53\xe2\x84\x56\xc3\xab\x81\x6f\x85\x56\xfb\xb0\xa0\xd9\x5b\xb1\x05\xed\x4d\x71\xfc\xf4\x6a\x41\xb3\xe7\x63\xe4\xb2\xd4\xf6\x61\x19\xe0\x15\x4d\x3b\x50\x41\x18\x03\x6e\x6d\x41\x64\xbb\x97\x0d\xc2\xd6\x11\x27\x68\x40\xda\x47\x9f\x1c\x2f\x20\xbf\x39\xa2\x18\x01\x86\xb3\x0c\x21\x47\xb6\xb6\x52\x05\x21\xae\x76\x02\xb4\x7a\xe2\xca\xf5\x64\x08\xbc\xfb\x6c\x9f\x65\x7d\xa5\x65\x39\x4e\xe2\xb3\xca\xc6\x84\x1a\xaf\x4f\xcc\x97\x98\x1d\x31\x75\x4e\x45\xb9\x07\xad\x1c\x57\x2c\xa8\x4d\xb5\xba\x17\x5b\x0f\x35\x79\xad\x54\x34\x41\x03\xf3\x35\x7c\x0b\x61\x16\x47\x7c\xb8\xa2\xeb\x5b\x2b\xd8\xf1\xe4\x2d\x62\xd9\x92\x2e\xcf\xb9\x52\x80\x97\xbc\x6b\xcd\xe2\xc1\x39\x63\x27\x19\x54\xaf\xfc\x03\x1e\x11\x56\x12\x49\x14\xe5\x10\x66\xec\x35\x40\x96\xa7\x03\x0e\xb9\xd1\x79\xfc\x04\xdd\x0d\x68\xa7\xa9\x96\xe9\xa2\x7c\xff\xfc\x15\x77\x9f\x6f\x34\xb8\x18\x70\xbd\xbe\xcb\x10\x2a\x09\x7d\x4c\xa3\x0e\xb0\x0e\xae\xf1\xda\x68\x2d\x7b\x86\xcb\xb2\x88\xe1\xfa\xf6\x1b\xd0\x25\x6f\xb4\x3c\x35\xb3\xc3\x56\xe1\x96\x27\x8e\xd6\x24\xa9\xfc\x54\x4b\x66\x1f\xa0\xb8\xd1\xaf\xee\xc6\xec\xaa\xbd\xba\xa8\x57\x93\x55\xb9\xb5\x2a\xd1\xaa\x7c\x35\xa6\x54\xf9\xea\xba\x3a\x35\xa5\x52\xf3\xca\xce\xb4\x42\xe5\xab\xf1\x7d\xf2\x84\x4c\x92\x24\xac\xbb\xb5\x29\xd1\xa6\x91\x1f\xa9\xfc\xaa\xbe\x52\x9e\x90\x4b\x0b\x35\xb7\xf0\x4c\xac\xd3\x45\x1f\x28\x0a\x9c\x3d\xf8\x72\x92\x2e\xcd\xda\x5b\xb1\x12\xc5\xca\xd7\xeb\x51\xc5\x0a\xb8\xab\x29\xd6\xc1\xd5\x4b\xcb\x35\xd7\x20\x4d\x2c\x59\xb0\x2c\x2a\x8e\xe2\xee\x59\xc3\x63\x61\x39\xf5\x20\xdc\xcf\x4b\xcf\x84\x23\xe4\x6a\xbe\xef\x06\x84\x0e\x8f\x88\x2f\x7f\xb7\xfa\x98\x21\xc4\x04\x70\x9a\xb8\x94\xd8\x20\xfc\x45\x95\xda\x2b\x16\xd8\x08\xa5\xdc\xda\xe4\x66\x3e\x49\xa9\x9f\xcf\xf5\x23\xc8\x46\xab\xbd\x1d\x4c\x0a\xea\x15\x40\xd4\x96\x1f\xa3\xfe\x0f\x18\xc6\xad\x13\xaa\xae\xec\x29\x70\x83\x70\xbe\xea\x10\xb5\x59\x07\x7e\x2f\x7e\x0c\x10\x9d\x02\x1b\xcc\xd0\x5d\xcb\xf8\x80\xf5\xdc\x4d\xc5\x34\x0e\x3c\x62\x7b\x43\x7c\x06\xfe\x69\x8d\xcc\x8f\x1d\x13\xa0\x7c\xde\x01\x5a\xaf\xef\x6e\x09\x8a\x24\xe8\xec\xab\x3a\x99\xa1\xc3\x49\xe8\x5c\x6e\x3e\xcc\x3b\x37\xe1\xe5\x7e\x0b\x4e\x1b\x9c\xc1\x63\xf3\x5b\xa3\xf2\x7e\xe6\x51\xf9\x37\xdf\x31\x89\x43\xe8\x9b\x6f\xfb\x0f\xa4\x8b\x8a\xec\x6f\x9c\x06\xf7\x59\xa6\xbd\x33\xde\x75\x26\x09\xd3\x06\xce\x7a\xa2\x1d\x91\x9e\x0f\xd0\xbc\xca\x16\x25\x12\x26\x49\x13\xbd\xc9\x6f\x2f\xfe\x2b\xce\xdf\x01\x00\x00\xff\xff\xa3\x88\xc6\x67\xcd\x1a\x00\x00")
+var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x58\xcb\x8e\xd3\x30\x14\xdd\xf7\x2b\xa2\x88\x15\x52\xa2\x0a\x46\x50\x16\x2c\x10\x2b\x76\x48\xb0\x8f\x1c\xdb\xed\x58\x4d\xed\xc8\x8f\x0e\xc3\xa8\xff\xce\x75\x5e\x93\x34\x76\x9a\xa4\x48\x34\xa2\xb3\xaa\x34\xc7\x27\xbe\xe7\x11\x5b\x91\x54\x09\x23\x31\x0d\x42\xf4\xdb\x48\x2a\x0f\x49\x6e\xd2\x8c\xe1\x84\xe5\x61\x10\x62\xc1\x31\xfc\x5b\xd1\x30\x78\x59\x05\x01\x47\x07\x1a\xf8\xfe\x3e\x07\xe1\x9b\x97\x23\x92\x31\xe5\xc7\x84\x91\x53\xd4\x2c\x8e\xb2\x34\x84\xd5\x99\xc0\x48\x33\xc1\x87\x57\x4b\xba\x03\xcc\xc9\x2e\x90\xd5\xde\x92\x9d\x14\x26\x4f\xba\x4f\x2f\x16\xd4\x7b\xee\x22\xe3\x54\xa8\xc7\xd8\xc2\x0b\x9a\x66\xa0\x04\x11\x02\x48\x95\xa0\xac\xd9\x0b\xd0\x28\x0d\x3f\xb1\x45\xaa\xbd\x09\x86\xe6\xfb\xa1\x11\x27\x48\x92\x70\x05\x60\x8d\x76\xaa\x50\x25\x08\x60\x62\x26\x05\x3f\x50\xae\x7b\x32\x58\xde\xd3\xea\xb4\x5a\xc9\x9e\xd2\x20\xcb\x28\x89\x2f\x2a\xeb\x12\x6a\xbc\x3e\x2e\x5f\x5c\x76\xb8\xd4\x39\x17\x65\x0b\x32\x68\xca\x89\x55\x1b\x76\xb9\x65\x3b\x23\x4b\xf2\x52\x29\x67\x82\x06\xe6\xab\xf9\x22\x96\x47\x1d\xbe\xb0\xa0\xeb\x5b\xcb\x48\x77\xf2\x06\x11\x37\xa4\xf1\x25\x57\x12\x69\x32\xda\xb6\x26\x7a\xd4\x3a\x57\xb3\x0c\x2a\x57\xfe\x05\x8f\x10\x49\x51\x86\x38\xa6\xd2\xce\xd8\x6b\x40\x96\x9e\x0f\x38\xe4\x46\xeb\xf1\x33\x74\xcf\xa5\xd0\x02\x8b\xcc\x5f\x94\x9f\x5f\xbf\x87\xed\xe7\xe7\x42\x6a\x17\xf0\xe1\xe1\x3d\xc0\x52\x84\xf7\x7e\x54\x05\x6b\xe1\x6a\xaf\x73\x21\xb2\x9e\xe1\x60\x9f\x0b\xd7\xb7\x1f\xa6\x48\x69\xad\x65\xe0\x79\xb1\x00\x5b\x81\x8b\xcf\x1c\x2d\x49\x7c\xf9\x29\x96\x2c\x3e\x40\x6e\xa3\x5f\xdd\x75\xd9\x55\x7a\x35\xa9\x57\xb3\x55\xb9\xb7\xca\xd3\xaa\xcd\x7a\x4c\xa9\x00\x75\x53\x9d\x9a\x53\xa9\x65\x65\x67\x5e\xa1\xc0\xa7\xd1\x7d\x32\x08\xcd\x92\xc4\xae\xbb\xb7\xc9\xd3\xa6\x91\x87\xd4\xe6\xa6\x4e\x29\x70\x74\x6a\xa1\x96\x16\x9e\x99\x75\x9a\x74\x40\x61\x49\xc9\xa3\x49\x67\xe9\x52\xaf\xbd\x17\xcb\x53\x2c\xb0\x62\x54\xb1\x2c\xee\x66\x8a\x55\xb9\x3a\xb5\x5c\x4b\x0d\xd2\xcc\x92\x59\xcb\x9c\xe2\x70\xaa\x9f\x84\xdc\x27\x8a\x62\x23\x99\x7e\x9e\x7a\x27\x9c\x70\xbe\xe7\x92\x09\xfb\x08\xf7\xf2\x77\xeb\x4f\x80\x21\x4c\x52\xec\xf9\x28\x01\x8f\xf8\xc6\x53\x61\x38\xb1\x6c\x08\x63\x08\x93\x77\x33\x5f\xb2\x4c\x3c\x5d\xea\x87\x95\x0d\x17\x7b\xab\x4c\xb2\xea\x25\x12\xf1\x1d\xed\xa2\xde\x5a\x0c\xa1\x4a\x33\x5e\x56\xf6\x1c\x08\x98\xcd\xba\x45\xd4\x64\x5d\xd2\x2d\xfb\x35\x40\x74\x0e\xac\x31\x43\xdf\x5a\xc6\x07\xac\xe7\xae\x2f\xa6\x6e\x60\x87\xed\x8a\xf8\xa8\xab\xf3\xa3\xc6\x04\x68\xb3\xec\x00\xc1\x41\x78\x4f\xd0\xf3\x8c\x33\xdf\x9b\xa1\xea\x26\x74\x29\x37\x1f\x17\xfe\xe2\x81\x97\xfb\x3d\x38\x4d\x70\x06\xaf\xcd\xd7\x46\xe5\xc3\xc2\xa3\xf2\x7f\xbe\x63\x3c\x97\xd0\xab\xbf\xf6\x57\xa4\x51\x41\xf6\x2f\x6e\x83\x30\xad\x30\x3a\x37\xba\x35\x89\x9d\xd6\x72\x96\x13\x1d\x51\x66\xe8\x00\xcd\xab\x6c\x4e\x22\x96\x7b\x69\x9c\x5f\xf2\x9b\x0f\xff\x05\xe7\x1f\xa3\x88\xc6\x67\xcd\x1a\x00\x00")
 
 func templatesConcourse_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -132,7 +133,27 @@ func templatesConcourse_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 6861, mode: os.FileMode(480), modTime: time.Unix(1533589346, 0)}
+	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 6861, mode: os.FileMode(480), modTime: time.Unix(1792398799, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDisk_encryptionTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x53\xcd\x8e\xd3\x30\x10\xbe\xe7\x29\x46\x16\xc7\xd6\xe5\x8c\x00\x89\x33\x37\x90\xb8\x20\x14\xb9\xce\x90\x9a\x4d\x6c\xcb\x63\x07\x85\x55\xde\x9d\xb1\x93\x34\x61\x29\x85\xa5\x87\xca\x89\xe7\xfb\xb5\x33\xa8\x60\xd4\xb9\x43\x10\x8d\xa1\x87\x1a\xad\x0e\xa3\x8f\xc6\xd9\xfa\x01\x47\x01\x8f\x15\x40\x1c\x3d\xc2\xf2\x7b\x03\x82\x62\x30\xb6\x15\xbc\xd1\x20\xe9\x60\xca\x74\xde\x78\x8f\x23\x7c\x52\xa9\x8b\xc0\x50\x30\x0d\xc4\x8b\x8a\xb0\x30\x12\x3f\x21\x64\x0d\x02\xf7\x15\xce\x8e\x2e\x30\xf4\x74\x00\x94\xad\x84\x4b\x8c\x9e\x5e\x9d\x4e\xe4\x7a\x3c\x0e\x99\x43\xce\xff\xea\x47\x0a\x28\x2d\xc6\x13\x73\xd2\xbc\xcf\xab\xd3\xeb\x01\x03\xb1\xee\x5b\x51\x4d\x55\x35\xdc\x0b\x51\x17\xa6\xda\x34\xcf\x4b\xf3\x01\xc9\xa5\xa0\x31\x07\x61\xc3\xd9\xfd\x16\x70\x79\xc1\xec\x07\x88\x0e\x3a\x8c\xd7\x78\xb0\xa9\x03\xf1\xfb\x44\xb8\xce\x16\xaf\x61\xe5\x15\x25\x5b\xe8\xeb\xa7\x9e\x19\x25\x40\xe4\x86\x66\xc7\x56\xf5\x57\xc7\x3b\xe7\x2f\x1e\x39\xb6\x44\x3b\x70\xb4\xe9\x98\x49\x8e\x1b\xc9\x31\x93\x30\x76\x55\xab\xdb\xe0\x92\xaf\x0b\x55\xc1\xae\xe2\xbf\x0e\xc8\xac\x2a\xf3\xd4\x94\xd1\x9d\xd3\xaa\x04\xb9\xa5\x1c\xb0\xe5\xad\x32\xb7\xb5\x9c\x57\x5c\xd8\x7e\xee\xc6\x91\x30\x88\x51\xa6\x41\x1b\x4d\x1c\x4b\xc8\xe5\x60\x18\xf5\x71\xa4\x88\xfd\x3b\x22\xd3\x5a\x6c\x32\xfd\x74\xbb\xb7\x4d\x55\x69\x8d\x44\xb5\x77\x9d\xd1\xe3\xd2\xdd\xd3\x5a\xe7\x2e\xf7\xf7\xe1\xae\xc5\xeb\x54\x09\x18\xd1\x2a\x1b\xf7\xc9\xee\x1c\xde\x5c\xe2\x9a\x4e\xbe\x94\x57\x74\xe1\x72\xe7\x6f\xa8\xff\x9b\xcb\xf3\x75\xd5\xc6\xab\x6e\xa6\x5b\x22\x79\x86\x1b\xca\x1f\x04\x31\xe5\x67\xd1\xf2\xe9\x1f\x40\x7c\x0f\xca\xe7\x7b\xc7\xcb\x64\xd7\x87\x2f\xb9\x4d\x97\xa2\x4f\xf1\xf7\xef\x85\x25\xcb\x25\x99\xdb\x1a\x54\x97\xf0\x1f\x3d\x2e\x97\xe6\x2f\xdc\x7b\xfb\xcf\xd7\xf8\x73\x0f\x53\xf5\x13\xd1\x22\x5b\x4a\xc9\x04\x00\x00")
+
+func templatesDisk_encryptionTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDisk_encryptionTf,
+		"templates/disk_encryption.tf",
+	)
+}
+
+func templatesDisk_encryptionTf() (*asset, error) {
+	bytes, err := templatesDisk_encryptionTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/disk_encryption.tf", size: 1225, mode: os.FileMode(480), modTime: time.Unix(1792402144, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -257,7 +278,7 @@ func templatesTlsTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\xc4\x20\x10\x40\xef\x7e\xc5\x20\x3d\xa7\xcd\xa5\xb7\x7e\x4b\x30\x3a\x2d\x43\xcd\x18\x46\x63\xa1\x21\xff\x5e\x12\xc1\xee\x8a\x2c\x9b\xdc\xe6\xbd\x07\xce\x64\x23\x64\x66\x8f\xa0\x91\xf3\x44\x4e\xc3\x7e\x28\xf5\x3f\x15\xfc\xa2\xc0\xed\x34\xd2\xb2\x7a\x9c\xfa\x49\xdc\xe6\x68\x85\xd6\x44\x81\x3b\x38\x21\x1b\x4e\x1d\x60\x3d\xe1\x23\x10\xd1\x0a\xa6\x16\x32\xa6\x9f\x20\xdf\x93\x25\x27\x1a\x76\x05\xe0\xf0\xd3\x6c\x3e\xc1\x07\xe8\xf1\x6d\xb8\xfe\xd7\xf1\x5d\xab\xbb\x8c\x38\xa1\xb0\xf1\xcf\x75\xab\x84\x4c\x0e\x05\xb4\xf9\xdd\x04\x65\x29\x45\xb3\xe9\x59\xbe\xec\xd9\xc8\xd0\x80\x43\x2b\x80\xba\x37\x94\xaf\xca\x15\x5c\x5a\xbd\x42\xab\x55\x70\xab\x95\x9b\x74\xb4\x02\x8e\xf3\xf5\x7f\x01\x00\x00\xff\xff\x59\x69\xa5\xda\xe3\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x91\xc1\x4e\xc3\x30\x0c\x86\xef\x7d\x0a\x2b\xe2\x08\x83\x5d\xb8\x71\xe0\x39\x10\xaa\xdc\xc4\x03\x8b\x2c\xa9\x1c\xb7\x68\x9b\xfa\xee\xb4\x09\x0a\xa3\xaa\x90\x48\x2e\x91\xbf\xff\xb7\xfe\xd8\x23\x0a\x63\xe7\x09\x0c\x85\xb1\x65\x67\xe0\x32\x35\xcd\x58\xab\x42\x6f\x1c\xc3\xba\x9a\xf8\xd8\x7b\x6a\xb7\x2d\x69\xe8\x92\x15\xee\x75\x36\x6e\x60\xa5\x80\x41\x37\x80\xf5\x4c\x7f\x81\x44\x56\x48\xd7\x30\x90\x7e\x46\xf9\x68\x2d\x3b\x99\x59\x03\xe0\xe8\x80\x83\x57\x78\x02\xb3\x7f\xd8\xe5\x7b\xbf\x7f\x34\xcd\x2f\x1b\x07\x25\x09\xe8\xff\xeb\x3b\xc7\x40\xa9\xe8\xf5\xd4\x13\x7c\x9f\xd9\xe3\x39\xa9\xb9\x6a\x53\xca\x2f\xaf\xb9\x54\x07\xb2\x28\x9f\x47\x64\x8f\x1d\x7b\xd6\x13\xe4\x86\x10\x0f\xa0\xef\x04\x65\xda\xb7\xf9\xed\x23\x3a\xe8\xd0\x63\xb0\x24\x09\x50\x28\x6b\xef\x84\xdc\x10\xdc\x3c\x42\x40\x2b\x31\xa5\x45\x7c\xcc\x29\x7b\x89\x23\x3b\x12\x30\x78\x1e\x84\xe4\x58\x72\xae\xf6\xb1\x24\xb8\xb9\xcc\x3f\xda\xad\xc0\xb4\xa4\xaf\xdb\xf9\xf9\x58\x11\x57\x90\x65\x75\x57\x6b\x59\x05\xd7\xb2\xb2\xb9\x0d\x59\x01\xd3\x92\xfe\x0b\xef\x57\x38\xa9\x89\x02\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -272,7 +293,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 483, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 649, mode: os.FileMode(480), modTime: time.Unix(1792397776, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -332,6 +353,7 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/disk_encryption.tf": templatesDisk_encryptionTf,
 	"templates/network.tf": templatesNetworkTf,
 	"templates/network_security_group.tf": templatesNetwork_security_groupTf,
 	"templates/output.tf": templatesOutputTf,
@@ -385,6 +407,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"disk_encryption.tf": &bintree{templatesDisk_encryptionTf, map[string]*bintree{}},
 		"network.tf": &bintree{templatesNetworkTf, map[string]*bintree{}},
 		"network_security_group.tf": &bintree{templatesNetwork_security_groupTf, map[string]*bintree{}},
 		"output.tf": &bintree{templatesOutputTf, map[string]*bintree{}},
@@ -63,21 +63,29 @@ resource "azurerm_network_security_rule" "cf-log" {
   network_security_group_name = "${azurerm_network_security_group.cf.name}"
 }
 
+# Zone-redundant application gateways need the v2 sku and a static standard
+# ip, so they are only used in regions with availability zones.
+locals {
+  cf_zone_redundant = "${length(var.zones) > 0 ? 1 : 0}"
+}
+
 resource "azurerm_public_ip" "cf" {
   name                         = "${var.env_id}-cf-lb-ip"
   location                     = "${var.region}"
   resource_group_name          = "${azurerm_resource_group.bosh.name}"
-  public_ip_address_allocation = "dynamic"
+  public_ip_address_allocation = "${local.cf_zone_redundant ? "static" : "dynamic"}"
+  sku                          = "${local.cf_zone_redundant ? "Standard" : "Basic"}"
 }
 
 resource "azurerm_application_gateway" "cf" {
   name                = "${var.env_id}-app-gateway"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
   location            = "${var.region}"
+  zones               = ["${var.zones}"]
 
   sku {
-    name     = "Standard_Small"
-    tier     = "Standard"
+    name     = "${local.cf_zone_redundant ? "Standard_v2" : "Standard_Small"}"
+    tier     = "${local.cf_zone_redundant ? "Standard_v2" : "Standard"}"
     capacity = 2
   }
 
@@ -0,0 +1,36 @@
+variable "disk_encryption_key" {
+  type        = "string"
+  description = "Key Vault key id that encrypts the disks of bosh vms, e.g. https://some-vault.vault.azure.net/keys/some-key/<version>"
+}
+
+variable "disk_encryption_key_vault_id" {
+  type        = "string"
+  description = "Resource id of the Key Vault of the key, to let the disk encryption set use the key"
+}
+
+resource "azurerm_disk_encryption_set" "bosh" {
+  name                = "${var.env_id}-disk-encryption-set"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  location            = "${var.region}"
+  key_vault_key_id    = "${var.disk_encryption_key}"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+
+resource "azurerm_key_vault_access_policy" "bosh_disk_encryption" {
+  key_vault_id = "${var.disk_encryption_key_vault_id}"
+  tenant_id    = "${azurerm_disk_encryption_set.bosh.identity.0.tenant_id}"
+  object_id    = "${azurerm_disk_encryption_set.bosh.identity.0.principal_id}"
+
+  key_permissions = ["get", "wrapkey", "unwrapkey"]
+}
+
+output "disk_encryption_set_name" {
+  value = "${azurerm_disk_encryption_set.bosh.name}"
+}
+
+output "disk_encryption_set_principal_id" {
+  value = "${azurerm_disk_encryption_set.bosh.identity.0.principal_id}"
+}
@@ -20,6 +20,12 @@ variable "internal_cidr" {
   default = "10.0.0.0/16"
 }
 
+variable "zones" {
+  type        = "list"
+  default     = []
+  description = "Availability zones of the region, the load balancers are zone-redundant across them"
+}
+
 provider "azurerm" {
   subscription_id = "${var.subscription_id}"
   tenant_id       = "${var.tenant_id}"
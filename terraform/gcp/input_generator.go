@@ -20,6 +20,14 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"system_domain": state.LB.Domain,
 	}
 
+	if state.Network.DualStack {
+		input["dual_stack"] = true
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		input["disk_encryption_key"] = state.Director.DiskEncryptionKey
+	}
+
 	if state.LB.Cert != "" && state.LB.Key != "" {
 		input["ssl_certificate"] = state.LB.Cert
 		input["ssl_certificate_private_key"] = state.LB.Key
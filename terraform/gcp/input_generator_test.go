@@ -51,6 +51,24 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 
+		It("turns on dual stack when the state asks for it", func() {
+			state.Network.DualStack = true
+
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["dual_stack"]).To(BeTrue())
+		})
+
+		It("passes the disk encryption key when the state has one", func() {
+			state.Director.DiskEncryptionKey = "projects/p/locations/us-east1/keyRings/r/cryptoKeys/k"
+
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["disk_encryption_key"]).To(Equal("projects/p/locations/us-east1/keyRings/r/cryptoKeys/k"))
+		})
+
 		Context("when cert and key are provided", func() {
 			BeforeEach(func() {
 				state.LB.Cert = "some-cert"
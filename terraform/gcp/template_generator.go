@@ -8,12 +8,18 @@ import (
 )
 
 type templates struct {
-	vars         string
-	jumpbox      string
-	boshDirector string
-	cfLB         string
-	cfDNS        string
-	concourseLB  string
+	vars              string
+	jumpbox           string
+	jumpboxDNS        string
+	directorAccess    string
+	boshDirector      string
+	cfLB              string
+	cfDNS             string
+	concourseLB       string
+	directorBlobstore string
+	directorDatabase  string
+	dualStack         string
+	diskEncryption    string
 }
 
 type TemplateGenerator struct{}
@@ -25,7 +31,13 @@ func NewTemplateGenerator() TemplateGenerator {
 func (t TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 
-	template := strings.Join([]string{tmpls.vars, tmpls.boshDirector, tmpls.jumpbox}, "\n")
+	template := strings.Join([]string{tmpls.vars, tmpls.boshDirector}, "\n")
+
+	if state.NoJumpbox {
+		template = strings.Join([]string{template, tmpls.directorAccess}, "\n")
+	} else {
+		template = strings.Join([]string{template, tmpls.jumpbox}, "\n")
+	}
 
 	switch state.LB.Type {
 	case "concourse":
@@ -38,9 +50,29 @@ func (t TemplateGenerator) Generate(state storage.State) string {
 
 		if state.LB.Domain != "" {
 			template = strings.Join([]string{template, tmpls.cfDNS}, "\n")
+
+			if !state.NoJumpbox {
+				template = strings.Join([]string{template, tmpls.jumpboxDNS}, "\n")
+			}
 		}
 	}
 
+	if state.Director.ExternalBlobstore() {
+		template = strings.Join([]string{template, tmpls.directorBlobstore}, "\n")
+	}
+
+	if state.Director.ExternalDatabase() {
+		template = strings.Join([]string{template, tmpls.directorDatabase}, "\n")
+	}
+
+	if state.Network.DualStack {
+		template = strings.Join([]string{template, tmpls.dualStack}, "\n")
+	}
+
+	if state.Director.DiskEncryptionKey != "" {
+		template = strings.Join([]string{template, tmpls.diskEncryption}, "\n")
+	}
+
 	return template
 }
 
@@ -90,10 +122,16 @@ func readTemplates() templates {
 	tmpls := templates{}
 	tmpls.vars = string(MustAsset("templates/vars.tf"))
 	tmpls.jumpbox = string(MustAsset("templates/jumpbox.tf"))
+	tmpls.jumpboxDNS = string(MustAsset("templates/jumpbox_dns.tf"))
+	tmpls.directorAccess = string(MustAsset("templates/director_access.tf"))
 	tmpls.boshDirector = string(MustAsset("templates/bosh_director.tf"))
 	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
 	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
+	tmpls.directorBlobstore = string(MustAsset("templates/director_blobstore.tf"))
+	tmpls.directorDatabase = string(MustAsset("templates/director_database.tf"))
+	tmpls.dualStack = string(MustAsset("templates/dual_stack.tf"))
+	tmpls.diskEncryption = string(MustAsset("templates/disk_encryption.tf"))
 
 	return tmpls
 }
@@ -123,7 +123,7 @@ resource "google_compute_instance_group" "router-lb-2" {
 		Context("when a CF LB is provided with a domain", func() {
 			BeforeEach(func() {
 				expectedTemplate = expectTemplate("vars", "bosh_director", "jumpbox", "cf_lb")
-				dns := expectTemplate("cf_dns")
+				dns := expectTemplate("cf_dns", "jumpbox_dns")
 				expectedTemplate += "\n" + instanceGroups + "\n" + backendService + "\n" + dns
 
 				state = storage.State{
@@ -139,6 +139,62 @@ resource "google_compute_instance_group" "router-lb-2" {
 				checkTemplate(template, expectedTemplate)
 			})
 		})
+
+		Context("when the director uses an external blobstore and database", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "bosh_director", "jumpbox", "director_blobstore", "director_database")
+				state = storage.State{
+					Director: storage.Director{Blobstore: "external", Database: "external"},
+				}
+			})
+			It("adds the gcs bucket and cloud sql instance templates", func() {
+				template := templateGenerator.Generate(state)
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when dual stack is on", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "bosh_director", "jumpbox", "dual_stack")
+				state = storage.State{Network: storage.Network{DualStack: true}}
+			})
+			It("adds the ipv6 firewall rule and outputs", func() {
+				template := templateGenerator.Generate(state)
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when a disk encryption key is provided", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "bosh_director", "jumpbox", "disk_encryption")
+				state = storage.State{Director: storage.Director{DiskEncryptionKey: "some-key"}}
+			})
+			It("lets compute engine use the key", func() {
+				template := templateGenerator.Generate(state)
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when there is no jumpbox", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "bosh_director", "director_access", "cf_lb")
+				dns := expectTemplate("cf_dns")
+				expectedTemplate += "\n" + instanceGroups + "\n" + backendService + "\n" + dns
+
+				state = storage.State{
+					NoJumpbox: true,
+					GCP:       storage.GCP{Zones: []string{"z1", "z2", "z3"}},
+					LB: storage.LB{
+						Type:   "cf",
+						Domain: "some-domain",
+					},
+				}
+			})
+			It("opens the director to the access cidrs and omits the jumpbox resources", func() {
+				template := templateGenerator.Generate(state)
+				checkTemplate(template, expectedTemplate)
+			})
+		})
 	})
 
 	Describe("GenerateBackendService", func() {
@@ -4,7 +4,13 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/director_access.tf
+// templates/director_blobstore.tf
+// templates/director_database.tf
+// templates/disk_encryption.tf
+// templates/dual_stack.tf
 // templates/jumpbox.tf
+// templates/jumpbox_dns.tf
 // templates/vars.tf
 // DO NOT EDIT!
 
@@ -73,7 +79,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x96\xdd\x6e\xa2\x40\x14\xc7\xef\x79\x8a\x93\xc9\x5e\x6c\x13\xcb\xb6\x54\xd4\xbd\xf0\x49\x8c\x99\x0c\x70\xa4\x6c\x47\x86\x0c\x07\x6d\xd3\xf0\xee\x9b\xe1\x1b\x45\x16\xdd\x9a\xb6\x17\x28\x9c\x8f\xff\xf9\xf1\x3f\x66\x0e\x42\x47\xc2\x93\x08\x2c\xcd\xbc\x18\x89\xfb\x51\xa0\x19\x7c\x5a\x00\xf4\x91\x20\x00\xc0\x1a\x58\x4a\x3a\x8a\x43\x66\x01\x04\xb8\x13\x99\x24\x73\xf3\xf9\xc9\x2e\xfe\x7f\x3d\x2f\x98\x95\x5b\x96\xc6\x54\x65\xda\x47\x60\xa1\x52\xa1\x44\xee\xab\x7d\x92\x11\xf2\x18\xe9\xa8\xf4\x1b\x03\xe6\x79\xf2\xb1\xf9\x66\x7a\xc4\x62\x5f\xf4\x38\xfd\x5b\x03\xfb\xf1\x79\x10\xda\xc6\xf8\xc0\xa3\x20\x6f\xb2\x2c\x00\x91\x91\xe2\xbe\x46\x41\xc8\x4b\xd1\xe6\x49\x0a\x6b\xd8\x09\x99\xe2\xa8\x94\x36\xbe\x52\x53\xde\x18\x12\x73\x26\xa1\x0a\xb5\x00\xa2\xa4\xc0\xc4\xb5\x88\x43\x6c\x03\x3b\x04\x73\x13\x56\x75\xea\xd6\x1b\x26\x63\x77\xb8\xd8\x29\xca\x1d\x97\x51\xfc\x96\x8f\x53\xdd\x45\x1a\x8f\x42\x4a\x06\x0c\xdf\x09\x75\x2c\x64\x7f\x8c\xb3\x01\x9a\xb0\x8e\xb6\xa9\xaa\x4c\xd1\x9c\x59\x16\x40\x29\xa7\x9c\xdd\x40\xdf\xb0\xda\x07\x4f\x6c\x6b\x02\x84\x94\xea\x58\x28\x01\x48\x94\xa6\xb4\x14\xb3\x61\x8e\xc3\x66\xc0\x16\xab\xc5\xca\x5c\x1d\xd7\x75\x5d\xb6\x2d\xc3\xb4\x22\xe5\x2b\x69\xe4\x90\x9f\x18\x81\xb9\x29\x45\x42\x87\x48\x9c\x44\x58\x76\xea\xcf\xe3\xa9\xf4\xf5\x51\x25\x18\xb3\xed\x54\x52\x6d\xca\x38\xaa\x36\xee\x2b\x58\x4d\xd0\x3f\x9d\xdb\x6a\x3e\x7f\x29\xae\xab\xf9\xfc\x0b\x39\x06\x91\x46\x9f\x94\xbe\x92\x65\x93\x36\x81\x67\x13\x7b\x6f\xa6\x9d\x59\x4e\xb9\xde\x04\x28\x8a\xab\xc5\x99\xcc\xa6\xce\x78\x24\x35\x15\xd1\x60\xca\x1d\x49\x75\x86\x1a\x33\xdf\xdc\x29\xed\xe7\xb8\x8e\xfb\x54\x7e\x58\x2e\x97\xdf\xe1\xb7\x3f\xd9\x3e\xf1\xd4\xbb\xe1\x53\xdc\x18\xa5\x79\x12\x7c\x47\x8e\x55\xa7\x49\x3b\xfc\xf2\xb2\xfa\xfd\x5f\xe8\x9a\x97\x36\x1b\xdf\xb0\xab\x8d\x3a\xd1\x9c\xdf\x64\xc8\x0e\xab\xc8\xdf\xb7\xb0\xa6\xac\xf6\xa5\x98\x2c\xb8\x6d\xfd\x55\x46\x49\x46\xc0\x7a\xe7\x98\x83\x90\x19\x5e\x4b\xa4\x53\xac\x7b\x2e\x19\xaf\xd7\x46\xda\xed\xf9\x65\xa0\x62\x2d\xba\x73\xa0\xeb\x14\x3d\x3f\xae\x0c\xa5\x86\xc7\xdb\xd4\x84\x82\xf0\x28\x3e\xb8\x08\x02\x8d\x69\xda\xaf\x5e\x7b\x94\x1b\xcd\x27\xf5\x0b\x13\xf7\xe0\xf7\x52\xab\x55\xe3\xbc\x51\x18\x25\x67\x0a\xcd\x3c\xaf\x2a\xa5\x9f\x27\x33\xce\xc0\x7d\xb8\xa0\xe4\xc6\x7a\x8b\x87\x0b\xf2\x8c\x8f\xfa\x75\x36\x85\xfd\xce\xf9\xd5\x9b\x68\x37\x67\x81\xea\x55\xce\xea\x84\xa1\x9f\x1a\xf3\x70\x3b\x3c\xc9\x40\xeb\x7f\x75\xad\x93\xab\xce\xdb\x41\x2b\x90\x08\x87\x5e\xd8\xe5\xd2\x75\x66\x6b\xcd\xbf\x01\x00\x00\xff\xff\x9d\xd6\x29\xe9\x6b\x0c\x00\x00")
+var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x95\x5d\x6b\xdb\x30\x14\x86\xef\xf3\x2b\x84\xd8\xc5\x06\x8d\xd7\x86\x7c\xc0\x60\x8c\x5e\xf4\xa2\x50\xba\x32\xc6\x58\x09\x45\x28\xb2\xe2\x68\x55\x24\x23\xc9\x4e\x4b\xc9\x7f\xdf\x91\x3f\x62\x39\xb1\x43\x1a\x18\x4b\x20\xc4\xf2\x39\xef\x79\xf5\x9c\x63\x39\xa7\x46\xd0\x85\xe4\x08\xdb\x6c\xa1\xb8\x23\x4c\xc4\x06\xa3\xb7\x01\x42\xee\x35\xe5\x08\x3e\x5f\xe1\x9e\x33\x42\x25\x18\x16\x63\xbe\xa4\x99\x74\x7e\xf1\xea\x32\x2a\xbe\x9f\xaf\xa6\x78\xb0\x1d\x0c\x0c\xb7\x3a\x33\x0c\xa4\x12\xad\x13\xc9\x09\xd3\xeb\x34\x73\x9c\x80\xec\x46\x9b\x67\x8c\xf0\x62\x21\x87\xbb\x2b\x5f\x43\xd1\x75\x51\x63\xff\x03\xf2\x1f\xde\x72\x6a\x22\xae\x72\x22\xe2\xed\x2e\x0b\x72\x68\xe6\x34\x61\x86\x53\x90\x2e\x4d\xfb\x3b\x16\x72\x96\x54\x5a\xee\xad\xe4\xbb\x5d\xc5\x19\x95\xc4\x3a\xca\xaa\x82\xb5\xff\xb2\x48\x99\xe0\x57\x2d\x33\x22\x75\x42\x2b\x5f\xfa\xda\x5a\x91\x28\x44\x15\xba\x7d\xc8\xa7\xc8\x23\x41\x86\xaa\x84\x23\xa7\x91\x5b\x71\xd4\x94\xbd\x40\x8a\xbf\x38\xbf\x0e\xa1\xe3\xe3\x1c\x9a\xac\x0a\x45\xb9\xd0\x45\xe2\x60\xff\x55\x28\x04\x8a\xb4\xe8\x11\x29\x0d\xed\x02\x83\xf6\x6d\x7d\x58\x55\x29\xd4\xeb\x6e\x4b\x14\x34\x25\xb2\x5c\x2e\x89\x14\xea\x19\x34\x40\xa4\x00\x47\xea\x41\x08\x7d\x35\x5c\xd1\x37\x84\x6f\x1f\x7e\x8d\x09\xfc\x4c\x31\xfa\x52\x5d\x7d\xbf\xbf\x7b\xc4\xdb\xd2\x70\x3e\x25\x94\x31\x6e\x6d\x29\xd5\x23\x72\xf3\xfb\xe7\xcd\x8f\xfb\xeb\xbb\x42\xc3\xa7\x1e\x63\xb9\x14\x86\x6f\xa8\x94\x9e\xa4\xb6\xab\x61\x0c\xd7\xcc\x69\xd3\x86\x79\x80\xb1\x1d\x1b\x60\x3a\x15\x90\x57\xae\xd8\x14\xc6\x88\xa3\x89\x1f\xbd\xf9\xd1\x42\x4f\x3e\x01\xdc\xea\x4d\x61\x0f\xa1\xd4\x68\xa7\x99\x96\xbe\xae\x63\xa9\x77\xb2\xf5\x31\x8e\x9a\x04\xba\xd8\x23\x2a\x94\xe3\x46\x51\x09\x7a\x27\xb2\xa9\x33\x86\x4e\x9f\x8a\xa8\x33\xe5\x1f\x92\x0a\x36\xb5\x0f\x49\x1b\x67\x4b\x8f\x73\x3c\x1e\x8d\x46\xf8\x02\xe1\xd1\x64\x34\xb9\x2c\xff\xcc\x66\x33\x48\x3a\x1f\xe7\x7e\x8f\xde\xc9\xf4\x44\x8e\xff\x89\x5d\x40\x44\xb0\x75\x83\xe4\x94\x29\xec\x8b\xc9\xe2\xf3\x26\x55\x67\x0e\xb6\x8a\x70\xeb\xe8\xcf\xa9\xcc\xf8\x7b\x89\x04\x62\xe1\x69\x7a\x5c\xaf\x89\x8c\x9a\x53\xb7\x43\xb1\x36\x1d\xbc\x03\x03\xd1\xc3\x43\xb6\x2b\x35\xd9\x9c\xe7\x26\x81\xb7\xd9\x86\xbe\x12\x1a\xc7\x30\x83\xb6\xad\x5e\xcf\x28\xf1\x9e\xf7\xf4\x8b\x21\x6e\xc1\x6f\xa5\xfe\xc9\xd6\xe9\x42\xbf\x10\xb2\x73\x28\xd2\x03\x87\x7e\x3f\x2b\x6d\xdd\xc7\xbd\x3d\x5e\xa0\xc9\xa7\x1e\x27\x67\xea\x4d\x7b\xf5\xfc\x20\xb5\x85\xe6\x87\xec\xea\xa7\x30\x6a\x3d\xba\x55\x2b\x9f\x3a\x1b\x02\xba\x5d\xd8\xfa\xa5\xeb\xcc\x66\x40\xfe\x02\xcc\x6f\xd6\xf0\x24\x09\x00\x00")
 
 func templatesBosh_directorTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,12 +94,12 @@ func templatesBosh_directorTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 3179, mode: os.FileMode(480), modTime: time.Unix(1533595563, 0)}
+	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 2340, mode: os.FileMode(480), modTime: time.Unix(1792396207, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\xcf\x8a\xdb\x30\x10\x87\xef\x7a\x8a\x41\xf4\x54\xb0\x59\xe8\x79\x0f\x85\x9e\x7b\xe9\xb1\x2c\x46\x91\x26\x8e\x8a\xac\x11\x33\xb2\xb3\xe9\xe2\x77\x2f\x72\xe2\x6c\xd2\xad\xe9\xfa\x10\xc8\x61\x73\xc9\x1f\xfd\x34\xf3\xcd\xa7\x60\x34\x18\xf6\x66\x13\x10\xb4\x1c\x24\x63\xd7\x38\xea\x8c\x8f\x1a\x5e\x14\x40\x3e\x24\x84\x47\xd0\x92\xd9\xc7\x56\xab\x51\x29\x46\xa1\x9e\x2d\x82\x6e\x89\xda\x80\x8d\x8b\xd2\x74\x26\x9a\x16\x5d\xf3\x9b\x22\x6a\xd0\x18\x87\xe9\xe7\xe3\xd7\x52\x28\x9a\x0e\xe1\xf4\x7a\x04\xfd\xe9\x65\x30\x5c\x97\x98\x77\x63\x35\xc5\x14\x40\xd9\x32\x07\xcf\xa1\x2b\xaa\xb1\x9e\x72\x28\x96\x7d\xca\x9e\x62\xc9\x7d\xfb\xfe\x03\x4a\x09\xd8\x12\x43\xde\x21\x5c\x55\x07\x8c\x83\x67\x8a\x1d\xc6\x3c\x0d\x40\x7d\x4e\x7d\xfe\x6b\xdc\x09\x57\x90\x07\x64\x39\x12\x0f\x26\xf4\x78\xc4\x58\x18\xb4\xbe\x1c\xb3\x2e\xe0\x73\x85\x71\xd9\x14\xa3\x25\x76\x8d\x60\xd6\xa0\xf7\x3e\x38\x6b\xd8\x55\x2e\xca\x1b\x4f\x8f\xa0\x3f\xd7\xef\x6c\x3e\x9b\x1b\x8f\x7a\x12\x46\x27\xcd\x64\xe7\xe7\xdc\xdc\x52\x97\xfa\x8c\x4d\x1b\x68\x63\x42\x63\x9c\x63\x14\xa9\xed\xb6\x3a\x7d\xd4\x4f\xf3\x81\x9f\xfb\x7f\x2d\xe5\x72\x0e\xaf\x27\xf7\xe5\xe1\x41\x29\x80\x4b\x92\x95\x8e\x46\x5d\x0a\x30\x3b\x93\x8d\x4c\x80\xe7\xcd\xff\x45\xac\x4f\xef\xa3\x7e\x7a\x9f\xe0\x0d\xc9\x6e\x49\x6e\x59\xbb\x81\xdf\x19\xf5\x57\xdf\xa5\x0d\x3d\x57\x3e\xdd\x8f\xd8\xb7\x6c\xab\x8d\xda\x6d\x25\xb2\xab\x12\xd3\xf3\xe1\x5f\x56\xe5\xa6\x52\xaf\xba\xdf\x9d\xd6\x4b\xba\xd5\x62\xb3\x4d\x4b\xff\xd4\x6c\xd3\x6d\x9d\x96\xde\x4c\x7d\x46\xbe\x4b\xa9\xaf\x78\xab\xad\x3a\x4a\x29\x20\x2f\x99\x3d\x2d\xdf\xd6\xee\xfe\x8e\x1e\xad\x57\x58\xab\x6d\x06\x6a\x5b\xc6\xd6\x64\x5a\x34\x7a\x11\xf9\xb0\xba\xf2\x16\xb0\x97\xe5\x8b\xc0\x5e\x3e\x74\xaa\x51\xfd\x09\x00\x00\xff\xff\xab\x21\x00\xec\xae\x0a\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x95\x3d\x6b\xc3\x30\x10\x86\x77\xff\x0a\x61\x3a\x15\x6c\x02\x9d\x3b\x14\x3a\x77\xe9\x58\x8a\x51\xac\x8b\x62\x90\x25\x71\x92\xed\xa6\xc1\xff\xbd\x27\x7f\x24\x4e\x1b\xd3\x78\x08\x64\x88\x17\xd9\xba\x57\x77\xef\x3d\xb2\x50\xcd\xb1\xe0\x6b\x05\x2c\x76\x3b\xe7\xa1\xcc\x84\x29\x79\xa1\x63\xb6\x8f\x18\xf3\x3b\x0b\xec\x99\x42\x1e\x0b\x2d\xe3\xa8\x8d\x22\x04\x67\x2a\xcc\x49\x2f\x8d\x91\x0a\x32\xa1\x5d\x56\x72\xcd\x25\x88\xec\xdb\x68\x88\x59\x0c\xba\xee\xa6\xfb\xcf\x90\x48\xf3\x12\xd8\xf0\x50\xbe\x87\x7d\xcd\x31\x0d\xb2\x42\xb4\x49\x27\x23\x51\x58\x32\x0a\x0f\xa2\x13\x57\x6d\xda\xe9\xc0\xe5\x58\x58\x5f\x18\x1d\x74\xaf\x6f\xef\x2c\xa4\x60\x1b\x83\xcc\x6f\x81\x9d\x64\x67\x34\x16\x68\x74\x09\xda\x77\x0d\x98\xca\xdb\xca\xff\x6a\xb7\xb3\xeb\x00\x6b\x40\xd7\x3b\xae\xb9\xaa\xa0\xb7\x31\xd3\x68\x3a\x6d\x33\x0d\xc6\xc7\x0c\xed\x3c\x29\x84\xdc\xa0\x20\xa1\x27\x4e\x4d\xa1\x44\xce\x51\x24\x14\xf9\xc3\x89\x4a\x3f\xa6\x17\x16\x1f\xc9\xb5\x3d\x1e\x0b\x5a\xb8\xac\xa3\xf3\x31\x16\xcf\x4d\x49\x6d\x43\x26\x95\x59\x73\x95\x71\x21\xc8\x9f\x4b\xf3\x4d\x32\xbc\xc6\x9f\xe3\x86\x1f\xea\xbf\x84\x74\xde\xab\xe3\xce\x3d\xad\x56\x11\xcd\x4d\x9d\x2c\x64\x44\x16\x29\x01\xa2\xe0\x9e\xbb\xce\xe0\x61\xf1\xbf\x16\xd3\x61\x6c\xc9\xeb\x45\x80\x69\xa9\x73\xdb\xc4\xa2\xf9\xda\x9d\x03\x4c\xc1\x2b\x20\x9e\x18\x3f\x56\xbf\x19\xba\xe7\xdc\x2d\x06\xeb\x73\x3b\xf7\xd3\x52\xe8\xba\x4c\x43\x6d\xa4\x53\x0c\x78\x93\x50\x8f\xf6\x16\x53\x15\xc6\x5a\x05\x38\x47\x76\x08\x5f\x97\x6e\xe3\x6e\x92\x6a\xb3\xfc\xf0\x2b\x23\x25\x82\xe4\xde\xcc\x12\x9d\x48\xee\x54\x17\xde\x59\x8d\x9b\xbf\xb6\x28\xef\x1d\x67\x1b\xfd\x00\x92\x36\x07\x52\x5c\x09\x00\x00")
 
 func templatesCf_dnsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -108,7 +114,7 @@ func templatesCf_dnsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2734, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2396, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -153,7 +159,107 @@ func templatesConcourse_lbTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x41\x0a\x83\x30\x10\x45\xf7\x39\x45\x08\xdd\xaa\x20\x64\x23\xf4\x2c\x21\x35\x83\xb5\x44\x27\x4c\x66\x44\x10\xef\x5e\x4a\x6d\xad\xd0\x4d\xbb\x1d\xe6\xbd\xff\x3f\x41\x46\xa1\x16\xb4\xe9\x10\xbb\x08\xae\xc5\x21\x09\x83\xf3\x21\x10\xe4\x6c\xb4\xb9\xc9\x90\x2e\x38\x17\x7d\x32\x7a\x51\x5a\x8f\x7e\x00\x7d\xd6\xe6\xb4\x4c\x9e\x4a\x18\x27\xd7\x87\xb5\xf8\xf8\x52\xab\x52\x28\x9c\x84\xdf\xb0\x13\x8a\x4f\x7a\xf2\x51\x36\xfc\x7b\x62\xb9\x9b\xca\xed\xb4\x36\x75\x7d\xb0\xc2\xcc\x40\xa3\x8f\xee\xd5\xe9\x2f\xeb\x41\x19\x7a\x82\x96\x91\xf6\xe1\x07\xef\x95\x39\xe5\xa6\xaa\x7e\x6b\x6d\xad\xb5\x8f\x94\x7b\x00\x00\x00\xff\xff\x10\xf1\x6b\x1b\x66\x01\x00\x00")
+var _templatesDirector_accessTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x90\xdb\x4e\x84\x30\x10\x86\xef\x79\x8a\xa6\xf1\x52\x50\x70\x45\xbc\xf0\x49\x36\xa6\x29\x65\x16\xc9\x76\x19\x32\x1d\x20\x66\xc3\xbb\xdb\x83\xa8\xd9\xd8\x5e\xb4\xfd\x3b\x87\xef\x9f\x45\xd3\xa0\x5b\x0b\x42\x76\x03\x81\x61\x24\xa5\x8d\x01\xe7\x94\x19\x3a\x72\x52\x5c\x33\x21\xf8\x73\x02\xe1\xd7\x9b\x90\x76\x70\x2c\xbd\xd4\xc1\x49\xcf\x96\xbd\x74\x94\xe5\x63\x11\xf7\x43\x23\xef\x85\x2c\x5f\xaa\xa2\xac\xe3\xbb\xac\xa2\xf0\x1a\x84\x26\x29\xb5\x7c\xcf\xb6\x2c\x23\x70\x38\x93\xf1\x6d\x7b\xc4\xde\x82\x32\x78\x99\x66\x06\x75\xf2\x14\xab\xb6\x56\xfe\x02\xe5\x09\x28\xa1\x8c\xfa\xb2\xa3\xdc\x5d\x17\x4d\x05\x8c\x8b\x1a\xba\x2d\xbf\x8d\x0e\xb1\xc0\x2b\xd2\x39\xc5\xde\xf4\xf9\xfe\x2b\xda\xd6\xe6\xfb\x3d\xd4\xde\x64\xe6\x33\x13\x9c\x22\x3d\xf6\xe0\xa2\xc7\xd4\xec\xdf\x19\x6d\xde\x92\xcf\xf1\xd0\xb8\x46\x46\x21\x26\x24\x76\x09\xf3\x28\xab\x38\x85\xba\xa9\xe3\x78\x9a\xc3\xe1\x29\x9e\xfe\x12\xce\xea\xd9\x2f\x5f\x21\xa6\x11\x32\x1a\xb4\x81\x98\xcd\x14\x3c\x6c\xa1\x34\x6b\xea\x81\x15\xeb\xfe\x2f\xcc\xee\xbc\x45\xf7\xf1\x63\x3f\x8e\xf7\x0b\x47\xec\x90\x55\xd5\x01\x00\x00")
+
+func templatesDirector_accessTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_accessTf,
+		"templates/director_access.tf",
+	)
+}
+
+func templatesDirector_accessTf() (*asset, error) {
+	bytes, err := templatesDirector_accessTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_access.tf", size: 469, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDirector_blobstoreTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x55\xb1\x6e\xc2\x30\x14\xdc\xf9\x0a\x2b\x62\x68\x07\xe8\x82\x3a\x54\x62\x40\x55\x55\x55\xaa\x5a\xa9\x43\x57\xcb\xb1\x5f\x83\xc1\xf1\x43\xb6\x93\x16\xa1\xfc\x7b\xed\x04\x52\x08\x69\x14\x10\x64\xb2\xe2\xf3\xbd\xbb\xe7\x4b\x9e\x01\x8b\x99\xe1\x40\x22\xc3\xb4\xc0\x94\x4a\x11\x91\x48\x48\x03\xdc\xa1\xa1\xb1\xc2\xd8\xfa\x05\x44\x64\x33\x20\x24\x5e\x3b\xa0\x0a\x74\xe2\xe6\x64\x4a\x26\x83\x62\x30\x30\x35\x41\x82\x98\x28\xa0\x01\xce\x12\xa0\x71\xc6\x97\xe0\x3a\xc8\x34\x4b\x81\xd4\xcf\x94\x44\xc3\x4d\xce\xcc\x18\x74\xee\x45\x14\xa3\x18\xed\x7c\x54\x1f\x19\x0d\x37\xb5\xc0\xf1\x31\xe3\x78\x0e\x3f\x45\xe4\x49\x15\x72\xe6\x24\xea\x06\xa9\x81\xc4\xbf\x2c\x11\x3b\x7d\x5c\x31\x6b\x03\xe2\xe3\xe9\xf9\xe5\xfd\x6d\xf6\x1a\x36\xbf\xd0\x5b\xa1\x02\xac\x33\xb8\xf6\x9b\xce\x64\xd0\xee\x12\x4c\x2e\x3d\x94\x71\x8e\x99\xee\xb2\xb9\x45\x78\xdd\x95\xa0\xd2\xd7\x0e\xdc\xd7\x96\x90\x76\xa5\xd8\x9a\x96\x3d\x6b\xb6\x8a\x04\x4a\xb2\x3b\x4d\xfe\xea\xf7\x51\x4e\x97\xb0\xee\x50\xdf\x44\x7b\x17\x65\xf9\x76\xb2\x36\x0b\x41\x72\x11\xf5\x88\x0a\x95\x2c\xa5\x29\xa4\x31\x98\xae\x08\x96\xd8\x43\x11\x07\x34\x1d\x1a\x08\x31\xa8\xa0\xba\x86\xb0\xb2\x77\xdb\xa3\x63\x8c\x17\xfe\xcc\x4c\xa4\x52\x07\x58\xa5\x22\xc0\xb6\x06\x67\x95\xbf\x87\x53\x8c\x43\xca\xa4\x2a\xa2\x9e\xf1\xf1\x2a\x7c\x7b\xfb\x64\xa7\x44\x5e\x30\x38\x25\xdf\x79\xa9\x69\x15\x7d\x62\x64\x1a\x1c\xe7\xe6\xa5\x41\x43\x73\x09\xdf\x61\xe3\xda\x99\xf9\xac\xea\x9c\x15\x9a\xa6\xf5\xce\xc4\x9c\xe0\x9d\x1b\x60\x7e\x71\x7d\xf3\x8f\xdb\x42\x97\x76\x8f\x99\x5b\x65\x6e\xef\x27\xb0\xb3\x1d\x74\x55\xb6\x72\xa6\x32\x38\xcf\x55\x6b\x81\x7a\x95\x70\x1b\x1a\x28\xbc\x38\xc9\x94\xa5\x0b\x8b\x7a\xbf\x64\x3d\x57\x62\x66\xe1\x7e\x22\x80\xa3\x80\x9b\xff\x3f\x93\x36\x21\x2b\x23\x73\xe6\x47\xa9\xdf\xbe\xad\x86\x12\x68\x2b\x9d\xcc\x61\x6f\xe6\x1c\x6b\xac\x3a\x76\x71\x81\xcd\x8b\xe8\xa3\xee\x17\x8e\x6f\xf2\x6c\x38\x08\x00\x00")
+
+func templatesDirector_blobstoreTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_blobstoreTf,
+		"templates/director_blobstore.tf",
+	)
+}
+
+func templatesDirector_blobstoreTf() (*asset, error) {
+	bytes, err := templatesDirector_blobstoreTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_blobstore.tf", size: 2104, mode: os.FileMode(480), modTime: time.Unix(1792390900, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDirector_databaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x56\x51\x4f\xdb\x30\x10\x7e\xef\xaf\xb0\x3a\x1e\x97\x88\x41\x37\x6d\x0f\x3c\x4c\xa8\x42\x48\x88\x31\x40\xbc\xa0\xc9\x72\x92\x6b\x6a\xd5\xb5\x83\xed\x04\x10\xea\x7f\xdf\x39\x4e\xd2\xa6\x4d\x4a\xca\xa4\x45\xaa\xd4\xf4\x7c\xdf\x7d\xdf\xdd\x67\xbb\x1a\x8c\xca\x75\x0c\x64\xac\x99\x4c\xd4\x92\xf2\x64\x4c\xc6\x09\xd7\x10\x5b\xa5\x69\xc2\x2c\x8b\x98\x81\x31\x79\x1b\x11\x12\xbd\x5a\xa0\x02\x64\x6a\xe7\xe4\x8c\x4c\x46\xab\xd1\x48\x6f\xe7\x1b\xab\xb9\x4c\xbb\x30\x68\xc6\x8c\x79\x56\x3a\xf1\x60\x15\x0e\x02\x9d\x9e\xe0\xab\xc9\x20\xe6\x4c\xe0\xeb\x8c\x09\x03\x0e\xfb\x13\x39\x17\x2a\x4f\xc8\xdd\xef\x2b\x92\x82\x35\x84\x91\x4c\xf3\x82\x59\x20\x3c\x23\x5c\xe2\x3b\x16\x4d\x81\x64\x00\x1a\x12\xf2\xcc\x11\xcf\xce\x81\x44\x91\x20\x12\x2c\xd6\x5a\x7c\x26\x46\xb9\xdf\x10\xac\xe6\x41\xe6\xcc\x10\xa9\x48\x96\x47\x82\xc7\x08\x15\x6e\xa8\x48\x95\x4a\x05\xd0\x58\x2d\xb3\x1c\xc5\xa6\x42\x45\x4c\x50\x96\x24\xb8\xc4\xf4\x77\x46\xb2\x25\x90\xe6\x39\x23\xe3\xa3\xb7\x82\xe9\x10\x64\x81\x0d\x5d\x05\x75\x56\xd0\x64\x61\x4e\x96\xeb\x4c\x19\x58\xe7\x3c\xdc\x9c\xd3\x9b\xe9\xf4\xf6\xf2\xfa\xc2\xc5\xab\xa2\xd4\xbe\x66\x50\xc6\x2f\xaf\xef\xa7\xb7\xd7\x3f\xaf\xca\x64\x0d\x33\xfe\xb2\x1e\xc6\xc9\xb1\x63\xe1\x35\x6f\xb2\xd8\xd2\x53\xad\x08\xb1\x43\x41\xfd\xdd\x80\x98\x51\xc1\xe5\x62\x35\x6e\x4f\xb4\xca\x35\xa0\x0b\x1e\x37\xb9\x38\x5d\x84\x93\x12\x05\x71\x25\xf7\xb4\xa4\x45\x66\xab\x39\x07\xd2\x42\x7b\x78\x12\x5d\x68\x55\x68\x4d\x2f\xf4\xe8\x2c\xe3\x26\xc4\x0a\x2e\x1d\x45\xe1\x2a\x48\xa8\xb3\x8a\x53\x50\x1a\xc7\x60\xfa\xe3\x2e\x9b\xf6\xd0\xc3\x1d\x7d\xa1\x9b\xf6\x6a\xfc\xa7\xbb\x5b\x4f\x62\xed\x78\x2e\x8d\x65\x32\x86\xa1\xc6\xe9\xf2\x4e\xa4\xcc\x3c\x38\x7a\x6b\xb6\x67\x07\x9f\x39\xbc\x94\x4d\x6a\xea\x16\xa0\x0d\x0e\xc7\x81\xdd\xfc\xba\xbb\xbf\xb8\x9d\xde\xd1\x1f\xf4\x9b\xef\x44\xea\x22\x1d\x05\x7d\x04\x81\x1c\x12\x64\x20\x13\x43\x4b\x8c\xc7\x41\x4e\xd8\xe5\x85\x1d\x2a\x27\x67\x2d\xae\x34\xa5\x5c\x42\x2c\x07\xed\x6a\x26\x51\x10\xe7\xc6\xaa\x65\xf0\x25\x38\xfd\x3e\x39\x2e\xcb\x12\xdc\x8d\x0e\x72\xc6\xd3\x5c\x33\x87\x5a\x65\xb9\x40\x31\xa1\x20\x59\x24\x70\xa7\x97\xb4\xfd\x39\xe1\xa3\xd5\xb9\x50\x33\xfb\xa0\xc9\xdc\xa3\xe1\x29\x47\x21\xd4\x18\x51\xb5\xc7\xea\xdc\x97\x59\x79\x8a\x11\x8b\x17\x79\x1f\xcd\x9a\x61\x2b\xcd\x7d\xde\xf3\xca\x00\x8b\xa0\x28\x67\x06\xc7\xb4\x36\x56\x4b\x68\xa7\xf5\x7a\xed\xdb\xcb\x28\xc7\x31\xff\x7f\x36\x38\xc3\xea\x7a\xf0\x28\xad\xeb\x24\xec\xbf\x4c\xd0\xb6\x26\x17\x76\x8f\x1e\x9c\x24\x8d\x41\xdb\x7e\x4d\xe8\x90\xa5\x92\xb4\x94\x56\xc9\x6a\x8e\xec\x96\xbe\xad\xe3\xeb\xf0\x86\xab\xdc\xa2\x17\x37\x88\x50\x78\xb1\xa0\x25\x1e\x37\x49\x44\xe7\xca\x58\xcf\xa8\x60\x22\xff\x58\x3b\xeb\x9d\x80\x1b\xa9\x3a\xc0\x86\x54\xce\x94\xde\xaa\xfc\x75\x82\xd7\xf2\xbb\x79\xde\x2b\xfd\x8c\x5d\xfc\x1f\x1a\xd2\xfe\xc7\xe0\x4b\x34\x43\x38\xdc\x21\xee\x30\x92\x86\x5b\x5e\x40\xbd\x41\xdf\xa5\xc0\x12\x96\xd9\x1d\x91\x78\x71\xdb\x14\x71\x07\x68\x70\x5a\x87\x0c\xf5\xc0\x36\x21\x72\xcc\xf6\xe1\xd6\xb6\xef\xc0\x2d\xef\x42\x8d\xf9\xe5\x82\x7d\x15\x04\x07\x69\xcb\x55\x7c\xc6\x63\xf4\xd5\x07\x2b\x0e\xab\x53\x7b\x77\x01\xaf\x9d\x13\x1f\x56\x6b\x03\xa5\x6f\xe6\x7f\x01\xc4\xf0\x83\x40\xf6\x0a\x00\x00")
+
+func templatesDirector_databaseTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDirector_databaseTf,
+		"templates/director_database.tf",
+	)
+}
+
+func templatesDirector_databaseTf() (*asset, error) {
+	bytes, err := templatesDirector_databaseTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/director_database.tf", size: 2806, mode: os.FileMode(480), modTime: time.Unix(1792398331, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDisk_encryptionTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x92\xc1\x4e\xc3\x30\x0c\x86\xef\x7d\x0a\xab\x70\x64\xa9\xb8\x22\x21\x81\x80\x13\xe2\x02\x0f\x50\x65\xa9\xe9\xc2\xda\xb8\x8a\xd3\xa2\x6a\xea\xbb\x13\x37\x61\x0c\x84\xa0\xa7\x26\xb6\x7f\xfb\xf3\x9f\x49\x7b\xab\xb7\x1d\x42\xd9\x58\xde\xd7\xe8\x8c\x9f\x87\x60\xc9\xd5\x7b\x9c\x4b\x38\x14\x00\x61\x1e\x10\xf2\x77\x0d\x25\x07\x6f\x5d\x5b\xc6\x40\x83\x6c\xbc\x5d\xb3\x25\x70\xd7\xd1\xd8\xc0\xe3\xd3\x0b\xc4\x52\x08\x3b\x1d\x20\xcb\x71\x3c\x21\x48\x03\x06\x7a\x85\x2d\xf1\x0e\xa6\x9e\x2f\x00\x55\xab\x60\xf0\xf4\x86\x26\x70\x35\x54\x1d\x19\x2d\x72\x5c\x8d\xbc\x41\xcd\xe1\xb2\x8a\x5a\xcf\xb1\x1f\x57\xbe\x5a\xb5\xe8\x11\x67\xae\xf6\x65\xb1\x14\x45\xa3\x83\x86\xb2\x25\x6a\x3b\xac\xb3\x4c\x09\xa5\xe8\xa7\xd1\xf3\x5d\x6d\x1b\x19\xf0\xfc\x30\x69\xaf\xbe\xee\x96\x55\xe4\x0c\xee\xa8\x1f\xc6\x80\xf0\xe0\x5a\xeb\xf0\xb7\xa1\xdf\x6d\xd8\xad\x47\x21\xd3\x0c\x36\x46\xe9\xdd\x01\xa3\x9f\xac\x41\xd0\x2d\xba\x70\x11\xa5\x1c\x05\x89\x4b\xea\x31\x66\x0c\x8d\x2e\x08\x78\x12\xf4\xb1\x3b\x79\x55\x78\x64\x1a\x7d\xcc\xf8\x24\xd8\xf7\x5c\x27\x46\x59\x7e\x6d\x75\x5f\xf7\xd8\x6f\xd1\x67\xa6\xfa\x87\x45\x89\xf1\xb4\xe2\x04\xf3\x17\x3b\x17\x31\xcd\x53\x77\x74\x73\xf5\x53\x2e\xb8\x32\x62\x5e\x1c\x40\x1d\x97\xfc\x90\x6a\xd1\xdf\x63\xfe\x91\xf2\x34\xd0\xe9\x73\x48\x98\xb7\x89\xf2\x2a\x1f\x37\xe7\x07\x31\x47\x7d\xf7\x46\x09\x85\x72\xa3\x48\x2c\x37\x26\xad\x7d\xc3\x33\x07\xec\x55\xc4\x55\x6d\x2e\xcf\x3b\x53\x31\x65\xf5\x88\xc6\x10\x53\xff\x78\xa3\x93\xee\x46\xfc\x0f\x7e\x29\x3e\x00\xc8\x8f\xa9\x9e\xee\x02\x00\x00")
+
+func templatesDisk_encryptionTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDisk_encryptionTf,
+		"templates/disk_encryption.tf",
+	)
+}
+
+func templatesDisk_encryptionTf() (*asset, error) {
+	bytes, err := templatesDisk_encryptionTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/disk_encryption.tf", size: 750, mode: os.FileMode(480), modTime: time.Unix(1792398928, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDual_stackTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x90\xcd\x0e\xc2\x20\x10\x84\xef\x7d\x8a\x0d\xf1\xda\xde\x34\x5e\x7c\x12\x63\x08\xa5\x2b\x21\x52\xb6\xd9\x02\x3d\x34\x7d\x77\xc1\xfa\x17\xa3\x89\x9c\x20\xfb\xcd\xce\x0c\x8c\x23\x45\xd6\x08\xc2\x10\x19\x87\x52\x53\x3f\xc4\x80\xf2\x6c\x19\x27\xe5\x9c\x00\x61\x7d\x40\xf6\xca\xd5\x56\xf7\x43\xda\x09\x98\x2b\x00\xaf\x7a\x84\x7c\x0e\x20\x36\x73\x52\xdc\xa0\x4f\xd2\x76\x4b\xfd\x49\x17\x16\xc3\x44\x7c\x59\xd9\x0f\x9f\xfb\xac\x69\x5b\x57\x3f\xee\x65\xf7\x22\xaa\xac\x5c\xc3\xc9\xa0\xcc\x98\xd5\xc7\x1f\x56\xe2\x54\xd8\x1c\x96\xa6\x5b\x36\x80\x81\x29\x90\x26\x57\x2c\xb7\xfb\x92\x61\x29\x48\x50\x6c\x30\xfc\xb1\x2e\xd3\x14\x43\x0e\xf8\x6a\x2f\x6d\x6e\x23\xb5\xed\x78\xfd\x80\xa4\x5c\xc4\xaf\x95\xc6\xd8\xbe\xb7\x5a\x9f\xcd\x53\x2e\x59\x79\x53\xfa\x2d\xd5\x15\x67\x2a\xea\x56\x7e\x01\x00\x00")
+
+func templatesDual_stackTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDual_stackTf,
+		"templates/dual_stack.tf",
+	)
+}
+
+func templatesDual_stackTf() (*asset, error) {
+	bytes, err := templatesDual_stackTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dual_stack.tf", size: 382, mode: os.FileMode(480), modTime: time.Unix(1792396207, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x94\xd1\x6e\xc2\x20\x14\x86\xef\xfb\x14\x84\xec\xd2\x56\xa3\xce\x74\x26\x7b\x12\xb3\x10\xda\xb2\xda\x89\x3d\x04\xa8\x9a\x98\xbe\xfb\x80\x16\x6b\xd5\xb9\x6e\x73\xf4\xa2\x04\xce\xf9\xcf\xcf\x17\x38\x92\x29\xa8\x64\xca\x10\xce\x01\x72\xce\x48\x0a\x5b\x51\x69\x46\x68\x96\x49\xa6\x14\x46\xf8\xa3\xda\x8a\x04\x0e\x61\x21\x30\x3a\x06\x08\x95\x74\xcb\xd0\x2b\xc2\x4f\xc7\x1d\x95\x11\x2b\x77\xa4\xc8\xea\xf0\x2c\x2a\xa8\x83\x40\x7e\xa5\xfb\x5e\x48\xb6\xa7\x9c\x1b\x61\x76\xd0\x4c\x96\x94\x9f\xc9\x9a\x71\xa5\x7c\x0a\xb3\x41\x4c\xef\x41\x6e\x9a\xa0\x0b\xe5\x76\x2f\x4a\x12\x1e\xfa\xb9\x15\xad\x71\x60\x32\x1b\x3b\x44\xd2\x32\x67\xca\xe4\xaf\xf0\x24\x72\xdf\x78\x82\xdf\x6c\x80\xf1\x04\x7b\xe7\x04\x21\x01\x52\xab\xc6\xcc\x0a\x4f\xa7\x78\x84\xf0\x22\x5e\xc4\xf6\x3f\x7d\x36\xc3\x64\xb8\x30\x09\x1a\x52\xe0\xd6\x8e\x4e\x85\x35\x58\x5b\x29\x4d\x65\xce\x34\xd1\x34\x6f\x2a\xf5\xcf\x93\x80\x5a\x87\x20\x58\x69\x54\x06\x92\xea\x52\xee\xa3\xea\xe2\x1e\xc1\x6a\x80\xff\xe1\xdc\xe2\xf9\x7c\xe6\xfe\x66\xf2\x40\x8e\x99\x81\x94\x6a\x90\xc3\x59\xfa\x8b\xaa\x21\x74\x0b\x77\x81\x5e\x04\xff\x23\xd5\xb6\xd2\x20\xa6\xb3\x59\xfc\xf2\x27\x74\x45\xd9\x3e\xa9\x11\xfa\x1e\x2a\x54\xda\x9c\xee\x04\x8e\x38\xd1\x86\xdb\x8e\xf2\xca\xf6\x82\x95\xf3\x72\x0d\xc4\x63\x8f\x4e\x77\xa6\xe5\x31\xf2\x09\xb7\x10\xd8\xcd\x9b\x95\x2b\xc9\xfb\x75\xaf\x2b\xb6\x6d\x2b\xea\xda\x51\xd4\x2e\xd5\x4b\x03\xef\x5c\xd5\xf7\x15\xe2\x1b\xdb\xaf\x54\x7b\x92\x1e\x5c\xd7\x3d\x7b\xba\x6b\xad\x85\x5a\x8e\xc7\x3f\x73\xed\x1e\x8a\xa9\xf2\x09\x03\xee\x03\x6f\xab\x05\x00\x00")
 
 func templatesJumpboxTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -168,7 +274,27 @@ func templatesJumpboxTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 358, mode: os.FileMode(480), modTime: time.Unix(1529614307, 0)}
+	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 1451, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJumpbox_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x8e\x41\x0a\xc3\x20\x10\x45\xf7\x39\x85\x48\xb7\x91\x40\xd7\x5d\xf4\x1c\xa5\x88\xc9\x0c\x69\x4b\x74\xc4\x31\xa1\x6d\xc8\xdd\xab\x49\x1a\xb2\x29\xd4\x95\xbe\xf1\xff\x79\x01\x99\xfa\xd0\xa0\x90\x2d\x51\xdb\xa1\x06\xc7\x3a\x60\x43\x01\x34\x63\x94\x42\xd6\xc4\xb7\x32\x51\x29\xc6\x42\x08\x67\x2c\x8a\xe5\x9c\x96\x99\x3a\x8c\xbb\xa8\x35\xce\xb4\x08\xfa\x4d\x0e\x15\xba\x61\x86\xf3\x23\x5f\x72\x7a\x92\xa9\x06\xd0\xa3\x03\xd6\xe4\x52\xcd\xe5\xbb\xbb\x21\xeb\xfb\x88\xda\x00\x04\x64\x56\x8f\xde\xfa\x9a\x9e\xe5\xdd\xcb\x6b\x0a\xc5\x97\xdf\xed\x3e\xe7\x9e\x18\x3b\xb1\xa1\x63\x55\x15\x89\xed\x15\xf2\xc7\x3f\xfd\x56\xb7\x54\x10\x02\x98\x68\x78\x36\xdb\xc2\xbf\xdd\xd4\x8a\xa6\x24\x39\x15\x1f\x16\x3b\x8c\x4b\x51\x01\x00\x00")
+
+func templatesJumpbox_dnsTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJumpbox_dnsTf,
+		"templates/jumpbox_dns.tf",
+	)
+}
+
+func templatesJumpbox_dnsTf() (*asset, error) {
+	bytes, err := templatesJumpbox_dnsTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/jumpbox_dns.tf", size: 337, mode: os.FileMode(480), modTime: time.Unix(1792391397, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -249,7 +375,13 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/director_access.tf": templatesDirector_accessTf,
+	"templates/director_blobstore.tf": templatesDirector_blobstoreTf,
+	"templates/director_database.tf": templatesDirector_databaseTf,
+	"templates/disk_encryption.tf": templatesDisk_encryptionTf,
+	"templates/dual_stack.tf": templatesDual_stackTf,
 	"templates/jumpbox.tf": templatesJumpboxTf,
+	"templates/jumpbox_dns.tf": templatesJumpbox_dnsTf,
 	"templates/vars.tf": templatesVarsTf,
 }
 
@@ -298,7 +430,13 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"director_access.tf": &bintree{templatesDirector_accessTf, map[string]*bintree{}},
+		"director_blobstore.tf": &bintree{templatesDirector_blobstoreTf, map[string]*bintree{}},
+		"director_database.tf": &bintree{templatesDirector_databaseTf, map[string]*bintree{}},
+		"disk_encryption.tf": &bintree{templatesDisk_encryptionTf, map[string]*bintree{}},
+		"dual_stack.tf": &bintree{templatesDual_stackTf, map[string]*bintree{}},
 		"jumpbox.tf": &bintree{templatesJumpboxTf, map[string]*bintree{}},
+		"jumpbox_dns.tf": &bintree{templatesJumpbox_dnsTf, map[string]*bintree{}},
 		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
 	}},
 }}
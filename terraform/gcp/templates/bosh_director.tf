@@ -8,38 +8,18 @@ resource "google_compute_network" "bbl-network" {
   auto_create_subnetworks = false
 }
 
+variable "dual_stack" {
+  default     = false
+  description = "Assign an IPv6 cidr range to the subnetwork, next to IPv4"
+}
+
 resource "google_compute_subnetwork" "bbl-subnet" {
   name          = "${var.env_id}-subnet"
   ip_cidr_range = "${var.subnet_cidr}"
   network       = "${google_compute_network.bbl-network.self_link}"
-}
-
-resource "google_compute_firewall" "external" {
-  name    = "${var.env_id}-external"
-  network = "${google_compute_network.bbl-network.name}"
-
-  source_ranges = ["0.0.0.0/0"]
-
-  allow {
-    ports    = ["22", "6868", "25555"]
-    protocol = "tcp"
-  }
 
-  target_tags = ["${var.env_id}-bosh-open"]
-}
-
-resource "google_compute_firewall" "bosh-open" {
-  name    = "${var.env_id}-bosh-open"
-  network = "${google_compute_network.bbl-network.name}"
-
-  source_tags = ["${var.env_id}-bosh-open"]
-
-  allow {
-    ports    = ["22", "6868", "8443", "8844", "25555"]
-    protocol = "tcp"
-  }
-
-  target_tags = ["${var.env_id}-bosh-director"]
+  stack_type       = "${var.dual_stack ? "IPV4_IPV6" : "IPV4_ONLY"}"
+  ipv6_access_type = "${var.dual_stack ? "EXTERNAL" : ""}"
 }
 
 resource "google_compute_firewall" "bosh-director" {
@@ -69,20 +49,6 @@ resource "google_compute_firewall" "internal-to-director" {
   target_tags = ["${var.env_id}-bosh-director"]
 }
 
-resource "google_compute_firewall" "jumpbox-to-all" {
-  name    = "${var.env_id}-jumpbox-to-all"
-  network = "${google_compute_network.bbl-network.name}"
-
-  source_tags = ["${var.env_id}-jumpbox"]
-
-  allow {
-    ports    = ["22", "3389"]
-    protocol = "tcp"
-  }
-
-  target_tags = ["${var.env_id}-internal", "${var.env_id}-bosh-director"]
-}
-
 resource "google_compute_firewall" "internal" {
   name    = "${var.env_id}-internal"
   network = "${google_compute_network.bbl-network.name}"
@@ -132,13 +98,6 @@ output "director__internal_ip" {
   value = "${cidrhost(var.subnet_cidr, 6)}"
 }
 
-output "jumpbox__tags" {
-  value = [
-    "${google_compute_firewall.bosh-open.name}",
-    "${var.env_id}-jumpbox",
-  ]
-}
-
 output "director__tags" {
   value = ["${google_compute_firewall.bosh-director.name}"]
 }
@@ -0,0 +1,18 @@
+variable "director_access_cidrs" {
+  type    = "list"
+  default = ["10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"]
+}
+
+resource "google_compute_firewall" "director-access" {
+  name    = "${var.env_id}-director-access"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_ranges = ["${var.director_access_cidrs}"]
+
+  allow {
+    ports    = ["22", "6868", "8443", "8844", "25555"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
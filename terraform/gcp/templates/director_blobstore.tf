@@ -0,0 +1,60 @@
+resource "random_id" "director_blobstore" {
+  byte_length = 4
+}
+
+resource "google_storage_bucket" "director_blobstore" {
+  name          = "${var.env_id}-bosh-blobstore-${random_id.director_blobstore.hex}"
+  location      = "${var.region}"
+  storage_class = "REGIONAL"
+  force_destroy = true
+}
+
+resource "google_service_account" "director_blobstore" {
+  account_id   = "bosh-director-${random_id.director_blobstore.hex}"
+  display_name = "${var.env_id} bosh director blobstore"
+}
+
+resource "google_service_account_key" "director_blobstore" {
+  service_account_id = "${google_service_account.director_blobstore.name}"
+}
+
+resource "google_storage_bucket_iam_member" "director_blobstore" {
+  bucket = "${google_storage_bucket.director_blobstore.name}"
+  role   = "roles/storage.objectAdmin"
+  member = "serviceAccount:${google_service_account.director_blobstore.email}"
+}
+
+resource "google_service_account" "agent_blobstore" {
+  account_id   = "bosh-agent-${random_id.director_blobstore.hex}"
+  display_name = "${var.env_id} bosh agent blobstore"
+}
+
+resource "google_service_account_key" "agent_blobstore" {
+  service_account_id = "${google_service_account.agent_blobstore.name}"
+}
+
+resource "google_storage_bucket_iam_member" "agent_blobstore_viewer" {
+  bucket = "${google_storage_bucket.director_blobstore.name}"
+  role   = "roles/storage.objectViewer"
+  member = "serviceAccount:${google_service_account.agent_blobstore.email}"
+}
+
+resource "google_storage_bucket_iam_member" "agent_blobstore_creator" {
+  bucket = "${google_storage_bucket.director_blobstore.name}"
+  role   = "roles/storage.objectCreator"
+  member = "serviceAccount:${google_service_account.agent_blobstore.email}"
+}
+
+output "director__bucket_name" {
+  value = "${google_storage_bucket.director_blobstore.name}"
+}
+
+output "director__director_gcs_credentials_json" {
+  value     = "${base64decode(google_service_account_key.director_blobstore.private_key)}"
+  sensitive = true
+}
+
+output "director__agent_gcs_credentials_json" {
+  value     = "${base64decode(google_service_account_key.agent_blobstore.private_key)}"
+  sensitive = true
+}
@@ -0,0 +1,100 @@
+resource "random_id" "director_database" {
+  byte_length = 4
+}
+
+resource "random_string" "director_database_password" {
+  length  = 32
+  special = false
+}
+
+# Cloud SQL gets a private ip in a range peered with the bbl network, so the
+# database has no public ip.
+resource "google_compute_global_address" "director_database" {
+  name          = "${var.env_id}-director-database"
+  purpose       = "VPC_PEERING"
+  address_type  = "INTERNAL"
+  prefix_length = 20
+  network       = "${google_compute_network.bbl-network.self_link}"
+}
+
+resource "google_service_networking_connection" "director_database" {
+  network                 = "${google_compute_network.bbl-network.self_link}"
+  service                 = "servicenetworking.googleapis.com"
+  reserved_peering_ranges = ["${google_compute_global_address.director_database.name}"]
+}
+
+resource "google_sql_database_instance" "director_database" {
+  name             = "${var.env_id}-bosh-${random_id.director_database.hex}"
+  database_version = "POSTGRES_9_6"
+  region           = "${var.region}"
+
+  depends_on = ["google_service_networking_connection.director_database"]
+
+  settings {
+    tier = "db-custom-1-3840"
+
+    ip_configuration {
+      ipv4_enabled    = false
+      private_network = "${google_compute_network.bbl-network.self_link}"
+      require_ssl     = true
+    }
+
+    backup_configuration {
+      enabled = true
+    }
+  }
+}
+
+resource "google_sql_database" "director_database" {
+  name     = "bosh"
+  instance = "${google_sql_database_instance.director_database.name}"
+}
+
+resource "google_sql_user" "director_database" {
+  name     = "bosh"
+  instance = "${google_sql_database_instance.director_database.name}"
+  password = "${random_string.director_database_password.result}"
+}
+
+resource "google_sql_ssl_cert" "director_database" {
+  common_name = "bosh-director"
+  instance    = "${google_sql_database_instance.director_database.name}"
+}
+
+output "director__external_db_host" {
+  value = "${google_sql_database_instance.director_database.private_ip_address}"
+}
+
+output "director__external_db_port" {
+  value = 5432
+}
+
+output "director__external_db_user" {
+  value = "${google_sql_user.director_database.name}"
+}
+
+output "director__external_db_password" {
+  value     = "${random_string.director_database_password.result}"
+  sensitive = true
+}
+
+output "director__external_db_adapter" {
+  value = "postgres"
+}
+
+output "director__external_db_name" {
+  value = "${google_sql_database.director_database.name}"
+}
+
+output "director__db_ca" {
+  value = "${google_sql_ssl_cert.director_database.server_ca_cert}"
+}
+
+output "director__db_client_certificate" {
+  value = "${google_sql_ssl_cert.director_database.cert}"
+}
+
+output "director__db_client_private_key" {
+  value     = "${google_sql_ssl_cert.director_database.private_key}"
+  sensitive = true
+}
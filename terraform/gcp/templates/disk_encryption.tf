@@ -0,0 +1,20 @@
+variable "disk_encryption_key" {
+  type        = "string"
+  description = "Cloud KMS key that encrypts the disks of bosh vms, e.g. projects/p/locations/us-east1/keyRings/r/cryptoKeys/k"
+}
+
+data "google_project" "bosh" {
+  project_id = "${var.project_id}"
+}
+
+# Compute Engine encrypts the disks with the key as its own service agent,
+# not as the service account of the director.
+resource "google_kms_crypto_key_iam_member" "bosh_disk_encryption" {
+  crypto_key_id = "${var.disk_encryption_key}"
+  role          = "roles/cloudkms.cryptoKeyEncrypterDecrypter"
+  member        = "serviceAccount:service-${data.google_project.bosh.number}@compute-system.iam.gserviceaccount.com"
+}
+
+output "disk_encryption_key" {
+  value = "${var.disk_encryption_key}"
+}
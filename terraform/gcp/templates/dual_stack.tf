@@ -0,0 +1,16 @@
+resource "google_compute_firewall" "internal-icmpv6" {
+  name    = "${var.env_id}-internal-icmpv6"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-internal"]
+
+  allow {
+    protocol = "58"
+  }
+
+  target_tags = ["${var.env_id}-internal"]
+}
+
+output "internal_ipv6_cidr" {
+  value = "${google_compute_subnetwork.bbl-subnet.ipv6_cidr_range}"
+}
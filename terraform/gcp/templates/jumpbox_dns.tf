@@ -0,0 +1,10 @@
+resource "google_dns_record_set" "bosh-dns" {
+  name       = "bosh.${google_dns_managed_zone.env_dns_zone.dns_name}"
+  depends_on = ["google_compute_address.jumpbox-ip"]
+  type       = "A"
+  ttl        = 300
+
+  managed_zone = "${google_dns_managed_zone.env_dns_zone.name}"
+
+  rrdatas = ["${google_compute_address.jumpbox-ip.address}"]
+}